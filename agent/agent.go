@@ -24,6 +24,7 @@ import (
 	"github.com/armon/go-metrics/prometheus"
 	"github.com/hashicorp/consul/agent/rpcclient"
 	"github.com/hashicorp/consul/agent/rpcclient/configentry"
+	"github.com/hashicorp/consul/agent/rpcclient/kv"
 	"github.com/hashicorp/go-connlimit"
 	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/go-memdb"
@@ -260,6 +261,13 @@ type Agent struct {
 	// checkH2PINGs maps the check ID to an associated HTTP2 PING check
 	checkH2PINGs map[structs.CheckID]*checks.CheckH2PING
 
+	// checkTLSs maps the check ID to an associated TLS check
+	checkTLSs map[structs.CheckID]*checks.CheckTLS
+
+	// checkDatastores maps the check ID to an associated Redis, PostgreSQL,
+	// MySQL or Memcached check
+	checkDatastores map[structs.CheckID]*checks.CheckDatastore
+
 	// checkTCPs maps the check ID to an associated TCP check
 	checkTCPs map[structs.CheckID]*checks.CheckTCP
 
@@ -278,6 +286,9 @@ type Agent struct {
 	// checkAliases maps the check ID to an associated Alias checks
 	checkAliases map[structs.CheckID]*checks.CheckAlias
 
+	// checkComposites maps the check ID to an associated Composite check
+	checkComposites map[structs.CheckID]*checks.CheckComposite
+
 	// checkOSServices maps the check ID to an associated OS Service check
 	checkOSServices map[structs.CheckID]*checks.CheckOSService
 
@@ -325,6 +336,10 @@ type Agent struct {
 	// dnsServer provides the DNS API
 	dnsServers []*DNSServer
 
+	// dnsRecursorCache caches the responses of the DNS recursors, it's
+	// shared by all the DNS servers.
+	dnsRecursorCache *dns.RecursorCache
+
 	// apiServers listening for connections. If any of these server goroutines
 	// fail, the agent will be shutdown.
 	apiServers *apiServers
@@ -388,6 +403,7 @@ type Agent struct {
 	// into Agent, which will allow us to remove this field.
 	rpcClientHealth      *health.Client
 	rpcClientConfigEntry *configentry.Client
+	rpcClientKV          *kv.Client
 
 	rpcClientPeering pbpeering.PeeringServiceClient
 
@@ -434,11 +450,14 @@ func New(bd BaseDeps) (*Agent, error) {
 		checkTTLs:       make(map[structs.CheckID]*checks.CheckTTL),
 		checkHTTPs:      make(map[structs.CheckID]*checks.CheckHTTP),
 		checkH2PINGs:    make(map[structs.CheckID]*checks.CheckH2PING),
+		checkTLSs:       make(map[structs.CheckID]*checks.CheckTLS),
+		checkDatastores: make(map[structs.CheckID]*checks.CheckDatastore),
 		checkTCPs:       make(map[structs.CheckID]*checks.CheckTCP),
 		checkUDPs:       make(map[structs.CheckID]*checks.CheckUDP),
 		checkGRPCs:      make(map[structs.CheckID]*checks.CheckGRPC),
 		checkDockers:    make(map[structs.CheckID]*checks.CheckDocker),
 		checkAliases:    make(map[structs.CheckID]*checks.CheckAlias),
+		checkComposites: make(map[structs.CheckID]*checks.CheckComposite),
 		checkOSServices: make(map[structs.CheckID]*checks.CheckOSService),
 		eventCh:         make(chan serf.UserEvent, 1024),
 		eventBuf:        make([]*UserEvent, 256),
@@ -456,6 +475,8 @@ func New(bd BaseDeps) (*Agent, error) {
 		cache:           bd.Cache,
 		routineManager:  routine.NewManager(bd.Logger),
 		scadaProvider:   bd.HCP.Provider,
+
+		dnsRecursorCache: dns.NewRecursorCache(bd.RuntimeConfig.DNSRecursorCacheSize, bd.RuntimeConfig.DNSRecursorPrefetch),
 	}
 
 	// TODO: create rpcClientHealth in BaseDeps once NetRPC is available without Agent
@@ -496,6 +517,18 @@ func New(bd BaseDeps) (*Agent, error) {
 			QueryOptionDefaults: config.ApplyDefaultQueryOptions(a.config),
 		},
 	}
+	a.rpcClientKV = &kv.Client{
+		Client: rpcclient.Client{
+			NetRPC:    &a,
+			ViewStore: bd.ViewStore,
+			MaterializerDeps: rpcclient.MaterializerDeps{
+				Conn:   conn,
+				Logger: bd.Logger.Named("rpcclient.kv"),
+			},
+			UseStreamingBackend: a.config.UseStreamingBackend,
+			QueryOptionDefaults: config.ApplyDefaultQueryOptions(a.config),
+		},
+	}
 
 	// We used to do this in the Start method. However it doesn't need to go
 	// there any longer. Originally it did because we passed the agent
@@ -548,6 +581,7 @@ func (a *Agent) GetConfig() *config.RuntimeConfig {
 func LocalConfig(cfg *config.RuntimeConfig) local.Config {
 	lc := local.Config{
 		AdvertiseAddr:       cfg.AdvertiseAddrLAN.String(),
+		CheckHistorySize:    cfg.CheckHistorySize,
 		CheckUpdateInterval: cfg.CheckUpdateInterval,
 		Datacenter:          cfg.Datacenter,
 		DiscardCheckOutput:  cfg.DiscardCheckOutput,
@@ -947,9 +981,24 @@ func (a *Agent) listenAndServeGRPC() error {
 }
 
 func (a *Agent) listenAndServeDNS() error {
-	notif := make(chan net.Addr, len(a.config.DNSAddrs))
-	errCh := make(chan error, len(a.config.DNSAddrs))
+	type dnsListener struct {
+		network string
+		addr    net.Addr
+	}
+	var listeners []dnsListener
 	for _, addr := range a.config.DNSAddrs {
+		listeners = append(listeners, dnsListener{addr.Network(), addr})
+	}
+	for _, addr := range a.config.DNSTLSAddrs {
+		listeners = append(listeners, dnsListener{"tcp-tls", addr})
+	}
+	for _, addr := range a.config.DNSHTTPSAddrs {
+		listeners = append(listeners, dnsListener{"https", addr})
+	}
+
+	notif := make(chan dnsListener, len(listeners))
+	errCh := make(chan error, len(listeners))
+	for _, l := range listeners {
 		// create server
 		s, err := NewDNSServer(a)
 		if err != nil {
@@ -959,13 +1008,13 @@ func (a *Agent) listenAndServeDNS() error {
 
 		// start server
 		a.wgServers.Add(1)
-		go func(addr net.Addr) {
+		go func(l dnsListener) {
 			defer a.wgServers.Done()
-			err := s.ListenAndServe(addr.Network(), addr.String(), func() { notif <- addr })
+			err := s.ListenAndServe(l.network, l.addr.String(), func() { notif <- l })
 			if err != nil && !strings.Contains(err.Error(), "accept") {
 				errCh <- err
 			}
-		}(addr)
+		}(l)
 	}
 	s, _ := NewDNSServer(a)
 
@@ -980,12 +1029,12 @@ func (a *Agent) listenAndServeDNS() error {
 	// wait for servers to be up
 	timeout := time.After(time.Second)
 	var merr *multierror.Error
-	for range a.config.DNSAddrs {
+	for range listeners {
 		select {
-		case addr := <-notif:
+		case l := <-notif:
 			a.logger.Info("Started DNS server",
-				"address", addr.String(),
-				"network", addr.Network(),
+				"address", l.addr.String(),
+				"network", l.network,
 			)
 
 		case err := <-errCh:
@@ -1291,6 +1340,7 @@ func newConsulConfig(runtimeCfg *config.RuntimeConfig, logger hclog.Logger) (*co
 
 	// Apply dev mode
 	cfg.DevMode = runtimeCfg.DevMode
+	cfg.DevResourceStoragePath = runtimeCfg.DevResourceStoragePath
 
 	// Override with our runtimeCfg
 	// todo(fs): these are now always set in the runtime runtimeCfg so we can simplify this
@@ -1403,6 +1453,7 @@ func newConsulConfig(runtimeCfg *config.RuntimeConfig, logger hclog.Logger) (*co
 	if runtimeCfg.SessionTTLMin != 0 {
 		cfg.SessionTTLMin = runtimeCfg.SessionTTLMin
 	}
+	cfg.KVRevisions = runtimeCfg.KVRevisions
 	if runtimeCfg.ReadReplica {
 		cfg.ReadReplica = runtimeCfg.ReadReplica
 	}
@@ -1470,6 +1521,9 @@ func newConsulConfig(runtimeCfg *config.RuntimeConfig, logger hclog.Logger) (*co
 
 	cfg.AutoEncryptAllowTLS = runtimeCfg.AutoEncryptAllowTLS
 
+	cfg.DNSSECEnabled = runtimeCfg.DNSSECEnabled
+	cfg.DNSSECKeyRotationPeriod = runtimeCfg.DNSSECKeyRotationPeriod
+
 	// Copy the Connect CA bootstrap runtimeCfg
 	if runtimeCfg.ConnectEnabled {
 		cfg.ConnectEnabled = true
@@ -1663,6 +1717,15 @@ func (a *Agent) ShutdownAgent() error {
 	for _, chk := range a.checkH2PINGs {
 		chk.Stop()
 	}
+	for _, chk := range a.checkTLSs {
+		chk.Stop()
+	}
+	for _, chk := range a.checkDatastores {
+		chk.Stop()
+	}
+	for _, chk := range a.checkComposites {
+		chk.Stop()
+	}
 
 	// Stop gRPC
 	if a.externalGRPCServer != nil {
@@ -1681,6 +1744,7 @@ func (a *Agent) ShutdownAgent() error {
 
 	a.rpcClientHealth.Close()
 	a.rpcClientConfigEntry.Close()
+	a.rpcClientKV.Close()
 
 	// Shutdown SCADA provider
 	if a.scadaProvider != nil {
@@ -1726,6 +1790,14 @@ func (a *Agent) ShutdownEndpoints() {
 			)
 			srv.Shutdown()
 		}
+		if srv.httpServer != nil {
+			a.logger.Info("Stopping server",
+				"protocol", "DNS",
+				"address", srv.httpServer.Addr,
+				"network", "https",
+			)
+			srv.httpServer.Close()
+		}
 	}
 	a.dnsServers = nil
 
@@ -2788,7 +2860,7 @@ func (a *Agent) addCheckLocked(check *structs.HealthCheck, chkType *structs.Chec
 	existing := a.State.Check(cid)
 	defer func() {
 		if existing != nil {
-			a.State.UpdateCheck(cid, existing.Status, existing.Output)
+			a.State.UpdateCheckStatus(cid, existing.Status, existing.Output)
 		}
 	}()
 
@@ -2930,6 +3002,14 @@ func (a *Agent) addCheck(check *structs.HealthCheck, chkType *structs.CheckType,
 				OutputMaxSize:    maxOutputSize,
 				TLSClientConfig:  tlsClientConfig,
 				StatusHandler:    statusHandler,
+
+				ResponseBodyRegex:   chkType.ResponseBodyRegex,
+				ResponseJSONPath:    chkType.ResponseJSONPath,
+				ResponseJSONValue:   chkType.ResponseJSONValue,
+				ResponseHeaders:     chkType.ResponseHeaders,
+				PassingStatusCodes:  chkType.PassingStatusCodes,
+				WarningStatusCodes:  chkType.WarningStatusCodes,
+				CriticalStatusCodes: chkType.CriticalStatusCodes,
 			}
 
 			if proxy != nil && proxy.Proxy.Expose.Checks {
@@ -3174,6 +3254,78 @@ func (a *Agent) addCheck(check *structs.HealthCheck, chkType *structs.CheckType,
 			h2ping.Start()
 			a.checkH2PINGs[cid] = h2ping
 
+		case chkType.IsTLS():
+			if existing, ok := a.checkTLSs[cid]; ok {
+				existing.Stop()
+				delete(a.checkTLSs, cid)
+			}
+			if chkType.Interval < checks.MinInterval {
+				a.logger.Warn("check has interval below minimum",
+					"check", cid.String(),
+					"minimum_interval", checks.MinInterval,
+				)
+				chkType.Interval = checks.MinInterval
+			}
+
+			// The certificate is verified against the host of the address
+			// rather than the agent's server name unless one is configured.
+			tlsClientConfig := a.tlsConfigurator.OutgoingTLSConfigForCheck(chkType.TLSSkipVerify, chkType.TLSServerName)
+			tlsClientConfig.ServerName = chkType.TLSServerName
+
+			tlsCheck := &checks.CheckTLS{
+				CheckID:           cid,
+				ServiceID:         sid,
+				TLS:               chkType.TLS,
+				CAFile:            chkType.TLSCAFile,
+				ExpiryWarningDays: chkType.TLSExpiryWarningDays,
+				Interval:          chkType.Interval,
+				Timeout:           chkType.Timeout,
+				Logger:            a.logger,
+				TLSClientConfig:   tlsClientConfig,
+				StatusHandler:     statusHandler,
+			}
+
+			tlsCheck.Start()
+			a.checkTLSs[cid] = tlsCheck
+
+		case chkType.IsRedis() || chkType.IsPostgreSQL() || chkType.IsMySQL() || chkType.IsMemcached():
+			if existing, ok := a.checkDatastores[cid]; ok {
+				existing.Stop()
+				delete(a.checkDatastores, cid)
+			}
+			if chkType.Interval < checks.MinInterval {
+				a.logger.Warn("check has interval below minimum",
+					"check", cid.String(),
+					"minimum_interval", checks.MinInterval,
+				)
+				chkType.Interval = checks.MinInterval
+			}
+
+			var protocol, addr string
+			switch {
+			case chkType.IsRedis():
+				protocol, addr = checks.DatastoreRedis, chkType.Redis
+			case chkType.IsPostgreSQL():
+				protocol, addr = checks.DatastorePostgreSQL, chkType.PostgreSQL
+			case chkType.IsMySQL():
+				protocol, addr = checks.DatastoreMySQL, chkType.MySQL
+			default:
+				protocol, addr = checks.DatastoreMemcached, chkType.Memcached
+			}
+
+			datastore := &checks.CheckDatastore{
+				CheckID:       cid,
+				ServiceID:     sid,
+				Protocol:      protocol,
+				Address:       addr,
+				Interval:      chkType.Interval,
+				Timeout:       chkType.Timeout,
+				Logger:        a.logger,
+				StatusHandler: statusHandler,
+			}
+			datastore.Start()
+			a.checkDatastores[cid] = datastore
+
 		case chkType.IsAlias():
 			if existing, ok := a.checkAliases[cid]; ok {
 				existing.Stop()
@@ -3206,6 +3358,35 @@ func (a *Agent) addCheck(check *structs.HealthCheck, chkType *structs.CheckType,
 			chkImpl.Start()
 			a.checkAliases[cid] = chkImpl
 
+		case chkType.IsComposite():
+			if existing, ok := a.checkComposites[cid]; ok {
+				existing.Stop()
+				delete(a.checkComposites, cid)
+			}
+
+			// Inputs are watched with the same token as alias checks.
+			compositeToken := a.tokens.UserToken()
+			if token != "" {
+				compositeToken = token
+			}
+
+			compositeCheck := &checks.CheckComposite{
+				CheckID:        cid,
+				ServiceID:      sid,
+				Inputs:         chkType.CompositeInputs,
+				Expression:     chkType.CompositeExpression,
+				MinPassing:     chkType.CompositeMinPassing,
+				Node:           a.config.NodeName,
+				Datacenter:     a.config.Datacenter,
+				Token:          compositeToken,
+				Notifier:       compositeCheckNotifier{cache: a.cache, state: a.State},
+				StatusHandler:  statusHandler,
+				Logger:         a.logger,
+				EnterpriseMeta: check.EnterpriseMeta,
+			}
+			compositeCheck.Start()
+			a.checkComposites[cid] = compositeCheck
+
 		default:
 			return fmt.Errorf("Check type is not valid")
 		}
@@ -3350,10 +3531,22 @@ func (a *Agent) cancelCheckMonitors(checkID structs.CheckID) {
 		check.Stop()
 		delete(a.checkH2PINGs, checkID)
 	}
+	if check, ok := a.checkTLSs[checkID]; ok {
+		check.Stop()
+		delete(a.checkTLSs, checkID)
+	}
+	if check, ok := a.checkDatastores[checkID]; ok {
+		check.Stop()
+		delete(a.checkDatastores, checkID)
+	}
 	if check, ok := a.checkAliases[checkID]; ok {
 		check.Stop()
 		delete(a.checkAliases, checkID)
 	}
+	if check, ok := a.checkComposites[checkID]; ok {
+		check.Stop()
+		delete(a.checkComposites, checkID)
+	}
 }
 
 // updateTTLCheck is used to update the status of a TTL check via the Agent API.
@@ -4182,6 +4375,7 @@ func (a *Agent) reloadConfigInternal(newCfg *config.RuntimeConfig) error {
 		MaxConnsPerClientIP: newCfg.HTTPMaxConnsPerClient,
 	})
 
+	a.dnsRecursorCache.Reload(newCfg.DNSRecursorCacheSize, newCfg.DNSRecursorPrefetch)
 	for _, s := range a.dnsServers {
 		if err := s.ReloadConfig(newCfg); err != nil {
 			return fmt.Errorf("Failed reloading dns config : %v", err)
@@ -4318,6 +4512,8 @@ func (a *Agent) registerCache() {
 
 	a.cache.RegisterType(cachetype.ConnectCARootName, &cachetype.ConnectCARoot{RPC: a})
 
+	a.cache.RegisterType(cachetype.DNSSECKeysName, &cachetype.DNSSECKeys{RPC: a})
+
 	a.cache.RegisterType(cachetype.ConnectCALeafName, &cachetype.ConnectCALeaf{
 		RPC:                              a,
 		Cache:                            a.cache,
@@ -4338,6 +4534,8 @@ func (a *Agent) registerCache() {
 
 	a.cache.RegisterType(cachetype.NodeServicesName, &cachetype.NodeServices{RPC: a})
 
+	a.cache.RegisterType(cachetype.NodeChecksName, &cachetype.NodeChecks{RPC: a})
+
 	a.cache.RegisterType(cachetype.ResolvedServiceConfigName, &cachetype.ResolvedServiceConfig{RPC: a})
 
 	a.cache.RegisterType(cachetype.CatalogListServicesName, &cachetype.CatalogListServices{RPC: a})
@@ -4376,6 +4574,83 @@ func (a *Agent) registerCache() {
 	a.registerEntCache()
 }
 
+// compositeCheckNotifier watches the inputs of composite checks through the
+// agent cache, or the local state for the checks of the local node.
+type compositeCheckNotifier struct {
+	cache *cache.Cache
+	state *local.State
+}
+
+func (n compositeCheckNotifier) NotifyServiceHealth(ctx context.Context, req *structs.ServiceSpecificRequest, correlationID string, ch chan<- cache.UpdateEvent) error {
+	return n.cache.Notify(ctx, cachetype.HealthServicesName, req, correlationID, ch)
+}
+
+func (n compositeCheckNotifier) NotifyNodeChecks(ctx context.Context, req *structs.NodeSpecificRequest, correlationID string, ch chan<- cache.UpdateEvent) error {
+	return n.cache.Notify(ctx, cachetype.NodeChecksName, req, correlationID, ch)
+}
+
+func (n compositeCheckNotifier) NotifyLocalChecks(ctx context.Context, req *structs.NodeSpecificRequest, correlationID string, ch chan<- cache.UpdateEvent) error {
+	// Checks that aren't registered with the agent, such as serfHealth, are
+	// only in the catalog.
+	catalogCh := make(chan cache.UpdateEvent, 1)
+	if err := n.cache.Notify(ctx, cachetype.NodeChecksName, req, correlationID, catalogCh); err != nil {
+		return err
+	}
+
+	// Buffered so that no update is lost, see local.State.Notify.
+	notifyCh := make(chan struct{}, 1)
+	n.state.NotifyChecks(notifyCh)
+
+	go func() {
+		defer n.state.StopNotifyChecks(notifyCh)
+
+		var catalog *cache.UpdateEvent
+		for {
+			select {
+			case u := <-catalogCh:
+				catalog = &u
+			case <-notifyCh:
+			case <-ctx.Done():
+				return
+			}
+
+			// Wait for the catalog's checks before sending the first update.
+			if catalog == nil {
+				continue
+			}
+
+			u := *catalog
+			if result, ok := u.Result.(*structs.IndexedHealthChecks); ok {
+				u.Result = n.mergeLocalChecks(result, &req.EnterpriseMeta)
+			}
+
+			select {
+			case ch <- u:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+// mergeLocalChecks returns the checks of the local node in the catalog, with
+// those registered with the agent replaced by their local state.
+func (n compositeCheckNotifier) mergeLocalChecks(catalog *structs.IndexedHealthChecks, entMeta *acl.EnterpriseMeta) *structs.IndexedHealthChecks {
+	local := n.state.Checks(entMeta)
+
+	merged := &structs.IndexedHealthChecks{QueryMeta: catalog.QueryMeta}
+	for _, chk := range catalog.HealthChecks {
+		if _, ok := local[chk.CompoundCheckID()]; !ok {
+			merged.HealthChecks = append(merged.HealthChecks, chk)
+		}
+	}
+	for _, chk := range local {
+		merged.HealthChecks = append(merged.HealthChecks, chk)
+	}
+	return merged
+}
+
 // LocalState returns the agent's local state
 func (a *Agent) LocalState() *local.State {
 	return a.State
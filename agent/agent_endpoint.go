@@ -943,6 +943,54 @@ func (s *HTTPHandlers) agentCheckUpdate(resp http.ResponseWriter, req *http.Requ
 	return nil, nil
 }
 
+// AgentCheckHistory returns the recent results of a local check, from the
+// oldest to the most recent one.
+func (s *HTTPHandlers) AgentCheckHistory(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	path := strings.TrimPrefix(req.URL.Path, "/v1/agent/check/")
+	id := strings.TrimSuffix(path, "/history")
+	if id == path || id == "" {
+		return nil, HTTPError{StatusCode: http.StatusNotFound, Reason: fmt.Sprintf("Invalid URL path: not a recognized HTTP API endpoint: %s", req.URL.Path)}
+	}
+
+	var token string
+	s.parseToken(req, &token)
+
+	var entMeta acl.EnterpriseMeta
+	if err := s.parseEntMetaNoWildcard(req, &entMeta); err != nil {
+		return nil, err
+	}
+
+	s.defaultMetaPartitionToAgent(&entMeta)
+	authz, err := s.agent.delegate.ResolveTokenAndDefaultMeta(token, &entMeta, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if !s.validateRequestPartition(resp, &entMeta) {
+		return nil, nil
+	}
+
+	cid := structs.NewCheckID(types.CheckID(id), &entMeta)
+	cid.Normalize()
+	check := s.agent.State.Check(cid)
+	if check == nil {
+		return nil, HTTPError{StatusCode: http.StatusNotFound, Reason: fmt.Sprintf("Unknown check ID %q. Ensure that the check ID is passed, not the check name.", cid.String())}
+	}
+
+	var authzContext acl.AuthorizerContext
+	check.FillAuthzContext(&authzContext)
+	if check.ServiceName != "" {
+		err = authz.ToAllowAuthorizer().ServiceReadAllowed(check.ServiceName, &authzContext)
+	} else {
+		err = authz.ToAllowAuthorizer().NodeReadAllowed(s.agent.config.NodeName, &authzContext)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return s.agent.State.CheckHistory(cid), nil
+}
+
 // agentHealthService Returns Health for a given service ID
 func agentHealthService(serviceID structs.ServiceID, s *HTTPHandlers) (int, string, api.HealthChecks) {
 	checks := s.agent.State.ChecksForService(serviceID, true)
@@ -3350,6 +3350,92 @@ func TestAgent_UpdateCheck_ACLDeny(t *testing.T) {
 	})
 }
 
+func TestAgent_CheckHistory(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+	a := NewTestAgent(t, "check_history_size = 2")
+	defer a.Shutdown()
+	testrpc.WaitForTestAgent(t, a.RPC, "dc1")
+
+	chk := &structs.HealthCheck{Name: "test", CheckID: "test"}
+	chkType := &structs.CheckType{TTL: 15 * time.Second}
+	require.NoError(t, a.AddCheck(chk, chkType, false, "", ConfigSourceLocal))
+
+	for _, c := range []checkUpdate{
+		{api.HealthPassing, "hello-passing"},
+		{api.HealthCritical, "hello-critical"},
+		{api.HealthWarning, "hello-warning"},
+	} {
+		req, _ := http.NewRequest("PUT", "/v1/agent/check/update/test", jsonReader(c))
+		resp := httptest.NewRecorder()
+		a.srv.h.ServeHTTP(resp, req)
+		require.Equal(t, http.StatusOK, resp.Code)
+	}
+
+	t.Run("history", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", "/v1/agent/check/test/history", nil)
+		resp := httptest.NewRecorder()
+		a.srv.h.ServeHTTP(resp, req)
+		require.Equal(t, http.StatusOK, resp.Code)
+
+		var history []structs.CheckResult
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&history))
+		require.Len(t, history, 2)
+		require.Equal(t, api.HealthCritical, history[0].Status)
+		require.Equal(t, "hello-critical", history[0].Output)
+		require.Equal(t, api.HealthWarning, history[1].Status)
+		require.Equal(t, "hello-warning", history[1].Output)
+	})
+
+	t.Run("unknown check", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", "/v1/agent/check/nope/history", nil)
+		resp := httptest.NewRecorder()
+		a.srv.h.ServeHTTP(resp, req)
+		require.Equal(t, http.StatusNotFound, resp.Code)
+		require.Contains(t, resp.Body.String(), `Unknown check ID "nope"`)
+	})
+
+	t.Run("unknown endpoint", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", "/v1/agent/check/test", nil)
+		resp := httptest.NewRecorder()
+		a.srv.h.ServeHTTP(resp, req)
+		require.Equal(t, http.StatusNotFound, resp.Code)
+	})
+}
+
+func TestAgent_CheckHistory_ACLDeny(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+	a := NewTestAgent(t, TestACLConfig())
+	defer a.Shutdown()
+	testrpc.WaitForLeader(t, a.RPC, "dc1")
+
+	chk := &structs.HealthCheck{Name: "test", CheckID: "test"}
+	chkType := &structs.CheckType{TTL: 15 * time.Second}
+	require.NoError(t, a.AddCheck(chk, chkType, false, "", ConfigSourceLocal))
+
+	t.Run("no token", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", "/v1/agent/check/test/history", nil)
+		resp := httptest.NewRecorder()
+		a.srv.h.ServeHTTP(resp, req)
+		require.Equal(t, http.StatusForbidden, resp.Code)
+	})
+
+	t.Run("root token", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", "/v1/agent/check/test/history", nil)
+		req.Header.Add("X-Consul-Token", "root")
+		resp := httptest.NewRecorder()
+		a.srv.h.ServeHTTP(resp, req)
+		require.Equal(t, http.StatusOK, resp.Code)
+	})
+}
+
 func TestAgent_RegisterService(t *testing.T) {
 	if testing.Short() {
 		t.Skip("too slow for testing.Short")
@@ -953,6 +953,77 @@ func TestAgent_AddServiceWithH2CPINGCheck(t *testing.T) {
 	requireCheckExists(t, a, "test-h2cping-check")
 }
 
+func TestAgent_AddServiceWithTLSCheck(t *testing.T) {
+	t.Parallel()
+	a := NewTestAgent(t, "")
+	defer a.Shutdown()
+	check := []*structs.CheckType{
+		{
+			CheckID:              "test-tls-check",
+			Name:                 "test-tls-check",
+			TLS:                  "localhost:12345",
+			TLSServerName:        "web.example",
+			TLSExpiryWarningDays: 30,
+			Interval:             10 * time.Second,
+		},
+	}
+
+	nodeService := &structs.NodeService{
+		ID:      "test-tls-check-service",
+		Service: "test-tls-check-service",
+	}
+	err := a.addServiceFromSource(nodeService, check, false, "", ConfigSourceLocal)
+	if err != nil {
+		t.Fatalf("Error registering service: %v", err)
+	}
+	requireCheckExists(t, a, "test-tls-check")
+
+	a.stateLock.Lock()
+	tlsCheck, ok := a.checkTLSs[structs.NewCheckID("test-tls-check", nil)]
+	a.stateLock.Unlock()
+	require.True(t, ok)
+	require.Equal(t, "localhost:12345", tlsCheck.TLS)
+	require.Equal(t, 30, tlsCheck.ExpiryWarningDays)
+	require.Equal(t, "web.example", tlsCheck.TLSClientConfig.ServerName)
+}
+
+func TestAgent_AddServiceWithDatastoreChecks(t *testing.T) {
+	t.Parallel()
+	a := NewTestAgent(t, "")
+	defer a.Shutdown()
+	check := []*structs.CheckType{
+		{CheckID: "test-redis-check", Redis: "localhost:6379", Interval: 10 * time.Second},
+		{CheckID: "test-postgresql-check", PostgreSQL: "localhost:5432", Interval: 10 * time.Second},
+		{CheckID: "test-mysql-check", MySQL: "localhost:3306", Interval: 10 * time.Second},
+		{CheckID: "test-memcached-check", Memcached: "localhost:11211", Interval: 10 * time.Second},
+	}
+
+	nodeService := &structs.NodeService{
+		ID:      "test-datastore-check-service",
+		Service: "test-datastore-check-service",
+	}
+	err := a.addServiceFromSource(nodeService, check, false, "", ConfigSourceLocal)
+	if err != nil {
+		t.Fatalf("Error registering service: %v", err)
+	}
+
+	for id, protocol := range map[types.CheckID]string{
+		"test-redis-check":      checks.DatastoreRedis,
+		"test-postgresql-check": checks.DatastorePostgreSQL,
+		"test-mysql-check":      checks.DatastoreMySQL,
+		"test-memcached-check":  checks.DatastoreMemcached,
+	} {
+		requireCheckExists(t, a, id)
+
+		a.stateLock.Lock()
+		datastore, ok := a.checkDatastores[structs.NewCheckID(id, nil)]
+		a.stateLock.Unlock()
+		require.True(t, ok, id)
+		require.Equal(t, protocol, datastore.Protocol)
+		require.Equal(t, protocol, a.State.Check(structs.NewCheckID(id, nil)).Type)
+	}
+}
+
 func TestAgent_AddServiceNoExec(t *testing.T) {
 	if testing.Short() {
 		t.Skip("too slow for testing.Short")
@@ -2061,6 +2132,80 @@ acl_token = "hello"
 	require.Equal(t, "goodbye", chkImpl.RPCReq.Token)
 }
 
+func TestAgent_AddCheck_Composite(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+
+	a := NewTestAgent(t, "")
+	defer a.Shutdown()
+	testrpc.WaitForTestAgent(t, a.RPC, "dc1")
+
+	registerDB := func(t *testing.T, node, status string) {
+		args := &structs.RegisterRequest{
+			Datacenter: "dc1",
+			Node:       node,
+			Address:    "127.0.0.1",
+			Service: &structs.NodeService{
+				ID:      "db",
+				Service: "db",
+			},
+			Check: &structs.HealthCheck{
+				Node:      node,
+				CheckID:   "db",
+				Name:      "db",
+				ServiceID: "db",
+				Status:    status,
+			},
+		}
+		var out struct{}
+		require.NoError(t, a.RPC(context.Background(), "Catalog.Register", args, &out))
+	}
+	registerDB(t, "db1", api.HealthPassing)
+	registerDB(t, "db2", api.HealthCritical)
+
+	cid := structs.NewCheckID("composite", nil)
+	health := &structs.HealthCheck{
+		Node:    a.Config.NodeName,
+		CheckID: cid.ID,
+		Name:    "Composite health check",
+		Status:  api.HealthCritical,
+	}
+	chk := &structs.CheckType{
+		CompositeInputs: []structs.CompositeCheckInput{
+			{Name: "db", Service: "db", MinPassing: 2},
+			{Name: "serf", CheckID: structs.SerfCheckID},
+		},
+		CompositeExpression: "db && serf",
+	}
+	require.NoError(t, a.AddCheck(health, chk, false, "", ConfigSourceLocal))
+
+	a.stateLock.Lock()
+	chkImpl, ok := a.checkComposites[cid]
+	a.stateLock.Unlock()
+	require.True(t, ok, "missing composite check")
+	require.Equal(t, a.Config.NodeName, chkImpl.Node)
+	require.Equal(t, "dc1", chkImpl.Datacenter)
+
+	retry.Run(t, func(r *retry.R) {
+		chk := a.State.Check(cid)
+		require.NotNil(r, chk)
+		require.Equal(r, api.HealthCritical, chk.Status)
+		require.Contains(r, chk.Output, `db: critical (service "db": 1/2 instances passing, 0 warning, 2 required)`)
+		require.Contains(r, chk.Output, `serf: passing (check "serfHealth" is passing)`)
+	})
+
+	registerDB(t, "db2", api.HealthPassing)
+	retry.Run(t, func(r *retry.R) {
+		require.Equal(r, api.HealthPassing, a.State.Check(cid).Status)
+	})
+
+	require.NoError(t, a.RemoveCheck(cid, false))
+	requireCheckMissingMap(t, a.checkComposites, cid.ID)
+}
+
 func TestAgent_RemoveCheck(t *testing.T) {
 	if testing.Short() {
 		t.Skip("too slow for testing.Short")
@@ -63,6 +63,17 @@ type CheckNotifier interface {
 	ServiceExists(serviceID structs.ServiceID) bool
 }
 
+// CheckResultNotifier is implemented by a CheckNotifier that also records the
+// history of the results of checks. The StatusHandler records the result of
+// every run of a check with it, including the runs which don't change the
+// status of the check because of the success_before_passing and
+// failures_before_* thresholds, and then uses UpdateCheckStatus instead of
+// UpdateCheck so the result isn't recorded twice.
+type CheckResultNotifier interface {
+	RecordCheckResult(checkID structs.CheckID, status, output string, latency time.Duration)
+	UpdateCheckStatus(checkID structs.CheckID, status, output string)
+}
+
 // CheckMonitor is used to periodically invoke a script to
 // determine the health of a given check. It is compatible with
 // nagios plugins and expects the output in the same format.
@@ -112,6 +123,7 @@ func (c *CheckMonitor) run() {
 	for {
 		select {
 		case <-next:
+			c.StatusHandler.startCheck()
 			c.check()
 			next = time.After(c.Interval)
 		case <-c.stopCh:
@@ -352,12 +364,26 @@ type CheckHTTP struct {
 	StatusHandler    *StatusHandler
 	DisableRedirects bool
 
+	// Assertions made on the response. The status codes are either single
+	// codes, such as "304", or ranges, such as "200-299".
+	ResponseBodyRegex   string
+	ResponseJSONPath    string
+	ResponseJSONValue   string
+	ResponseHeaders     map[string]string
+	PassingStatusCodes  []string
+	WarningStatusCodes  []string
+	CriticalStatusCodes []string
+
 	httpClient *http.Client
 	stop       bool
 	stopCh     chan struct{}
 	stopLock   sync.Mutex
 	stopWg     sync.WaitGroup
 
+	// Parsed from the assertions when the check is started
+	assertions    *httpAssertions
+	assertionsErr error
+
 	// Set if checks are exposed through Connect proxies
 	// If set, this is the target of check()
 	ProxyHTTP string
@@ -374,6 +400,14 @@ func (c *CheckHTTP) CheckType() structs.CheckType {
 		ProxyHTTP:     c.ProxyHTTP,
 		Timeout:       c.Timeout,
 		OutputMaxSize: c.OutputMaxSize,
+
+		ResponseBodyRegex:   c.ResponseBodyRegex,
+		ResponseJSONPath:    c.ResponseJSONPath,
+		ResponseJSONValue:   c.ResponseJSONValue,
+		ResponseHeaders:     c.ResponseHeaders,
+		PassingStatusCodes:  c.PassingStatusCodes,
+		WarningStatusCodes:  c.WarningStatusCodes,
+		CriticalStatusCodes: c.CriticalStatusCodes,
 	}
 }
 
@@ -411,6 +445,8 @@ func (c *CheckHTTP) Start() {
 		}
 	}
 
+	c.assertions, c.assertionsErr = newHTTPAssertions(c)
+
 	c.stop = false
 	c.stopCh = make(chan struct{})
 	c.stopWg.Add(1)
@@ -439,6 +475,7 @@ func (c *CheckHTTP) run() {
 	for {
 		select {
 		case <-next:
+			c.StatusHandler.startCheck()
 			c.check()
 			next = time.After(c.Interval)
 		case <-c.stopCh:
@@ -459,6 +496,11 @@ func (c *CheckHTTP) check() {
 		target = c.ProxyHTTP
 	}
 
+	if c.assertionsErr != nil {
+		c.StatusHandler.updateCheck(c.CheckID, api.HealthCritical, c.assertionsErr.Error())
+		return
+	}
+
 	bodyReader := strings.NewReader(c.Body)
 	req, err := http.NewRequest(method, target, bodyReader)
 	if err != nil {
@@ -491,30 +533,37 @@ func (c *CheckHTTP) check() {
 	}
 	defer resp.Body.Close()
 
-	// Read the response into a circular buffer to limit the size
+	// Read the response into a circular buffer to limit the size. The
+	// beginning of the body is kept aside if the assertions need it.
 	output, _ := circbuf.NewBuffer(int64(c.OutputMaxSize))
-	if _, err := io.Copy(output, resp.Body); err != nil {
+	var body []byte
+	if c.assertions.readsBody() {
+		body, err = io.ReadAll(io.LimitReader(resp.Body, MaxResponseAssertionSize))
+		output.Write(body)
+	}
+	if err == nil {
+		_, err = io.Copy(output, resp.Body)
+	}
+	if err != nil {
 		c.Logger.Warn("Check error while reading body",
 			"check", c.CheckID.String(),
 			"error", err,
 		)
 	}
 
+	status := c.assertions.status(resp.StatusCode)
+	if status != api.HealthCritical {
+		if reason := c.assertions.check(resp.Header, body); reason != "" {
+			// Format the response body along with the failed assertion
+			result := fmt.Sprintf("HTTP %s %s: %s: %s Output: %s", method, target, resp.Status, reason, output.String())
+			c.StatusHandler.updateCheck(c.CheckID, api.HealthCritical, result)
+			return
+		}
+	}
+
 	// Format the response body
 	result := fmt.Sprintf("HTTP %s %s: %s Output: %s", method, target, resp.Status, output.String())
-
-	if resp.StatusCode >= 200 && resp.StatusCode <= 299 {
-		// PASSING (2xx)
-		c.StatusHandler.updateCheck(c.CheckID, api.HealthPassing, result)
-	} else if resp.StatusCode == 429 {
-		// WARNING
-		// 429 Too Many Requests (RFC 6585)
-		// The user has sent too many requests in a given amount of time.
-		c.StatusHandler.updateCheck(c.CheckID, api.HealthWarning, result)
-	} else {
-		// CRITICAL
-		c.StatusHandler.updateCheck(c.CheckID, api.HealthCritical, result)
-	}
+	c.StatusHandler.updateCheck(c.CheckID, status, result)
 }
 
 type CheckH2PING struct {
@@ -605,6 +654,7 @@ func (c *CheckH2PING) run() {
 	for {
 		select {
 		case <-next:
+			c.StatusHandler.startCheck()
 			c.check()
 			next = time.After(c.Interval)
 		case <-c.stopCh:
@@ -684,6 +734,7 @@ func (c *CheckTCP) run() {
 	for {
 		select {
 		case <-next:
+			c.StatusHandler.startCheck()
 			c.check()
 			next = time.After(c.Interval)
 		case <-c.stopCh:
@@ -763,6 +814,7 @@ func (c *CheckUDP) run() {
 	for {
 		select {
 		case <-next:
+			c.StatusHandler.startCheck()
 			c.check()
 			next = time.After(c.Interval)
 		case <-c.stopCh:
@@ -889,6 +941,7 @@ func (c *CheckDocker) run() {
 	for {
 		select {
 		case <-next:
+			c.StatusHandler.startCheck()
 			c.check()
 			next = time.After(c.Interval)
 		case <-c.stop:
@@ -1019,6 +1072,7 @@ func (c *CheckGRPC) run() {
 	for {
 		select {
 		case <-next:
+			c.StatusHandler.startCheck()
 			c.check()
 			next = time.After(c.Interval)
 		case <-c.stopCh:
@@ -1104,6 +1158,7 @@ func (c *CheckOSService) run() {
 	for {
 		select {
 		case <-next:
+			c.StatusHandler.startCheck()
 			c.check()
 			next = time.After(c.Interval)
 		case <-c.stopCh:
@@ -1180,6 +1235,10 @@ type StatusHandler struct {
 	failuresBeforeWarning  int
 	failuresBeforeCritical int
 	failuresCounter        int
+
+	// started is when the current run of the check started, used to report
+	// its latency.
+	started time.Time
 }
 
 // NewStatusHandler set counters values to threshold in order to immediatly update status after first check.
@@ -1195,7 +1254,36 @@ func NewStatusHandler(inner CheckNotifier, logger hclog.Logger, successBeforePas
 	}
 }
 
+// startCheck records the start of a run of the check.
+func (s *StatusHandler) startCheck() {
+	s.started = time.Now()
+}
+
+// record records the result of the current run of the check, along with its
+// latency, if inner records them.
+func (s *StatusHandler) record(checkID structs.CheckID, status, output string) {
+	n, ok := s.inner.(CheckResultNotifier)
+	if !ok {
+		return
+	}
+	var latency time.Duration
+	if !s.started.IsZero() {
+		latency = time.Since(s.started)
+	}
+	n.RecordCheckResult(checkID, status, output, latency)
+}
+
+// notify updates the status of the check with inner.
+func (s *StatusHandler) notify(checkID structs.CheckID, status, output string) {
+	if n, ok := s.inner.(CheckResultNotifier); ok {
+		n.UpdateCheckStatus(checkID, status, output)
+		return
+	}
+	s.inner.UpdateCheck(checkID, status, output)
+}
+
 func (s *StatusHandler) updateCheck(checkID structs.CheckID, status, output string) {
+	s.record(checkID, status, output)
 
 	if status == api.HealthPassing || status == api.HealthWarning {
 		s.successCounter++
@@ -1205,7 +1293,7 @@ func (s *StatusHandler) updateCheck(checkID structs.CheckID, status, output stri
 				"check", checkID.String(),
 				"status", status,
 			)
-			s.inner.UpdateCheck(checkID, status, output)
+			s.notify(checkID, status, output)
 			return
 		}
 		s.logger.Warn("Check passed but has not reached success threshold",
@@ -1219,13 +1307,13 @@ func (s *StatusHandler) updateCheck(checkID structs.CheckID, status, output stri
 		s.successCounter = 0
 		if s.failuresCounter >= s.failuresBeforeCritical {
 			s.logger.Warn("Check is now critical", "check", checkID.String())
-			s.inner.UpdateCheck(checkID, status, output)
+			s.notify(checkID, status, output)
 			return
 		}
 		// Defaults to same value as failuresBeforeCritical if not set.
 		if s.failuresCounter >= s.failuresBeforeWarning {
 			s.logger.Warn("Check is now warning", "check", checkID.String())
-			s.inner.UpdateCheck(checkID, api.HealthWarning, output)
+			s.notify(checkID, api.HealthWarning, output)
 			return
 		}
 		s.logger.Warn("Check failed but has not reached warning/failure threshold",
@@ -506,6 +506,236 @@ func TestCheckHTTP_DisableRedirects(t *testing.T) {
 	})
 }
 
+func TestCheckHTTP_ResponseAssertions(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		desc   string
+		code   int
+		body   string
+		header http.Header
+		check  *CheckHTTP
+		status string
+		output string
+	}{
+		{
+			desc:   "body regex matches",
+			code:   200,
+			body:   "status: ok",
+			check:  &CheckHTTP{ResponseBodyRegex: `status: (ok|fine)`},
+			status: api.HealthPassing,
+		},
+		{
+			desc:   "body regex doesn't match",
+			code:   200,
+			body:   "status: degraded",
+			check:  &CheckHTTP{ResponseBodyRegex: `status: (ok|fine)`},
+			status: api.HealthCritical,
+			output: "response body does not match",
+		},
+		{
+			desc:   "JSON path has the expected value",
+			code:   200,
+			body:   `{"checks": [{"status": "healthy", "count": 3}]}`,
+			check:  &CheckHTTP{ResponseJSONPath: "$.checks[0].status", ResponseJSONValue: "healthy"},
+			status: api.HealthPassing,
+		},
+		{
+			desc:   "JSON path has a number",
+			code:   200,
+			body:   `{"checks": [{"status": "healthy", "count": 3}]}`,
+			check:  &CheckHTTP{ResponseJSONPath: "checks[0].count", ResponseJSONValue: "3"},
+			status: api.HealthPassing,
+		},
+		{
+			desc:   "JSON path has another value",
+			code:   200,
+			body:   `{"status": "degraded"}`,
+			check:  &CheckHTTP{ResponseJSONPath: "$.status", ResponseJSONValue: "healthy"},
+			status: api.HealthCritical,
+			output: `JSON path "$.status" is "degraded", expected "healthy"`,
+		},
+		{
+			desc:   "JSON path is missing",
+			code:   200,
+			body:   `{"checks": []}`,
+			check:  &CheckHTTP{ResponseJSONPath: "$.checks[0].status"},
+			status: api.HealthCritical,
+			output: "not found in response body",
+		},
+		{
+			desc:   "body isn't JSON",
+			code:   200,
+			body:   "ok",
+			check:  &CheckHTTP{ResponseJSONPath: "$.status"},
+			status: api.HealthCritical,
+			output: "response body is not valid JSON",
+		},
+		{
+			desc:   "required header is present",
+			code:   200,
+			header: http.Header{"X-Ready": []string{"yes"}, "X-Version": []string{"1.2.3"}},
+			check:  &CheckHTTP{ResponseHeaders: map[string]string{"X-Ready": "yes", "X-Version": ""}},
+			status: api.HealthPassing,
+		},
+		{
+			desc:   "required header has another value",
+			code:   200,
+			header: http.Header{"X-Ready": []string{"no"}},
+			check:  &CheckHTTP{ResponseHeaders: map[string]string{"X-Ready": "yes"}},
+			status: api.HealthCritical,
+			output: `response header "X-Ready" is "no", expected "yes"`,
+		},
+		{
+			desc:   "required header is missing",
+			code:   200,
+			check:  &CheckHTTP{ResponseHeaders: map[string]string{"X-Ready": ""}},
+			status: api.HealthCritical,
+			output: `missing response header "X-Ready"`,
+		},
+		{
+			desc:   "passing status codes",
+			code:   304,
+			check:  &CheckHTTP{PassingStatusCodes: []string{"200-299", "304"}},
+			status: api.HealthPassing,
+		},
+		{
+			desc:   "2xx isn't passing unless listed",
+			code:   204,
+			check:  &CheckHTTP{PassingStatusCodes: []string{"200"}},
+			status: api.HealthCritical,
+		},
+		{
+			desc:   "warning status codes",
+			code:   503,
+			check:  &CheckHTTP{WarningStatusCodes: []string{"503"}},
+			status: api.HealthWarning,
+		},
+		{
+			desc:   "critical status codes",
+			code:   202,
+			check:  &CheckHTTP{CriticalStatusCodes: []string{"202"}},
+			status: api.HealthCritical,
+		},
+		{
+			desc:   "failed assertion on a warning",
+			code:   429,
+			body:   "busy",
+			check:  &CheckHTTP{ResponseBodyRegex: "ok"},
+			status: api.HealthCritical,
+			output: "response body does not match",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.desc, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				for k, v := range tt.header {
+					w.Header()[k] = v
+				}
+				w.WriteHeader(tt.code)
+				fmt.Fprint(w, tt.body)
+			}))
+			defer server.Close()
+
+			notif := mock.NewNotify()
+			logger := testutil.Logger(t)
+			statusHandler := NewStatusHandler(notif, logger, 0, 0, 0)
+			cid := structs.NewCheckID("foo", nil)
+
+			check := tt.check
+			check.CheckID = cid
+			check.HTTP = server.URL
+			check.Interval = 10 * time.Millisecond
+			check.Logger = logger
+			check.StatusHandler = statusHandler
+			check.Start()
+			defer check.Stop()
+
+			retry.Run(t, func(r *retry.R) {
+				if got, want := notif.Updates(cid), 2; got < want {
+					r.Fatalf("got %d updates want at least %d", got, want)
+				}
+				if got, want := notif.State(cid), tt.status; got != want {
+					r.Fatalf("got state %q want %q", got, want)
+				}
+				if output := notif.Output(cid); !strings.Contains(output, tt.output) {
+					r.Fatalf("got output %q want %q", output, tt.output)
+				}
+			})
+		})
+	}
+}
+
+func TestCheckHTTP_ResponseAssertions_Proxied(t *testing.T) {
+	t.Parallel()
+
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"status": "degraded"}`)
+	}))
+	defer proxy.Close()
+
+	notif := mock.NewNotify()
+	logger := testutil.Logger(t)
+	statusHandler := NewStatusHandler(notif, logger, 0, 0, 0)
+	cid := structs.NewCheckID("foo", nil)
+
+	check := &CheckHTTP{
+		CheckID:           cid,
+		HTTP:              "",
+		Method:            "GET",
+		OutputMaxSize:     DefaultBufSize,
+		Interval:          10 * time.Millisecond,
+		Logger:            logger,
+		ProxyHTTP:         proxy.URL,
+		StatusHandler:     statusHandler,
+		ResponseJSONPath:  "$.status",
+		ResponseJSONValue: "healthy",
+	}
+	check.Start()
+	defer check.Stop()
+
+	// The assertions are made on the response of the proxied check.
+	retry.Run(t, func(r *retry.R) {
+		if got, want := notif.State(cid), api.HealthCritical; got != want {
+			r.Fatalf("got state %q want %q", got, want)
+		}
+		if output := notif.Output(cid); !strings.Contains(output, `expected "healthy"`) {
+			r.Fatalf("unexpected output %q", output)
+		}
+	})
+}
+
+func TestCheckHTTP_InvalidResponseAssertions(t *testing.T) {
+	t.Parallel()
+
+	notif := mock.NewNotify()
+	logger := testutil.Logger(t)
+	statusHandler := NewStatusHandler(notif, logger, 0, 0, 0)
+	cid := structs.NewCheckID("foo", nil)
+
+	check := &CheckHTTP{
+		CheckID:          cid,
+		HTTP:             "http://127.0.0.1:0",
+		Interval:         10 * time.Millisecond,
+		Logger:           logger,
+		StatusHandler:    statusHandler,
+		ResponseJSONPath: "$.checks[x]",
+	}
+	check.Start()
+	defer check.Stop()
+
+	retry.Run(t, func(r *retry.R) {
+		if got, want := notif.State(cid), api.HealthCritical; got != want {
+			r.Fatalf("got state %q want %q", got, want)
+		}
+		if output := notif.Output(cid); !strings.Contains(output, "invalid JSON path") {
+			r.Fatalf("unexpected output %q", output)
+		}
+	})
+}
+
 func TestCheckHTTPTCP_BigTimeout(t *testing.T) {
 	testCases := []struct {
 		timeoutIn, intervalIn, timeoutWant time.Duration
@@ -1126,6 +1356,75 @@ func TestStatusHandlerMaintainWarningStatusWhenCheckIsFlapping(t *testing.T) {
 	})
 }
 
+// resultNotify is a CheckNotifier that records the check results.
+type resultNotify struct {
+	*mock.Notify
+
+	lock      sync.Mutex
+	statuses  []string
+	latencies []time.Duration
+}
+
+func (n *resultNotify) RecordCheckResult(id structs.CheckID, status, output string, latency time.Duration) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	n.statuses = append(n.statuses, status)
+	n.latencies = append(n.latencies, latency)
+}
+
+func (n *resultNotify) UpdateCheckStatus(id structs.CheckID, status, output string) {
+	n.UpdateCheck(id, status, output)
+}
+
+func TestStatusHandlerReportsLatency(t *testing.T) {
+	t.Parallel()
+	cid := structs.NewCheckID("foo", nil)
+	notif := &resultNotify{Notify: mock.NewNotify()}
+	statusHandler := NewStatusHandler(notif, testutil.Logger(t), 0, 0, 0)
+
+	// Results without a run of the check have no latency.
+	statusHandler.updateCheck(cid, api.HealthPassing, "bar")
+
+	statusHandler.startCheck()
+	time.Sleep(10 * time.Millisecond)
+	statusHandler.updateCheck(cid, api.HealthCritical, "bar")
+
+	require.Equal(t, 2, notif.Updates(cid))
+	require.Equal(t, api.HealthCritical, notif.State(cid))
+	require.Len(t, notif.latencies, 2)
+	require.Zero(t, notif.latencies[0])
+	require.GreaterOrEqual(t, notif.latencies[1], 10*time.Millisecond)
+}
+
+func TestStatusHandlerRecordsEveryResult(t *testing.T) {
+	t.Parallel()
+	cid := structs.NewCheckID("foo", nil)
+	notif := &resultNotify{Notify: mock.NewNotify()}
+	statusHandler := NewStatusHandler(notif, testutil.Logger(t), 2, 3, 3)
+
+	// The first result sets the status right away.
+	statusHandler.updateCheck(cid, api.HealthPassing, "bar")
+
+	// Results which don't reach the thresholds are recorded without changing
+	// the status.
+	statusHandler.updateCheck(cid, api.HealthCritical, "bar")
+	statusHandler.updateCheck(cid, api.HealthCritical, "bar")
+	statusHandler.updateCheck(cid, api.HealthPassing, "bar")
+	require.Equal(t, 1, notif.Updates(cid))
+	require.Equal(t, api.HealthPassing, notif.State(cid))
+
+	statusHandler.updateCheck(cid, api.HealthPassing, "bar")
+	require.Equal(t, 2, notif.Updates(cid))
+
+	require.Equal(t, []string{
+		api.HealthPassing,
+		api.HealthCritical,
+		api.HealthCritical,
+		api.HealthPassing,
+		api.HealthPassing,
+	}, notif.statuses)
+}
+
 func TestCheckTCPCritical(t *testing.T) {
 	t.Parallel()
 	var (
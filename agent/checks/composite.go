@@ -0,0 +1,370 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package checks
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/go-hclog"
+
+	"github.com/hashicorp/consul/acl"
+	"github.com/hashicorp/consul/agent/cache"
+	"github.com/hashicorp/consul/agent/structs"
+	"github.com/hashicorp/consul/api"
+)
+
+// CompositeNotifier is used by composite checks to watch the health of their
+// inputs through the agent cache.
+type CompositeNotifier interface {
+	// NotifyServiceHealth watches the instances of a service and their
+	// checks. Results are *structs.IndexedCheckServiceNodes.
+	NotifyServiceHealth(ctx context.Context, req *structs.ServiceSpecificRequest, correlationID string, ch chan<- cache.UpdateEvent) error
+
+	// NotifyNodeChecks watches the checks of a node. Results are
+	// *structs.IndexedHealthChecks.
+	NotifyNodeChecks(ctx context.Context, req *structs.NodeSpecificRequest, correlationID string, ch chan<- cache.UpdateEvent) error
+
+	// NotifyLocalChecks is like NotifyNodeChecks for the local node, but the
+	// checks registered with the agent are read from its local state, which
+	// is ahead of the catalog until anti-entropy syncs them.
+	NotifyLocalChecks(ctx context.Context, req *structs.NodeSpecificRequest, correlationID string, ch chan<- cache.UpdateEvent) error
+}
+
+// CheckComposite is a check type that combines the health of several local
+// or remote checks and services, its inputs.
+//
+// With an Expression, the status of the check is the status of the boolean
+// expression over its inputs. With MinPassing, the check is passing if at
+// least MinPassing inputs are passing, warning if at least MinPassing inputs
+// are passing or warning, and critical otherwise. Without either, the status
+// of the check is the worst status of its inputs. The output of the check
+// lists the status of each input.
+type CheckComposite struct {
+	CheckID    structs.CheckID
+	ServiceID  structs.ServiceID
+	Inputs     []structs.CompositeCheckInput
+	Expression string
+	MinPassing int
+
+	// Node and Datacenter are the node of check inputs and the datacenter of
+	// all inputs when the inputs don't set them.
+	Node       string
+	Datacenter string
+
+	// Token is the ACL token used to watch the inputs.
+	Token string
+
+	Notifier      CompositeNotifier
+	StatusHandler *StatusHandler
+	Logger        hclog.Logger
+
+	acl.EnterpriseMeta
+
+	cancel   context.CancelFunc
+	stopLock sync.Mutex
+	stopWg   sync.WaitGroup
+}
+
+// compositeInputStatus is the status of an input of a composite check.
+type compositeInputStatus struct {
+	status string
+	reason string
+}
+
+func (c *CheckComposite) CheckType() structs.CheckType {
+	return structs.CheckType{
+		CheckID:             c.CheckID.ID,
+		CompositeInputs:     c.Inputs,
+		CompositeExpression: c.Expression,
+		CompositeMinPassing: c.MinPassing,
+	}
+}
+
+// Start is used to start a composite check.
+// The check runs until stop is called
+func (c *CheckComposite) Start() {
+	c.stopLock.Lock()
+	defer c.stopLock.Unlock()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	c.cancel = cancel
+	c.stopWg.Add(1)
+	go c.run(ctx)
+}
+
+// Stop is used to stop a composite check.
+func (c *CheckComposite) Stop() {
+	c.stopLock.Lock()
+	defer c.stopLock.Unlock()
+	if c.cancel != nil {
+		c.cancel()
+	}
+
+	// Wait for the c.run() goroutine to complete before returning.
+	c.stopWg.Wait()
+}
+
+// run watches the inputs of the check and updates its status whenever one of
+// them changes, until Stop() is called.
+func (c *CheckComposite) run(ctx context.Context) {
+	defer c.stopWg.Done()
+
+	var expr *structs.CompositeCheckExpr
+	if c.Expression != "" {
+		var err error
+		if expr, err = structs.ParseCompositeCheckExpr(c.Expression); err != nil {
+			c.StatusHandler.updateCheck(c.CheckID, api.HealthCritical, fmt.Sprintf("Invalid composite expression %q: %v", c.Expression, err))
+			return
+		}
+	}
+
+	ch := make(chan cache.UpdateEvent, len(c.Inputs))
+	for i := range c.Inputs {
+		if err := c.watch(ctx, i, ch); err != nil {
+			c.StatusHandler.updateCheck(c.CheckID, api.HealthCritical, fmt.Sprintf("Failed to watch composite input %q: %v", c.Inputs[i].Name, err))
+			return
+		}
+	}
+
+	statuses := make([]*compositeInputStatus, len(c.Inputs))
+	pending := len(c.Inputs)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case u := <-ch:
+			i, err := strconv.Atoi(u.CorrelationID)
+			if err != nil || i < 0 || i >= len(c.Inputs) {
+				c.Logger.Error("unexpected composite check update", "check", c.CheckID.String(), "correlation_id", u.CorrelationID)
+				continue
+			}
+			if statuses[i] == nil {
+				pending--
+			}
+			statuses[i] = c.inputStatus(c.Inputs[i], u)
+
+			// The check stays in its initial state until every input is known.
+			if pending == 0 {
+				status, output := c.evaluate(expr, statuses)
+				c.StatusHandler.updateCheck(c.CheckID, status, output)
+			}
+		}
+	}
+}
+
+// watch starts watching the input at index i, whose updates are sent to ch
+// with the index as correlation ID.
+func (c *CheckComposite) watch(ctx context.Context, i int, ch chan<- cache.UpdateEvent) error {
+	input := c.Inputs[i]
+	dc := input.Datacenter
+	if dc == "" {
+		dc = c.Datacenter
+	}
+	correlationID := strconv.Itoa(i)
+
+	if input.IsService() {
+		req := &structs.ServiceSpecificRequest{
+			Datacenter:     dc,
+			ServiceName:    input.Service,
+			QueryOptions:   structs.QueryOptions{Token: c.Token},
+			EnterpriseMeta: c.EnterpriseMeta,
+		}
+		return c.Notifier.NotifyServiceHealth(ctx, req, correlationID, ch)
+	}
+
+	node := input.Node
+	if node == "" {
+		node = c.Node
+	}
+	req := &structs.NodeSpecificRequest{
+		Datacenter:     dc,
+		Node:           node,
+		QueryOptions:   structs.QueryOptions{Token: c.Token},
+		EnterpriseMeta: c.EnterpriseMeta,
+	}
+	if c.isLocal(input) {
+		return c.Notifier.NotifyLocalChecks(ctx, req, correlationID, ch)
+	}
+	return c.Notifier.NotifyNodeChecks(ctx, req, correlationID, ch)
+}
+
+// isLocal returns whether the input watches checks of the local node.
+func (c *CheckComposite) isLocal(input structs.CompositeCheckInput) bool {
+	return !input.IsService() &&
+		(input.Node == "" || input.Node == c.Node) &&
+		(input.Datacenter == "" || input.Datacenter == c.Datacenter)
+}
+
+// inputStatus returns the status of an input from an update of its watch.
+func (c *CheckComposite) inputStatus(input structs.CompositeCheckInput, u cache.UpdateEvent) *compositeInputStatus {
+	if u.Err != nil {
+		return &compositeInputStatus{api.HealthCritical, fmt.Sprintf("failed to fetch %s: %v", c.describe(input), u.Err)}
+	}
+
+	switch result := u.Result.(type) {
+	case *structs.IndexedCheckServiceNodes:
+		minPassing := input.MinPassing
+		if minPassing <= 0 {
+			minPassing = 1
+		}
+		var passing, warning int
+		for _, csn := range result.Nodes {
+			switch worstCheckStatus(csn.Checks) {
+			case api.HealthPassing:
+				passing++
+			case api.HealthWarning:
+				warning++
+			}
+		}
+		status := api.HealthCritical
+		switch {
+		case passing >= minPassing:
+			status = api.HealthPassing
+		case passing+warning >= minPassing:
+			status = api.HealthWarning
+		}
+		return &compositeInputStatus{status, fmt.Sprintf("%s: %d/%d instances passing, %d warning, %d required",
+			c.describe(input), passing, len(result.Nodes), warning, minPassing)}
+
+	case *structs.IndexedHealthChecks:
+		if input.CheckID == "" {
+			// The check itself is one of the local node's checks, but must not
+			// depend on its own status.
+			checks := result.HealthChecks
+			if c.isLocal(input) {
+				checks = make(structs.HealthChecks, 0, len(result.HealthChecks))
+				for _, chk := range result.HealthChecks {
+					if chk.CheckID != c.CheckID.ID {
+						checks = append(checks, chk)
+					}
+				}
+			}
+			if len(checks) == 0 {
+				return &compositeInputStatus{api.HealthCritical, fmt.Sprintf("%s: no checks found", c.describe(input))}
+			}
+			status := worstCheckStatus(checks)
+			return &compositeInputStatus{status, fmt.Sprintf("%s: %d checks, worst is %s",
+				c.describe(input), len(checks), status)}
+		}
+		for _, chk := range result.HealthChecks {
+			if chk.CheckID == input.CheckID {
+				return &compositeInputStatus{chk.Status, fmt.Sprintf("%s is %s", c.describe(input), chk.Status)}
+			}
+		}
+		return &compositeInputStatus{api.HealthCritical, fmt.Sprintf("%s not found", c.describe(input))}
+
+	default:
+		return &compositeInputStatus{api.HealthCritical, fmt.Sprintf("unexpected result type %T for %s", u.Result, c.describe(input))}
+	}
+}
+
+// evaluate returns the status and the output of the check given the status
+// of all of its inputs. The output is deterministic so that unchanged inputs
+// don't update the check.
+func (c *CheckComposite) evaluate(expr *structs.CompositeCheckExpr, statuses []*compositeInputStatus) (string, string) {
+	byName := make(map[string]string, len(c.Inputs))
+	counts := make(map[string]int)
+	for i, input := range c.Inputs {
+		byName[input.Name] = statuses[i].status
+		counts[normalizeCheckStatus(statuses[i].status)]++
+	}
+
+	var status, reason string
+	switch {
+	case expr != nil:
+		status = expr.Eval(func(name string) string { return byName[name] })
+		reason = fmt.Sprintf("expression %q is %s", c.Expression, status)
+
+	case c.MinPassing > 0:
+		passing, warning := counts[api.HealthPassing], counts[api.HealthWarning]
+		status = api.HealthCritical
+		switch {
+		case passing >= c.MinPassing:
+			status = api.HealthPassing
+		case passing+warning >= c.MinPassing:
+			status = api.HealthWarning
+		}
+		reason = fmt.Sprintf("%d/%d inputs passing, %d warning, %d required", passing, len(c.Inputs), warning, c.MinPassing)
+
+	default:
+		status = api.HealthPassing
+		for _, s := range statuses {
+			status = worseCheckStatus(status, s.status)
+		}
+		var names []string
+		for _, input := range c.Inputs {
+			if normalizeCheckStatus(byName[input.Name]) == status {
+				names = append(names, input.Name)
+			}
+		}
+		if len(names) > 1 {
+			reason = fmt.Sprintf("%s are %s", strings.Join(names, ", "), status)
+		} else {
+			reason = fmt.Sprintf("%s is %s", names[0], status)
+		}
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Composite check is %s: %s", status, reason)
+	for i, input := range c.Inputs {
+		fmt.Fprintf(&sb, "\n%s: %s (%s)", input.Name, statuses[i].status, statuses[i].reason)
+	}
+	return status, sb.String()
+}
+
+// describe returns a description of an input for the output of the check.
+func (c *CheckComposite) describe(input structs.CompositeCheckInput) string {
+	var desc string
+	switch {
+	case input.IsService():
+		desc = fmt.Sprintf("service %q", input.Service)
+	case input.CheckID == "":
+		desc = fmt.Sprintf("node %q", input.Node)
+	case input.Node == "":
+		desc = fmt.Sprintf("check %q", input.CheckID)
+	default:
+		desc = fmt.Sprintf("check %q on node %q", input.CheckID, input.Node)
+	}
+	if input.Datacenter != "" {
+		desc += fmt.Sprintf(" in %s", input.Datacenter)
+	}
+	return desc
+}
+
+// worstCheckStatus returns the worst status of the checks, passing if there
+// are none.
+func worstCheckStatus(checks structs.HealthChecks) string {
+	status := api.HealthPassing
+	for _, chk := range checks {
+		status = worseCheckStatus(status, chk.Status)
+	}
+	return status
+}
+
+// worseCheckStatus returns the worst of two statuses. Statuses other than
+// passing and warning, such as maintenance, are considered critical.
+func worseCheckStatus(a, b string) string {
+	a, b = normalizeCheckStatus(a), normalizeCheckStatus(b)
+	switch {
+	case a == api.HealthCritical || b == api.HealthCritical:
+		return api.HealthCritical
+	case a == api.HealthWarning || b == api.HealthWarning:
+		return api.HealthWarning
+	default:
+		return api.HealthPassing
+	}
+}
+
+func normalizeCheckStatus(status string) string {
+	switch status {
+	case api.HealthPassing, api.HealthWarning:
+		return status
+	default:
+		return api.HealthCritical
+	}
+}
@@ -0,0 +1,337 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package checks
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/consul/agent/cache"
+	"github.com/hashicorp/consul/agent/mock"
+	"github.com/hashicorp/consul/agent/structs"
+	"github.com/hashicorp/consul/api"
+	"github.com/hashicorp/consul/sdk/testutil"
+	"github.com/hashicorp/consul/sdk/testutil/retry"
+	"github.com/hashicorp/consul/types"
+)
+
+// compositeServiceResult returns the health of a service with an instance
+// for each status.
+func compositeServiceResult(statuses ...string) *structs.IndexedCheckServiceNodes {
+	var result structs.IndexedCheckServiceNodes
+	for i, status := range statuses {
+		node := fmt.Sprintf("node%d", i)
+		result.Nodes = append(result.Nodes, structs.CheckServiceNode{
+			Node:    &structs.Node{Node: node},
+			Service: &structs.NodeService{ID: "db", Service: "db"},
+			Checks: structs.HealthChecks{
+				{Node: node, CheckID: "serfHealth", Status: api.HealthPassing},
+				{Node: node, CheckID: "db", ServiceID: "db", Status: status},
+			},
+		})
+	}
+	return &result
+}
+
+// compositeNodeResult returns the checks of a node with the given statuses.
+func compositeNodeResult(statuses map[types.CheckID]string) *structs.IndexedHealthChecks {
+	var result structs.IndexedHealthChecks
+	for id, status := range statuses {
+		result.HealthChecks = append(result.HealthChecks, &structs.HealthCheck{Node: "node1", CheckID: id, Status: status})
+	}
+	return &result
+}
+
+func TestCheckComposite_Evaluate(t *testing.T) {
+	t.Parallel()
+
+	db := structs.CompositeCheckInput{Name: "db", Service: "db", MinPassing: 2}
+	redis := structs.CompositeCheckInput{Name: "cache", Node: "node1", CheckID: "redis"}
+	node := structs.CompositeCheckInput{Name: "node", Node: "node1"}
+
+	tests := []struct {
+		desc       string
+		inputs     []structs.CompositeCheckInput
+		results    []cache.UpdateEvent
+		expression string
+		minPassing int
+		status     string
+		output     string
+	}{
+		{
+			desc:   "worst status",
+			inputs: []structs.CompositeCheckInput{db, redis},
+			results: []cache.UpdateEvent{
+				{Result: compositeServiceResult(api.HealthPassing, api.HealthPassing, api.HealthCritical)},
+				{Result: compositeNodeResult(map[types.CheckID]string{"redis": api.HealthWarning})},
+			},
+			status: api.HealthWarning,
+			output: "Composite check is warning: cache is warning\n" +
+				`db: passing (service "db": 2/3 instances passing, 0 warning, 2 required)` + "\n" +
+				`cache: warning (check "redis" on node "node1" is warning)`,
+		},
+		{
+			desc:   "worst status of several inputs",
+			inputs: []structs.CompositeCheckInput{db, redis},
+			results: []cache.UpdateEvent{
+				{Result: compositeServiceResult(api.HealthPassing)},
+				{Result: compositeNodeResult(map[types.CheckID]string{"redis": api.HealthCritical})},
+			},
+			status: api.HealthCritical,
+			output: "Composite check is critical: db, cache are critical\n",
+		},
+		{
+			desc:   "service quorum with warnings",
+			inputs: []structs.CompositeCheckInput{db},
+			results: []cache.UpdateEvent{
+				{Result: compositeServiceResult(api.HealthPassing, api.HealthWarning, api.HealthCritical)},
+			},
+			status: api.HealthWarning,
+			output: `db: warning (service "db": 1/3 instances passing, 1 warning, 2 required)`,
+		},
+		{
+			desc:   "service without instances",
+			inputs: []structs.CompositeCheckInput{{Name: "db", Service: "db", Datacenter: "dc2"}},
+			results: []cache.UpdateEvent{
+				{Result: compositeServiceResult()},
+			},
+			status: api.HealthCritical,
+			output: `db: critical (service "db" in dc2: 0/0 instances passing, 0 warning, 1 required)`,
+		},
+		{
+			desc:   "missing check",
+			inputs: []structs.CompositeCheckInput{redis},
+			results: []cache.UpdateEvent{
+				{Result: compositeNodeResult(map[types.CheckID]string{"memcached": api.HealthPassing})},
+			},
+			status: api.HealthCritical,
+			output: `cache: critical (check "redis" on node "node1" not found)`,
+		},
+		{
+			desc:   "all checks of a node",
+			inputs: []structs.CompositeCheckInput{node},
+			results: []cache.UpdateEvent{
+				{Result: compositeNodeResult(map[types.CheckID]string{"serfHealth": api.HealthPassing, "disk": api.HealthWarning})},
+			},
+			status: api.HealthWarning,
+			output: `node: warning (node "node1": 2 checks, worst is warning)`,
+		},
+		{
+			desc:   "node in maintenance",
+			inputs: []structs.CompositeCheckInput{node},
+			results: []cache.UpdateEvent{
+				{Result: compositeNodeResult(map[types.CheckID]string{"_node_maintenance": api.HealthMaint})},
+			},
+			status: api.HealthCritical,
+			output: `node: critical (node "node1": 1 checks, worst is critical)`,
+		},
+		{
+			desc:   "unknown node",
+			inputs: []structs.CompositeCheckInput{node},
+			results: []cache.UpdateEvent{
+				{Result: compositeNodeResult(nil)},
+			},
+			status: api.HealthCritical,
+			output: `node: critical (node "node1": no checks found)`,
+		},
+		{
+			desc:   "fetch error",
+			inputs: []structs.CompositeCheckInput{db},
+			results: []cache.UpdateEvent{
+				{Err: fmt.Errorf("Permission denied")},
+			},
+			status: api.HealthCritical,
+			output: `db: critical (failed to fetch service "db": Permission denied)`,
+		},
+		{
+			desc:       "expression with a failing input",
+			inputs:     []structs.CompositeCheckInput{db, redis},
+			expression: "db || cache",
+			results: []cache.UpdateEvent{
+				{Result: compositeServiceResult(api.HealthCritical, api.HealthCritical)},
+				{Result: compositeNodeResult(map[types.CheckID]string{"redis": api.HealthPassing})},
+			},
+			status: api.HealthPassing,
+			output: `Composite check is passing: expression "db || cache" is passing`,
+		},
+		{
+			desc:       "expression over both failing inputs",
+			inputs:     []structs.CompositeCheckInput{db, redis},
+			expression: "!(db and cache)",
+			results: []cache.UpdateEvent{
+				{Result: compositeServiceResult(api.HealthCritical, api.HealthCritical)},
+				{Result: compositeNodeResult(map[types.CheckID]string{"redis": api.HealthCritical})},
+			},
+			status: api.HealthPassing,
+		},
+		{
+			desc:       "quorum of inputs",
+			inputs:     []structs.CompositeCheckInput{db, redis, node},
+			minPassing: 2,
+			results: []cache.UpdateEvent{
+				{Result: compositeServiceResult(api.HealthPassing, api.HealthPassing)},
+				{Result: compositeNodeResult(map[types.CheckID]string{"redis": api.HealthWarning})},
+				{Result: compositeNodeResult(map[types.CheckID]string{"serfHealth": api.HealthCritical})},
+			},
+			status: api.HealthWarning,
+			output: "Composite check is warning: 1/3 inputs passing, 1 warning, 2 required",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.desc, func(t *testing.T) {
+			c := &CheckComposite{
+				Inputs:     tt.inputs,
+				Expression: tt.expression,
+				MinPassing: tt.minPassing,
+			}
+			var expr *structs.CompositeCheckExpr
+			if tt.expression != "" {
+				var err error
+				expr, err = structs.ParseCompositeCheckExpr(tt.expression)
+				require.NoError(t, err)
+			}
+			statuses := make([]*compositeInputStatus, len(tt.inputs))
+			for i, input := range tt.inputs {
+				statuses[i] = c.inputStatus(input, tt.results[i])
+			}
+
+			status, output := c.evaluate(expr, statuses)
+			require.Equal(t, tt.status, status, output)
+			require.Contains(t, output, tt.output)
+		})
+	}
+}
+
+func TestCheckComposite_InputStatus_ExcludesItself(t *testing.T) {
+	t.Parallel()
+
+	c := &CheckComposite{
+		CheckID:    structs.NewCheckID("composite", nil),
+		Node:       "node1",
+		Datacenter: "dc1",
+	}
+	result := cache.UpdateEvent{Result: compositeNodeResult(map[types.CheckID]string{
+		"composite":  api.HealthCritical,
+		"serfHealth": api.HealthPassing,
+	})}
+
+	// The composite check is one of the local node's checks, so it must not
+	// count towards its own status.
+	status := c.inputStatus(structs.CompositeCheckInput{Name: "node"}, result)
+	require.Equal(t, api.HealthPassing, status.status)
+
+	// A check with the same ID on another node is a different check.
+	status = c.inputStatus(structs.CompositeCheckInput{Name: "node", Node: "node2"}, result)
+	require.Equal(t, api.HealthCritical, status.status)
+}
+
+// fakeCompositeNotifier records the watches of a composite check.
+type fakeCompositeNotifier struct {
+	lock     sync.Mutex
+	services []*structs.ServiceSpecificRequest
+	nodes    []*structs.NodeSpecificRequest
+	local    []*structs.NodeSpecificRequest
+	ch       chan<- cache.UpdateEvent
+}
+
+func (n *fakeCompositeNotifier) NotifyServiceHealth(_ context.Context, req *structs.ServiceSpecificRequest, _ string, ch chan<- cache.UpdateEvent) error {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	n.services = append(n.services, req)
+	n.ch = ch
+	return nil
+}
+
+func (n *fakeCompositeNotifier) NotifyNodeChecks(_ context.Context, req *structs.NodeSpecificRequest, _ string, ch chan<- cache.UpdateEvent) error {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	n.nodes = append(n.nodes, req)
+	n.ch = ch
+	return nil
+}
+
+func (n *fakeCompositeNotifier) NotifyLocalChecks(_ context.Context, req *structs.NodeSpecificRequest, _ string, ch chan<- cache.UpdateEvent) error {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	n.local = append(n.local, req)
+	n.ch = ch
+	return nil
+}
+
+func (n *fakeCompositeNotifier) send(t *testing.T, i int, result interface{}) {
+	t.Helper()
+	var ch chan<- cache.UpdateEvent
+	retry.Run(t, func(r *retry.R) {
+		n.lock.Lock()
+		defer n.lock.Unlock()
+		if n.ch == nil {
+			r.Fatal("inputs aren't watched")
+		}
+		ch = n.ch
+	})
+	ch <- cache.UpdateEvent{CorrelationID: strconv.Itoa(i), Result: result}
+}
+
+func TestCheckComposite_Start(t *testing.T) {
+	t.Parallel()
+
+	notif := mock.NewNotify()
+	notifier := &fakeCompositeNotifier{}
+	cid := structs.NewCheckID("composite", nil)
+
+	check := &CheckComposite{
+		CheckID: cid,
+		Inputs: []structs.CompositeCheckInput{
+			{Name: "db", Service: "db", Datacenter: "dc2"},
+			{Name: "local", CheckID: "redis"},
+		},
+		Expression:    "db && local",
+		Node:          "node1",
+		Datacenter:    "dc1",
+		Token:         "foo",
+		Notifier:      notifier,
+		StatusHandler: NewStatusHandler(notif, testutil.Logger(t), 0, 0, 0),
+		Logger:        testutil.Logger(t),
+	}
+	check.Start()
+	defer check.Stop()
+
+	notifier.send(t, 0, compositeServiceResult(api.HealthPassing))
+
+	// The check isn't updated until all inputs are known.
+	notifier.send(t, 0, compositeServiceResult(api.HealthPassing, api.HealthPassing))
+	require.Equal(t, 0, notif.Updates(cid))
+
+	notifier.send(t, 1, compositeNodeResult(map[types.CheckID]string{"redis": api.HealthWarning}))
+	retry.Run(t, func(r *retry.R) {
+		require.Equal(r, api.HealthWarning, notif.State(cid))
+	})
+	require.Contains(t, notif.Output(cid), `local: warning (check "redis" is warning)`)
+
+	notifier.send(t, 1, compositeNodeResult(map[types.CheckID]string{"redis": api.HealthPassing}))
+	retry.Run(t, func(r *retry.R) {
+		require.Equal(r, api.HealthPassing, notif.State(cid))
+	})
+
+	notifier.lock.Lock()
+	defer notifier.lock.Unlock()
+	require.Len(t, notifier.services, 1)
+	require.Equal(t, "dc2", notifier.services[0].Datacenter)
+	require.Equal(t, "db", notifier.services[0].ServiceName)
+	require.Equal(t, "foo", notifier.services[0].Token)
+
+	// The checks of the local node are watched in the agent's local state.
+	require.Empty(t, notifier.nodes)
+	require.Len(t, notifier.local, 1)
+	require.Equal(t, "dc1", notifier.local[0].Datacenter)
+	require.Equal(t, "node1", notifier.local[0].Node)
+	require.Equal(t, "foo", notifier.local[0].Token)
+}
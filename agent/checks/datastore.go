@@ -0,0 +1,329 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package checks
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+
+	"github.com/hashicorp/consul/agent/structs"
+	"github.com/hashicorp/consul/api"
+	"github.com/hashicorp/consul/lib"
+)
+
+// The protocols spoken by datastore checks.
+const (
+	DatastoreRedis      = "redis"
+	DatastorePostgreSQL = "postgresql"
+	DatastoreMySQL      = "mysql"
+	DatastoreMemcached  = "memcached"
+)
+
+// CheckDatastore is used to periodically make a minimal protocol handshake
+// with a datastore, to know whether it's answering requests without
+// credentials or a script check:
+//
+//   - Redis checks send a PING and expect a PONG.
+//   - PostgreSQL checks negotiate SSL, which every server answers before
+//     authentication.
+//   - MySQL checks read the greeting of the server and close the
+//     connection without authenticating, so no login attempt is made.
+//   - Memcached checks ask for the version of the server.
+type CheckDatastore struct {
+	CheckID       structs.CheckID
+	ServiceID     structs.ServiceID
+	Protocol      string
+	Address       string
+	Interval      time.Duration
+	Timeout       time.Duration
+	Logger        hclog.Logger
+	StatusHandler *StatusHandler
+
+	dialer   *net.Dialer
+	stop     bool
+	stopCh   chan struct{}
+	stopLock sync.Mutex
+	stopWg   sync.WaitGroup
+}
+
+func (c *CheckDatastore) CheckType() structs.CheckType {
+	t := structs.CheckType{
+		CheckID:  c.CheckID.ID,
+		Interval: c.Interval,
+		Timeout:  c.Timeout,
+	}
+	switch c.Protocol {
+	case DatastoreRedis:
+		t.Redis = c.Address
+	case DatastorePostgreSQL:
+		t.PostgreSQL = c.Address
+	case DatastoreMySQL:
+		t.MySQL = c.Address
+	case DatastoreMemcached:
+		t.Memcached = c.Address
+	}
+	return t
+}
+
+// Start is used to start a datastore check.
+// The check runs until stop is called
+func (c *CheckDatastore) Start() {
+	c.stopLock.Lock()
+	defer c.stopLock.Unlock()
+
+	if c.dialer == nil {
+		// Create the socket dialer
+		c.dialer = &net.Dialer{
+			Timeout: 10 * time.Second,
+		}
+		if c.Timeout > 0 {
+			c.dialer.Timeout = c.Timeout
+		}
+	}
+
+	c.stop = false
+	c.stopCh = make(chan struct{})
+	c.stopWg.Add(1)
+	go c.run()
+}
+
+// Stop is used to stop a datastore check.
+func (c *CheckDatastore) Stop() {
+	c.stopLock.Lock()
+	defer c.stopLock.Unlock()
+	if !c.stop {
+		c.stop = true
+		close(c.stopCh)
+	}
+
+	// Wait for the c.run() goroutine to complete before returning.
+	c.stopWg.Wait()
+}
+
+// run is invoked by a goroutine to run until Stop() is called
+func (c *CheckDatastore) run() {
+	defer c.stopWg.Done()
+	// Get the randomized initial pause time
+	initialPauseTime := lib.RandomStagger(c.Interval)
+	next := time.After(initialPauseTime)
+	for {
+		select {
+		case <-next:
+			c.StatusHandler.startCheck()
+			c.check()
+			next = time.After(c.Interval)
+		case <-c.stopCh:
+			return
+		}
+	}
+}
+
+// check is invoked periodically to perform the datastore check
+func (c *CheckDatastore) check() {
+	status, output := c.doCheck()
+	if status != api.HealthPassing {
+		c.Logger.Warn("Datastore check failed",
+			"check", c.CheckID.String(),
+			"protocol", c.Protocol,
+			"output", output,
+		)
+	}
+	c.StatusHandler.updateCheck(c.CheckID, status, output)
+}
+
+func (c *CheckDatastore) doCheck() (string, string) {
+	conn, err := c.dialer.Dial("tcp", c.Address)
+	if err != nil {
+		return api.HealthCritical, err.Error()
+	}
+	defer conn.Close()
+
+	// The whole handshake must complete within the timeout of the check.
+	if err := conn.SetDeadline(time.Now().Add(c.dialer.Timeout)); err != nil {
+		return api.HealthCritical, err.Error()
+	}
+
+	var status, output string
+	switch c.Protocol {
+	case DatastoreRedis:
+		status, output, err = checkRedis(conn)
+	case DatastorePostgreSQL:
+		status, output, err = checkPostgreSQL(conn)
+	case DatastoreMySQL:
+		status, output, err = checkMySQL(conn)
+	case DatastoreMemcached:
+		status, output, err = checkMemcached(conn)
+	default:
+		err = fmt.Errorf("unsupported protocol %q", c.Protocol)
+	}
+	if err != nil {
+		return api.HealthCritical, fmt.Sprintf("%s check %s: %v", c.Protocol, c.Address, err)
+	}
+	return status, fmt.Sprintf("%s check %s: %s", c.Protocol, c.Address, output)
+}
+
+// checkRedis sends a PING to a Redis server.
+func checkRedis(conn net.Conn) (string, string, error) {
+	if _, err := io.WriteString(conn, "*1\r\n$4\r\nPING\r\n"); err != nil {
+		return "", "", err
+	}
+	reply, err := readLine(conn)
+	if err != nil {
+		return "", "", err
+	}
+
+	switch {
+	case reply == "+PONG":
+		return api.HealthPassing, "PONG", nil
+	case strings.HasPrefix(reply, "-NOAUTH"):
+		// The server answered, it's just not willing to talk to
+		// unauthenticated clients.
+		return api.HealthPassing, "server requires authentication", nil
+	case strings.HasPrefix(reply, "-"):
+		// Such as LOADING, MASTERDOWN or BUSY.
+		return api.HealthCritical, fmt.Sprintf("error reply: %s", reply[1:]), nil
+	default:
+		return "", "", fmt.Errorf("unexpected reply %q", reply)
+	}
+}
+
+// postgreSQLSSLRequestCode is the code of the SSLRequest message, which must
+// be answered with a single byte by PostgreSQL servers.
+const postgreSQLSSLRequestCode = 80877103
+
+// checkPostgreSQL negotiates SSL with a PostgreSQL server. The server closes
+// the connection without logging an error when the client drops it after the
+// negotiation.
+func checkPostgreSQL(conn net.Conn) (string, string, error) {
+	msg := make([]byte, 8)
+	binary.BigEndian.PutUint32(msg[0:4], 8)
+	binary.BigEndian.PutUint32(msg[4:8], postgreSQLSSLRequestCode)
+	if _, err := conn.Write(msg); err != nil {
+		return "", "", err
+	}
+
+	reply := make([]byte, 1)
+	if _, err := io.ReadFull(conn, reply); err != nil {
+		return "", "", err
+	}
+	switch reply[0] {
+	case 'S':
+		return api.HealthPassing, "SSL negotiation succeeded, SSL is supported", nil
+	case 'N':
+		return api.HealthPassing, "SSL negotiation succeeded, SSL is not supported", nil
+	case 'E':
+		// Servers that fail to process the request answer with an error
+		// message.
+		return api.HealthCritical, "server answered SSL negotiation with an error", nil
+	default:
+		return "", "", fmt.Errorf("unexpected reply %q to SSL negotiation", reply[0])
+	}
+}
+
+// MySQL error codes that make MySQL checks warning rather than critical.
+const (
+	mysqlErrConCount = 1040 // Too many connections
+)
+
+// checkMySQL reads the greeting of a MySQL server. The connection is closed
+// without a handshake response, so the server counts it as an interrupted
+// connection of the agent host, like it does for TCP checks.
+func checkMySQL(conn net.Conn) (string, string, error) {
+	greeting, err := readMySQLPacket(conn)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to read server greeting: %w", err)
+	}
+	if len(greeting) == 0 {
+		return "", "", fmt.Errorf("empty server greeting")
+	}
+
+	switch greeting[0] {
+	case 0xff:
+		// The server refused the connection, such as when there are too
+		// many connections or when this host is blocked.
+		code, msg := parseMySQLError(greeting)
+		status := api.HealthCritical
+		if code == mysqlErrConCount {
+			status = api.HealthWarning
+		}
+		return status, fmt.Sprintf("server refused connection: error %d: %s", code, msg), nil
+	case 10:
+	default:
+		return "", "", fmt.Errorf("unsupported protocol version %d", greeting[0])
+	}
+
+	version, _, ok := bytes.Cut(greeting[1:], []byte{0})
+	if !ok {
+		return "", "", fmt.Errorf("malformed server greeting")
+	}
+
+	return api.HealthPassing, fmt.Sprintf("server version %s", version), nil
+}
+
+// readMySQLPacket reads the payload of a MySQL packet.
+func readMySQLPacket(r io.Reader) ([]byte, error) {
+	header := make([]byte, 4)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, err
+	}
+	length := int(header[0]) | int(header[1])<<8 | int(header[2])<<16
+	payload := make([]byte, length)
+	if _, err := io.ReadFull(r, payload); err != nil {
+		return nil, err
+	}
+	return payload, nil
+}
+
+// parseMySQLError returns the code and the message of a MySQL error packet.
+func parseMySQLError(packet []byte) (int, string) {
+	if len(packet) < 3 {
+		return 0, "malformed error packet"
+	}
+	code := int(binary.LittleEndian.Uint16(packet[1:3]))
+	msg := packet[3:]
+	// Skip the SQL state marker and the SQL state when present.
+	if len(msg) >= 6 && msg[0] == '#' {
+		msg = msg[6:]
+	}
+	return code, string(msg)
+}
+
+// checkMemcached asks a Memcached server for its version.
+func checkMemcached(conn net.Conn) (string, string, error) {
+	if _, err := io.WriteString(conn, "version\r\n"); err != nil {
+		return "", "", err
+	}
+	reply, err := readLine(conn)
+	if err != nil {
+		return "", "", err
+	}
+
+	switch {
+	case strings.HasPrefix(reply, "VERSION "):
+		return api.HealthPassing, fmt.Sprintf("server version %s", strings.TrimPrefix(reply, "VERSION ")), nil
+	case strings.HasPrefix(reply, "SERVER_ERROR"), strings.HasPrefix(reply, "ERROR"):
+		return api.HealthCritical, fmt.Sprintf("error reply: %s", reply), nil
+	default:
+		return "", "", fmt.Errorf("unexpected reply %q", reply)
+	}
+}
+
+// readLine reads a line terminated by CRLF, as used by the Redis and the
+// Memcached protocols.
+func readLine(conn net.Conn) (string, error) {
+	line, err := bufio.NewReader(conn).ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
@@ -0,0 +1,299 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package checks
+
+import (
+	"bufio"
+	"encoding/binary"
+	"io"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/consul/agent/mock"
+	"github.com/hashicorp/consul/agent/structs"
+	"github.com/hashicorp/consul/api"
+	"github.com/hashicorp/consul/sdk/testutil"
+	"github.com/hashicorp/consul/sdk/testutil/retry"
+)
+
+// startDatastoreServer starts a TCP server handling each connection with
+// handle, and returns its address.
+func startDatastoreServer(t *testing.T, handle func(conn net.Conn)) string {
+	t.Helper()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { ln.Close() })
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				conn.SetDeadline(time.Now().Add(5 * time.Second))
+				handle(conn)
+			}()
+		}
+	}()
+	return ln.Addr().String()
+}
+
+// lineServer answers the first line it reads with reply.
+func lineServer(expected, reply string) func(conn net.Conn) {
+	return func(conn net.Conn) {
+		r := bufio.NewReader(conn)
+		var req strings.Builder
+		for !strings.HasSuffix(req.String(), expected) {
+			b, err := r.ReadByte()
+			if err != nil {
+				return
+			}
+			req.WriteByte(b)
+		}
+		io.WriteString(conn, reply)
+	}
+}
+
+// postgreSQLServer answers SSL negotiation with reply.
+func postgreSQLServer(reply byte) func(conn net.Conn) {
+	return func(conn net.Conn) {
+		msg := make([]byte, 8)
+		if _, err := io.ReadFull(conn, msg); err != nil {
+			return
+		}
+		if binary.BigEndian.Uint32(msg[0:4]) != 8 || binary.BigEndian.Uint32(msg[4:8]) != postgreSQLSSLRequestCode {
+			return
+		}
+		conn.Write([]byte{reply})
+	}
+}
+
+// mysqlServer sends greeting, then reads until the client closes the
+// connection. sent receives the bytes sent by the client.
+func mysqlServer(greeting []byte, sent chan<- []byte) func(conn net.Conn) {
+	return func(conn net.Conn) {
+		length := len(greeting)
+		conn.Write(append([]byte{byte(length), byte(length >> 8), byte(length >> 16), 0}, greeting...))
+
+		data, _ := io.ReadAll(conn)
+		if sent != nil {
+			sent <- data
+		}
+	}
+}
+
+func mysqlGreeting(version string) []byte {
+	greeting := append([]byte{10}, version...)
+	greeting = append(greeting, 0)
+	// Connection ID, auth plugin data and the rest of the greeting.
+	return append(greeting, make([]byte, 40)...)
+}
+
+func TestCheckDatastore(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		desc     string
+		protocol string
+		handle   func(conn net.Conn)
+		status   string
+		output   string
+	}{
+		{
+			desc:     "redis pong",
+			protocol: DatastoreRedis,
+			handle:   lineServer("PING\r\n", "+PONG\r\n"),
+			status:   api.HealthPassing,
+			output:   "PONG",
+		},
+		{
+			desc:     "redis requires authentication",
+			protocol: DatastoreRedis,
+			handle:   lineServer("PING\r\n", "-NOAUTH Authentication required.\r\n"),
+			status:   api.HealthPassing,
+			output:   "server requires authentication",
+		},
+		{
+			desc:     "redis loading",
+			protocol: DatastoreRedis,
+			handle:   lineServer("PING\r\n", "-LOADING Redis is loading the dataset in memory\r\n"),
+			status:   api.HealthCritical,
+			output:   "error reply: LOADING Redis is loading the dataset in memory",
+		},
+		{
+			desc:     "not redis",
+			protocol: DatastoreRedis,
+			handle:   lineServer("PING\r\n", "HTTP/1.1 400 Bad Request\r\n"),
+			status:   api.HealthCritical,
+			output:   `unexpected reply "HTTP/1.1 400 Bad Request"`,
+		},
+		{
+			desc:     "postgresql with SSL",
+			protocol: DatastorePostgreSQL,
+			handle:   postgreSQLServer('S'),
+			status:   api.HealthPassing,
+			output:   "SSL is supported",
+		},
+		{
+			desc:     "postgresql without SSL",
+			protocol: DatastorePostgreSQL,
+			handle:   postgreSQLServer('N'),
+			status:   api.HealthPassing,
+			output:   "SSL is not supported",
+		},
+		{
+			desc:     "postgresql error",
+			protocol: DatastorePostgreSQL,
+			handle:   postgreSQLServer('E'),
+			status:   api.HealthCritical,
+			output:   "server answered SSL negotiation with an error",
+		},
+		{
+			desc:     "postgresql closing the connection",
+			protocol: DatastorePostgreSQL,
+			handle:   func(conn net.Conn) {},
+			status:   api.HealthCritical,
+			output:   "EOF",
+		},
+		{
+			desc:     "mysql greeting",
+			protocol: DatastoreMySQL,
+			handle:   mysqlServer(mysqlGreeting("8.0.33"), nil),
+			status:   api.HealthPassing,
+			output:   "server version 8.0.33",
+		},
+		{
+			desc:     "mysql too many connections",
+			protocol: DatastoreMySQL,
+			handle:   mysqlServer(append([]byte{0xff, 0x10, 0x04}, "Too many connections"...), nil),
+			status:   api.HealthWarning,
+			output:   "server refused connection: error 1040: Too many connections",
+		},
+		{
+			desc:     "mysql host blocked",
+			protocol: DatastoreMySQL,
+			handle:   mysqlServer(append([]byte{0xff, 0x69, 0x04}, "Host is blocked"...), nil),
+			status:   api.HealthCritical,
+			output:   "server refused connection: error 1129: Host is blocked",
+		},
+		{
+			desc:     "mysql unsupported protocol",
+			protocol: DatastoreMySQL,
+			handle:   mysqlServer([]byte{9, '5', 0}, nil),
+			status:   api.HealthCritical,
+			output:   "unsupported protocol version 9",
+		},
+		{
+			desc:     "memcached version",
+			protocol: DatastoreMemcached,
+			handle:   lineServer("version\r\n", "VERSION 1.6.21\r\n"),
+			status:   api.HealthPassing,
+			output:   "server version 1.6.21",
+		},
+		{
+			desc:     "memcached error",
+			protocol: DatastoreMemcached,
+			handle:   lineServer("version\r\n", "SERVER_ERROR out of memory\r\n"),
+			status:   api.HealthCritical,
+			output:   "error reply: SERVER_ERROR out of memory",
+		},
+		{
+			desc:     "timeout",
+			protocol: DatastoreRedis,
+			handle:   func(conn net.Conn) { io.Copy(io.Discard, conn) },
+			status:   api.HealthCritical,
+			output:   "i/o timeout",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.desc, func(t *testing.T) {
+			t.Parallel()
+			addr := startDatastoreServer(t, tt.handle)
+			check := &CheckDatastore{
+				Protocol: tt.protocol,
+				Address:  addr,
+				dialer:   &net.Dialer{Timeout: 200 * time.Millisecond},
+			}
+
+			status, output := check.doCheck()
+			require.Equal(t, tt.status, status, output)
+			require.Contains(t, output, tt.output)
+			require.True(t, strings.HasPrefix(output, tt.protocol+" check "+addr+": "), output)
+		})
+	}
+
+	t.Run("connection refused", func(t *testing.T) {
+		ln, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		addr := ln.Addr().String()
+		ln.Close()
+
+		check := &CheckDatastore{
+			Protocol: DatastoreMemcached,
+			Address:  addr,
+			dialer:   &net.Dialer{Timeout: 200 * time.Millisecond},
+		}
+		status, output := check.doCheck()
+		require.Equal(t, api.HealthCritical, status)
+		require.Contains(t, output, "connection refused")
+	})
+}
+
+func TestCheckDatastore_MySQLDoesNotLogIn(t *testing.T) {
+	t.Parallel()
+
+	sent := make(chan []byte, 1)
+	addr := startDatastoreServer(t, mysqlServer(mysqlGreeting("5.7.42-log"), sent))
+	check := &CheckDatastore{
+		Protocol: DatastoreMySQL,
+		Address:  addr,
+		dialer:   &net.Dialer{Timeout: time.Second},
+	}
+
+	status, output := check.doCheck()
+	require.Equal(t, api.HealthPassing, status, output)
+	select {
+	case data := <-sent:
+		require.Empty(t, data, "the check should close the connection after the greeting")
+	case <-time.After(5 * time.Second):
+		t.Fatal("the check didn't close the connection")
+	}
+}
+
+func TestCheckDatastore_Start(t *testing.T) {
+	t.Parallel()
+
+	addr := startDatastoreServer(t, lineServer("PING\r\n", "+PONG\r\n"))
+
+	notif := mock.NewNotify()
+	logger := testutil.Logger(t)
+	statusHandler := NewStatusHandler(notif, logger, 0, 0, 0)
+	cid := structs.NewCheckID("foo", nil)
+
+	check := &CheckDatastore{
+		CheckID:       cid,
+		Protocol:      DatastoreRedis,
+		Address:       addr,
+		Interval:      10 * time.Millisecond,
+		Logger:        logger,
+		StatusHandler: statusHandler,
+	}
+	check.Start()
+	defer check.Stop()
+
+	require.Equal(t, addr, check.CheckType().Redis)
+	retry.Run(t, func(r *retry.R) {
+		if got, want := notif.State(cid), api.HealthPassing; got != want {
+			r.Fatalf("got state %q want %q", got, want)
+		}
+	})
+}
@@ -0,0 +1,244 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package checks
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/consul/agent/structs"
+	"github.com/hashicorp/consul/api"
+)
+
+// MaxResponseAssertionSize is the maximum size of the response body of an
+// HTTP check that is read to match the body regex and JSON path. The output
+// of the check is still limited to OutputMaxSize.
+const MaxResponseAssertionSize = 1024 * 1024
+
+// httpAssertions holds the parsed assertions made on the response of an
+// HTTP check.
+type httpAssertions struct {
+	bodyRegex *regexp.Regexp
+	jsonPath  []interface{}
+	jsonValue string
+	headers   map[string]string
+
+	passing  []structs.HTTPStatusCodeRange
+	warning  []structs.HTTPStatusCodeRange
+	critical []structs.HTTPStatusCodeRange
+}
+
+func newHTTPAssertions(c *CheckHTTP) (*httpAssertions, error) {
+	a := &httpAssertions{
+		jsonValue: c.ResponseJSONValue,
+		headers:   c.ResponseHeaders,
+	}
+
+	var err error
+	if c.ResponseBodyRegex != "" {
+		if a.bodyRegex, err = regexp.Compile(c.ResponseBodyRegex); err != nil {
+			return nil, fmt.Errorf("invalid response body regex: %w", err)
+		}
+	}
+	if c.ResponseJSONPath != "" {
+		if a.jsonPath, err = parseJSONPath(c.ResponseJSONPath); err != nil {
+			return nil, err
+		}
+	}
+	if a.passing, err = structs.ParseHTTPStatusCodeRanges(c.PassingStatusCodes); err != nil {
+		return nil, err
+	}
+	if a.warning, err = structs.ParseHTTPStatusCodeRanges(c.WarningStatusCodes); err != nil {
+		return nil, err
+	}
+	if a.critical, err = structs.ParseHTTPStatusCodeRanges(c.CriticalStatusCodes); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// readsBody returns true if the assertions need the response body.
+func (a *httpAssertions) readsBody() bool {
+	return a.bodyRegex != nil || a.jsonPath != nil
+}
+
+// status returns the status of the check for the status code of the
+// response. Without explicit status codes, 2xx codes are passing and 429 Too
+// Many Requests (RFC 6585) is a warning.
+func (a *httpAssertions) status(code int) string {
+	switch {
+	case statusCodeIn(a.critical, code):
+		return api.HealthCritical
+	case statusCodeIn(a.warning, code):
+		return api.HealthWarning
+	case statusCodeIn(a.passing, code):
+		return api.HealthPassing
+	case len(a.passing) == 0 && code >= 200 && code <= 299:
+		return api.HealthPassing
+	case len(a.warning) == 0 && code == http.StatusTooManyRequests:
+		return api.HealthWarning
+	default:
+		return api.HealthCritical
+	}
+}
+
+// check returns the reason the response doesn't satisfy the assertions, or
+// an empty string if it does.
+func (a *httpAssertions) check(header http.Header, body []byte) string {
+	names := make([]string, 0, len(a.headers))
+	for name := range a.headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		values := header.Values(name)
+		if len(values) == 0 {
+			return fmt.Sprintf("missing response header %q", name)
+		}
+		expected := a.headers[name]
+		if expected == "" {
+			continue
+		}
+		var found bool
+		for _, v := range values {
+			if v == expected {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Sprintf("response header %q is %q, expected %q", name, strings.Join(values, ", "), expected)
+		}
+	}
+
+	if a.bodyRegex != nil && !a.bodyRegex.Match(body) {
+		return fmt.Sprintf("response body does not match %q", a.bodyRegex.String())
+	}
+
+	if a.jsonPath != nil {
+		dec := json.NewDecoder(bytes.NewReader(body))
+		dec.UseNumber()
+		var doc interface{}
+		if err := dec.Decode(&doc); err != nil {
+			return fmt.Sprintf("response body is not valid JSON: %v", err)
+		}
+		v, ok := jsonPathLookup(doc, a.jsonPath)
+		if !ok {
+			return fmt.Sprintf("JSON path %q not found in response body", formatJSONPath(a.jsonPath))
+		}
+		if a.jsonValue != "" {
+			if actual := jsonValueString(v); actual != a.jsonValue {
+				return fmt.Sprintf("JSON path %q is %q, expected %q", formatJSONPath(a.jsonPath), actual, a.jsonValue)
+			}
+		}
+	}
+
+	return ""
+}
+
+func statusCodeIn(ranges []structs.HTTPStatusCodeRange, code int) bool {
+	for _, r := range ranges {
+		if r.Contains(code) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseJSONPath parses a JSON path made of object keys and array indices,
+// such as "$.checks[0].status". The leading "$." is optional. The returned
+// path holds a string for each key and an int for each index.
+func parseJSONPath(path string) ([]interface{}, error) {
+	rest := strings.TrimPrefix(path, "$")
+	if rest != "" && rest[0] != '.' && rest[0] != '[' {
+		rest = "." + rest
+	}
+
+	var segments []interface{}
+	for rest != "" {
+		switch rest[0] {
+		case '.':
+			rest = rest[1:]
+			end := strings.IndexAny(rest, ".[")
+			if end < 0 {
+				end = len(rest)
+			}
+			if end == 0 {
+				return nil, fmt.Errorf("invalid JSON path %q: empty key", path)
+			}
+			segments = append(segments, rest[:end])
+			rest = rest[end:]
+		case '[':
+			end := strings.IndexByte(rest, ']')
+			if end < 0 {
+				return nil, fmt.Errorf("invalid JSON path %q: missing ]", path)
+			}
+			idx, err := strconv.Atoi(rest[1:end])
+			if err != nil || idx < 0 {
+				return nil, fmt.Errorf("invalid JSON path %q: invalid index %q", path, rest[1:end])
+			}
+			segments = append(segments, idx)
+			rest = rest[end+1:]
+		default:
+			return nil, fmt.Errorf("invalid JSON path %q: expected . or [ at %q", path, rest)
+		}
+	}
+	return segments, nil
+}
+
+func formatJSONPath(path []interface{}) string {
+	var sb strings.Builder
+	sb.WriteString("$")
+	for _, segment := range path {
+		switch s := segment.(type) {
+		case string:
+			sb.WriteString("." + s)
+		case int:
+			sb.WriteString("[" + strconv.Itoa(s) + "]")
+		}
+	}
+	return sb.String()
+}
+
+func jsonPathLookup(doc interface{}, path []interface{}) (interface{}, bool) {
+	v := doc
+	for _, segment := range path {
+		switch s := segment.(type) {
+		case string:
+			obj, ok := v.(map[string]interface{})
+			if !ok {
+				return nil, false
+			}
+			if v, ok = obj[s]; !ok {
+				return nil, false
+			}
+		case int:
+			arr, ok := v.([]interface{})
+			if !ok || s >= len(arr) {
+				return nil, false
+			}
+			v = arr[s]
+		}
+	}
+	return v, true
+}
+
+// jsonValueString returns the value as compared to ResponseJSONValue: strings
+// are compared as is and other values as their JSON encoding.
+func jsonValueString(v interface{}) string {
+	if s, ok := v.(string); ok {
+		return s
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return ""
+	}
+	return string(b)
+}
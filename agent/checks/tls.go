@@ -0,0 +1,247 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package checks
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+
+	"github.com/hashicorp/consul/agent/structs"
+	"github.com/hashicorp/consul/api"
+	"github.com/hashicorp/consul/lib"
+)
+
+// DefaultTLSExpiryWarningDays is the number of days before the expiry of a
+// certificate from which TLS checks are warning.
+const DefaultTLSExpiryWarningDays = 14
+
+// CheckTLS is used to periodically make a TLS handshake with a target and
+// inspect the certificate chain presented by the server.
+//
+// The check is critical if the certificate is expired, if it isn't trusted
+// or if it doesn't match the server name, and warning if it expires within
+// ExpiryWarningDays. The output of the check contains a summary of the
+// certificate chain.
+type CheckTLS struct {
+	CheckID           structs.CheckID
+	ServiceID         structs.ServiceID
+	TLS               string
+	CAFile            string
+	ExpiryWarningDays int
+	Interval          time.Duration
+	Timeout           time.Duration
+	Logger            hclog.Logger
+	TLSClientConfig   *tls.Config
+	StatusHandler     *StatusHandler
+
+	// now returns the current time, it's overridden in tests.
+	now func() time.Time
+
+	stop     bool
+	stopCh   chan struct{}
+	stopLock sync.Mutex
+	stopWg   sync.WaitGroup
+}
+
+func (c *CheckTLS) CheckType() structs.CheckType {
+	return structs.CheckType{
+		CheckID:              c.CheckID.ID,
+		TLS:                  c.TLS,
+		TLSCAFile:            c.CAFile,
+		TLSExpiryWarningDays: c.ExpiryWarningDays,
+		Interval:             c.Interval,
+		Timeout:              c.Timeout,
+	}
+}
+
+// Start is used to start a TLS check.
+// The check runs until stop is called
+func (c *CheckTLS) Start() {
+	c.stopLock.Lock()
+	defer c.stopLock.Unlock()
+
+	if c.Timeout <= 0 {
+		c.Timeout = 10 * time.Second
+	}
+	if c.ExpiryWarningDays <= 0 {
+		c.ExpiryWarningDays = DefaultTLSExpiryWarningDays
+	}
+	if c.now == nil {
+		c.now = time.Now
+	}
+
+	c.stop = false
+	c.stopCh = make(chan struct{})
+	c.stopWg.Add(1)
+	go c.run()
+}
+
+// Stop is used to stop a TLS check.
+func (c *CheckTLS) Stop() {
+	c.stopLock.Lock()
+	defer c.stopLock.Unlock()
+	if !c.stop {
+		c.stop = true
+		close(c.stopCh)
+	}
+
+	// Wait for the c.run() goroutine to complete before returning.
+	c.stopWg.Wait()
+}
+
+// run is invoked by a goroutine to run until Stop() is called
+func (c *CheckTLS) run() {
+	defer c.stopWg.Done()
+	// Get the randomized initial pause time
+	initialPauseTime := lib.RandomStagger(c.Interval)
+	next := time.After(initialPauseTime)
+	for {
+		select {
+		case <-next:
+			c.StatusHandler.startCheck()
+			c.check()
+			next = time.After(c.Interval)
+		case <-c.stopCh:
+			return
+		}
+	}
+}
+
+// check is invoked periodically to perform the TLS check
+func (c *CheckTLS) check() {
+	status, output := c.doCheck()
+	c.StatusHandler.updateCheck(c.CheckID, status, output)
+}
+
+func (c *CheckTLS) doCheck() (string, string) {
+	cfg, err := c.tlsConfig()
+	if err != nil {
+		return api.HealthCritical, err.Error()
+	}
+
+	// The certificates are verified after the handshake so that the chain is
+	// available to report why it isn't valid.
+	verify := !cfg.InsecureSkipVerify
+	cfg.InsecureSkipVerify = true
+
+	dialer := &net.Dialer{Timeout: c.Timeout}
+	conn, err := tls.DialWithDialer(dialer, "tcp", c.TLS, cfg)
+	if err != nil {
+		return api.HealthCritical, fmt.Sprintf("TLS handshake with %s failed: %v", c.TLS, err)
+	}
+	state := conn.ConnectionState()
+	conn.Close()
+
+	certs := state.PeerCertificates
+	if len(certs) == 0 {
+		return api.HealthCritical, fmt.Sprintf("TLS server %s didn't present a certificate", c.TLS)
+	}
+	chain := tlsChainSummary(certs)
+
+	now := c.now()
+	if verify {
+		opts := x509.VerifyOptions{
+			Roots:         cfg.RootCAs,
+			DNSName:       cfg.ServerName,
+			Intermediates: x509.NewCertPool(),
+			CurrentTime:   now,
+		}
+		for _, cert := range certs[1:] {
+			opts.Intermediates.AddCert(cert)
+		}
+		if _, err := certs[0].Verify(opts); err != nil {
+			return api.HealthCritical, fmt.Sprintf("TLS certificate of %s is invalid: %v\n\n%s", c.TLS, err, chain)
+		}
+	}
+
+	// The chain is only as valid as its certificate expiring first.
+	expiring := certs[0]
+	for _, cert := range certs[1:] {
+		if cert.NotAfter.Before(expiring.NotAfter) {
+			expiring = cert
+		}
+	}
+	name := tlsCertName(expiring)
+	remaining := expiring.NotAfter.Sub(now)
+	switch {
+	case remaining <= 0:
+		return api.HealthCritical, fmt.Sprintf("TLS certificate %q of %s expired on %s\n\n%s",
+			name, c.TLS, expiring.NotAfter.UTC().Format(time.RFC3339), chain)
+	case remaining <= time.Duration(c.ExpiryWarningDays)*24*time.Hour:
+		return api.HealthWarning, fmt.Sprintf("TLS certificate %q of %s expires in %d days, on %s\n\n%s",
+			name, c.TLS, int(remaining.Hours()/24), expiring.NotAfter.UTC().Format(time.RFC3339), chain)
+	default:
+		return api.HealthPassing, fmt.Sprintf("TLS certificate of %s is valid until %s\n\n%s",
+			c.TLS, expiring.NotAfter.UTC().Format(time.RFC3339), chain)
+	}
+}
+
+// tlsConfig returns the TLS configuration of the handshake, with the CA
+// bundle of the check and the server name for SNI.
+func (c *CheckTLS) tlsConfig() (*tls.Config, error) {
+	var cfg *tls.Config
+	if c.TLSClientConfig != nil {
+		cfg = c.TLSClientConfig.Clone()
+	} else {
+		cfg = &tls.Config{}
+	}
+
+	if c.CAFile != "" {
+		pem, err := os.ReadFile(c.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA file: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("failed to parse CA file %s", c.CAFile)
+		}
+		cfg.RootCAs = pool
+	}
+
+	if cfg.ServerName == "" {
+		host, _, err := net.SplitHostPort(c.TLS)
+		if err != nil {
+			return nil, fmt.Errorf("invalid TLS check address %q: %w", c.TLS, err)
+		}
+		cfg.ServerName = host
+	}
+	return cfg, nil
+}
+
+// tlsChainSummary returns a line for each certificate of the chain.
+func tlsChainSummary(certs []*x509.Certificate) string {
+	var sb strings.Builder
+	sb.WriteString("Certificate chain:")
+	for i, cert := range certs {
+		fmt.Fprintf(&sb, "\n%d: subject=%q issuer=%q not_before=%s not_after=%s",
+			i, cert.Subject.String(), cert.Issuer.String(),
+			cert.NotBefore.UTC().Format(time.RFC3339), cert.NotAfter.UTC().Format(time.RFC3339))
+		if len(cert.DNSNames) > 0 {
+			fmt.Fprintf(&sb, " dns_names=%s", strings.Join(cert.DNSNames, ","))
+		}
+		if len(cert.IPAddresses) > 0 {
+			ips := make([]string, 0, len(cert.IPAddresses))
+			for _, ip := range cert.IPAddresses {
+				ips = append(ips, ip.String())
+			}
+			fmt.Fprintf(&sb, " ip_addresses=%s", strings.Join(ips, ","))
+		}
+	}
+	return sb.String()
+}
+
+func tlsCertName(cert *x509.Certificate) string {
+	if cert.Subject.CommonName != "" {
+		return cert.Subject.CommonName
+	}
+	return cert.Subject.String()
+}
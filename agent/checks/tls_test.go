@@ -0,0 +1,192 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package checks
+
+import (
+	"crypto/tls"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/consul/agent/mock"
+	"github.com/hashicorp/consul/agent/structs"
+	"github.com/hashicorp/consul/api"
+	"github.com/hashicorp/consul/sdk/testutil"
+	"github.com/hashicorp/consul/sdk/testutil/retry"
+	"github.com/hashicorp/consul/tlsutil"
+)
+
+// startTLSServer starts a TLS server presenting a certificate for
+// server.example and 127.0.0.1, valid for a year. It returns the address of
+// the server and the path of the CA file.
+func startTLSServer(t *testing.T) (string, string) {
+	t.Helper()
+
+	signer, _, err := tlsutil.GeneratePrivateKey()
+	require.NoError(t, err)
+	ca, _, err := tlsutil.GenerateCA(tlsutil.CAOpts{Signer: signer})
+	require.NoError(t, err)
+	certPEM, keyPEM, err := tlsutil.GenerateCert(tlsutil.CertOpts{
+		Signer:      signer,
+		CA:          ca,
+		Name:        "server.example",
+		Days:        365,
+		DNSNames:    []string{"server.example"},
+		IPAddresses: []net.IP{net.ParseIP("127.0.0.1")},
+	})
+	require.NoError(t, err)
+	cert, err := tls.X509KeyPair([]byte(certPEM), []byte(keyPEM))
+	require.NoError(t, err)
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	require.NoError(t, os.WriteFile(caFile, []byte(ca), 0600))
+
+	ln, err := tls.Listen("tcp", "127.0.0.1:0", &tls.Config{Certificates: []tls.Certificate{cert}})
+	require.NoError(t, err)
+	t.Cleanup(func() { ln.Close() })
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			conn.(*tls.Conn).Handshake()
+			conn.Close()
+		}
+	}()
+
+	return ln.Addr().String(), caFile
+}
+
+func TestCheckTLS(t *testing.T) {
+	t.Parallel()
+
+	addr, caFile := startTLSServer(t)
+
+	tests := []struct {
+		desc       string
+		caFile     string
+		serverName string
+		skipVerify bool
+		after      time.Duration
+		status     string
+		output     string
+	}{
+		{
+			desc:   "valid certificate",
+			caFile: caFile,
+			status: api.HealthPassing,
+			output: "is valid until",
+		},
+		{
+			desc:       "valid certificate with SNI",
+			caFile:     caFile,
+			serverName: "server.example",
+			status:     api.HealthPassing,
+			output:     `dns_names=server.example ip_addresses=127.0.0.1`,
+		},
+		{
+			desc:   "expires soon",
+			caFile: caFile,
+			after:  360 * 24 * time.Hour,
+			status: api.HealthWarning,
+			output: `TLS certificate "server.example" of ` + addr + ` expires in`,
+		},
+		{
+			desc:   "expired",
+			caFile: caFile,
+			after:  366 * 24 * time.Hour,
+			status: api.HealthCritical,
+			output: "certificate has expired",
+		},
+		{
+			desc:       "expired without verification",
+			skipVerify: true,
+			after:      366 * 24 * time.Hour,
+			status:     api.HealthCritical,
+			output:     `TLS certificate "server.example" of ` + addr + ` expired on`,
+		},
+		{
+			desc:       "mismatched server name",
+			caFile:     caFile,
+			serverName: "other.example",
+			status:     api.HealthCritical,
+			output:     "certificate is valid for server.example, not other.example",
+		},
+		{
+			desc:   "untrusted certificate",
+			status: api.HealthCritical,
+			output: "certificate signed by unknown authority",
+		},
+		{
+			desc:       "untrusted certificate without verification",
+			skipVerify: true,
+			status:     api.HealthPassing,
+			output:     "is valid until",
+		},
+		{
+			desc:   "missing CA file",
+			caFile: filepath.Join(t.TempDir(), "missing.pem"),
+			status: api.HealthCritical,
+			output: "failed to read CA file",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.desc, func(t *testing.T) {
+			check := &CheckTLS{
+				TLS:               addr,
+				CAFile:            tt.caFile,
+				ExpiryWarningDays: DefaultTLSExpiryWarningDays,
+				Timeout:           time.Second,
+				TLSClientConfig: &tls.Config{
+					ServerName:         tt.serverName,
+					InsecureSkipVerify: tt.skipVerify,
+				},
+				now: func() time.Time { return time.Now().Add(tt.after) },
+			}
+
+			status, output := check.doCheck()
+			require.Equal(t, tt.status, status, output)
+			require.Contains(t, output, tt.output)
+			if tt.caFile == caFile || tt.skipVerify {
+				require.Contains(t, output, "Certificate chain:\n0: subject=\"CN=server.example\"")
+			}
+		})
+	}
+}
+
+func TestCheckTLS_Start(t *testing.T) {
+	t.Parallel()
+
+	addr, caFile := startTLSServer(t)
+
+	notif := mock.NewNotify()
+	logger := testutil.Logger(t)
+	statusHandler := NewStatusHandler(notif, logger, 0, 0, 0)
+	cid := structs.NewCheckID("foo", nil)
+
+	check := &CheckTLS{
+		CheckID:       cid,
+		TLS:           addr,
+		CAFile:        caFile,
+		Interval:      10 * time.Millisecond,
+		Logger:        logger,
+		StatusHandler: statusHandler,
+	}
+	check.Start()
+	defer check.Stop()
+
+	require.Equal(t, DefaultTLSExpiryWarningDays, check.ExpiryWarningDays)
+	retry.Run(t, func(r *retry.R) {
+		if got, want := notif.State(cid), api.HealthPassing; got != want {
+			r.Fatalf("got state %q want %q", got, want)
+		}
+	})
+}
@@ -441,6 +441,8 @@ func (b *builder) build() (rt RuntimeConfig, err error) {
 
 	// determine port values and replace values <= 0 and > 65535 with -1
 	dnsPort := b.portVal("ports.dns", c.Ports.DNS)
+	dnsTLSPort := b.portVal("ports.dns_tls", c.Ports.DNSTLS)
+	dnsHTTPSPort := b.portVal("ports.dns_https", c.Ports.DNSHTTPS)
 	httpPort := b.portVal("ports.http", c.Ports.HTTP)
 	httpsPort := b.portVal("ports.https", c.Ports.HTTPS)
 	serverPort := b.portVal("ports.server", c.Ports.Server)
@@ -585,6 +587,8 @@ func (b *builder) build() (rt RuntimeConfig, err error) {
 		b.warn("client_addr is empty, client services (DNS, HTTP, HTTPS, GRPC) will not be listening for connections")
 	}
 	dnsAddrs := b.makeAddrs(b.expandAddrs("addresses.dns", c.Addresses.DNS), clientAddrs, dnsPort)
+	dnsTLSAddrs := b.makeAddrs(b.expandAddrs("addresses.dns_tls", c.Addresses.DNSTLS), clientAddrs, dnsTLSPort)
+	dnsHTTPSAddrs := b.makeAddrs(b.expandAddrs("addresses.dns_https", c.Addresses.DNSHTTPS), clientAddrs, dnsHTTPSPort)
 	httpAddrs := b.makeAddrs(b.expandAddrs("addresses.http", c.Addresses.HTTP), clientAddrs, httpPort)
 	httpsAddrs := b.makeAddrs(b.expandAddrs("addresses.https", c.Addresses.HTTPS), clientAddrs, httpsPort)
 	grpcAddrs := b.makeAddrs(b.expandAddrs("addresses.grpc", c.Addresses.GRPC), clientAddrs, grpcPort)
@@ -902,20 +906,32 @@ func (b *builder) build() (rt RuntimeConfig, err error) {
 		DNSDomain:             stringVal(c.DNSDomain),
 		DNSAltDomain:          altDomain,
 		DNSEnableTruncate:     boolVal(c.DNS.EnableTruncate),
+		DNSHTTPSAddrs:         dnsHTTPSAddrs,
+		DNSHTTPSPort:          dnsHTTPSPort,
 		DNSMaxStale:           b.durationVal("dns_config.max_stale", c.DNS.MaxStale),
 		DNSNodeTTL:            b.durationVal("dns_config.node_ttl", c.DNS.NodeTTL),
 		DNSOnlyPassing:        boolVal(c.DNS.OnlyPassing),
 		DNSPort:               dnsPort,
 		DNSRecursorStrategy:   b.dnsRecursorStrategyVal(stringVal(c.DNS.RecursorStrategy)),
 		DNSRecursorTimeout:    b.durationVal("recursor_timeout", c.DNS.RecursorTimeout),
+		DNSRecursorCacheSize:  intVal(c.DNS.RecursorCacheSize) * 1024 * 1024,
+		DNSRecursorPrefetch:   boolValWithDefault(c.DNS.RecursorPrefetch, true),
 		DNSRecursors:          dnsRecursors,
 		DNSServiceTTL:         dnsServiceTTL,
 		DNSSOA:                soa,
+		DNSTLSAddrs:           dnsTLSAddrs,
+		DNSTLSPort:            dnsTLSPort,
 		DNSUDPAnswerLimit:     intVal(c.DNS.UDPAnswerLimit),
 		DNSNodeMetaTXT:        boolValWithDefault(c.DNS.NodeMetaTXT, true),
 		DNSUseCache:           boolVal(c.DNS.UseCache),
 		DNSCacheMaxAge:        b.durationVal("dns_config.cache_max_age", c.DNS.CacheMaxAge),
 
+		DNSZoneTransferAllowedCIDRs: b.cidrsVal("dns_config.zone_transfer.allowed_cidrs", c.DNS.ZoneTransfer.AllowedCIDRs),
+		DNSZoneTransferToken:        stringVal(c.DNS.ZoneTransfer.Token),
+
+		DNSSECEnabled:           boolVal(c.DNS.DNSSEC.Enabled),
+		DNSSECKeyRotationPeriod: b.durationVal("dns_config.dnssec.key_rotation_period", c.DNS.DNSSEC.KeyRotationPeriod),
+
 		// HTTP
 		HTTPPort:            httpPort,
 		HTTPSPort:           httpsPort,
@@ -975,6 +991,7 @@ func (b *builder) build() (rt RuntimeConfig, err error) {
 		},
 		AutoReloadConfig:                       boolVal(c.AutoReloadConfig),
 		CheckUpdateInterval:                    b.durationVal("check_update_interval", c.CheckUpdateInterval),
+		CheckHistorySize:                       intVal(c.CheckHistorySize),
 		CheckOutputMaxSize:                     intValWithDefault(c.CheckOutputMaxSize, 4096),
 		Checks:                                 checks,
 		ClientAddrs:                            clientAddrs,
@@ -998,6 +1015,7 @@ func (b *builder) build() (rt RuntimeConfig, err error) {
 		Datacenter:                             datacenter,
 		DefaultQueryTime:                       b.durationVal("default_query_time", c.DefaultQueryTime),
 		DevMode:                                boolVal(b.opts.DevMode),
+		DevResourceStoragePath:                 stringVal(c.DevResourceStoragePath),
 		DisableAnonymousSignature:              boolVal(c.DisableAnonymousSignature),
 		DisableCoordinates:                     boolVal(c.DisableCoordinates),
 		DisableHostNodeID:                      boolVal(c.DisableHostNodeID),
@@ -1021,6 +1039,7 @@ func (b *builder) build() (rt RuntimeConfig, err error) {
 		HTTPMaxConnsPerClient:      intVal(c.Limits.HTTPMaxConnsPerClient),
 		HTTPSHandshakeTimeout:      b.durationVal("limits.https_handshake_timeout", c.Limits.HTTPSHandshakeTimeout),
 		KVMaxValueSize:             uint64Val(c.Limits.KVMaxValueSize),
+		KVRevisions:                c.KVRevisions,
 		LeaveDrainTime:             b.durationVal("performance.leave_drain_time", c.Performance.LeaveDrainTime),
 		LeaveOnTerm:                leaveOnTerm,
 		StaticRuntimeConfig: StaticRuntimeConfig{
@@ -1300,6 +1319,14 @@ func (b *builder) validate(rt RuntimeConfig) error {
 			return fmt.Errorf("DNS address cannot be a unix socket")
 		}
 	}
+	for _, a := range append(rt.DNSTLSAddrs, rt.DNSHTTPSAddrs...) {
+		if _, ok := a.(*net.UnixAddr); ok {
+			return fmt.Errorf("DNS-over-TLS and DNS-over-HTTPS addresses cannot be unix sockets")
+		}
+	}
+	if (len(rt.DNSTLSAddrs) > 0 || len(rt.DNSHTTPSAddrs) > 0) && rt.TLS.HTTPS.CertFile == "" {
+		return fmt.Errorf("DNS-over-TLS and DNS-over-HTTPS require tls.https.cert_file (or tls.defaults.cert_file) to be configured")
+	}
 	for _, a := range rt.DNSRecursors {
 		if ipaddr.IsAny(a) {
 			return fmt.Errorf("DNS recursor address cannot be 0.0.0.0, :: or [::]")
@@ -1320,12 +1347,23 @@ func (b *builder) validate(rt RuntimeConfig) error {
 	if rt.BootstrapExpect > 0 && rt.DevMode {
 		return fmt.Errorf("'bootstrap_expect > 0' not allowed in dev mode")
 	}
+	if rt.DevResourceStoragePath != "" && !rt.DevMode {
+		return fmt.Errorf("'dev_resource_storage_path' is only allowed in dev mode")
+	}
 	if rt.BootstrapExpect > 0 && rt.Bootstrap {
 		return fmt.Errorf("'bootstrap_expect > 0' and 'bootstrap = true' are mutually exclusive")
 	}
 	if rt.CheckOutputMaxSize < 1 {
 		return fmt.Errorf("check_output_max_size must be positive, to discard check output use the discard_check_output flag")
 	}
+	if rt.CheckHistorySize < 0 {
+		return fmt.Errorf("check_history_size cannot be negative, to disable check history set it to 0")
+	}
+	for prefix, n := range rt.KVRevisions {
+		if n < 0 {
+			return fmt.Errorf("kv_revisions[%q] cannot be negative, to disable KV revisions set it to 0", prefix)
+		}
+	}
 	if rt.AEInterval <= 0 {
 		return fmt.Errorf("ae_interval cannot be %s. Must be positive", rt.AEInterval)
 	}
@@ -1348,6 +1386,12 @@ func (b *builder) validate(rt RuntimeConfig) error {
 	if rt.DNSARecordLimit < 0 {
 		return fmt.Errorf("dns_config.a_record_limit cannot be %d. Must be greater than or equal to zero", rt.DNSARecordLimit)
 	}
+	if rt.DNSSECKeyRotationPeriod != 0 && rt.DNSSECKeyRotationPeriod < minDNSSECKeyRotationPeriod {
+		return fmt.Errorf("dns_config.dnssec.key_rotation_period cannot be %s. Must be 0 or at least %s", rt.DNSSECKeyRotationPeriod, minDNSSECKeyRotationPeriod)
+	}
+	if rt.DNSRecursorCacheSize < 0 {
+		return fmt.Errorf("dns_config.recursor_cache_size_mb cannot be %d. Must be greater than or equal to zero", rt.DNSRecursorCacheSize/1024/1024)
+	}
 	if err := structs.ValidateNodeMetadata(rt.NodeMeta, false); err != nil {
 		return fmt.Errorf("node_meta invalid: %v", err)
 	}
@@ -1414,6 +1458,12 @@ func (b *builder) validate(rt RuntimeConfig) error {
 		// we leave this for consistency
 		return err
 	}
+	if err := addrsUnique(inuse, "DNS-over-TLS", rt.DNSTLSAddrs); err != nil {
+		return err
+	}
+	if err := addrsUnique(inuse, "DNS-over-HTTPS", rt.DNSHTTPSAddrs); err != nil {
+		return err
+	}
 	if err := addrsUnique(inuse, "HTTP", rt.HTTPAddrs); err != nil {
 		return err
 	}
@@ -1609,6 +1659,13 @@ func (b *builder) checkVal(v *CheckDefinition) *structs.CheckDefinition {
 		Method:                         stringVal(v.Method),
 		Body:                           stringVal(v.Body),
 		DisableRedirects:               boolVal(v.DisableRedirects),
+		ResponseBodyRegex:              stringVal(v.ResponseBodyRegex),
+		ResponseJSONPath:               stringVal(v.ResponseJSONPath),
+		ResponseJSONValue:              stringVal(v.ResponseJSONValue),
+		ResponseHeaders:                v.ResponseHeaders,
+		PassingStatusCodes:             v.PassingStatusCodes,
+		WarningStatusCodes:             v.WarningStatusCodes,
+		CriticalStatusCodes:            v.CriticalStatusCodes,
 		TCP:                            stringVal(v.TCP),
 		UDP:                            stringVal(v.UDP),
 		Interval:                       b.durationVal(fmt.Sprintf("check[%s].interval", id), v.Interval),
@@ -1620,6 +1677,9 @@ func (b *builder) checkVal(v *CheckDefinition) *structs.CheckDefinition {
 		TLSSkipVerify:                  boolVal(v.TLSSkipVerify),
 		AliasNode:                      stringVal(v.AliasNode),
 		AliasService:                   stringVal(v.AliasService),
+		CompositeInputs:                b.compositeInputsVal(v.CompositeInputs),
+		CompositeExpression:            stringVal(v.CompositeExpression),
+		CompositeMinPassing:            intVal(v.CompositeMinPassing),
 		Timeout:                        b.durationVal(fmt.Sprintf("check[%s].timeout", id), v.Timeout),
 		TTL:                            b.durationVal(fmt.Sprintf("check[%s].ttl", id), v.TTL),
 		SuccessBeforePassing:           intVal(v.SuccessBeforePassing),
@@ -1628,12 +1688,37 @@ func (b *builder) checkVal(v *CheckDefinition) *structs.CheckDefinition {
 		H2PING:                         stringVal(v.H2PING),
 		H2PingUseTLS:                   H2PingUseTLSVal,
 		OSService:                      stringVal(v.OSService),
+		Redis:                          stringVal(v.Redis),
+		PostgreSQL:                     stringVal(v.PostgreSQL),
+		MySQL:                          stringVal(v.MySQL),
+		Memcached:                      stringVal(v.Memcached),
+		TLS:                            stringVal(v.TLS),
+		TLSCAFile:                      stringVal(v.TLSCAFile),
+		TLSExpiryWarningDays:           intVal(v.TLSExpiryWarningDays),
 		DeregisterCriticalServiceAfter: b.durationVal(fmt.Sprintf("check[%s].deregister_critical_service_after", id), v.DeregisterCriticalServiceAfter),
 		OutputMaxSize:                  intValWithDefault(v.OutputMaxSize, checks.DefaultBufSize),
 		EnterpriseMeta:                 v.EnterpriseMeta.ToStructs(),
 	}
 }
 
+func (b *builder) compositeInputsVal(v []CompositeCheckInput) []structs.CompositeCheckInput {
+	if len(v) == 0 {
+		return nil
+	}
+	inputs := make([]structs.CompositeCheckInput, len(v))
+	for i, input := range v {
+		inputs[i] = structs.CompositeCheckInput{
+			Name:       stringVal(input.Name),
+			Service:    stringVal(input.Service),
+			Node:       stringVal(input.Node),
+			CheckID:    types.CheckID(stringVal(input.CheckID)),
+			Datacenter: stringVal(input.Datacenter),
+			MinPassing: intVal(input.MinPassing),
+		}
+	}
+	return inputs
+}
+
 func (b *builder) svcTaggedAddresses(v map[string]ServiceAddress) map[string]structs.ServiceAddress {
 	if len(v) <= 0 {
 		return nil
@@ -2582,6 +2667,10 @@ func UIPathBuilder(UIContentString string) string {
 	return "/ui/"
 }
 
+// minDNSSECKeyRotationPeriod leaves enough time for a new DNSSEC key to be
+// published and the previous key to be retired before the next rotation.
+const minDNSSECKeyRotationPeriod = 24 * time.Hour
+
 const remoteScriptCheckSecurityWarning = "using enable-script-checks without ACLs and without allow_write_http_from is DANGEROUS, use enable-local-script-checks instead, see https://www.hashicorp.com/blog/protecting-consul-from-rce-risk-in-specific-configurations/"
 
 // validateRemoteScriptsChecks returns an error if EnableRemoteScriptChecks is
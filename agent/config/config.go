@@ -150,6 +150,7 @@ type Config struct {
 	BootstrapExpect                  *int                `mapstructure:"bootstrap_expect" json:"bootstrap_expect,omitempty"`
 	Cache                            Cache               `mapstructure:"cache" json:"-"`
 	Check                            *CheckDefinition    `mapstructure:"check" json:"-"` // needs to be a pointer to avoid partial merges
+	CheckHistorySize                 *int                `mapstructure:"check_history_size" json:"check_history_size,omitempty"`
 	CheckOutputMaxSize               *int                `mapstructure:"check_output_max_size" json:"check_output_max_size,omitempty"`
 	CheckUpdateInterval              *string             `mapstructure:"check_update_interval" json:"check_update_interval,omitempty"`
 	Checks                           []CheckDefinition   `mapstructure:"checks" json:"-"`
@@ -165,6 +166,7 @@ type Config struct {
 	DataDir                          *string             `mapstructure:"data_dir" json:"data_dir,omitempty"`
 	Datacenter                       *string             `mapstructure:"datacenter" json:"datacenter,omitempty"`
 	DefaultQueryTime                 *string             `mapstructure:"default_query_time" json:"default_query_time,omitempty"`
+	DevResourceStoragePath           *string             `mapstructure:"dev_resource_storage_path" json:"dev_resource_storage_path,omitempty"`
 	DisableAnonymousSignature        *bool               `mapstructure:"disable_anonymous_signature" json:"disable_anonymous_signature,omitempty"`
 	DisableCoordinates               *bool               `mapstructure:"disable_coordinates" json:"disable_coordinates,omitempty"`
 	DisableHostNodeID                *bool               `mapstructure:"disable_host_node_id" json:"disable_host_node_id,omitempty"`
@@ -186,6 +188,7 @@ type Config struct {
 	GossipLAN                        GossipLANConfig     `mapstructure:"gossip_lan" json:"-"`
 	GossipWAN                        GossipWANConfig     `mapstructure:"gossip_wan" json:"-"`
 	HTTPConfig                       HTTPConfig          `mapstructure:"http_config" json:"-"`
+	KVRevisions                      map[string]int      `mapstructure:"kv_revisions" json:"kv_revisions,omitempty"`
 	LeaveOnTerm                      *bool               `mapstructure:"leave_on_terminate" json:"leave_on_terminate,omitempty"`
 	LicensePath                      *string             `mapstructure:"license_path" json:"license_path,omitempty"`
 	Limits                           Limits              `mapstructure:"limits" json:"-"`
@@ -346,11 +349,13 @@ type Consul struct {
 }
 
 type Addresses struct {
-	DNS     *string `mapstructure:"dns"`
-	HTTP    *string `mapstructure:"http"`
-	HTTPS   *string `mapstructure:"https"`
-	GRPC    *string `mapstructure:"grpc"`
-	GRPCTLS *string `mapstructure:"grpc_tls"`
+	DNS      *string `mapstructure:"dns"`
+	DNSTLS   *string `mapstructure:"dns_tls"`
+	DNSHTTPS *string `mapstructure:"dns_https"`
+	HTTP     *string `mapstructure:"http"`
+	HTTPS    *string `mapstructure:"https"`
+	GRPC     *string `mapstructure:"grpc"`
+	GRPCTLS  *string `mapstructure:"grpc_tls"`
 }
 
 type AdvertiseAddrsConfig struct {
@@ -407,43 +412,70 @@ type ServiceDefinition struct {
 }
 
 type CheckDefinition struct {
-	ID                             *string             `mapstructure:"id"`
-	Name                           *string             `mapstructure:"name"`
-	Notes                          *string             `mapstructure:"notes"`
-	ServiceID                      *string             `mapstructure:"service_id" alias:"serviceid"`
-	Token                          *string             `mapstructure:"token"`
-	Status                         *string             `mapstructure:"status"`
-	ScriptArgs                     []string            `mapstructure:"args" alias:"scriptargs"`
-	HTTP                           *string             `mapstructure:"http"`
-	Header                         map[string][]string `mapstructure:"header"`
-	Method                         *string             `mapstructure:"method"`
-	Body                           *string             `mapstructure:"body"`
-	DisableRedirects               *bool               `mapstructure:"disable_redirects"`
-	OutputMaxSize                  *int                `mapstructure:"output_max_size"`
-	TCP                            *string             `mapstructure:"tcp"`
-	UDP                            *string             `mapstructure:"udp"`
-	Interval                       *string             `mapstructure:"interval"`
-	DockerContainerID              *string             `mapstructure:"docker_container_id" alias:"dockercontainerid"`
-	Shell                          *string             `mapstructure:"shell"`
-	GRPC                           *string             `mapstructure:"grpc"`
-	GRPCUseTLS                     *bool               `mapstructure:"grpc_use_tls"`
-	TLSServerName                  *string             `mapstructure:"tls_server_name"`
-	TLSSkipVerify                  *bool               `mapstructure:"tls_skip_verify" alias:"tlsskipverify"`
-	AliasNode                      *string             `mapstructure:"alias_node"`
-	AliasService                   *string             `mapstructure:"alias_service"`
-	Timeout                        *string             `mapstructure:"timeout"`
-	TTL                            *string             `mapstructure:"ttl"`
-	H2PING                         *string             `mapstructure:"h2ping"`
-	H2PingUseTLS                   *bool               `mapstructure:"h2ping_use_tls"`
-	OSService                      *string             `mapstructure:"os_service"`
-	SuccessBeforePassing           *int                `mapstructure:"success_before_passing"`
-	FailuresBeforeWarning          *int                `mapstructure:"failures_before_warning"`
-	FailuresBeforeCritical         *int                `mapstructure:"failures_before_critical"`
-	DeregisterCriticalServiceAfter *string             `mapstructure:"deregister_critical_service_after" alias:"deregistercriticalserviceafter"`
+	ID                             *string               `mapstructure:"id"`
+	Name                           *string               `mapstructure:"name"`
+	Notes                          *string               `mapstructure:"notes"`
+	ServiceID                      *string               `mapstructure:"service_id" alias:"serviceid"`
+	Token                          *string               `mapstructure:"token"`
+	Status                         *string               `mapstructure:"status"`
+	ScriptArgs                     []string              `mapstructure:"args" alias:"scriptargs"`
+	HTTP                           *string               `mapstructure:"http"`
+	Header                         map[string][]string   `mapstructure:"header"`
+	Method                         *string               `mapstructure:"method"`
+	Body                           *string               `mapstructure:"body"`
+	DisableRedirects               *bool                 `mapstructure:"disable_redirects"`
+	ResponseBodyRegex              *string               `mapstructure:"response_body_regex"`
+	ResponseJSONPath               *string               `mapstructure:"response_json_path"`
+	ResponseJSONValue              *string               `mapstructure:"response_json_value"`
+	ResponseHeaders                map[string]string     `mapstructure:"response_headers"`
+	PassingStatusCodes             []string              `mapstructure:"passing_status_codes"`
+	WarningStatusCodes             []string              `mapstructure:"warning_status_codes"`
+	CriticalStatusCodes            []string              `mapstructure:"critical_status_codes"`
+	OutputMaxSize                  *int                  `mapstructure:"output_max_size"`
+	TCP                            *string               `mapstructure:"tcp"`
+	UDP                            *string               `mapstructure:"udp"`
+	Interval                       *string               `mapstructure:"interval"`
+	DockerContainerID              *string               `mapstructure:"docker_container_id" alias:"dockercontainerid"`
+	Shell                          *string               `mapstructure:"shell"`
+	GRPC                           *string               `mapstructure:"grpc"`
+	GRPCUseTLS                     *bool                 `mapstructure:"grpc_use_tls"`
+	TLSServerName                  *string               `mapstructure:"tls_server_name"`
+	TLSSkipVerify                  *bool                 `mapstructure:"tls_skip_verify" alias:"tlsskipverify"`
+	AliasNode                      *string               `mapstructure:"alias_node"`
+	AliasService                   *string               `mapstructure:"alias_service"`
+	CompositeInputs                []CompositeCheckInput `mapstructure:"composite_inputs"`
+	CompositeExpression            *string               `mapstructure:"composite_expression"`
+	CompositeMinPassing            *int                  `mapstructure:"composite_min_passing"`
+	Timeout                        *string               `mapstructure:"timeout"`
+	TTL                            *string               `mapstructure:"ttl"`
+	H2PING                         *string               `mapstructure:"h2ping"`
+	H2PingUseTLS                   *bool                 `mapstructure:"h2ping_use_tls"`
+	OSService                      *string               `mapstructure:"os_service"`
+	Redis                          *string               `mapstructure:"redis"`
+	PostgreSQL                     *string               `mapstructure:"postgresql"`
+	MySQL                          *string               `mapstructure:"mysql"`
+	Memcached                      *string               `mapstructure:"memcached"`
+	TLS                            *string               `mapstructure:"tls"`
+	TLSCAFile                      *string               `mapstructure:"tls_ca_file"`
+	TLSExpiryWarningDays           *int                  `mapstructure:"tls_expiry_warning_days"`
+	SuccessBeforePassing           *int                  `mapstructure:"success_before_passing"`
+	FailuresBeforeWarning          *int                  `mapstructure:"failures_before_warning"`
+	FailuresBeforeCritical         *int                  `mapstructure:"failures_before_critical"`
+	DeregisterCriticalServiceAfter *string               `mapstructure:"deregister_critical_service_after" alias:"deregistercriticalserviceafter"`
 
 	EnterpriseMeta `mapstructure:",squash"`
 }
 
+// CompositeCheckInput is an input of a composite check.
+type CompositeCheckInput struct {
+	Name       *string `mapstructure:"name"`
+	Service    *string `mapstructure:"service"`
+	Node       *string `mapstructure:"node"`
+	CheckID    *string `mapstructure:"check_id"`
+	Datacenter *string `mapstructure:"datacenter"`
+	MinPassing *int    `mapstructure:"min_passing"`
+}
+
 // ServiceConnect is the connect block within a service registration
 type ServiceConnect struct {
 	// Native is true when this service can natively understand Connect.
@@ -650,17 +682,31 @@ type DNS struct {
 	OnlyPassing        *bool             `mapstructure:"only_passing"`
 	RecursorStrategy   *string           `mapstructure:"recursor_strategy"`
 	RecursorTimeout    *string           `mapstructure:"recursor_timeout"`
+	RecursorCacheSize  *int              `mapstructure:"recursor_cache_size_mb"`
+	RecursorPrefetch   *bool             `mapstructure:"recursor_cache_prefetch"`
 	ServiceTTL         map[string]string `mapstructure:"service_ttl"`
 	UDPAnswerLimit     *int              `mapstructure:"udp_answer_limit"`
 	NodeMetaTXT        *bool             `mapstructure:"enable_additional_node_meta_txt"`
 	SOA                *SOA              `mapstructure:"soa"`
 	UseCache           *bool             `mapstructure:"use_cache"`
 	CacheMaxAge        *string           `mapstructure:"cache_max_age"`
+	ZoneTransfer       DNSZoneTransfer   `mapstructure:"zone_transfer"`
+	DNSSEC             DNSSEC            `mapstructure:"dnssec"`
 
 	// Enterprise Only
 	PreferNamespace *bool `mapstructure:"prefer_namespace"`
 }
 
+type DNSZoneTransfer struct {
+	AllowedCIDRs []string `mapstructure:"allowed_cidrs"`
+	Token        *string  `mapstructure:"token"`
+}
+
+type DNSSEC struct {
+	Enabled           *bool   `mapstructure:"enabled"`
+	KeyRotationPeriod *string `mapstructure:"key_rotation_period"`
+}
+
 type HTTPConfig struct {
 	BlockEndpoints     []string          `mapstructure:"block_endpoints"`
 	AllowWriteHTTPFrom []string          `mapstructure:"allow_write_http_from"`
@@ -703,6 +749,8 @@ type Telemetry struct {
 
 type Ports struct {
 	DNS            *int `mapstructure:"dns" json:"dns,omitempty"`
+	DNSTLS         *int `mapstructure:"dns_tls" json:"dns_tls,omitempty"`
+	DNSHTTPS       *int `mapstructure:"dns_https" json:"dns_https,omitempty"`
 	HTTP           *int `mapstructure:"http" json:"http,omitempty"`
 	HTTPS          *int `mapstructure:"https" json:"https,omitempty"`
 	SerfLAN        *int `mapstructure:"serf_lan" json:"serf_lan,omitempty"`
@@ -47,9 +47,12 @@ func AddFlags(fs *flag.FlagSet, f *LoadOpts) {
 	add(&f.FlagValues.Datacenter, "datacenter", "Datacenter of the agent.")
 	add(&f.FlagValues.DefaultQueryTime, "default-query-time", "the amount of time a blocking query will wait before Consul will force a response. This value can be overridden by the 'wait' query parameter.")
 	add(&f.DevMode, "dev", "Starts the agent in development mode.")
+	add(&f.FlagValues.DevResourceStoragePath, "dev-resource-storage-path", "Path to a file in which to store resources in development mode. By default, resources are only stored in memory.")
 	add(&f.FlagValues.DisableHostNodeID, "disable-host-node-id", "Setting this to true will prevent Consul from using information from the host to generate a node ID, and will cause Consul to generate a random node ID instead.")
 	add(&f.FlagValues.DisableKeyringFile, "disable-keyring-file", "Disables the backing up of the keyring to a file.")
 	add(&f.FlagValues.Ports.DNS, "dns-port", "DNS port to use.")
+	add(&f.FlagValues.Ports.DNSTLS, "dns-tls-port", "Sets the DNS-over-TLS port to listen on.")
+	add(&f.FlagValues.Ports.DNSHTTPS, "dns-https-port", "Sets the DNS-over-HTTPS port to listen on.")
 	add(&f.FlagValues.DNSDomain, "domain", "Domain to use for DNS interface.")
 	add(&f.FlagValues.DNSAltDomain, "alt-domain", "Alternate domain to use for DNS interface.")
 	add(&f.FlagValues.EnableScriptChecks, "enable-script-checks", "Enables health check scripts.")
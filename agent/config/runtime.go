@@ -262,6 +262,18 @@ type RuntimeConfig struct {
 	// hcl: dns_config { recursor_timeout = "duration" }
 	DNSRecursorTimeout time.Duration
 
+	// DNSRecursorCacheSize is the maximum size in bytes of the cache of
+	// responses from the DNS recursors. The cache is disabled when this is 0.
+	//
+	// hcl: dns_config { recursor_cache_size_mb = int }
+	DNSRecursorCacheSize int
+
+	// DNSRecursorPrefetch controls whether popular entries of the
+	// recursor cache are refreshed shortly before they expire.
+	//
+	// hcl: dns_config { recursor_cache_prefetch = (true|false) }
+	DNSRecursorPrefetch bool
+
 	// DNSServiceTTL provides the TTL value for a service
 	// query for given service. The "*" wildcard can be used
 	// to set a default for all services.
@@ -301,6 +313,34 @@ type RuntimeConfig struct {
 	// hcl: dns_config { cache_max_age = "duration" }
 	DNSCacheMaxAge time.Duration
 
+	// DNSZoneTransferAllowedCIDRs restricts AXFR and IXFR zone transfers of
+	// the DNS domain to clients in the given networks. Zone transfers are
+	// disabled when this is empty.
+	//
+	// hcl: dns_config { zone_transfer { allowed_cidrs = []string } }
+	DNSZoneTransferAllowedCIDRs []*net.IPNet
+
+	// DNSZoneTransferToken is the ACL token used to read the catalog when
+	// serving zone transfers. The records visible to the token are
+	// transferred. If empty, the agent's default token is used.
+	//
+	// hcl: dns_config { zone_transfer { token = string } }
+	DNSZoneTransferToken string
+
+	// DNSSECEnabled enables DNSSEC signing of the responses for the DNS
+	// domain. On servers, it also enables the management of the signing keys
+	// by the leader of the primary datacenter.
+	//
+	// hcl: dns_config { dnssec { enabled = (true|false) } }
+	DNSSECEnabled bool
+
+	// DNSSECKeyRotationPeriod is how long a DNSSEC key is used for before it
+	// is replaced by a new one. Keys aren't rotated when this is 0. It's only
+	// used by the servers of the primary datacenter.
+	//
+	// hcl: dns_config { dnssec { key_rotation_period = "duration" } }
+	DNSSECKeyRotationPeriod time.Duration
+
 	// HTTPUseCache whether or not to use cache for http queries. Defaults
 	// to true.
 	//
@@ -416,6 +456,12 @@ type RuntimeConfig struct {
 	// hcl: check_update_interval = "duration"
 	CheckUpdateInterval time.Duration
 
+	// CheckHistorySize is the number of recent results kept by the agent for
+	// each check. Setting it to 0 disables the check history.
+	//
+	// hcl: check_history_size = int
+	CheckHistorySize int
+
 	// Maximum size for the output of a healtcheck
 	// hcl check_output_max_size int
 	// flag: -check_output_max_size int
@@ -555,6 +601,42 @@ type RuntimeConfig struct {
 	// flags: -dns-port int
 	DNSPort int
 
+	// DNSTLSAddrs contains the list of TCP addresses the DNS-over-TLS (RFC 7858)
+	// server will bind to. If the endpoint is disabled (ports.dns_tls <= 0) the
+	// list is empty.
+	//
+	// The ip addresses are taken from 'addresses.dns_tls', or the 'client_addr'
+	// addresses if it was not provided. The server uses the certificates
+	// configured in the 'tls.https' stanza.
+	//
+	// hcl: client_addr = string addresses { dns_tls = string } ports { dns_tls = int }
+	DNSTLSAddrs []net.Addr
+
+	// DNSTLSPort is the port the DNS-over-TLS server listens on. It is disabled
+	// by default.
+	//
+	// hcl: ports { dns_tls = int }
+	// flags: -dns-tls-port int
+	DNSTLSPort int
+
+	// DNSHTTPSAddrs contains the list of TCP addresses the DNS-over-HTTPS (RFC
+	// 8484) server will bind to. If the endpoint is disabled (ports.dns_https <= 0)
+	// the list is empty.
+	//
+	// The ip addresses are taken from 'addresses.dns_https', or the 'client_addr'
+	// addresses if it was not provided. The server uses the certificates
+	// configured in the 'tls.https' stanza.
+	//
+	// hcl: client_addr = string addresses { dns_https = string } ports { dns_https = int }
+	DNSHTTPSAddrs []net.Addr
+
+	// DNSHTTPSPort is the port the DNS-over-HTTPS server listens on. It is
+	// disabled by default.
+	//
+	// hcl: ports { dns_https = int }
+	// flags: -dns-https-port int
+	DNSHTTPSPort int
+
 	// DNSSOA is the settings applied for DNS SOA
 	// hcl: soa {}
 	DNSSOA RuntimeSOAConfig
@@ -579,6 +661,14 @@ type RuntimeConfig struct {
 	// flag: -dev
 	DevMode bool
 
+	// DevResourceStoragePath is the path to a file in which a dev mode agent
+	// stores resources, so that they persist across restarts. By default, dev
+	// mode agents only store resources in memory.
+	//
+	// hcl: dev_resource_storage_path = string
+	// flag: -dev-resource-storage-path string
+	DevResourceStoragePath string
+
 	// DisableAnonymousSignature is used to turn off the anonymous signature
 	// send with the update check. This is used to deduplicate messages.
 	//
@@ -787,6 +877,13 @@ type RuntimeConfig struct {
 	// hcl: limits { kv_max_value_size = uint64 }
 	KVMaxValueSize uint64
 
+	// KVRevisions maps KV key prefixes to the number of previous revisions
+	// of the keys under them that the servers retain. When several prefixes
+	// match a key the longest one applies.
+	//
+	// hcl: kv_revisions { "prefix" = int }
+	KVRevisions map[string]int
+
 	// LeaveDrainTime is used to wait after a server has left the LAN Serf
 	// pool for RPCs to drain and new requests to be sent to other servers.
 	//
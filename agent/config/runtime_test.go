@@ -363,6 +363,14 @@ func TestLoad_IntegrationWithFlags(t *testing.T) {
 			rt.GRPCTLSAddrs = []net.Addr{defaultGrpcTlsAddr}
 		},
 	})
+	run(t, testCase{
+		desc: "-dev-resource-storage-path without dev mode",
+		args: []string{
+			`-data-dir=` + dataDir,
+			`-dev-resource-storage-path=` + filepath.Join(dataDir, "resources.db"),
+		},
+		expectedErr: "'dev_resource_storage_path' is only allowed in dev mode",
+	})
 	run(t, testCase{
 		desc: "-disable-host-node-id",
 		args: []string{
@@ -1915,6 +1923,36 @@ func TestLoad_IntegrationWithFlags(t *testing.T) {
 		hcl:         []string{`bootstrap = true bootstrap_expect = 3 server = true`},
 		expectedErr: "'bootstrap_expect > 0' and 'bootstrap = true' are mutually exclusive",
 	})
+	run(t, testCase{
+		desc: "negative check_history_size",
+		args: []string{
+			`-data-dir=` + dataDir,
+		},
+		json:        []string{`{ "check_history_size": -1 }`},
+		hcl:         []string{`check_history_size = -1`},
+		expectedErr: "check_history_size cannot be negative",
+	})
+	run(t, testCase{
+		desc: "kv_revisions",
+		args: []string{
+			`-data-dir=` + dataDir,
+		},
+		json: []string{`{ "kv_revisions": { "config/": 10, "config/app/": 0 } }`},
+		hcl:  []string{`kv_revisions { "config/" = 10 "config/app/" = 0 }`},
+		expected: func(rt *RuntimeConfig) {
+			rt.DataDir = dataDir
+			rt.KVRevisions = map[string]int{"config/": 10, "config/app/": 0}
+		},
+	})
+	run(t, testCase{
+		desc: "negative kv_revisions",
+		args: []string{
+			`-data-dir=` + dataDir,
+		},
+		json:        []string{`{ "kv_revisions": { "config/": -1 } }`},
+		hcl:         []string{`kv_revisions { "config/" = -1 }`},
+		expectedErr: `kv_revisions["config/"] cannot be negative`,
+	})
 	run(t, testCase{
 		desc: "bootstrap-expect=1 equals bootstrap",
 		args: []string{
@@ -2024,6 +2062,50 @@ func TestLoad_IntegrationWithFlags(t *testing.T) {
 		hcl:         []string{`addresses = { dns = "unix:///foo" }`},
 		expectedErr: "DNS address cannot be a unix socket",
 	})
+	run(t, testCase{
+		desc: "dns tls does not allow socket",
+		args: []string{
+			`-datacenter=a`,
+			`-data-dir=` + dataDir,
+		},
+		json:        []string{`{ "addresses": {"dns_tls": "unix:///foo" }, "ports": { "dns_tls": 853 } }`},
+		hcl:         []string{`addresses = { dns_tls = "unix:///foo" } ports = { dns_tls = 853 }`},
+		expectedErr: "DNS-over-TLS and DNS-over-HTTPS addresses cannot be unix sockets",
+	})
+	run(t, testCase{
+		desc: "dns https requires cert",
+		args: []string{
+			`-datacenter=a`,
+			`-data-dir=` + dataDir,
+		},
+		json:        []string{`{ "ports": { "dns_https": 8443 } }`},
+		hcl:         []string{`ports = { dns_https = 8443 }`},
+		expectedErr: "DNS-over-TLS and DNS-over-HTTPS require tls.https.cert_file",
+	})
+	run(t, testCase{
+		desc: "dns tls and https ports",
+		args: []string{
+			`-data-dir=` + dataDir,
+			`-dns-tls-port=853`,
+		},
+		json: []string{`{
+			"ports": { "dns_https": 8443 },
+			"tls": { "https": { "cert_file": "a", "key_file": "b" } }
+		}`},
+		hcl: []string{`
+			ports = { dns_https = 8443 }
+			tls { https { cert_file = "a" key_file = "b" } }
+		`},
+		expected: func(rt *RuntimeConfig) {
+			rt.DataDir = dataDir
+			rt.DNSTLSPort = 853
+			rt.DNSTLSAddrs = []net.Addr{tcpAddr("127.0.0.1:853")}
+			rt.DNSHTTPSPort = 8443
+			rt.DNSHTTPSAddrs = []net.Addr{tcpAddr("127.0.0.1:8443")}
+			rt.TLS.HTTPS.CertFile = "a"
+			rt.TLS.HTTPS.KeyFile = "b"
+		},
+	})
 	run(t, testCase{
 		desc: "ui enabled and dir specified",
 		args: []string{
@@ -2084,6 +2166,33 @@ func TestLoad_IntegrationWithFlags(t *testing.T) {
 		hcl:         []string{`dns_config = { a_record_limit = -1 }`},
 		expectedErr: "dns_config.a_record_limit cannot be -1. Must be greater than or equal to zero",
 	})
+	run(t, testCase{
+		desc: "dns_config.recursor_cache_size_mb invalid",
+		args: []string{
+			`-data-dir=` + dataDir,
+		},
+		json:        []string{`{ "dns_config": { "recursor_cache_size_mb": -1 } }`},
+		hcl:         []string{`dns_config = { recursor_cache_size_mb = -1 }`},
+		expectedErr: "dns_config.recursor_cache_size_mb cannot be -1. Must be greater than or equal to zero",
+	})
+	run(t, testCase{
+		desc: "dns_config.zone_transfer.allowed_cidrs invalid",
+		args: []string{
+			`-data-dir=` + dataDir,
+		},
+		json:        []string{`{ "dns_config": { "zone_transfer": { "allowed_cidrs": ["10.0.0.1"] } } }`},
+		hcl:         []string{`dns_config = { zone_transfer = { allowed_cidrs = ["10.0.0.1"] } }`},
+		expectedErr: "dns_config.zone_transfer.allowed_cidrs: invalid cidr: 10.0.0.1",
+	})
+	run(t, testCase{
+		desc: "dns_config.dnssec.key_rotation_period too short",
+		args: []string{
+			`-data-dir=` + dataDir,
+		},
+		json:        []string{`{ "dns_config": { "dnssec": { "key_rotation_period": "1h" } } }`},
+		hcl:         []string{`dns_config = { dnssec = { key_rotation_period = "1h" } }`},
+		expectedErr: "dns_config.dnssec.key_rotation_period cannot be 1h0m0s. Must be 0 or at least 24h0m0s",
+	})
 	run(t, testCase{
 		desc: "performance.raft_multiplier < 0",
 		args: []string{
@@ -2500,6 +2609,55 @@ func TestLoad_IntegrationWithFlags(t *testing.T) {
 			rt.DataDir = dataDir
 		},
 	})
+	run(t, testCase{
+		desc: "composite check",
+		args: []string{
+			`-data-dir=` + dataDir,
+		},
+		json: []string{
+			`{ "check": { "name": "a", "composite_inputs": [
+				{ "name": "db", "service": "db", "datacenter": "dc2", "min_passing": 2 },
+				{ "name": "cache", "node": "node1", "check_id": "redis" }
+			], "composite_expression": "db && cache" } }`,
+		},
+		hcl: []string{
+			`check = {
+				name = "a"
+				composite_inputs = [
+					{ name = "db", service = "db", datacenter = "dc2", min_passing = 2 },
+					{ name = "cache", node = "node1", check_id = "redis" }
+				]
+				composite_expression = "db && cache"
+			}`,
+		},
+		expected: func(rt *RuntimeConfig) {
+			rt.Checks = []*structs.CheckDefinition{
+				{
+					Name: "a",
+					CompositeInputs: []structs.CompositeCheckInput{
+						{Name: "db", Service: "db", Datacenter: "dc2", MinPassing: 2},
+						{Name: "cache", Node: "node1", CheckID: "redis"},
+					},
+					CompositeExpression: "db && cache",
+					OutputMaxSize:       checks.DefaultBufSize,
+				},
+			}
+			rt.DataDir = dataDir
+		},
+	})
+	run(t, testCase{
+		desc: "composite check with unknown input in expression",
+		args: []string{
+			`-data-dir=` + dataDir,
+		},
+		json: []string{
+			`{ "check": { "name": "a", "composite_inputs": [ { "name": "db", "service": "db" } ], "composite_expression": "db || cache" } }`,
+		},
+		hcl: []string{
+			`check = { name = "a", composite_inputs = [ { name = "db", service = "db" } ], composite_expression = "db || cache" }`,
+		},
+		expectedErr: `Invalid CompositeExpression: unknown input "cache"`,
+	})
 	run(t, testCase{
 		desc: "os_service check no interval",
 		args: []string{
@@ -2511,7 +2669,7 @@ func TestLoad_IntegrationWithFlags(t *testing.T) {
 		hcl: []string{
 			`check = { name = "a", os_service = "foo" }`,
 		},
-		expectedErr: `Interval must be > 0 for Script, HTTP, H2PING, TCP, UDP or OSService checks`,
+		expectedErr: `Interval must be > 0 for Script, HTTP, H2PING, TCP, UDP, TLS, Redis, PostgreSQL, MySQL, Memcached or OSService checks`,
 	})
 	run(t, testCase{
 		desc: "os_service check",
@@ -6077,6 +6235,7 @@ func TestLoad_FullConfig(t *testing.T) {
 			EntryFetchMaxBurst: 42,
 			EntryFetchRate:     0.334,
 		},
+		CheckHistorySize:   27,
 		CheckOutputMaxSize: checks.DefaultBufSize,
 		Checks: []*structs.CheckDefinition{
 			{
@@ -6153,11 +6312,25 @@ func TestLoad_FullConfig(t *testing.T) {
 				Method:                         "Dou0nGT5",
 				Body:                           "5PBQd2OT",
 				DisableRedirects:               true,
+				ResponseBodyRegex:              "aRgYx3cT",
+				ResponseJSONPath:               "kF2dnVqp",
+				ResponseJSONValue:              "tw0QbE8s",
+				ResponseHeaders:                map[string]string{"Uc6sPnXo": "Jb4vGk9D"},
+				PassingStatusCodes:             []string{"200-299", "304"},
+				WarningStatusCodes:             []string{"429"},
+				CriticalStatusCodes:            []string{"500-599"},
 				OutputMaxSize:                  checks.DefaultBufSize,
 				TCP:                            "JY6fTTcw",
 				H2PING:                         "rQ8eyCSF",
 				H2PingUseTLS:                   false,
 				OSService:                      "aZaCAXww",
+				Redis:                          "kR7wNq2D:6379",
+				PostgreSQL:                     "Yb4tLz9M:5432",
+				MySQL:                          "Hc3vPj6X:3306",
+				Memcached:                      "Wd8sFm1Q:11211",
+				TLS:                            "Ew2fWm6y",
+				TLSCAFile:                      "pQ4Zb8sL",
+				TLSExpiryWarningDays:           30,
 				Interval:                       18714 * time.Second,
 				DockerContainerID:              "qF66POS9",
 				Shell:                          "sOnDy228",
@@ -6251,15 +6424,25 @@ func TestLoad_FullConfig(t *testing.T) {
 		DNSDomain:                        "7W1xXSqd",
 		DNSAltDomain:                     "1789hsd",
 		DNSEnableTruncate:                true,
+		DNSHTTPSAddrs:                    []net.Addr{tcpAddr("61.28.39.74:7443")},
+		DNSHTTPSPort:                     7443,
 		DNSMaxStale:                      29685 * time.Second,
 		DNSNodeTTL:                       7084 * time.Second,
 		DNSOnlyPassing:                   true,
 		DNSPort:                          7001,
 		DNSRecursorStrategy:              "sequential",
 		DNSRecursorTimeout:               4427 * time.Second,
+		DNSRecursorCacheSize:             26 * 1024 * 1024,
+		DNSRecursorPrefetch:              false,
+		DNSZoneTransferAllowedCIDRs:      []*net.IPNet{cidr("10.91.0.0/16")},
+		DNSZoneTransferToken:             "d3f8a6e1-5b3c-4c0e-9f1b-2b4f6a7c8d90",
+		DNSSECEnabled:                    true,
+		DNSSECKeyRotationPeriod:          2161 * time.Hour,
 		DNSRecursors:                     []string{"63.38.39.58", "92.49.18.18"},
 		DNSSOA:                           RuntimeSOAConfig{Refresh: 3600, Retry: 600, Expire: 86400, Minttl: 0},
 		DNSServiceTTL:                    map[string]time.Duration{"*": 32030 * time.Second},
+		DNSTLSAddrs:                      []net.Addr{tcpAddr("42.17.85.13:7853")},
+		DNSTLSPort:                       7853,
 		DNSUDPAnswerLimit:                29909,
 		DNSNodeMetaTXT:                   true,
 		DNSUseCache:                      true,
@@ -6303,6 +6486,7 @@ func TestLoad_FullConfig(t *testing.T) {
 		HTTPSPort:             15127,
 		HTTPUseCache:          false,
 		KVMaxValueSize:        1234567800,
+		KVRevisions:           map[string]int{"config/": 17},
 		LeaveDrainTime:        8265 * time.Second,
 		LeaveOnTerm:           true,
 		Logging: logging.Config{
@@ -44,6 +44,8 @@ acl = {
 }
 addresses = {
     dns = "93.95.95.81"
+    dns_tls = "42.17.85.13"
+    dns_https = "61.28.39.74"
     http = "83.39.91.39"
     https = "95.17.17.19"
     grpc = "32.31.61.91"
@@ -115,6 +117,15 @@ check = {
     method = "Dou0nGT5"
     body = "5PBQd2OT"
     disable_redirects = true
+    response_body_regex = "aRgYx3cT"
+    response_json_path = "kF2dnVqp"
+    response_json_value = "tw0QbE8s"
+    response_headers = {
+        Uc6sPnXo = "Jb4vGk9D"
+    }
+    passing_status_codes = [ "200-299", "304" ]
+    warning_status_codes = [ "429" ]
+    critical_status_codes = [ "500-599" ]
     tcp = "JY6fTTcw"
     h2ping = "rQ8eyCSF"
     h2ping_use_tls = false
@@ -123,6 +134,13 @@ check = {
     docker_container_id = "qF66POS9"
     shell = "sOnDy228"
     os_service = "aZaCAXww"
+    redis = "kR7wNq2D:6379"
+    postgresql = "Yb4tLz9M:5432"
+    mysql = "Hc3vPj6X:3306"
+    memcached = "Wd8sFm1Q:11211"
+    tls = "Ew2fWm6y"
+    tls_ca_file = "pQ4Zb8sL"
+    tls_expiry_warning_days = 30
     tls_server_name = "7BdnzBYk"
     tls_skip_verify = true
     timeout = "5954s"
@@ -188,6 +206,7 @@ checks = [
         deregister_critical_service_after = "2366s"
     }
 ]
+check_history_size = 27
 check_update_interval = "16507s"
 client_addr = "93.83.18.19"
 config_entries {
@@ -267,6 +286,16 @@ dns_config {
     node_ttl = "7084s"
     only_passing = true
     recursor_timeout = "4427s"
+    recursor_cache_size_mb = 26
+    recursor_cache_prefetch = false
+    dnssec {
+        enabled = true
+        key_rotation_period = "2161h"
+    }
+    zone_transfer {
+        allowed_cidrs = ["10.91.0.0/16"]
+        token = "d3f8a6e1-5b3c-4c0e-9f1b-2b4f6a7c8d90"
+    }
     service_ttl = {
         "*" = "32030s"
     }
@@ -296,6 +325,9 @@ http_config {
     max_header_bytes = 10
 }
 key_file = "IEkkwgIA"
+kv_revisions {
+    "config/" = 17
+}
 leave_on_terminate = true
 license_path = "/path/to/license.lic"
 limits {
@@ -336,6 +368,8 @@ performance {
 pid_file = "43xN80Km"
 ports {
     dns = 7001
+    dns_tls = 7853
+    dns_https = 7443
     http = 7999
     https = 15127
     server = 3757
@@ -107,6 +107,10 @@ type Config struct {
 	// DevMode is used to enable a development server mode.
 	DevMode bool
 
+	// DevResourceStoragePath is the path to a BoltDB file in which to store
+	// resources in development mode. If empty, resources are stored in memory.
+	DevResourceStoragePath string
+
 	// NodeID is a unique identifier for this node across space and time.
 	NodeID types.NodeID
 
@@ -263,6 +267,11 @@ type Config struct {
 	// Minimum Session TTL
 	SessionTTLMin time.Duration
 
+	// KVRevisions maps KV key prefixes to the number of previous revisions
+	// of the keys under them that are retained. When several prefixes match
+	// a key the longest one applies.
+	KVRevisions map[string]int
+
 	// maxTokenExpirationDuration is the maximum difference allowed between
 	// ACLToken CreateTime and ExpirationTime values if ExpirationTime is set
 	// on a token.
@@ -409,6 +418,14 @@ type Config struct {
 	// datacenters should exclusively traverse mesh gateways.
 	ConnectMeshGatewayWANFederationEnabled bool
 
+	// DNSSECEnabled is whether the leader of the primary datacenter manages
+	// the keys used by the agents to sign their DNS responses.
+	DNSSECEnabled bool
+
+	// DNSSECKeyRotationPeriod is how long a DNSSEC key is used for before it
+	// is replaced. Keys aren't rotated if this is 0.
+	DNSSECKeyRotationPeriod time.Duration
+
 	// DisableFederationStateAntiEntropy solely exists for use in unit tests to
 	// disable a background routine.
 	DisableFederationStateAntiEntropy bool
@@ -23,6 +23,7 @@ func init() {
 	registerRestorer(structs.RegisterRequestType, restoreRegistration)
 	registerRestorer(structs.KVSRequestType, restoreKV)
 	registerRestorer(structs.TombstoneRequestType, restoreTombstone)
+	registerRestorer(structs.KVSRevisionRequestType, restoreKVSRevision)
 	registerRestorer(structs.SessionRequestType, restoreSession)
 	registerRestorer(structs.CoordinateBatchUpdateType, restoreCoordinates)
 	registerRestorer(structs.PreparedQueryRequestType, restorePreparedQuery)
@@ -66,6 +67,9 @@ func persistOSS(s *snapshot, sink raft.SnapshotSink, encoder *codec.Encoder) err
 	if err := s.persistTombstones(sink, encoder); err != nil {
 		return err
 	}
+	if err := s.persistKVsRevisions(sink, encoder); err != nil {
+		return err
+	}
 	if err := s.persistPreparedQueries(sink, encoder); err != nil {
 		return err
 	}
@@ -299,6 +303,24 @@ func (s *snapshot) persistKVs(sink raft.SnapshotSink,
 	return nil
 }
 
+func (s *snapshot) persistKVsRevisions(sink raft.SnapshotSink,
+	encoder *codec.Encoder) error {
+	revs, err := s.state.KVsRevisions()
+	if err != nil {
+		return err
+	}
+
+	for rev := revs.Next(); rev != nil; rev = revs.Next() {
+		if _, err := sink.Write([]byte{byte(structs.KVSRevisionRequestType)}); err != nil {
+			return err
+		}
+		if err := encoder.Encode(rev.(*structs.DirEntryRevision)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (s *snapshot) persistTombstones(sink raft.SnapshotSink,
 	encoder *codec.Encoder) error {
 	stones, err := s.state.Tombstones()
@@ -673,6 +695,17 @@ func restoreTombstone(header *SnapshotHeader, restore *state.Restore, decoder *c
 	return nil
 }
 
+func restoreKVSRevision(header *SnapshotHeader, restore *state.Restore, decoder *codec.Decoder) error {
+	var req structs.DirEntryRevision
+	if err := decoder.Decode(&req); err != nil {
+		return err
+	}
+	if err := restore.KVSRevision(&req); err != nil {
+		return err
+	}
+	return nil
+}
+
 func restoreSession(header *SnapshotHeader, restore *state.Restore, decoder *codec.Decoder) error {
 	var req structs.Session
 	if err := decoder.Decode(&req); err != nil {
@@ -160,8 +160,9 @@ func TestFSM_SnapshotRestore_OSS(t *testing.T) {
 	require.NoError(t, fsm.state.ACLBindingRuleSet(1, bindingRule))
 
 	fsm.state.KVSSet(11, &structs.DirEntry{
-		Key:   "/remove",
-		Value: []byte("foo"),
+		Key:             "/remove",
+		Value:           []byte("foo"),
+		RetainRevisions: 1,
 	})
 	fsm.state.KVSDelete(12, "/remove", nil)
 	idx, _, err := fsm.state.KVSList(nil, "/remove", nil)
@@ -754,6 +755,13 @@ func TestFSM_SnapshotRestore_OSS(t *testing.T) {
 		require.Nil(t, stones.Next())
 	}()
 
+	// Verify KV revisions are restored
+	_, history, err := fsm2.state.KVSHistory(nil, "/remove", nil)
+	require.NoError(t, err)
+	require.Len(t, history, 1)
+	require.Equal(t, []byte("foo"), history[0].Value)
+	require.True(t, history[0].Deleted)
+
 	// Verify coordinates are restored
 	_, coords, err := fsm2.state.Coordinates(nil, nil)
 	require.NoError(t, err)
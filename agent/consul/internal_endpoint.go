@@ -277,6 +277,46 @@ func (m *Internal) CatalogOverview(args *structs.DCSpecificRequest, reply *struc
 	return nil
 }
 
+// DNSSECKeys returns the keys used by the agents to sign their DNS responses,
+// including their private keys. The keys are managed by the primary
+// datacenter, so requests should target it.
+//
+// Every agent serving DNS holds the private keys, so they can sign the
+// responses of the zone. Reading them requires operator:write, since whoever
+// holds them can forge signed records.
+func (m *Internal) DNSSECKeys(args *structs.DCSpecificRequest, reply *structs.IndexedDNSSECKeys) error {
+	if done, err := m.srv.ForwardRPC("Internal.DNSSECKeys", args, reply); done {
+		return err
+	}
+
+	authz, err := m.srv.ResolveTokenAndDefaultMeta(args.Token, &args.EnterpriseMeta, nil)
+	if err != nil {
+		return err
+	}
+
+	if authz.OperatorWrite(nil) != acl.Allow {
+		return acl.PermissionDeniedByACLUnnamed(authz, nil, acl.ResourceOperator, acl.AccessWrite)
+	}
+
+	return m.srv.blockingQuery(
+		&args.QueryOptions,
+		&reply.QueryMeta,
+		func(ws memdb.WatchSet, state *state.Store) error {
+			index, entry, err := state.SystemMetadataGet(ws, structs.SystemMetadataDNSSECKeys)
+			if err != nil {
+				return err
+			}
+
+			reply.Index, reply.Keys = index, nil
+			if entry == nil {
+				return nil
+			}
+			reply.Index = entry.ModifyIndex
+			reply.Keys, err = decodeDNSSECKeys(entry.Value)
+			return err
+		})
+}
+
 func (m *Internal) ServiceTopology(args *structs.ServiceSpecificRequest, reply *structs.IndexedServiceTopology) error {
 	if done, err := m.srv.ForwardRPC("Internal.ServiceTopology", args, reply); done {
 		return err
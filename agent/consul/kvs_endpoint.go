@@ -93,9 +93,40 @@ func kvsPreApply(logger hclog.Logger, srv *Server, authz resolver.Result, op api
 		}
 	}
 
+	// The expiration time of an entry is also based on wall-time, so it's
+	// computed from the TTL before commit for the same reason.
+	switch op {
+	case api.KVSet, api.KVCAS, api.KVLock, api.KVUnlock:
+		if dirEnt.ExpirationTTL < 0 {
+			return false, fmt.Errorf("TTL for key %q must not be negative", dirEnt.Key)
+		}
+		dirEnt.ExpirationTime = nil
+		if dirEnt.ExpirationTTL > 0 {
+			expires := time.Now().Add(dirEnt.ExpirationTTL)
+			dirEnt.ExpirationTime = &expires
+			dirEnt.ExpirationTTL = 0
+		}
+
+		// The number of revisions to retain is also set before commit, so
+		// that servers with a different configuration agree on it.
+		dirEnt.RetainRevisions = kvsRetainRevisions(srv.config.KVRevisions, dirEnt.Key)
+	}
+
 	return true, nil
 }
 
+// kvsRetainRevisions returns the number of previous revisions of a key to
+// retain, from the longest prefix of the key in the given configuration.
+func kvsRetainRevisions(revisions map[string]int, key string) int {
+	retain, longest := 0, -1
+	for prefix, n := range revisions {
+		if strings.HasPrefix(key, prefix) && len(prefix) > longest {
+			retain, longest = n, len(prefix)
+		}
+	}
+	return retain
+}
+
 // Apply is used to apply a KVS update request to the data store.
 func (k *KVS) Apply(args *structs.KVSRequest, reply *bool) error {
 	if done, err := k.srv.ForwardRPC("KVS.Apply", args, reply); done {
@@ -128,6 +159,9 @@ func (k *KVS) Apply(args *structs.KVSRequest, reply *bool) error {
 		return fmt.Errorf("raft apply failed: %w", err)
 	}
 
+	// Track the expiration of the entry.
+	k.srv.resetKVSTimer(&args.DirEnt)
+
 	// Check if the return type is a bool.
 	if respBool, ok := resp.(bool); ok {
 		*reply = respBool
@@ -135,7 +169,8 @@ func (k *KVS) Apply(args *structs.KVSRequest, reply *bool) error {
 	return nil
 }
 
-// Get is used to lookup a single key.
+// Get is used to lookup a single key, or the revision of the key that was
+// current at a given index.
 func (k *KVS) Get(args *structs.KeyRequest, reply *structs.IndexedDirEntries) error {
 	if done, err := k.srv.ForwardRPC("KVS.Get", args, reply); done {
 		return err
@@ -155,7 +190,14 @@ func (k *KVS) Get(args *structs.KeyRequest, reply *structs.IndexedDirEntries) er
 		&args.QueryOptions,
 		&reply.QueryMeta,
 		func(ws memdb.WatchSet, state *state.Store) error {
-			index, ent, err := state.KVSGet(ws, args.Key, &args.EnterpriseMeta)
+			var index uint64
+			var ent *structs.DirEntry
+			var err error
+			if args.AtIndex != 0 {
+				index, ent, err = state.KVSGetAtIndex(ws, args.Key, args.AtIndex, &args.EnterpriseMeta)
+			} else {
+				index, ent, err = state.KVSGet(ws, args.Key, &args.EnterpriseMeta)
+			}
 			if err != nil {
 				return err
 			}
@@ -169,12 +211,59 @@ func (k *KVS) Get(args *structs.KeyRequest, reply *structs.IndexedDirEntries) er
 				return errNotFound
 			}
 
-			reply.Index = ent.ModifyIndex
+			// The revision of a key at a given index may be older than
+			// the current entry, so use the index of the KV store.
+			if args.AtIndex != 0 {
+				reply.Index = index
+			} else {
+				reply.Index = ent.ModifyIndex
+			}
 			reply.Entries = structs.DirEntries{ent}
 			return nil
 		})
 }
 
+// History is used to lookup the current entry of a key along with the
+// previous revisions of the key that were retained.
+func (k *KVS) History(args *structs.KeyRequest, reply *structs.IndexedDirEntryRevisions) error {
+	if done, err := k.srv.ForwardRPC("KVS.History", args, reply); done {
+		return err
+	}
+
+	var authzContext acl.AuthorizerContext
+	authz, err := k.srv.ResolveTokenAndDefaultMeta(args.Token, &args.EnterpriseMeta, &authzContext)
+	if err != nil {
+		return err
+	}
+
+	if err := k.srv.validateEnterpriseRequest(&args.EnterpriseMeta, false); err != nil {
+		return err
+	}
+
+	return k.srv.blockingQuery(
+		&args.QueryOptions,
+		&reply.QueryMeta,
+		func(ws memdb.WatchSet, state *state.Store) error {
+			index, history, err := state.KVSHistory(ws, args.Key, &args.EnterpriseMeta)
+			if err != nil {
+				return err
+			}
+			if err := authz.ToAllowAuthorizer().KeyReadAllowed(args.Key, &authzContext); err != nil {
+				return err
+			}
+
+			// Must provide non-zero index to prevent blocking
+			// Index 1 is impossible anyways (due to Raft internals)
+			if index == 0 {
+				reply.Index = 1
+			} else {
+				reply.Index = index
+			}
+			reply.Revisions = history
+			return nil
+		})
+}
+
 // List is used to list all keys with a given prefix.
 func (k *KVS) List(args *structs.KeyRequest, reply *structs.IndexedDirEntries) error {
 	if done, err := k.srv.ForwardRPC("KVS.List", args, reply); done {
@@ -229,6 +229,109 @@ func TestKVS_Get_ACLDeny(t *testing.T) {
 
 }
 
+func TestKVS_History(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+	dir1, s1 := testServerWithConfig(t, func(c *Config) {
+		c.KVRevisions = map[string]int{"config/": 2}
+	})
+	defer os.RemoveAll(dir1)
+	defer s1.Shutdown()
+	codec := rpcClient(t, s1)
+	defer codec.Close()
+
+	testrpc.WaitForTestAgent(t, s1.RPC, "dc1")
+
+	var indexes []uint64
+	for _, value := range []string{"one", "two", "three", "four"} {
+		arg := structs.KVSRequest{
+			Datacenter: "dc1",
+			Op:         api.KVSet,
+			DirEnt: structs.DirEntry{
+				Key:   "config/app",
+				Value: []byte(value),
+			},
+		}
+		var out bool
+		require.NoError(t, msgpackrpc.CallWithCodec(codec, "KVS.Apply", &arg, &out))
+
+		getR := structs.KeyRequest{Datacenter: "dc1", Key: "config/app"}
+		var dirent structs.IndexedDirEntries
+		require.NoError(t, msgpackrpc.CallWithCodec(codec, "KVS.Get", &getR, &dirent))
+		require.Equal(t, 2, dirent.Entries[0].RetainRevisions)
+		indexes = append(indexes, dirent.Entries[0].ModifyIndex)
+	}
+
+	// Only the last revisions are retained.
+	histR := structs.KeyRequest{Datacenter: "dc1", Key: "config/app"}
+	var history structs.IndexedDirEntryRevisions
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "KVS.History", &histR, &history))
+	require.Len(t, history.Revisions, 3)
+	require.Equal(t, indexes[3], history.Index)
+	for i, value := range []string{"four", "three", "two"} {
+		require.Equal(t, value, string(history.Revisions[i].Value))
+	}
+
+	// Point-in-time reads return the revision that was current at the index.
+	getR := structs.KeyRequest{Datacenter: "dc1", Key: "config/app", AtIndex: indexes[2]}
+	var dirent structs.IndexedDirEntries
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "KVS.Get", &getR, &dirent))
+	require.Len(t, dirent.Entries, 1)
+	require.Equal(t, "three", string(dirent.Entries[0].Value))
+
+	getR.AtIndex = indexes[0]
+	dirent = structs.IndexedDirEntries{}
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "KVS.Get", &getR, &dirent))
+	require.Empty(t, dirent.Entries)
+}
+
+func TestKVS_History_ACLDeny(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+	dir1, s1 := testServerWithConfig(t, func(c *Config) {
+		c.PrimaryDatacenter = "dc1"
+		c.ACLsEnabled = true
+		c.ACLInitialManagementToken = "root"
+		c.ACLResolverSettings.ACLDefaultPolicy = "deny"
+	})
+	defer os.RemoveAll(dir1)
+	defer s1.Shutdown()
+	codec := rpcClient(t, s1)
+	defer codec.Close()
+
+	testrpc.WaitForTestAgent(t, s1.RPC, "dc1", testrpc.WithToken("root"))
+
+	histR := structs.KeyRequest{
+		Datacenter: "dc1",
+		Key:        "zip",
+	}
+	var history structs.IndexedDirEntryRevisions
+	err := msgpackrpc.CallWithCodec(codec, "KVS.History", &histR, &history)
+	require.True(t, acl.IsErrPermissionDenied(err), "unexpected error: %v", err)
+
+	histR.Token = "root"
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "KVS.History", &histR, &history))
+	require.Empty(t, history.Revisions)
+}
+
+func TestKVSRetainRevisions(t *testing.T) {
+	revisions := map[string]int{
+		"":            1,
+		"config/":     10,
+		"config/app/": 0,
+	}
+	require.Equal(t, 1, kvsRetainRevisions(revisions, "foo"))
+	require.Equal(t, 10, kvsRetainRevisions(revisions, "config/db"))
+	require.Equal(t, 0, kvsRetainRevisions(revisions, "config/app/key"))
+	require.Equal(t, 0, kvsRetainRevisions(nil, "foo"))
+}
+
 func TestKVSEndpoint_List(t *testing.T) {
 	if testing.Short() {
 		t.Skip("too slow for testing.Short")
@@ -0,0 +1,113 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package consul
+
+import (
+	"time"
+
+	"github.com/armon/go-metrics"
+	"github.com/armon/go-metrics/prometheus"
+
+	"github.com/hashicorp/consul/acl"
+	"github.com/hashicorp/consul/agent/structs"
+	"github.com/hashicorp/consul/api"
+)
+
+var KVSTTLSummaries = []prometheus.SummaryDefinition{
+	{
+		Name: []string{"kvs_ttl", "expire"},
+		Help: "Measures the time spent deleting an expired KV entry.",
+	},
+}
+
+// initializeKVSTimers is used when a leader is newly elected to start
+// tracking the expiration of all the KV entries that have an expiration time.
+func (s *Server) initializeKVSTimers() error {
+	entries, err := s.fsm.State().KVSListExpiring()
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		s.resetKVSTimer(entry)
+	}
+	return nil
+}
+
+// resetKVSTimer is used to track the expiration of a KV entry, replacing any
+// timer for the same key. Entries without an expiration time are ignored.
+func (s *Server) resetKVSTimer(entry *structs.DirEntry) {
+	if !entry.HasExpirationTime() {
+		return
+	}
+
+	key, entMeta := entry.Key, entry.EnterpriseMeta
+	s.kvsTimers.ResetOrCreate(kvsTimerID(key, &entMeta), time.Until(*entry.ExpirationTime), func() {
+		s.expireKVS(key, &entMeta)
+	})
+}
+
+// kvsTimerID returns the ID of the timer of a KV entry, which must be unique
+// across partitions and namespaces.
+func kvsTimerID(key string, entMeta *acl.EnterpriseMeta) string {
+	return entMeta.PartitionOrDefault() + "/" + entMeta.NamespaceOrDefault() + "/" + key
+}
+
+// expireKVS is invoked when the expiration time of a KV entry is reached and
+// we need to delete it.
+func (s *Server) expireKVS(key string, entMeta *acl.EnterpriseMeta) {
+	defer metrics.MeasureSince([]string{"kvs_ttl", "expire"}, time.Now())
+
+	// Clear the timer
+	s.kvsTimers.Del(kvsTimerID(key, entMeta))
+
+	// Retry with exponential backoff to delete the entry
+	for attempt := uint(0); attempt < maxInvalidateAttempts; attempt++ {
+		// The entry may have been deleted or written with another expiration
+		// time since the timer was set, so look at its current state.
+		_, entry, err := s.fsm.State().KVSGet(nil, key, entMeta)
+		if err != nil {
+			s.logger.Error("Failed to look up expiring KV entry", "key", key, "error", err)
+			return
+		}
+		if entry == nil || !entry.HasExpirationTime() {
+			return
+		}
+		if time.Now().Before(*entry.ExpirationTime) {
+			s.resetKVSTimer(entry)
+			return
+		}
+
+		// Delete the entry only if it was not modified since we looked it
+		// up, otherwise look at it again.
+		args := structs.KVSRequest{
+			Datacenter: s.config.Datacenter,
+			Op:         api.KVDeleteCAS,
+			DirEnt: structs.DirEntry{
+				Key:            key,
+				EnterpriseMeta: entry.EnterpriseMeta,
+				RaftIndex: structs.RaftIndex{
+					ModifyIndex: entry.ModifyIndex,
+				},
+			},
+		}
+		resp, err := s.leaderRaftApply("KVS.Apply", structs.KVSRequestType, args)
+		if err == nil {
+			if deleted, _ := resp.(bool); deleted {
+				s.logger.Debug("KV entry expired", "key", key)
+				return
+			}
+			continue
+		}
+
+		s.logger.Error("Expiration failed", "key", key, "error", err)
+		time.Sleep((1 << attempt) * invalidateRetryBase)
+	}
+	s.logger.Error("maximum expiration attempts reached for KV entry", "key", key)
+}
+
+// clearAllKVSTimers is used when a leader is stepping down and we no longer
+// need to track the expiration of KV entries.
+func (s *Server) clearAllKVSTimers() {
+	s.kvsTimers.StopAll()
+}
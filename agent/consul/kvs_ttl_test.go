@@ -0,0 +1,268 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package consul
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	msgpackrpc "github.com/hashicorp/consul-net-rpc/net-rpc-msgpackrpc"
+
+	"github.com/hashicorp/consul/agent/structs"
+	"github.com/hashicorp/consul/api"
+	"github.com/hashicorp/consul/sdk/testutil/retry"
+	"github.com/hashicorp/consul/testrpc"
+)
+
+func TestInitializeKVSTimers(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+	dir1, s1 := testServer(t)
+	defer os.RemoveAll(dir1)
+	defer s1.Shutdown()
+
+	testrpc.WaitForLeader(t, s1.RPC, "dc1")
+
+	state := s1.fsm.State()
+	expires := time.Now().Add(time.Hour)
+	require.NoError(t, state.KVSSet(100, &structs.DirEntry{Key: "foo", Value: []byte("foo"), ExpirationTime: &expires}))
+	require.NoError(t, state.KVSSet(101, &structs.DirEntry{Key: "bar", Value: []byte("bar")}))
+
+	require.NoError(t, s1.initializeKVSTimers())
+
+	entMeta := structs.DefaultEnterpriseMetaInDefaultPartition()
+	require.NotNil(t, s1.kvsTimers.Get(kvsTimerID("foo", entMeta)))
+	require.Nil(t, s1.kvsTimers.Get(kvsTimerID("bar", entMeta)))
+
+	s1.clearAllKVSTimers()
+	require.Zero(t, s1.kvsTimers.Len())
+}
+
+func TestExpireKVS(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+	dir1, s1 := testServer(t)
+	defer os.RemoveAll(dir1)
+	defer s1.Shutdown()
+
+	testrpc.WaitForLeader(t, s1.RPC, "dc1")
+
+	state := s1.fsm.State()
+	entMeta := structs.DefaultEnterpriseMetaInDefaultPartition()
+	expired := time.Now().Add(-time.Second)
+	later := time.Now().Add(time.Hour)
+	require.NoError(t, state.KVSSet(100, &structs.DirEntry{Key: "expired", Value: []byte("a"), ExpirationTime: &expired}))
+	require.NoError(t, state.KVSSet(101, &structs.DirEntry{Key: "renewed", Value: []byte("b"), ExpirationTime: &later}))
+	require.NoError(t, state.KVSSet(102, &structs.DirEntry{Key: "permanent", Value: []byte("c")}))
+
+	// An expired entry is deleted.
+	s1.expireKVS("expired", entMeta)
+	_, entry, err := state.KVSGet(nil, "expired", entMeta)
+	require.NoError(t, err)
+	require.Nil(t, entry)
+
+	// An entry that was renewed since its timer was set is kept, and its
+	// timer is reset.
+	s1.expireKVS("renewed", entMeta)
+	_, entry, err = state.KVSGet(nil, "renewed", entMeta)
+	require.NoError(t, err)
+	require.NotNil(t, entry)
+	require.NotNil(t, s1.kvsTimers.Get(kvsTimerID("renewed", entMeta)))
+
+	// An entry that no longer expires is kept.
+	s1.expireKVS("permanent", entMeta)
+	_, entry, err = state.KVSGet(nil, "permanent", entMeta)
+	require.NoError(t, err)
+	require.NotNil(t, entry)
+	require.Nil(t, s1.kvsTimers.Get(kvsTimerID("permanent", entMeta)))
+}
+
+func TestKVS_Apply_TTL(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+	dir1, s1 := testServer(t)
+	defer os.RemoveAll(dir1)
+	defer s1.Shutdown()
+	codec := rpcClient(t, s1)
+	defer codec.Close()
+
+	testrpc.WaitForLeader(t, s1.RPC, "dc1")
+
+	arg := structs.KVSRequest{
+		Datacenter: "dc1",
+		Op:         api.KVSet,
+		DirEnt: structs.DirEntry{
+			Key:           "test",
+			Value:         []byte("test"),
+			ExpirationTTL: 500 * time.Millisecond,
+		},
+	}
+	var out bool
+	start := time.Now()
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "KVS.Apply", &arg, &out))
+
+	// The TTL is converted to an expiration time.
+	state := s1.fsm.State()
+	_, d, err := state.KVSGet(nil, "test", nil)
+	require.NoError(t, err)
+	require.NotNil(t, d)
+	require.Zero(t, d.ExpirationTTL)
+	require.True(t, d.HasExpirationTime())
+	require.WithinDuration(t, start.Add(500*time.Millisecond), *d.ExpirationTime, time.Second)
+
+	// The entry is deleted once it expires.
+	retry.Run(t, func(r *retry.R) {
+		_, d, err := state.KVSGet(nil, "test", nil)
+		require.NoError(r, err)
+		require.Nil(r, d)
+	})
+	require.True(t, time.Since(start) >= 500*time.Millisecond)
+
+	// Writing an entry without a TTL clears its expiration time.
+	arg.DirEnt.ExpirationTTL = time.Hour
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "KVS.Apply", &arg, &out))
+	arg.DirEnt.ExpirationTTL = 0
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "KVS.Apply", &arg, &out))
+	_, d, err = state.KVSGet(nil, "test", nil)
+	require.NoError(t, err)
+	require.False(t, d.HasExpirationTime())
+
+	// Negative TTLs are rejected.
+	arg.DirEnt.ExpirationTTL = -time.Second
+	err = msgpackrpc.CallWithCodec(codec, "KVS.Apply", &arg, &out)
+	require.ErrorContains(t, err, `TTL for key "test" must not be negative`)
+}
+
+func TestTxn_Apply_TTL(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+	dir1, s1 := testServer(t)
+	defer os.RemoveAll(dir1)
+	defer s1.Shutdown()
+	codec := rpcClient(t, s1)
+	defer codec.Close()
+
+	testrpc.WaitForLeader(t, s1.RPC, "dc1")
+
+	arg := structs.TxnRequest{
+		Datacenter: "dc1",
+		Ops: structs.TxnOps{
+			&structs.TxnOp{
+				KV: &structs.TxnKVOp{
+					Verb: api.KVSet,
+					DirEnt: structs.DirEntry{
+						Key:           "ephemeral",
+						Value:         []byte("test"),
+						ExpirationTTL: 500 * time.Millisecond,
+					},
+				},
+			},
+			&structs.TxnOp{
+				KV: &structs.TxnKVOp{
+					Verb: api.KVSet,
+					DirEnt: structs.DirEntry{
+						Key:   "permanent",
+						Value: []byte("test"),
+					},
+				},
+			},
+		},
+	}
+	var out structs.TxnResponse
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "Txn.Apply", &arg, &out))
+	require.Empty(t, out.Errors)
+	require.Len(t, out.Results, 2)
+	require.NotNil(t, out.Results[0].KV.ExpirationTime)
+	require.Nil(t, out.Results[1].KV.ExpirationTime)
+
+	state := s1.fsm.State()
+	retry.Run(t, func(r *retry.R) {
+		_, d, err := state.KVSGet(nil, "ephemeral", nil)
+		require.NoError(r, err)
+		require.Nil(r, d)
+	})
+	_, d, err := state.KVSGet(nil, "permanent", nil)
+	require.NoError(t, err)
+	require.NotNil(t, d)
+}
+
+func TestServer_KVSTTL_Failover(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+	dir1, s1 := testServer(t)
+	defer os.RemoveAll(dir1)
+	defer s1.Shutdown()
+	testrpc.WaitForTestAgent(t, s1.RPC, "dc1")
+
+	dir2, s2 := testServerDCBootstrap(t, "dc1", false)
+	defer os.RemoveAll(dir2)
+	defer s2.Shutdown()
+
+	dir3, s3 := testServerDCBootstrap(t, "dc1", false)
+	defer os.RemoveAll(dir3)
+	defer s3.Shutdown()
+	servers := []*Server{s1, s2, s3}
+
+	// Try to join
+	joinLAN(t, s2, s1)
+	joinLAN(t, s3, s1)
+	retry.Run(t, func(r *retry.R) { r.Check(wantPeers(s1, 3)) })
+
+	// Find the leader
+	var leader *Server
+	var followers []*Server
+	for _, s := range servers {
+		if s.IsLeader() {
+			leader = s
+		} else {
+			followers = append(followers, s)
+		}
+	}
+	require.NotNil(t, leader)
+
+	codec := rpcClient(t, leader)
+	defer codec.Close()
+
+	arg := structs.KVSRequest{
+		Datacenter: "dc1",
+		Op:         api.KVSet,
+		DirEnt: structs.DirEntry{
+			Key:           "test",
+			Value:         []byte("test"),
+			ExpirationTTL: 2 * time.Second,
+		},
+	}
+	var out bool
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "KVS.Apply", &arg, &out))
+	require.Equal(t, 1, leader.kvsTimers.Len())
+
+	// Shutdown the leader, the new leader must delete the entry.
+	leader.Shutdown()
+
+	retry.Run(t, func(r *retry.R) {
+		for _, s := range followers {
+			_, d, err := s.fsm.State().KVSGet(nil, "test", nil)
+			require.NoError(r, err)
+			require.Nil(r, d)
+		}
+	})
+}
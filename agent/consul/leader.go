@@ -305,6 +305,12 @@ func (s *Server) establishLeadership(ctx context.Context) error {
 		return err
 	}
 
+	// Start tracking the expiration of KV entries. Unlike sessions, entries
+	// keep the expiration time they were written with across failovers.
+	if err := s.initializeKVSTimers(); err != nil {
+		return err
+	}
+
 	if err := s.establishEnterpriseLeadership(ctx); err != nil {
 		return err
 	}
@@ -328,6 +334,8 @@ func (s *Server) establishLeadership(ctx context.Context) error {
 		return err
 	}
 
+	s.startDNSSECKeyRotation(ctx)
+
 	// Attempt to bootstrap config entries. We wait until after starting the
 	// Connect leader tasks so we hopefully have transitioned to supporting
 	// service-intentions.
@@ -337,6 +345,9 @@ func (s *Server) establishLeadership(ctx context.Context) error {
 
 	s.setConsistentReadReady()
 
+	s.controllerManager.SetRaftLeader(true)
+	s.startResourceMigration(ctx)
+
 	if s.config.LogStoreConfig.Verification.Enabled {
 		s.startLogVerification(ctx)
 	}
@@ -355,6 +366,9 @@ func (s *Server) revokeLeadership() {
 
 	s.stopLogVerification()
 
+	s.controllerManager.SetRaftLeader(false)
+	s.stopResourceMigration()
+
 	// Disable the tombstone GC, since it is only useful as a leader
 	s.tombstoneGC.SetEnabled(false)
 
@@ -362,6 +376,9 @@ func (s *Server) revokeLeadership() {
 	// are no longer responsible for session expirations.
 	s.clearAllSessionTimers()
 
+	// Likewise, KV entry expirations are handled by the next leader.
+	s.clearAllKVSTimers()
+
 	s.revokeEnterpriseLeadership()
 
 	s.stopFederationStateAntiEntropy()
@@ -376,6 +393,8 @@ func (s *Server) revokeLeadership() {
 
 	s.stopConnectLeader()
 
+	s.stopDNSSECKeyRotation()
+
 	s.stopACLTokenReaping()
 
 	s.resetConsistentReadReady()
@@ -0,0 +1,220 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package consul
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/go-uuid"
+	"github.com/miekg/dns"
+
+	"github.com/hashicorp/consul/agent/structs"
+)
+
+const (
+	// dnssecKeyRotationInterval is how often the leader checks whether the
+	// DNSSEC keys need to be generated, activated or removed.
+	dnssecKeyRotationInterval = time.Minute
+
+	// dnssecKeyPrePublishDelay is how long a new key is published before it
+	// is used for signing. It must exceed the TTL of the DNSKEY records so
+	// that resolvers which cached the old DNSKEY RRset fetch the new one
+	// before they see signatures made with the new key.
+	dnssecKeyPrePublishDelay = 2 * time.Hour
+
+	// dnssecKeyRetention is how long a retired key is still published for.
+	// It matches the validity of the signatures made by the agents, so that
+	// every signature made with the key has expired when it's removed. The
+	// agents keep signing the DNSKEY RRset with it until then, so the DS
+	// record of the parent zone can still refer to it.
+	dnssecKeyRetention = 24 * time.Hour
+
+	// dnssecKeyAlgorithm is the algorithm of the generated keys. ECDSA P-256
+	// keys and signatures are small, which keeps signed responses within the
+	// UDP size limits.
+	dnssecKeyAlgorithm = dns.ECDSAP256SHA256
+)
+
+// startDNSSECKeyRotation starts the routine managing the DNSSEC keys. The
+// keys are only managed by the primary datacenter, the agents of the other
+// datacenters fetch them from it.
+func (s *Server) startDNSSECKeyRotation(ctx context.Context) {
+	if !s.config.DNSSECEnabled || !s.config.InPrimaryDatacenter() {
+		return
+	}
+	s.leaderRoutineManager.Start(ctx, dnssecKeyRotationRoutineName, s.runDNSSECKeyRotation)
+}
+
+func (s *Server) stopDNSSECKeyRotation() {
+	s.leaderRoutineManager.Stop(dnssecKeyRotationRoutineName)
+}
+
+func (s *Server) runDNSSECKeyRotation(ctx context.Context) error {
+	logger := s.logger.Named("dnssec")
+
+	if err := s.rotateDNSSECKeys(time.Now()); err != nil {
+		logger.Error("error rotating DNSSEC keys", "error", err)
+	}
+
+	ticker := time.NewTicker(dnssecKeyRotationInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := s.rotateDNSSECKeys(time.Now()); err != nil {
+				logger.Error("error rotating DNSSEC keys", "error", err)
+			}
+		}
+	}
+}
+
+// rotateDNSSECKeys updates the DNSSEC keys stored in the system metadata if
+// they need to be generated, activated or removed.
+func (s *Server) rotateDNSSECKeys(now time.Time) error {
+	keys, err := s.getDNSSECKeys()
+	if err != nil {
+		return err
+	}
+
+	newKeys, changed, err := nextDNSSECKeys(keys, now, s.config.DNSSECKeyRotationPeriod)
+	if err != nil || !changed {
+		return err
+	}
+
+	raw, err := json.Marshal(newKeys)
+	if err != nil {
+		return err
+	}
+	if err := s.SetSystemMetadataKey(structs.SystemMetadataDNSSECKeys, string(raw)); err != nil {
+		return fmt.Errorf("failed to store DNSSEC keys: %w", err)
+	}
+
+	s.logger.Named("dnssec").Info("updated DNSSEC keys", "keys", len(newKeys))
+	return nil
+}
+
+func (s *Server) getDNSSECKeys() ([]*structs.DNSSECKey, error) {
+	raw, err := s.GetSystemMetadata(structs.SystemMetadataDNSSECKeys)
+	if err != nil {
+		return nil, err
+	}
+	return decodeDNSSECKeys(raw)
+}
+
+func decodeDNSSECKeys(raw string) ([]*structs.DNSSECKey, error) {
+	if raw == "" {
+		return nil, nil
+	}
+	var keys []*structs.DNSSECKey
+	if err := json.Unmarshal([]byte(raw), &keys); err != nil {
+		return nil, fmt.Errorf("failed to decode DNSSEC keys: %w", err)
+	}
+	return keys, nil
+}
+
+// nextDNSSECKeys returns the keys which should be in use at the given time,
+// and whether they differ from the current keys. The current keys aren't
+// modified.
+//
+// A rotation publishes a new pending key once the active key is older than
+// the rotation period, then activates it after dnssecKeyPrePublishDelay and
+// retires the previous key, which is removed after dnssecKeyRetention. Keys
+// aren't rotated if the rotation period is 0.
+func nextDNSSECKeys(current []*structs.DNSSECKey, now time.Time, rotationPeriod time.Duration) ([]*structs.DNSSECKey, bool, error) {
+	var (
+		keys    []*structs.DNSSECKey
+		active  *structs.DNSSECKey
+		pending *structs.DNSSECKey
+		changed bool
+	)
+	for _, key := range current {
+		if key.Retired() && now.Sub(key.RetiredAt) >= dnssecKeyRetention {
+			changed = true
+			continue
+		}
+
+		k := *key
+		switch {
+		case k.Active:
+			active = &k
+		case k.Pending():
+			pending = &k
+		}
+		keys = append(keys, &k)
+	}
+
+	switch {
+	case active == nil && pending == nil:
+		// There is nothing for resolvers to have cached yet, so the first key
+		// can be used right away.
+		key, err := generateDNSSECKey(now)
+		if err != nil {
+			return nil, false, err
+		}
+		key.Active = true
+		key.ActivatedAt = now
+		keys = append(keys, key)
+		changed = true
+
+	case pending != nil && (active == nil || now.Sub(pending.CreatedAt) >= dnssecKeyPrePublishDelay):
+		pending.Active = true
+		pending.ActivatedAt = now
+		if active != nil {
+			active.Active = false
+			active.RetiredAt = now
+		}
+		changed = true
+
+	case pending == nil && rotationPeriod > 0 && now.Sub(active.ActivatedAt) >= rotationPeriod:
+		key, err := generateDNSSECKey(now)
+		if err != nil {
+			return nil, false, err
+		}
+		keys = append(keys, key)
+		changed = true
+	}
+
+	sort.SliceStable(keys, func(i, j int) bool {
+		return keys[i].CreatedAt.Before(keys[j].CreatedAt)
+	})
+	return keys, changed, nil
+}
+
+// generateDNSSECKey generates a new pending key.
+func generateDNSSECKey(now time.Time) (*structs.DNSSECKey, error) {
+	id, err := uuid.GenerateUUID()
+	if err != nil {
+		return nil, err
+	}
+
+	// The key is used as a combined signing key, which signs all the RRsets
+	// including the DNSKEY RRset, so it has the secure entry point flag set
+	// and the DS record of the parent zone must refer to it.
+	dnskey := &dns.DNSKEY{
+		Hdr:       dns.RR_Header{Name: ".", Rrtype: dns.TypeDNSKEY, Class: dns.ClassINET},
+		Flags:     dns.ZONE | dns.SEP,
+		Protocol:  3,
+		Algorithm: dnssecKeyAlgorithm,
+	}
+	privateKey, err := dnskey.Generate(256)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate DNSSEC key: %w", err)
+	}
+
+	return &structs.DNSSECKey{
+		ID:         id,
+		KeyTag:     dnskey.KeyTag(),
+		Algorithm:  dnskey.Algorithm,
+		PublicKey:  dnskey.PublicKey,
+		PrivateKey: dnskey.PrivateKeyString(privateKey),
+		CreatedAt:  now,
+	}, nil
+}
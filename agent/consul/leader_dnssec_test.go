@@ -0,0 +1,153 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package consul
+
+import (
+	"testing"
+	"time"
+
+	msgpackrpc "github.com/hashicorp/consul-net-rpc/net-rpc-msgpackrpc"
+	"github.com/miekg/dns"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/consul/acl"
+	"github.com/hashicorp/consul/agent/structs"
+	"github.com/hashicorp/consul/sdk/testutil/retry"
+	"github.com/hashicorp/consul/testrpc"
+)
+
+func TestNextDNSSECKeys(t *testing.T) {
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	const rotationPeriod = 30 * 24 * time.Hour
+
+	// The first key is activated right away.
+	keys, changed, err := nextDNSSECKeys(nil, now, rotationPeriod)
+	require.NoError(t, err)
+	require.True(t, changed)
+	require.Len(t, keys, 1)
+	first := keys[0]
+	require.True(t, first.Active)
+	require.Equal(t, now, first.ActivatedAt)
+
+	// The key can be loaded by the agents.
+	dnskey := &dns.DNSKEY{
+		Hdr:       dns.RR_Header{Name: "consul.", Rrtype: dns.TypeDNSKEY, Class: dns.ClassINET},
+		Flags:     dns.ZONE | dns.SEP,
+		Protocol:  3,
+		Algorithm: first.Algorithm,
+		PublicKey: first.PublicKey,
+	}
+	require.Equal(t, first.KeyTag, dnskey.KeyTag())
+	_, err = dnskey.NewPrivateKey(first.PrivateKey)
+	require.NoError(t, err)
+
+	// Nothing changes until the rotation period elapses.
+	now = now.Add(rotationPeriod - time.Second)
+	keys, changed, err = nextDNSSECKeys(keys, now, rotationPeriod)
+	require.NoError(t, err)
+	require.False(t, changed)
+	require.Len(t, keys, 1)
+
+	// Then a new key is published but not used yet.
+	now = now.Add(time.Second)
+	keys, changed, err = nextDNSSECKeys(keys, now, rotationPeriod)
+	require.NoError(t, err)
+	require.True(t, changed)
+	require.Len(t, keys, 2)
+	require.True(t, keys[0].Active)
+	second := keys[1]
+	require.True(t, second.Pending())
+	require.NotEqual(t, first.ID, second.ID)
+
+	// It's activated once resolvers had time to see it.
+	now = now.Add(dnssecKeyPrePublishDelay)
+	keys, changed, err = nextDNSSECKeys(keys, now, rotationPeriod)
+	require.NoError(t, err)
+	require.True(t, changed)
+	require.Len(t, keys, 2)
+	require.True(t, keys[0].Retired())
+	require.Equal(t, now, keys[0].RetiredAt)
+	require.True(t, keys[1].Active)
+	require.Equal(t, second.ID, keys[1].ID)
+
+	// The previous key is removed after its signatures expired.
+	now = now.Add(dnssecKeyRetention)
+	keys, changed, err = nextDNSSECKeys(keys, now, rotationPeriod)
+	require.NoError(t, err)
+	require.True(t, changed)
+	require.Len(t, keys, 1)
+	require.Equal(t, second.ID, keys[0].ID)
+
+	// Keys aren't rotated without a rotation period.
+	now = now.Add(10 * rotationPeriod)
+	_, changed, err = nextDNSSECKeys(keys, now, 0)
+	require.NoError(t, err)
+	require.False(t, changed)
+}
+
+func TestLeader_DNSSECKeyRotation(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+
+	_, s1 := testServerWithConfig(t, func(c *Config) {
+		c.PrimaryDatacenter = "dc1"
+		c.DNSSECEnabled = true
+		c.DNSSECKeyRotationPeriod = time.Hour
+		c.ACLsEnabled = true
+		c.ACLInitialManagementToken = TestDefaultInitialManagementToken
+		c.ACLResolverSettings.ACLDefaultPolicy = "deny"
+	})
+	codec := rpcClient(t, s1)
+
+	testrpc.WaitForLeader(t, s1.RPC, "dc1")
+
+	arg := structs.DCSpecificRequest{
+		Datacenter: "dc1",
+	}
+	var out structs.IndexedDNSSECKeys
+	err := msgpackrpc.CallWithCodec(codec, "Internal.DNSSECKeys", &arg, &out)
+	require.True(t, acl.IsErrPermissionDenied(err))
+
+	// The keys include the private keys, so operator:read isn't enough.
+	opReadToken, err := upsertTestTokenWithPolicyRules(
+		codec, TestDefaultInitialManagementToken, "dc1", `operator = "read"`)
+	require.NoError(t, err)
+
+	arg.Token = opReadToken.SecretID
+	err = msgpackrpc.CallWithCodec(codec, "Internal.DNSSECKeys", &arg, &out)
+	require.True(t, acl.IsErrPermissionDenied(err))
+
+	opWriteToken, err := upsertTestTokenWithPolicyRules(
+		codec, TestDefaultInitialManagementToken, "dc1", `operator = "write"`)
+	require.NoError(t, err)
+
+	arg.Token = opWriteToken.SecretID
+	retry.Run(t, func(r *retry.R) {
+		out = structs.IndexedDNSSECKeys{}
+		require.NoError(r, msgpackrpc.CallWithCodec(codec, "Internal.DNSSECKeys", &arg, &out))
+		require.Len(r, out.Keys, 1)
+	})
+	require.True(t, out.Keys[0].Active)
+	require.NotEmpty(t, out.Keys[0].PrivateKey)
+
+	// Rotating the keys unblocks queries waiting for them to change.
+	index := out.Index
+	errCh := make(chan error, 1)
+	go func() {
+		arg := arg
+		arg.MinQueryIndex = index
+		arg.MaxQueryTime = 10 * time.Second
+		errCh <- msgpackrpc.CallWithCodec(rpcClient(t, s1), "Internal.DNSSECKeys", &arg, &out)
+	}()
+
+	require.NoError(t, s1.rotateDNSSECKeys(time.Now().Add(time.Hour)))
+
+	require.NoError(t, <-errCh)
+	require.Greater(t, out.Index, index)
+	require.Len(t, out.Keys, 2)
+	require.True(t, out.Keys[1].Pending())
+}
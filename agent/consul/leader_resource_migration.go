@@ -0,0 +1,26 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package consul
+
+import (
+	"context"
+
+	"github.com/hashicorp/consul/internal/resource/migration"
+	"github.com/hashicorp/consul/logging"
+)
+
+// startResourceMigration starts rewriting resources stored at old
+// GroupVersions to their type's storage version.
+func (s *Server) startResourceMigration(ctx context.Context) {
+	migrator := &migration.Migrator{
+		Backend:  s.storageBackend,
+		Registry: s.typeRegistry,
+		Logger:   s.loggers.Named(logging.ResourceMigration),
+	}
+	s.leaderRoutineManager.Start(ctx, resourceMigrationRoutineName, migrator.Run)
+}
+
+func (s *Server) stopResourceMigration() {
+	s.leaderRoutineManager.Stop(resourceMigrationRoutineName)
+}
@@ -68,8 +68,12 @@ import (
 	"github.com/hashicorp/consul/agent/rpc/peering"
 	"github.com/hashicorp/consul/agent/structs"
 	"github.com/hashicorp/consul/agent/token"
+	"github.com/hashicorp/consul/internal/controller"
 	"github.com/hashicorp/consul/internal/resource"
 	"github.com/hashicorp/consul/internal/resource/demo"
+	"github.com/hashicorp/consul/internal/resource/reaper"
+	"github.com/hashicorp/consul/internal/storage"
+	"github.com/hashicorp/consul/internal/storage/boltdb"
 	raftstorage "github.com/hashicorp/consul/internal/storage/raft"
 	"github.com/hashicorp/consul/lib"
 	"github.com/hashicorp/consul/lib/routine"
@@ -137,6 +141,7 @@ const (
 	caSigningMetricRoutineName            = "CA signing expiration metric"
 	configEntryControllersRoutineName     = "config entry controllers"
 	configReplicationRoutineName          = "config entry replication"
+	dnssecKeyRotationRoutineName          = "DNSSEC key rotation"
 	federationStateReplicationRoutineName = "federation state replication"
 	federationStateAntiEntropyRoutineName = "federation state anti-entropy"
 	federationStatePruningRoutineName     = "federation state pruning"
@@ -149,6 +154,7 @@ const (
 	peeringDeletionRoutineName            = "peering deferred deletion"
 	peeringStreamsMetricsRoutineName      = "metrics for streaming peering resources"
 	raftLogVerifierRoutineName            = "raft log verifier"
+	resourceMigrationRoutineName          = "resource storage version migration"
 )
 
 var (
@@ -261,6 +267,11 @@ type Server struct {
 	// raftStorageBackend is the Raft-backed storage backend for resources.
 	raftStorageBackend *raftstorage.Backend
 
+	// storageBackend is the storage backend used by the resource service. It's
+	// the raftStorageBackend unless a dev mode agent has been configured to store
+	// resources in a local file.
+	storageBackend storage.Backend
+
 	// reconcileCh is used to pass events from the serf handler
 	// into the leader manager, so that the strong state can be
 	// updated
@@ -358,6 +369,10 @@ type Server struct {
 	// destroy the session via standard session destroy processing
 	sessionTimers *SessionTimers
 
+	// kvsTimers track the expiration time of each KV entry that has an
+	// expiration time. On expiration, the entry is deleted through Raft.
+	kvsTimers *SessionTimers
+
 	// statsFetcher is used by autopilot to check the status of the other
 	// Consul router.
 	statsFetcher *StatsFetcher
@@ -435,6 +450,10 @@ type Server struct {
 	// with the Resource Service in-process (i.e. not via the network) without auth.
 	// It should only be used for purely-internal workloads, such as controllers.
 	internalResourceServiceClient pbresource.ResourceServiceClient
+
+	// controllerManager schedules the execution of controllers.
+	controllerManager *controller.Manager
+
 	// handles metrics reporting to HashiCorp
 	reportingManager *reporting.ReportingManager
 }
@@ -491,6 +510,7 @@ func NewServer(config *Config, flat Deps, externalGRPCServer *grpc.Server, incom
 		externalGRPCServer:      externalGRPCServer,
 		reassertLeaderCh:        make(chan chan error),
 		sessionTimers:           NewSessionTimers(),
+		kvsTimers:               NewSessionTimers(),
 		tombstoneGC:             gc,
 		serverLookup:            NewServerLookup(),
 		shutdownCh:              shutdownCh,
@@ -509,6 +529,21 @@ func NewServer(config *Config, flat Deps, externalGRPCServer *grpc.Server, incom
 	}
 	go s.raftStorageBackend.Run(&lib.StopChannelContext{StopCh: shutdownCh})
 
+	s.storageBackend = s.raftStorageBackend
+	if s.config.DevMode && s.config.DevResourceStoragePath != "" {
+		backend, err := boltdb.NewBackend(s.config.DevResourceStoragePath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create dev storage backend: %w", err)
+		}
+		go func() {
+			backend.Run(&lib.StopChannelContext{StopCh: shutdownCh})
+			if err := backend.Close(); err != nil {
+				logger.Error("failed to close dev storage backend", "error", err)
+			}
+		}()
+		s.storageBackend = backend
+	}
+
 	s.fsm = fsm.NewFromDeps(fsm.Deps{
 		Logger: flat.Logger,
 		NewStateStore: func() *state.Store {
@@ -786,6 +821,10 @@ func NewServer(config *Config, flat Deps, externalGRPCServer *grpc.Server, incom
 	if err := s.setupInternalResourceService(logger); err != nil {
 		return nil, err
 	}
+	s.controllerManager = controller.NewManager(
+		s.internalResourceServiceClient,
+		logger.Named(logging.ControllerRuntime),
+	)
 
 	// Initialize Autopilot. This must happen before starting leadership monitoring
 	// as establishing leadership could attempt to use autopilot and cause a panic.
@@ -823,13 +862,22 @@ func NewServer(config *Config, flat Deps, externalGRPCServer *grpc.Server, incom
 		return nil, err
 	}
 
-	if s.config.DevMode {
-		demo.Register(s.typeRegistry)
-	}
+	s.registerResources()
+	go s.controllerManager.Run(&lib.StopChannelContext{StopCh: shutdownCh})
 
 	return s, nil
 }
 
+func (s *Server) registerResources() {
+	registerTypes(s.typeRegistry, s.config.DevMode)
+
+	reaper.RegisterControllers(s.controllerManager)
+
+	if s.config.DevMode {
+		demo.RegisterControllers(s.controllerManager)
+	}
+}
+
 func newGRPCHandlerFromConfig(deps Deps, config *Config, s *Server) connHandler {
 	if s.peeringBackend == nil {
 		panic("peeringBackend is required during construction")
@@ -1288,7 +1336,7 @@ func (s *Server) setupExternalGRPC(config *Config, logger hclog.Logger) {
 
 	resourcegrpc.NewServer(resourcegrpc.Config{
 		Registry:    s.typeRegistry,
-		Backend:     s.raftStorageBackend,
+		Backend:     s.storageBackend,
 		ACLResolver: s.ACLResolver,
 		Logger:      logger.Named("grpc-api.resource"),
 	}).Register(s.externalGRPCServer)
@@ -1299,7 +1347,7 @@ func (s *Server) setupInternalResourceService(logger hclog.Logger) error {
 
 	resourcegrpc.NewServer(resourcegrpc.Config{
 		Registry:    s.typeRegistry,
-		Backend:     s.raftStorageBackend,
+		Backend:     s.storageBackend,
 		ACLResolver: resolver.DANGER_NO_AUTH{},
 		Logger:      logger.Named("grpc-api.resource"),
 	}).Register(server)
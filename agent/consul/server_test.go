@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"net"
 	"os"
+	"path/filepath"
 	"reflect"
 	"strings"
 	"sync"
@@ -40,7 +41,10 @@ import (
 	"github.com/hashicorp/consul/agent/rpc/middleware"
 	"github.com/hashicorp/consul/agent/structs"
 	"github.com/hashicorp/consul/agent/token"
+	"github.com/hashicorp/consul/internal/resource/demo"
+	"github.com/hashicorp/consul/internal/storage"
 	"github.com/hashicorp/consul/ipaddr"
+	"github.com/hashicorp/consul/proto/private/prototest"
 	"github.com/hashicorp/consul/sdk/freeport"
 	"github.com/hashicorp/consul/sdk/testutil"
 	"github.com/hashicorp/consul/sdk/testutil/retry"
@@ -2101,3 +2105,31 @@ func TestServer_hcpManager(t *testing.T) {
 	hcp1.AssertExpectations(t)
 
 }
+
+func TestServer_DevResourceStorage(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	path := filepath.Join(testutil.TempDir(t, "resources"), "resources.db")
+	configure := func(c *Config) {
+		c.DevMode = true
+		c.DevResourceStoragePath = path
+	}
+
+	_, s1 := testServerWithConfig(t, configure)
+
+	artist, err := demo.GenerateV2Artist()
+	require.NoError(t, err)
+
+	artist, err = s1.storageBackend.WriteCAS(context.Background(), artist)
+	require.NoError(t, err)
+	require.NoError(t, s1.Shutdown())
+
+	// Resources survive a restart.
+	_, s2 := testServerWithConfig(t, configure)
+
+	stored, err := s2.storageBackend.Read(context.Background(), storage.StrongConsistency, artist.Id)
+	require.NoError(t, err)
+	prototest.AssertDeepEqual(t, artist, stored)
+}
@@ -136,6 +136,31 @@ func TestPBToStreamSubscribeRequest(t *testing.T) {
 			},
 			err: nil,
 		},
+		"KV": {
+			req: &pbsubscribe.SubscribeRequest{
+				Topic: EventTopicKV,
+				Subject: &pbsubscribe.SubscribeRequest_NamedSubject{
+					NamedSubject: &pbsubscribe.NamedSubject{
+						Key:       "foo/",
+						Namespace: "consul",
+						Partition: "partition",
+					},
+				},
+				Token: aclToken,
+				Index: 2,
+			},
+			entMeta: acl.EnterpriseMeta{},
+			expectedSubscribeRequest: &stream.SubscribeRequest{
+				Topic: EventTopicKV,
+				Subject: EventSubjectKV{
+					Prefix:         "foo/",
+					EnterpriseMeta: acl.EnterpriseMeta{},
+				},
+				Token: aclToken,
+				Index: 2,
+			},
+			err: nil,
+		},
 		"Service list without wildcard returns error": {
 			req: &pbsubscribe.SubscribeRequest{
 				Topic: EventTopicServiceList,
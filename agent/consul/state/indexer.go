@@ -161,6 +161,14 @@ func (b *indexBuilder) Int64(v int64) {
 	b.Raw(buf)
 }
 
+// Uint64 appends the big endian encoding of v to the buffer, so that index
+// values sort in numeric order.
+func (b *indexBuilder) Uint64(v uint64) {
+	buf := make([]byte, 8)
+	binary.BigEndian.PutUint64(buf, v)
+	b.Raw(buf)
+}
+
 // Raw appends the bytes without a null terminator to the buffer. Raw should
 // only be used when v has a fixed length, or when building the last segment of
 // a prefix index.
@@ -187,6 +195,7 @@ type multiValueID interface {
 }
 
 var _ singleValueID = (*structs.DirEntry)(nil)
+var _ singleValueID = (*structs.DirEntryRevision)(nil)
 var _ singleValueID = (*Tombstone)(nil)
 var _ singleValueID = (*Query)(nil)
 var _ singleValueID = (*structs.Session)(nil)
@@ -18,6 +18,7 @@ const (
 	tableTombstones = "tombstones"
 
 	indexSession = "session"
+	indexExpires = "expires"
 )
 
 // kvsTableSchema returns a new table schema used for storing structs.DirEntry
@@ -39,10 +40,32 @@ func kvsTableSchema() *memdb.TableSchema {
 					Field: "Session",
 				},
 			},
+			indexExpires: {
+				Name:         indexExpires,
+				AllowMissing: true,
+				Unique:       false,
+				Indexer: indexerSingle[*TimeQuery, *structs.DirEntry]{
+					readIndex:  indexFromTimeQuery,
+					writeIndex: indexExpiresFromDirEntry,
+				},
+			},
 		},
 	}
 }
 
+func indexExpiresFromDirEntry(e *structs.DirEntry) ([]byte, error) {
+	if !e.HasExpirationTime() {
+		return nil, errMissingValueForIndex
+	}
+	if e.ExpirationTime.Unix() < 0 {
+		return nil, fmt.Errorf("kvs expiration time cannot be before the unix epoch: %s", e.ExpirationTime)
+	}
+
+	var b indexBuilder
+	b.Time(*e.ExpirationTime)
+	return b.Bytes(), nil
+}
+
 // indexFromIDValue creates an index key from any struct that implements singleValueID
 func indexFromIDValue(e singleValueID) ([]byte, error) {
 	v := e.IDValue()
@@ -109,6 +132,9 @@ func (s *Store) ReapTombstones(idx uint64, index uint64) error {
 	if err := s.kvsGraveyard.ReapTxn(tx, index); err != nil {
 		return fmt.Errorf("failed to reap kvs tombstones: %s", err)
 	}
+	if err := kvsReapRevisionsTxn(tx, index); err != nil {
+		return fmt.Errorf("failed to reap kvs revisions: %s", err)
+	}
 
 	return tx.Commit()
 }
@@ -166,6 +192,16 @@ func kvsSetTxn(tx WriteTxn, idx uint64, entry *structs.DirEntry, updateSession b
 	}
 	entry.ModifyIndex = idx
 
+	// Keep the replaced entry in the history of the key.
+	if existing != nil {
+		err = kvsRetainRevisionTxn(tx, idx, existing, false, entry.RetainRevisions)
+	} else {
+		err = kvsPruneRevisionsTxn(tx, entry.Key, entry.EnterpriseMeta, entry.RetainRevisions)
+	}
+	if err != nil {
+		return err
+	}
+
 	// Store the kv pair in the state store and update the index.
 	if err := insertKVTxn(tx, entry, false, false); err != nil {
 		return fmt.Errorf("failed inserting kvs entry: %s", err)
@@ -259,6 +295,24 @@ func (s *Store) kvsListTxn(tx ReadTxn,
 	return idx, entries, nil
 }
 
+// KVSListExpiring returns the entries that have an expiration time, in all
+// partitions and namespaces, ordered by expiration time.
+func (s *Store) KVSListExpiring() (structs.DirEntries, error) {
+	tx := s.db.Txn(false)
+	defer tx.Abort()
+
+	iter, err := tx.Get(tableKVs, indexExpires)
+	if err != nil {
+		return nil, fmt.Errorf("failed kvs lookup: %s", err)
+	}
+
+	var entries structs.DirEntries
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		entries = append(entries, raw.(*structs.DirEntry))
+	}
+	return entries, nil
+}
+
 // KVSDelete is used to perform a shallow delete on a single key in the
 // the state store.
 func (s *Store) KVSDelete(idx uint64, key string, entMeta *acl.EnterpriseMeta) error {
@@ -295,7 +349,16 @@ func (s *Store) kvsDeleteTxn(tx WriteTxn, idx uint64, key string, entMeta *acl.E
 		return fmt.Errorf("failed adding to graveyard: %s", err)
 	}
 
-	return kvsDeleteWithEntry(tx, entry.(*structs.DirEntry), idx)
+	// Keep the deleted entry in the history of the key, until the tombstone
+	// is reaped.
+	e := entry.(*structs.DirEntry)
+	if e.RetainRevisions > 0 {
+		if err := kvsRetainRevisionTxn(tx, idx, e, true, e.RetainRevisions); err != nil {
+			return err
+		}
+	}
+
+	return kvsDeleteWithEntry(tx, e, idx)
 }
 
 // KVSDeleteCAS is used to try doing a KV delete operation with a given
@@ -0,0 +1,129 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package state
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/consul/acl"
+	"github.com/hashicorp/consul/agent/consul/stream"
+	"github.com/hashicorp/consul/agent/structs"
+	"github.com/hashicorp/consul/proto/private/pbsubscribe"
+)
+
+// EventSubjectKV is a stream.Subject used to route and receive events for the
+// KV entries whose key starts with Prefix.
+type EventSubjectKV struct {
+	Prefix         string
+	EnterpriseMeta acl.EnterpriseMeta
+}
+
+func (s EventSubjectKV) String() string {
+	return fmt.Sprintf(
+		"%s/%s/%s",
+		s.EnterpriseMeta.PartitionOrDefault(),
+		s.EnterpriseMeta.NamespaceOrDefault(),
+		s.Prefix,
+	)
+}
+
+// EventPayloadKV is used as the Payload for a stream.Event to indicate changes
+// to a KV entry.
+type EventPayloadKV struct {
+	Op    pbsubscribe.KVUpdate_UpdateOp
+	Value *structs.DirEntry
+}
+
+func (e EventPayloadKV) Subject() stream.Subject {
+	return EventSubjectKV{
+		Prefix:         e.Value.Key,
+		EnterpriseMeta: e.Value.EnterpriseMeta,
+	}
+}
+
+// MatchesSubjectPrefixes makes EventPayloadKV a stream.PrefixSubjectPayload.
+// The String of an EventSubjectKV ends with its prefix, so the event of a key
+// is delivered to the subscribers of every prefix of the key in the same
+// partition and namespace.
+func (e EventPayloadKV) MatchesSubjectPrefixes() {}
+
+func (e EventPayloadKV) HasReadPermission(authz acl.Authorizer) bool {
+	var authzContext acl.AuthorizerContext
+	e.Value.FillAuthzContext(&authzContext)
+	return authz.KeyRead(e.Value.Key, &authzContext) == acl.Allow
+}
+
+func (e EventPayloadKV) ToSubscriptionEvent(idx uint64) *pbsubscribe.Event {
+	return &pbsubscribe.Event{
+		Index: idx,
+		Payload: &pbsubscribe.Event_KV{
+			KV: &pbsubscribe.KVUpdate{
+				Op:    e.Op,
+				Entry: pbsubscribe.NewKVEntryFromStructs(e.Value),
+			},
+		},
+	}
+}
+
+// KVEventsFromChanges returns the events that will be emitted when KV entries
+// change in the state store.
+func KVEventsFromChanges(tx ReadTxn, changes Changes) ([]stream.Event, error) {
+	var events []stream.Event
+	for _, change := range changes.Changes {
+		if change.Table != tableKVs {
+			continue
+		}
+
+		op := pbsubscribe.KVUpdate_Upsert
+		if change.Deleted() {
+			op = pbsubscribe.KVUpdate_Delete
+		}
+		events = append(events, stream.Event{
+			Topic: EventTopicKV,
+			Index: changes.Index,
+			Payload: EventPayloadKV{
+				Op:    op,
+				Value: changeObject(change).(*structs.DirEntry),
+			},
+		})
+	}
+	return events, nil
+}
+
+// KVSnapshot is a stream.SnapshotFunc that returns a snapshot of the KV
+// entries whose key starts with the prefix of the subscription, or of all the
+// KV entries for the wildcard subject.
+func (s *Store) KVSnapshot(req stream.SubscribeRequest, buf stream.SnapshotAppender) (uint64, error) {
+	var (
+		prefix  string
+		entMeta acl.EnterpriseMeta
+	)
+	if subject, ok := req.Subject.(EventSubjectKV); ok {
+		prefix = subject.Prefix
+		entMeta = subject.EnterpriseMeta
+	} else if req.Subject == stream.SubjectWildcard {
+		entMeta = *structs.WildcardEnterpriseMetaInPartition(structs.WildcardSpecifier)
+	} else {
+		return 0, fmt.Errorf("subject must be of type EventSubjectKV or be SubjectWildcard, was: %T", req.Subject)
+	}
+
+	idx, entries, err := s.KVSList(nil, prefix, &entMeta)
+	if err != nil {
+		return 0, err
+	}
+
+	// Append each entry as a separate item so that they can be serialized
+	// separately, to prevent the encoding of one massive message.
+	for _, entry := range entries {
+		buf.Append([]stream.Event{{
+			Topic: EventTopicKV,
+			Index: idx,
+			Payload: EventPayloadKV{
+				Op:    pbsubscribe.KVUpdate_Upsert,
+				Value: entry,
+			},
+		}})
+	}
+	return idx, nil
+}
@@ -0,0 +1,161 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package state
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/consul/acl"
+	"github.com/hashicorp/consul/agent/consul/stream"
+	"github.com/hashicorp/consul/agent/structs"
+	"github.com/hashicorp/consul/proto/private/pbsubscribe"
+)
+
+func TestKVEventsFromChanges(t *testing.T) {
+	const changeIndex uint64 = 123
+
+	testCases := map[string]struct {
+		setup  func(s *Store, tx *txn) error
+		mutate func(s *Store, tx *txn) error
+		ops    map[string]pbsubscribe.KVUpdate_UpdateOp
+	}{
+		"upsert": {
+			mutate: func(_ *Store, tx *txn) error {
+				return kvsSetTxn(tx, changeIndex, &structs.DirEntry{Key: "foo", Value: []byte("bar")}, false)
+			},
+			ops: map[string]pbsubscribe.KVUpdate_UpdateOp{
+				"foo": pbsubscribe.KVUpdate_Upsert,
+			},
+		},
+		"delete": {
+			setup: func(_ *Store, tx *txn) error {
+				return kvsSetTxn(tx, 1, &structs.DirEntry{Key: "foo", Value: []byte("bar")}, false)
+			},
+			mutate: func(s *Store, tx *txn) error {
+				return s.kvsDeleteTxn(tx, changeIndex, "foo", nil)
+			},
+			ops: map[string]pbsubscribe.KVUpdate_UpdateOp{
+				"foo": pbsubscribe.KVUpdate_Delete,
+			},
+		},
+		"delete tree": {
+			setup: func(_ *Store, tx *txn) error {
+				for _, key := range []string{"foo/a", "foo/b", "bar"} {
+					if err := kvsSetTxn(tx, 1, &structs.DirEntry{Key: key}, false); err != nil {
+						return err
+					}
+				}
+				return nil
+			},
+			mutate: func(s *Store, tx *txn) error {
+				return s.kvsDeleteTreeTxn(tx, changeIndex, "foo/", nil)
+			},
+			ops: map[string]pbsubscribe.KVUpdate_UpdateOp{
+				"foo/a": pbsubscribe.KVUpdate_Delete,
+				"foo/b": pbsubscribe.KVUpdate_Delete,
+			},
+		},
+	}
+	for desc, tc := range testCases {
+		t.Run(desc, func(t *testing.T) {
+			store := testStateStore(t)
+
+			if tc.setup != nil {
+				tx := store.db.WriteTxn(0)
+				require.NoError(t, tc.setup(store, tx))
+				require.NoError(t, tx.Commit())
+			}
+
+			tx := store.db.WriteTxn(0)
+			t.Cleanup(tx.Abort)
+			require.NoError(t, tc.mutate(store, tx))
+
+			events, err := KVEventsFromChanges(tx, Changes{Index: changeIndex, Changes: tx.Changes()})
+			require.NoError(t, err)
+
+			ops := make(map[string]pbsubscribe.KVUpdate_UpdateOp)
+			for _, event := range events {
+				require.Equal(t, EventTopicKV, event.Topic)
+				require.Equal(t, changeIndex, event.Index)
+				payload := event.Payload.(EventPayloadKV)
+				ops[payload.Value.Key] = payload.Op
+			}
+			require.Equal(t, tc.ops, ops)
+		})
+	}
+}
+
+func TestKVSnapshot(t *testing.T) {
+	store := testStateStore(t)
+	require.NoError(t, store.KVSSet(1, &structs.DirEntry{Key: "foo/a", Value: []byte("a")}))
+	require.NoError(t, store.KVSSet(2, &structs.DirEntry{Key: "foo/b", Value: []byte("b")}))
+	require.NoError(t, store.KVSSet(3, &structs.DirEntry{Key: "bar", Value: []byte("bar")}))
+
+	testCases := map[string]struct {
+		subject stream.Subject
+		index   uint64
+		keys    []string
+	}{
+		"prefix": {
+			subject: EventSubjectKV{Prefix: "foo/"},
+			index:   2,
+			keys:    []string{"foo/a", "foo/b"},
+		},
+		"wildcard": {
+			subject: stream.SubjectWildcard,
+			index:   3,
+			keys:    []string{"bar", "foo/a", "foo/b"},
+		},
+		"no match": {
+			subject: EventSubjectKV{Prefix: "baz"},
+			index:   3,
+		},
+	}
+	for desc, tc := range testCases {
+		t.Run(desc, func(t *testing.T) {
+			buf := &snapshotAppender{}
+
+			idx, err := store.KVSnapshot(stream.SubscribeRequest{Topic: EventTopicKV, Subject: tc.subject}, buf)
+			require.NoError(t, err)
+			require.Equal(t, tc.index, idx)
+
+			var keys []string
+			for _, events := range buf.events {
+				require.Len(t, events, 1)
+				payload := events[0].Payload.(EventPayloadKV)
+				require.Equal(t, pbsubscribe.KVUpdate_Upsert, payload.Op)
+				keys = append(keys, payload.Value.Key)
+			}
+			require.Equal(t, tc.keys, keys)
+		})
+	}
+}
+
+func TestEventPayloadKV_SubjectPrefixes(t *testing.T) {
+	payload := EventPayloadKV{Value: &structs.DirEntry{Key: "foo/b"}}
+	require.Equal(t, EventSubjectKV{Prefix: "foo/b"}, payload.Subject())
+
+	// The subjects of the prefixes of the key are prefixes of its subject.
+	subject := payload.Subject().String()
+	for _, prefix := range []string{"f", "foo/", "foo/b"} {
+		require.True(t, strings.HasPrefix(subject, EventSubjectKV{Prefix: prefix}.String()), prefix)
+	}
+	require.False(t, strings.HasPrefix(subject, EventSubjectKV{Prefix: "foo/bar"}.String()))
+}
+
+func TestEventPayloadKV_HasReadPermission(t *testing.T) {
+	policy, err := acl.NewPolicyFromSource(`key_prefix "foo/" { policy = "read" }`, nil, nil)
+	require.NoError(t, err)
+	authz, err := acl.NewPolicyAuthorizerWithDefaults(acl.DenyAll(), []*acl.Policy{policy}, nil)
+	require.NoError(t, err)
+
+	allowed := EventPayloadKV{Value: &structs.DirEntry{Key: "foo/a"}}
+	require.True(t, allowed.HasReadPermission(authz))
+
+	denied := EventPayloadKV{Value: &structs.DirEntry{Key: "bar"}}
+	require.False(t, denied.HasReadPermission(authz))
+}
@@ -43,6 +43,41 @@ func prefixIndexForIDValue(arg interface{}) ([]byte, error) {
 	return nil, fmt.Errorf("unexpected type %T for singleValueID prefix index", arg)
 }
 
+func kvsRevisionsIndexer() indexerSingleWithPrefix[KVRevisionQuery, *structs.DirEntryRevision, Query] {
+	return indexerSingleWithPrefix[KVRevisionQuery, *structs.DirEntryRevision, Query]{
+		readIndex:   indexFromKVRevisionQuery,
+		writeIndex:  indexFromDirEntryRevision,
+		prefixIndex: prefixIndexForKVRevisions,
+	}
+}
+
+func indexFromKVRevisionQuery(q KVRevisionQuery) ([]byte, error) {
+	if q.Key == "" {
+		return nil, errMissingValueForIndex
+	}
+
+	var b indexBuilder
+	b.String(q.Key)
+	b.Uint64(q.ModifyIndex)
+	return b.Bytes(), nil
+}
+
+func indexFromDirEntryRevision(r *structs.DirEntryRevision) ([]byte, error) {
+	return indexFromKVRevisionQuery(KVRevisionQuery{Key: r.Key, ModifyIndex: r.ModifyIndex})
+}
+
+// prefixIndexForKVRevisions returns the prefix of the index values of all the
+// revisions of a key, ordered by ModifyIndex.
+func prefixIndexForKVRevisions(q Query) ([]byte, error) {
+	if q.Value == "" {
+		return nil, nil
+	}
+
+	var b indexBuilder
+	b.String(q.Value)
+	return b.Bytes(), nil
+}
+
 func insertKVTxn(tx WriteTxn, entry *structs.DirEntry, updateMax bool, _ bool) error {
 	if err := tx.Insert(tableKVs, entry); err != nil {
 		return err
@@ -84,6 +119,23 @@ func kvsListEntriesTxn(tx ReadTxn, ws memdb.WatchSet, prefix string, entMeta acl
 // kvsDeleteTreeTxn is the inner method that does a recursive delete inside an
 // existing transaction.
 func (s *Store) kvsDeleteTreeTxn(tx WriteTxn, idx uint64, prefix string, entMeta *acl.EnterpriseMeta) error {
+	// Keep the deleted entries that retain revisions in their history.
+	entries, err := tx.Get(tableKVs, indexID+"_prefix", prefix)
+	if err != nil {
+		return fmt.Errorf("failed kvs lookup: %s", err)
+	}
+	var retained []*structs.DirEntry
+	for entry := entries.Next(); entry != nil; entry = entries.Next() {
+		if e := entry.(*structs.DirEntry); e.RetainRevisions > 0 {
+			retained = append(retained, e)
+		}
+	}
+	for _, e := range retained {
+		if err := kvsRetainRevisionTxn(tx, idx, e, true, e.RetainRevisions); err != nil {
+			return err
+		}
+	}
+
 	// For prefix deletes, only insert one tombstone and delete the entire subtree
 	deleted, err := tx.DeletePrefix(tableKVs, indexID+"_prefix", prefix)
 	if err != nil {
@@ -7,11 +7,14 @@
 package state
 
 import (
+	"time"
+
 	"github.com/hashicorp/consul/acl"
 	"github.com/hashicorp/consul/agent/structs"
 )
 
 func testIndexerTableKVs() map[string]indexerTestCase {
+	expires := time.Date(2024, 1, 1, 16, 0, 0, 0, time.UTC)
 	return map[string]indexerTestCase{
 		indexID: {
 			read: indexValue{
@@ -37,6 +40,55 @@ func testIndexerTableKVs() map[string]indexerTestCase {
 				},
 			},
 		},
+		indexExpires: {
+			read: indexValue{
+				source:   &TimeQuery{Value: expires},
+				expected: []byte{0x0, 0x0, 0x0, 0x0, 0x65, 0x92, 0xe1, 0x80},
+			},
+			write: indexValue{
+				source:   &structs.DirEntry{Key: "TheKey", ExpirationTime: &expires},
+				expected: []byte{0x0, 0x0, 0x0, 0x0, 0x65, 0x92, 0xe1, 0x80},
+			},
+			extra: []indexerTestCase{
+				{
+					write: indexValue{
+						source:               &structs.DirEntry{Key: "TheKey"},
+						expectedIndexMissing: true,
+					},
+				},
+			},
+		},
+	}
+}
+
+func testIndexerTableKVsRevisions() map[string]indexerTestCase {
+	return map[string]indexerTestCase{
+		indexID: {
+			read: indexValue{
+				source:   KVRevisionQuery{Key: "TheKey", ModifyIndex: 258},
+				expected: []byte("TheKey\x00\x00\x00\x00\x00\x00\x00\x01\x02"),
+			},
+			write: indexValue{
+				source: &structs.DirEntryRevision{
+					DirEntry: structs.DirEntry{
+						Key:       "TheKey",
+						RaftIndex: structs.RaftIndex{ModifyIndex: 258},
+					},
+					ReplacedIndex: 300,
+				},
+				expected: []byte("TheKey\x00\x00\x00\x00\x00\x00\x00\x01\x02"),
+			},
+			prefix: []indexValue{
+				{
+					source:   Query{},
+					expected: nil,
+				},
+				{
+					source:   Query{Value: "TheKey"},
+					expected: []byte("TheKey\x00"),
+				},
+			},
+		},
 	}
 }
 
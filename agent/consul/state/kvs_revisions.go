@@ -0,0 +1,254 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package state
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/go-memdb"
+
+	"github.com/hashicorp/consul/acl"
+	"github.com/hashicorp/consul/agent/structs"
+)
+
+const (
+	tableKVsRevisions = "kvs_revisions"
+
+	indexDeletedAt = "deleted_at"
+)
+
+// kvsRevisionsTableSchema returns a new table schema used for storing the
+// previous revisions of the KV entries that retain them.
+func kvsRevisionsTableSchema() *memdb.TableSchema {
+	return &memdb.TableSchema{
+		Name: tableKVsRevisions,
+		Indexes: map[string]*memdb.IndexSchema{
+			indexID: {
+				Name:         indexID,
+				AllowMissing: false,
+				Unique:       true,
+				Indexer:      kvsRevisionsIndexer(),
+			},
+			indexDeletedAt: {
+				Name:         indexDeletedAt,
+				AllowMissing: true,
+				Unique:       false,
+				Indexer: indexerSingle[uint64, *structs.DirEntryRevision]{
+					readIndex:  indexFromUint64,
+					writeIndex: indexDeletedAtFromDirEntryRevision,
+				},
+			},
+		},
+	}
+}
+
+func indexFromUint64(v uint64) ([]byte, error) {
+	var b indexBuilder
+	b.Uint64(v)
+	return b.Bytes(), nil
+}
+
+// indexDeletedAtFromDirEntryRevision indexes the revisions that record the
+// deletion of their key by the index of the deletion.
+func indexDeletedAtFromDirEntryRevision(r *structs.DirEntryRevision) ([]byte, error) {
+	if !r.Deleted {
+		return nil, errMissingValueForIndex
+	}
+	return indexFromUint64(r.ReplacedIndex)
+}
+
+// KVRevisionQuery is used to look up a single revision of a KV entry.
+type KVRevisionQuery struct {
+	Key         string
+	ModifyIndex uint64
+	acl.EnterpriseMeta
+}
+
+// NamespaceOrDefault exists because structs.EnterpriseMeta uses a pointer
+// receiver for this method. Remove once that is fixed.
+func (q KVRevisionQuery) NamespaceOrDefault() string {
+	return q.EnterpriseMeta.NamespaceOrDefault()
+}
+
+// PartitionOrDefault exists because structs.EnterpriseMeta uses a pointer
+// receiver for this method. Remove once that is fixed.
+func (q KVRevisionQuery) PartitionOrDefault() string {
+	return q.EnterpriseMeta.PartitionOrDefault()
+}
+
+// KVsRevisions is used to pull all the KV revisions for use during snapshots.
+func (s *Snapshot) KVsRevisions() (memdb.ResultIterator, error) {
+	return s.tx.Get(tableKVsRevisions, indexID+"_prefix")
+}
+
+// KVSRevision is used when restoring from a snapshot.
+func (s *Restore) KVSRevision(rev *structs.DirEntryRevision) error {
+	if err := s.tx.Insert(tableKVsRevisions, rev); err != nil {
+		return fmt.Errorf("failed inserting kvs revision: %s", err)
+	}
+	return nil
+}
+
+// KVSHistory returns the current entry of a key, if any, followed by the
+// previous revisions of the key that were retained, newest first. The current
+// entry is returned with a zero ReplacedIndex.
+func (s *Store) KVSHistory(ws memdb.WatchSet, key string, entMeta *acl.EnterpriseMeta) (uint64, structs.DirEntryRevisions, error) {
+	tx := s.db.Txn(false)
+	defer tx.Abort()
+
+	if entMeta == nil {
+		entMeta = structs.DefaultEnterpriseMetaInDefaultPartition()
+	}
+
+	idx, entry, err := kvsGetTxn(tx, ws, key, *entMeta)
+	if err != nil {
+		return 0, nil, err
+	}
+	revs, err := kvsRevisionsTxn(tx, ws, key, *entMeta)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	var history structs.DirEntryRevisions
+	var lindex uint64
+	if entry != nil {
+		history = append(history, &structs.DirEntryRevision{DirEntry: *entry})
+		lindex = entry.ModifyIndex
+	}
+	for i := len(revs) - 1; i >= 0; i-- {
+		history = append(history, revs[i])
+		if revs[i].ReplacedIndex > lindex {
+			lindex = revs[i].ReplacedIndex
+		}
+	}
+
+	// Use the index of the latest change to the history of the key if
+	// there is one, otherwise use the full table index from above.
+	if lindex != 0 {
+		idx = lindex
+	}
+	return idx, history, nil
+}
+
+// KVSGetAtIndex is used to retrieve the revision of a key that was current at
+// the given index. A nil entry is returned if the key did not exist at that
+// index, or if that revision was not retained.
+func (s *Store) KVSGetAtIndex(ws memdb.WatchSet, key string, index uint64, entMeta *acl.EnterpriseMeta) (uint64, *structs.DirEntry, error) {
+	tx := s.db.Txn(false)
+	defer tx.Abort()
+
+	if entMeta == nil {
+		entMeta = structs.DefaultEnterpriseMetaInDefaultPartition()
+	}
+
+	idx, entry, err := kvsGetTxn(tx, ws, key, *entMeta)
+	if err != nil {
+		return 0, nil, err
+	}
+	if entry != nil && entry.ModifyIndex <= index {
+		return idx, entry, nil
+	}
+
+	revs, err := kvsRevisionsTxn(tx, ws, key, *entMeta)
+	if err != nil {
+		return 0, nil, err
+	}
+	for _, rev := range revs {
+		if rev.ModifyIndex <= index && index < rev.ReplacedIndex {
+			return idx, &rev.DirEntry, nil
+		}
+	}
+	return idx, nil, nil
+}
+
+// kvsRevisionsTxn returns the previous revisions of a key, oldest first.
+func kvsRevisionsTxn(tx ReadTxn, ws memdb.WatchSet, key string, entMeta acl.EnterpriseMeta) (structs.DirEntryRevisions, error) {
+	iter, err := tx.Get(tableKVsRevisions, indexID+"_prefix", Query{Value: key, EnterpriseMeta: entMeta})
+	if err != nil {
+		return nil, fmt.Errorf("failed kvs revisions lookup: %s", err)
+	}
+	ws.Add(iter.WatchCh())
+
+	var revs structs.DirEntryRevisions
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		revs = append(revs, raw.(*structs.DirEntryRevision))
+	}
+	return revs, nil
+}
+
+// kvsRetainRevisionTxn keeps replaced as a revision of its key that was
+// replaced, or deleted, at idx if retain is positive. The oldest revisions of
+// the key are then dropped so that at most retain of them are kept.
+func kvsRetainRevisionTxn(tx WriteTxn, idx uint64, replaced *structs.DirEntry, deleted bool, retain int) error {
+	if retain > 0 {
+		rev := &structs.DirEntryRevision{
+			DirEntry:      *replaced,
+			ReplacedIndex: idx,
+			Deleted:       deleted,
+		}
+		if err := tx.Insert(tableKVsRevisions, rev); err != nil {
+			return fmt.Errorf("failed inserting kvs revision: %s", err)
+		}
+	}
+	return kvsPruneRevisionsTxn(tx, replaced.Key, replaced.EnterpriseMeta, retain)
+}
+
+// kvsPruneRevisionsTxn drops the oldest revisions of a key so that at most
+// retain of them are kept.
+func kvsPruneRevisionsTxn(tx WriteTxn, key string, entMeta acl.EnterpriseMeta, retain int) error {
+	revs, err := kvsRevisionsTxn(tx, nil, key, entMeta)
+	if err != nil {
+		return err
+	}
+	if retain < 0 {
+		retain = 0
+	}
+	for i := 0; i < len(revs)-retain; i++ {
+		if err := tx.Delete(tableKVsRevisions, revs[i]); err != nil {
+			return fmt.Errorf("failed deleting kvs revision: %s", err)
+		}
+	}
+	return nil
+}
+
+// kvsReapRevisionsTxn deletes the revisions of the keys that no longer exist
+// and were deleted at or before the given index. This is done along with the
+// reaping of the tombstones, so that the history of a deleted key is available
+// as long as its tombstone.
+//
+// A key that no longer exists only has revisions if it retained them when it
+// was deleted, so only the revisions recording a deletion are scanned, in the
+// order of their deletion.
+func kvsReapRevisionsTxn(tx WriteTxn, index uint64) error {
+	iter, err := tx.Get(tableKVsRevisions, indexDeletedAt)
+	if err != nil {
+		return fmt.Errorf("failed querying kvs revisions: %s", err)
+	}
+
+	// Find the deletions that are eligible.
+	var deletions []*structs.DirEntryRevision
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		rev := raw.(*structs.DirEntryRevision)
+		if rev.ReplacedIndex > index {
+			break
+		}
+		deletions = append(deletions, rev)
+	}
+
+	// Delete the revisions in a separate loop so we don't trash the
+	// iterator.
+	for _, rev := range deletions {
+		entry, err := tx.First(tableKVs, indexID, Query{Value: rev.Key, EnterpriseMeta: rev.EnterpriseMeta})
+		if err != nil {
+			return fmt.Errorf("failed kvs lookup: %s", err)
+		}
+		if entry != nil {
+			continue
+		}
+		if err := kvsPruneRevisionsTxn(tx, rev.Key, rev.EnterpriseMeta, 0); err != nil {
+			return err
+		}
+	}
+	return nil
+}
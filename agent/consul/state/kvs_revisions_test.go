@@ -0,0 +1,246 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package state
+
+import (
+	"testing"
+
+	"github.com/hashicorp/go-memdb"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/consul/agent/structs"
+)
+
+func testSetKeyWithRevisions(t *testing.T, s *Store, idx uint64, key, value string, retain int) {
+	t.Helper()
+	entry := &structs.DirEntry{Key: key, Value: []byte(value), RetainRevisions: retain}
+	require.NoError(t, s.KVSSet(idx, entry))
+}
+
+func historyValues(history structs.DirEntryRevisions) []string {
+	var values []string
+	for _, rev := range history {
+		values = append(values, string(rev.Value))
+	}
+	return values
+}
+
+func TestStateStore_KVSHistory(t *testing.T) {
+	s := testStateStore(t)
+
+	// Unknown keys have no history.
+	idx, history, err := s.KVSHistory(nil, "foo", nil)
+	require.NoError(t, err)
+	require.Zero(t, idx)
+	require.Empty(t, history)
+
+	// Entries that don't retain revisions only have their current value.
+	testSetKey(t, s, 1, "foo", "one", nil)
+	testSetKey(t, s, 2, "foo", "two", nil)
+	idx, history, err = s.KVSHistory(nil, "foo", nil)
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), idx)
+	require.Equal(t, []string{"two"}, historyValues(history))
+	require.Zero(t, history[0].ReplacedIndex)
+
+	// The replaced values are kept once revisions are retained, newest
+	// first, and only the last ones are kept.
+	testSetKeyWithRevisions(t, s, 3, "foo", "three", 2)
+	testSetKeyWithRevisions(t, s, 4, "foo", "four", 2)
+	testSetKeyWithRevisions(t, s, 5, "foo", "five", 2)
+	idx, history, err = s.KVSHistory(nil, "foo", nil)
+	require.NoError(t, err)
+	require.Equal(t, uint64(5), idx)
+	require.Equal(t, []string{"five", "four", "three"}, historyValues(history))
+	require.Equal(t, uint64(5), history[1].ReplacedIndex)
+	require.Equal(t, uint64(4), history[1].ModifyIndex)
+	require.Equal(t, uint64(4), history[2].ReplacedIndex)
+	require.Equal(t, uint64(3), history[2].ModifyIndex)
+
+	// Writes that don't change the entry don't add revisions.
+	testSetKeyWithRevisions(t, s, 6, "foo", "five", 2)
+	_, history, err = s.KVSHistory(nil, "foo", nil)
+	require.NoError(t, err)
+	require.Equal(t, []string{"five", "four", "three"}, historyValues(history))
+
+	// Other keys have their own history.
+	testSetKeyWithRevisions(t, s, 7, "foo/bar", "bar", 2)
+	_, history, err = s.KVSHistory(nil, "foo/bar", nil)
+	require.NoError(t, err)
+	require.Equal(t, []string{"bar"}, historyValues(history))
+
+	// The history is dropped once revisions are no longer retained.
+	testSetKey(t, s, 8, "foo", "eight", nil)
+	_, history, err = s.KVSHistory(nil, "foo", nil)
+	require.NoError(t, err)
+	require.Equal(t, []string{"eight"}, historyValues(history))
+}
+
+func TestStateStore_KVSGetAtIndex(t *testing.T) {
+	s := testStateStore(t)
+
+	testSetKeyWithRevisions(t, s, 2, "foo", "two", 5)
+	testSetKeyWithRevisions(t, s, 4, "foo", "four", 5)
+	require.NoError(t, s.KVSDelete(6, "foo", nil))
+	testSetKeyWithRevisions(t, s, 8, "foo", "eight", 5)
+
+	cases := map[uint64]string{
+		1:  "",
+		2:  "two",
+		3:  "two",
+		4:  "four",
+		5:  "four",
+		6:  "",
+		7:  "",
+		8:  "eight",
+		10: "eight",
+	}
+	for index, value := range cases {
+		_, entry, err := s.KVSGetAtIndex(nil, "foo", index, nil)
+		require.NoError(t, err)
+		if value == "" {
+			require.Nil(t, entry, "index %d", index)
+			continue
+		}
+		require.NotNil(t, entry, "index %d", index)
+		require.Equal(t, value, string(entry.Value), "index %d", index)
+	}
+}
+
+func TestStateStore_KVSHistory_Delete(t *testing.T) {
+	s := testStateStore(t)
+
+	testSetKeyWithRevisions(t, s, 1, "foo", "foo", 3)
+	testSetKeyWithRevisions(t, s, 2, "foo/bar", "bar", 3)
+	testSetKeyWithRevisions(t, s, 3, "foo/bar", "bar2", 3)
+	testSetKey(t, s, 4, "foo/baz", "baz", nil)
+
+	// Deleted entries are kept in the history of their key.
+	require.NoError(t, s.KVSDelete(5, "foo", nil))
+	require.NoError(t, s.KVSDeleteTree(6, "foo/", nil))
+
+	idx, history, err := s.KVSHistory(nil, "foo", nil)
+	require.NoError(t, err)
+	require.Equal(t, uint64(5), idx)
+	require.Equal(t, []string{"foo"}, historyValues(history))
+	require.True(t, history[0].Deleted)
+	require.Equal(t, uint64(5), history[0].ReplacedIndex)
+
+	_, history, err = s.KVSHistory(nil, "foo/bar", nil)
+	require.NoError(t, err)
+	require.Equal(t, []string{"bar2", "bar"}, historyValues(history))
+	require.True(t, history[0].Deleted)
+	require.False(t, history[1].Deleted)
+
+	_, history, err = s.KVSHistory(nil, "foo/baz", nil)
+	require.NoError(t, err)
+	require.Empty(t, history)
+
+	// Recreating a key keeps its history.
+	testSetKeyWithRevisions(t, s, 7, "foo/bar", "bar3", 3)
+
+	// The history of deleted keys is reaped along with their tombstones.
+	require.NoError(t, s.ReapTombstones(8, 5))
+	_, history, err = s.KVSHistory(nil, "foo", nil)
+	require.NoError(t, err)
+	require.Empty(t, history)
+	_, history, err = s.KVSHistory(nil, "foo/bar", nil)
+	require.NoError(t, err)
+	require.Equal(t, []string{"bar3", "bar2", "bar"}, historyValues(history))
+
+	require.NoError(t, s.ReapTombstones(9, 7))
+	_, history, err = s.KVSHistory(nil, "foo/bar", nil)
+	require.NoError(t, err)
+	require.Equal(t, []string{"bar3", "bar2", "bar"}, historyValues(history))
+}
+
+func TestStateStore_KVSHistory_ReapOrder(t *testing.T) {
+	s := testStateStore(t)
+
+	testSetKeyWithRevisions(t, s, 1, "a", "a1", 3)
+	testSetKeyWithRevisions(t, s, 2, "a", "a2", 3)
+	testSetKeyWithRevisions(t, s, 3, "b", "b1", 3)
+	require.NoError(t, s.KVSDelete(4, "b", nil))
+	require.NoError(t, s.KVSDelete(5, "a", nil))
+
+	// Only the keys deleted at or before the index are reaped, along with
+	// all of their revisions.
+	require.NoError(t, s.ReapTombstones(6, 4))
+	_, history, err := s.KVSHistory(nil, "b", nil)
+	require.NoError(t, err)
+	require.Empty(t, history)
+	_, history, err = s.KVSHistory(nil, "a", nil)
+	require.NoError(t, err)
+	require.Equal(t, []string{"a2", "a1"}, historyValues(history))
+
+	require.NoError(t, s.ReapTombstones(7, 5))
+	_, history, err = s.KVSHistory(nil, "a", nil)
+	require.NoError(t, err)
+	require.Empty(t, history)
+
+	snap := s.Snapshot()
+	defer snap.Close()
+	revs, err := snap.KVsRevisions()
+	require.NoError(t, err)
+	require.Nil(t, revs.Next())
+}
+
+func TestStateStore_KVSHistory_Watches(t *testing.T) {
+	s := testStateStore(t)
+
+	testSetKeyWithRevisions(t, s, 1, "foo", "one", 2)
+
+	// Deleting a key fires the watch on its history.
+	ws := memdb.NewWatchSet()
+	_, _, err := s.KVSHistory(ws, "foo", nil)
+	require.NoError(t, err)
+	require.NoError(t, s.KVSDelete(2, "foo", nil))
+	require.True(t, watchFired(ws))
+}
+
+func TestStateStore_KVSRevisions_Snapshot_Restore(t *testing.T) {
+	s := testStateStore(t)
+
+	testSetKeyWithRevisions(t, s, 1, "foo", "one", 2)
+	testSetKeyWithRevisions(t, s, 2, "foo", "two", 2)
+	testSetKeyWithRevisions(t, s, 3, "bar", "bar", 2)
+	require.NoError(t, s.KVSDelete(4, "bar", nil))
+
+	snap := s.Snapshot()
+	defer snap.Close()
+
+	iter, err := snap.KVsRevisions()
+	require.NoError(t, err)
+	var dump structs.DirEntryRevisions
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		dump = append(dump, raw.(*structs.DirEntryRevision))
+	}
+	require.Len(t, dump, 2)
+
+	iter, err = snap.KVs()
+	require.NoError(t, err)
+	var entries structs.DirEntries
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		entries = append(entries, raw.(*structs.DirEntry))
+	}
+
+	restored := testStateStore(t)
+	restore := restored.Restore()
+	for _, entry := range entries {
+		require.NoError(t, restore.KVS(entry))
+	}
+	for _, rev := range dump {
+		require.NoError(t, restore.KVSRevision(rev))
+	}
+	require.NoError(t, restore.Commit())
+
+	_, history, err := restored.KVSHistory(nil, "foo", nil)
+	require.NoError(t, err)
+	require.Equal(t, []string{"two", "one"}, historyValues(history))
+
+	_, history, err = restored.KVSHistory(nil, "bar", nil)
+	require.NoError(t, err)
+	require.Equal(t, []string{"bar"}, historyValues(history))
+	require.True(t, history[0].Deleted)
+}
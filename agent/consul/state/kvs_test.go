@@ -488,6 +488,42 @@ func TestStateStore_KVSList(t *testing.T) {
 	}
 }
 
+func TestStateStore_KVSListExpiring(t *testing.T) {
+	s := testStateStore(t)
+
+	// Nothing expires in an empty store.
+	entries, err := s.KVSListExpiring()
+	require.NoError(t, err)
+	require.Empty(t, entries)
+
+	now := time.Now()
+	later := now.Add(time.Hour)
+	sooner := now.Add(time.Minute)
+	require.NoError(t, s.KVSSet(1, &structs.DirEntry{Key: "foo", Value: []byte("foo")}))
+	require.NoError(t, s.KVSSet(2, &structs.DirEntry{Key: "foo/bar", Value: []byte("bar"), ExpirationTime: &later}))
+	require.NoError(t, s.KVSSet(3, &structs.DirEntry{Key: "foo/baz", Value: []byte("baz"), ExpirationTime: &sooner}))
+
+	// Entries are ordered by expiration time.
+	entries, err = s.KVSListExpiring()
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	require.Equal(t, "foo/baz", entries[0].Key)
+	require.Equal(t, "foo/bar", entries[1].Key)
+
+	// Updating the expiration time of an entry is a write.
+	require.NoError(t, s.KVSSet(4, &structs.DirEntry{Key: "foo/bar", Value: []byte("bar"), ExpirationTime: &sooner}))
+	_, entry, err := s.KVSGet(nil, "foo/bar", nil)
+	require.NoError(t, err)
+	require.Equal(t, uint64(4), entry.ModifyIndex)
+
+	// Writing an entry without an expiration time makes it permanent.
+	require.NoError(t, s.KVSSet(5, &structs.DirEntry{Key: "foo/baz", Value: []byte("baz")}))
+	require.NoError(t, s.KVSDelete(6, "foo/bar", nil))
+	entries, err = s.KVSListExpiring()
+	require.NoError(t, err)
+	require.Empty(t, entries)
+}
+
 func TestStateStore_KVSDelete(t *testing.T) {
 	s := testStateStore(t)
 
@@ -211,6 +211,7 @@ var (
 	EventTopicBoundAPIGateway      = pbsubscribe.Topic_BoundAPIGateway
 	EventTopicIPRateLimit          = pbsubscribe.Topic_IPRateLimit
 	EventTopicSamenessGroup        = pbsubscribe.Topic_SamenessGroup
+	EventTopicKV                   = pbsubscribe.Topic_KV
 )
 
 func processDBChanges(tx ReadTxn, changes Changes) ([]stream.Event, error) {
@@ -221,6 +222,7 @@ func processDBChanges(tx ReadTxn, changes Changes) ([]stream.Event, error) {
 		ServiceHealthEventsFromChanges,
 		ServiceListUpdateEventsFromChanges,
 		ConfigEntryEventsFromChanges,
+		KVEventsFromChanges,
 		// TODO: add other table handlers here.
 	}
 	for _, fn := range fns {
@@ -65,6 +65,17 @@ type Payload interface {
 	ToSubscriptionEvent(idx uint64) *pbsubscribe.Event
 }
 
+// PrefixSubjectPayload is a Payload that is delivered to the subscribers of
+// every subject of its topic whose String is a prefix of the String of its
+// Subject. For example: a change to a KV entry is delivered to the subscribers
+// of every prefix of its key.
+type PrefixSubjectPayload interface {
+	Payload
+
+	// MatchesSubjectPrefixes only marks the payload as a PrefixSubjectPayload.
+	MatchesSubjectPrefixes()
+}
+
 // PayloadEvents is a Payload that may be returned by Subscription.Next when
 // there are multiple events at an index.
 //
@@ -8,6 +8,8 @@ import (
 	"fmt"
 	"sync"
 	"time"
+
+	iradix "github.com/hashicorp/go-immutable-radix"
 )
 
 // EventPublisher receives change events from Publish, and sends the events to
@@ -23,13 +25,19 @@ type EventPublisher struct {
 	// seconds.
 	snapCacheTTL time.Duration
 
-	// This lock protects the snapCache, topicBuffers and topicBuffer.refs.
+	// This lock protects the snapCache, topicBuffers, topicBuffer.refs and
+	// subjects.
 	lock sync.RWMutex
 
 	// topicBuffers stores the head of the linked-list buffers to publish events to
 	// for a topic.
 	topicBuffers map[topicSubject]*topicBuffer
 
+	// subjects stores the subjects of the topicBuffers of each topic, so that
+	// the subjects that are a prefix of the subject of a PrefixSubjectPayload
+	// are found without looking up every prefix.
+	subjects map[string]*iradix.Tree
+
 	// snapCache if a cache of EventSnapshots indexed by topic and subject.
 	// TODO(streaming): new snapshotCache struct for snapCache and snapCacheTTL
 	snapCache map[topicSubject]*eventSnapshot
@@ -103,6 +111,7 @@ func NewEventPublisher(snapCacheTTL time.Duration) *EventPublisher {
 	e := &EventPublisher{
 		snapCacheTTL: snapCacheTTL,
 		topicBuffers: make(map[topicSubject]*topicBuffer),
+		subjects:     make(map[string]*iradix.Tree),
 		snapCache:    make(map[topicSubject]*eventSnapshot),
 		publishCh:    make(chan []Event, 64),
 		subscriptions: &subscriptions{
@@ -197,11 +206,19 @@ func (e *EventPublisher) publishEvent(events []Event) {
 			continue
 		}
 
-		groupKey := topicSubject{
-			Topic:   event.Topic.String(),
-			Subject: event.Payload.Subject().String(),
+		subjects := []string{event.Payload.Subject().String()}
+		if _, ok := event.Payload.(PrefixSubjectPayload); ok {
+			e.lock.RLock()
+			subjects = e.subjectsWithPrefixOf(event.Topic, subjects[0])
+			e.lock.RUnlock()
+		}
+		for _, subject := range subjects {
+			groupKey := topicSubject{
+				Topic:   event.Topic.String(),
+				Subject: subject,
+			}
+			groupedEvents[groupKey] = append(groupedEvents[groupKey], event)
 		}
-		groupedEvents[groupKey] = append(groupedEvents[groupKey], event)
 
 		// If the topic supports wildcard subscribers, copy the events to a wildcard
 		// buffer too.
@@ -237,11 +254,52 @@ func (e *EventPublisher) bufferForSubscription(key topicSubject) *topicBuffer {
 			buf: newEventBuffer(),
 		}
 		e.topicBuffers[key] = buf
+
+		subjects, ok := e.subjects[key.Topic]
+		if !ok {
+			subjects = iradix.New()
+		}
+		e.subjects[key.Topic], _, _ = subjects.Insert([]byte(key.Subject), nil)
 	}
 
 	return buf
 }
 
+// freeBufferForSubscription removes the topic event buffer for the given topic
+// and key.
+//
+// Warning: e.lock MUST be held when calling this function.
+func (e *EventPublisher) freeBufferForSubscription(key topicSubject) {
+	delete(e.topicBuffers, key)
+
+	if subjects, ok := e.subjects[key.Topic]; ok {
+		subjects, _, _ = subjects.Delete([]byte(key.Subject))
+		if subjects.Len() == 0 {
+			delete(e.subjects, key.Topic)
+		} else {
+			e.subjects[key.Topic] = subjects
+		}
+	}
+}
+
+// subjectsWithPrefixOf returns the subjects of the topic event buffers of the
+// given topic that are a prefix of subject, including subject itself.
+//
+// Warning: e.lock MUST be held when calling this function.
+func (e *EventPublisher) subjectsWithPrefixOf(topic Topic, subject string) []string {
+	subjects, ok := e.subjects[topic.String()]
+	if !ok {
+		return nil
+	}
+
+	var prefixes []string
+	subjects.Root().WalkPath([]byte(subject), func(k []byte, _ interface{}) bool {
+		prefixes = append(prefixes, string(k))
+		return false
+	})
+	return prefixes
+}
+
 // bufferForPublishing returns the event buffer to which events for the given
 // topic and key should be appended. nil will be returned if there are no
 // subscribers for the given topic and key.
@@ -296,7 +354,7 @@ func (e *EventPublisher) Subscribe(req *SubscribeRequest) (*Subscription, error)
 		topicBuf.refs--
 
 		if topicBuf.refs == 0 {
-			delete(e.topicBuffers, req.topicSubject())
+			e.freeBufferForSubscription(req.topicSubject())
 
 			// Evict cached snapshot too because the topic buffer will have been spliced
 			// onto it. If we don't do this, any new subscribers started before the cache
@@ -669,6 +669,61 @@ func TestEventPublisher_Publish_WildcardNotAllowed(t *testing.T) {
 	})
 }
 
+func TestEventPublisher_Publish_PrefixSubject(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	publisher := NewEventPublisher(0)
+	registerTestSnapshotHandlers(t, publisher)
+	go publisher.Run(ctx)
+
+	subscribe := func(key string) <-chan eventOrErr {
+		sub, err := publisher.Subscribe(&SubscribeRequest{
+			Topic:   testTopic,
+			Subject: StringSubject(key),
+		})
+		require.NoError(t, err)
+		t.Cleanup(sub.Unsubscribe)
+
+		eventCh := runSubscription(ctx, sub)
+		require.Equal(t, testSnapshotEvent, getNextEvent(t, eventCh))
+		next := getNextEvent(t, eventCh)
+		require.True(t, next.IsEndOfSnapshot(), "expected end of snapshot")
+		return eventCh
+	}
+	aCh := subscribe("a")
+	abCh := subscribe("ab")
+	abcCh := subscribe("abc")
+	bCh := subscribe("b")
+
+	event := Event{
+		Topic:   testTopic,
+		Payload: prefixSubjectPayload{key: "ab"},
+		Index:   2,
+	}
+	publisher.Publish([]Event{event})
+
+	// The event is delivered to the subscribers of each prefix of its subject.
+	require.Equal(t, event, getNextEvent(t, aCh))
+	require.Equal(t, event, getNextEvent(t, abCh))
+	assertNoResult(t, abcCh)
+	assertNoResult(t, bCh)
+}
+
+type prefixSubjectPayload struct {
+	key string
+}
+
+func (p prefixSubjectPayload) Subject() Subject { return StringSubject(p.key) }
+
+func (prefixSubjectPayload) MatchesSubjectPrefixes() {}
+
+func (prefixSubjectPayload) HasReadPermission(acl.Authorizer) bool { return true }
+
+func (prefixSubjectPayload) ToSubscriptionEvent(uint64) *pbsubscribe.Event {
+	panic("prefixSubjectPayload does not implement ToSubscriptionEvent")
+}
+
 type wildcardPayload struct{}
 
 func (wildcardPayload) Subject() Subject                              { return SubjectWildcard }
@@ -7,6 +7,7 @@ import (
 	"google.golang.org/grpc"
 
 	"github.com/hashicorp/consul/acl"
+	"github.com/hashicorp/consul/agent/consul/state"
 	"github.com/hashicorp/consul/agent/consul/stream"
 	"github.com/hashicorp/consul/agent/grpc-internal/services/subscribe"
 	"github.com/hashicorp/consul/agent/structs"
@@ -34,5 +35,33 @@ func (s subscribeBackend) Forward(info structs.RPCInfo, f func(*grpc.ClientConn)
 }
 
 func (s subscribeBackend) Subscribe(req *stream.SubscribeRequest) (*stream.Subscription, error) {
+	if err := s.checkKeyListPermission(req); err != nil {
+		return nil, err
+	}
 	return s.srv.publisher.Subscribe(req)
 }
+
+// checkKeyListPermission enforces the key list policy on subscriptions to a
+// prefix of KV entries, like it is for KVS.List. The events of the entries
+// are still filtered by the key read permission of the token.
+func (s subscribeBackend) checkKeyListPermission(req *stream.SubscribeRequest) error {
+	if req.Topic != state.EventTopicKV || !s.srv.config.ACLEnableKeyListPolicy {
+		return nil
+	}
+
+	var subject state.EventSubjectKV
+	switch sub := req.Subject.(type) {
+	case state.EventSubjectKV:
+		subject = sub
+	default:
+		// The wildcard subject lists all the keys.
+		subject.EnterpriseMeta = *structs.DefaultEnterpriseMetaInDefaultPartition()
+	}
+
+	var authzContext acl.AuthorizerContext
+	authz, err := s.srv.ResolveTokenAndDefaultMeta(req.Token, &subject.EnterpriseMeta, &authzContext)
+	if err != nil {
+		return err
+	}
+	return authz.ToAllowAuthorizer().KeyListAllowed(subject.Prefix, &authzContext)
+}
@@ -17,11 +17,17 @@ import (
 	"golang.org/x/sync/errgroup"
 	gogrpc "google.golang.org/grpc"
 
+	msgpackrpc "github.com/hashicorp/consul-net-rpc/net-rpc-msgpackrpc"
+
+	"github.com/hashicorp/consul/acl"
+	"github.com/hashicorp/consul/agent/consul/state"
+	"github.com/hashicorp/consul/agent/consul/stream"
 	grpc "github.com/hashicorp/consul/agent/grpc-internal"
 	"github.com/hashicorp/consul/agent/grpc-internal/balancer"
 	"github.com/hashicorp/consul/agent/grpc-internal/resolver"
 	"github.com/hashicorp/consul/agent/router"
 	"github.com/hashicorp/consul/agent/structs"
+	"github.com/hashicorp/consul/api"
 	"github.com/hashicorp/consul/proto/private/pbservice"
 	"github.com/hashicorp/consul/proto/private/pbsubscribe"
 	"github.com/hashicorp/consul/sdk/testutil"
@@ -498,3 +504,84 @@ func svcOrErr(event *pbsubscribe.Event) (*pbservice.NodeService, error) {
 	}
 	return csn.Service, nil
 }
+
+func TestSubscribeBackend_KV_ACLEnableKeyListPolicy(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+	_, s1 := testServerWithConfig(t, func(c *Config) {
+		c.PrimaryDatacenter = "dc1"
+		c.ACLsEnabled = true
+		c.ACLInitialManagementToken = "root"
+		c.ACLResolverSettings.ACLDefaultPolicy = "deny"
+		c.ACLEnableKeyListPolicy = true
+	})
+	codec := rpcClient(t, s1)
+	defer codec.Close()
+
+	testrpc.WaitForTestAgent(t, s1.RPC, "dc1", testrpc.WithToken("root"))
+
+	for _, key := range []string{"bar/bar1", "bar/secret", "zip"} {
+		arg := structs.KVSRequest{
+			Datacenter:   "dc1",
+			Op:           api.KVSet,
+			DirEnt:       structs.DirEntry{Key: key},
+			WriteRequest: structs.WriteRequest{Token: "root"},
+		}
+		var out bool
+		require.NoError(t, msgpackrpc.CallWithCodec(codec, "KVS.Apply", &arg, &out))
+	}
+
+	id := createToken(t, codec, `
+key_prefix "bar" {
+	policy = "list"
+}
+key_prefix "bar/secret" {
+	policy = "deny"
+}
+key_prefix "zip" {
+	policy = "read"
+}
+`)
+	backend := subscribeBackend{srv: s1}
+
+	// Subscriptions to prefixes without list permissions are denied.
+	for _, subject := range []stream.Subject{state.EventSubjectKV{Prefix: "zip"}, stream.SubjectWildcard} {
+		_, err := backend.Subscribe(&stream.SubscribeRequest{
+			Topic:   state.EventTopicKV,
+			Subject: subject,
+			Token:   id,
+		})
+		require.True(t, acl.IsErrPermissionDenied(err), "expected permission denied, got: %v", err)
+	}
+
+	// Subscriptions to prefixes with list permissions are allowed, and the
+	// events are filtered by the key read permissions.
+	sub, err := backend.Subscribe(&stream.SubscribeRequest{
+		Topic:   state.EventTopicKV,
+		Subject: state.EventSubjectKV{Prefix: "bar/"},
+		Token:   id,
+	})
+	require.NoError(t, err)
+	defer sub.Unsubscribe()
+
+	authz, err := s1.ResolveToken(id)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	var keys []string
+	for {
+		event, err := sub.Next(ctx)
+		require.NoError(t, err)
+		if event.IsEndOfSnapshot() {
+			break
+		}
+		if event.Payload.HasReadPermission(authz) {
+			keys = append(keys, event.Payload.(state.EventPayloadKV).Value.Key)
+		}
+	}
+	require.Equal(t, []string{"bar/bar1"}, keys)
+}
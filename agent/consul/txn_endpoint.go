@@ -165,6 +165,13 @@ func (t *Txn) Apply(args *structs.TxnRequest, reply *structs.TxnResponse) error
 		return fmt.Errorf("raft apply failed: %w", err)
 	}
 
+	// Track the expiration of the entries.
+	for _, op := range args.Ops {
+		if op.KV != nil {
+			t.srv.resetKVSTimer(&op.KV.DirEnt)
+		}
+	}
+
 	// Convert the return type. This should be a cheap copy since we are
 	// just taking the two slices.
 	if txnResp, ok := resp.(structs.TxnResponse); ok {
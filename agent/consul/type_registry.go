@@ -0,0 +1,28 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package consul
+
+import (
+	"github.com/hashicorp/consul/internal/resource"
+	"github.com/hashicorp/consul/internal/resource/demo"
+)
+
+// NewTypeRegistry returns a registry populated with all the resource types
+// supported by Consul.
+//
+// Note: it's used by the CLI, which can't know whether the server runs in dev
+// mode, so it includes the types that servers only register in dev mode.
+func NewTypeRegistry() resource.Registry {
+	registry := resource.NewRegistry()
+	registerTypes(registry, true)
+	return registry
+}
+
+// registerTypes registers the resource types supported by Consul. The demo
+// types are only registered in dev mode.
+func registerTypes(registry resource.Registry, devMode bool) {
+	if devMode {
+		demo.Register(registry)
+	}
+}
@@ -0,0 +1,33 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package consul
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/consul/internal/resource"
+	"github.com/hashicorp/consul/internal/resource/demo"
+	"github.com/hashicorp/consul/proto-public/pbresource"
+)
+
+func TestNewTypeRegistry(t *testing.T) {
+	registry := NewTypeRegistry()
+
+	for _, typ := range []*pbresource.Type{
+		resource.TypeV1Tombstone,
+		demo.TypeV1Artist,
+		demo.TypeV2Artist,
+	} {
+		_, ok := registry.Resolve(typ)
+		require.True(t, ok, resource.ToGVK(typ))
+	}
+
+	// Servers only register the demo types in dev mode.
+	registry = resource.NewRegistry()
+	registerTypes(registry, false)
+	_, ok := registry.Resolve(demo.TypeV2Artist)
+	require.False(t, ok)
+}
@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"math"
 	"net"
+	"net/http"
 	"regexp"
 	"strings"
 	"sync/atomic"
@@ -38,6 +39,18 @@ var DNSCounters = []prometheus.CounterDefinition{
 		Name: []string{"dns", "stale_queries"},
 		Help: "Increments when an agent serves a query within the allowed stale threshold.",
 	},
+	{
+		Name: []string{"dns", "recursor_cache", "hit"},
+		Help: "Increments when a recursive query is answered from the recursor cache.",
+	},
+	{
+		Name: []string{"dns", "recursor_cache", "miss"},
+		Help: "Increments when a recursive query isn't in the recursor cache and is forwarded to the recursors.",
+	},
+	{
+		Name: []string{"dns", "recursor_cache", "prefetch"},
+		Help: "Increments when a recursor cache entry is refreshed before it expires.",
+	},
 }
 
 var DNSSummaries = []prometheus.SummaryDefinition{
@@ -104,6 +117,11 @@ type dnsConfig struct {
 	TTLStrict          map[string]time.Duration
 	DisableCompression bool
 
+	ZoneTransferAllowedCIDRs []*net.IPNet
+	ZoneTransferToken        string
+
+	DNSSECEnabled bool
+
 	enterpriseDNSConfig
 }
 
@@ -137,9 +155,19 @@ type DNSServer struct {
 	altDomain string
 	logger    hclog.Logger
 
+	// httpServer serves DNS-over-HTTPS requests, it's nil for the other
+	// protocols which are served by the embedded dns.Server.
+	httpServer *http.Server
+
 	// config stores the config as an atomic value (for hot-reloading). It is always of type *dnsConfig
 	config atomic.Value
 
+	// zones keeps the recent versions of the zone served by zone transfers.
+	zones dnsZoneHistory
+
+	// dnssec holds the keys used to sign responses.
+	dnssec dnssecKeyring
+
 	// recursorEnabled stores whever the recursor handler is enabled as an atomic flag.
 	// the recursor handler is only enabled if recursors are configured. This flag is used during config hot-reloading
 	recursorEnabled uint32
@@ -206,7 +234,10 @@ func GetDNSConfig(conf *config.RuntimeConfig) (*dnsConfig, error) {
 			Refresh: conf.DNSSOA.Refresh,
 			Retry:   conf.DNSSOA.Retry,
 		},
-		enterpriseDNSConfig: getEnterpriseDNSConfig(conf),
+		ZoneTransferAllowedCIDRs: conf.DNSZoneTransferAllowedCIDRs,
+		ZoneTransferToken:        conf.DNSZoneTransferToken,
+		DNSSECEnabled:            conf.DNSSECEnabled,
+		enterpriseDNSConfig:      getEnterpriseDNSConfig(conf),
 	}
 	if conf.DNSServiceTTL != nil {
 		cfg.TTLRadix = radix.New()
@@ -251,15 +282,26 @@ func (cfg *dnsConfig) GetTTLForService(service string) (time.Duration, bool) {
 	return 0, false
 }
 
+// ListenAndServe serves DNS queries on the given address. The network may be
+// "udp", "tcp", "tcp-tls" for DNS-over-TLS, or "https" for DNS-over-HTTPS. The
+// encrypted protocols use the agent's HTTPS certificates, which are fetched
+// from the TLS configurator on each handshake so are reloaded along with it.
 func (d *DNSServer) ListenAndServe(network, addr string, notif func()) error {
+	if network == "https" {
+		return d.listenAndServeHTTPS(addr, notif)
+	}
+
 	d.Server = &dns.Server{
 		Addr:              addr,
 		Net:               network,
 		Handler:           d.mux,
 		NotifyStartedFunc: notif,
 	}
-	if network == "udp" {
+	switch network {
+	case "udp":
 		d.UDPSize = 65535
+	case "tcp-tls":
+		d.TLSConfig = dnsTLSConfig(d.agent.tlsConfigurator)
 	}
 	return d.Server.ListenAndServe()
 }
@@ -289,6 +331,7 @@ func (d *DNSServer) ReloadConfig(newCfg *config.RuntimeConfig) error {
 	}
 	d.config.Store(cfg)
 	d.toggleRecursorHandlerFromConfig(cfg)
+	d.zones.invalidate()
 	return nil
 }
 
@@ -520,12 +563,27 @@ func (d *DNSServer) handleQuery(resp dns.ResponseWriter, req *dns.Msg) {
 	m.Authoritative = true
 	m.RecursionAvailable = (len(cfg.Recursors) > 0)
 
+	qType := req.Question[0].Qtype
+	if (qType == dns.TypeAXFR || qType == dns.TypeIXFR) && len(cfg.ZoneTransferAllowedCIDRs) > 0 {
+		d.handleZoneTransfer(cfg, network, resp, req)
+		return
+	}
+
 	var err error
 
-	switch req.Question[0].Qtype {
+	switch qType {
 	case dns.TypeSOA:
 		ns, glue := d.nameservers(req.Question[0].Name, cfg, maxRecursionLevelDefault)
-		m.Answer = append(m.Answer, d.soa(cfg, q.Name))
+		soa := d.soa(cfg, q.Name)
+		// Secondaries compare the serial to that of the zone they transferred.
+		// The catalog isn't read to answer the query, the serial is that of the
+		// most recently built zone.
+		if len(cfg.ZoneTransferAllowedCIDRs) > 0 {
+			if zone := d.cachedZone(cfg, d.getResponseDomain(q.Name)); zone != nil {
+				soa.Serial = zone.soa.Serial
+			}
+		}
+		m.Answer = append(m.Answer, soa)
 		m.Ns = append(m.Ns, ns...)
 		m.Extra = append(m.Extra, glue...)
 		m.SetRcode(req, dns.RcodeSuccess)
@@ -540,7 +598,11 @@ func (d *DNSServer) handleQuery(resp dns.ResponseWriter, req *dns.Msg) {
 		m.SetRcode(req, dns.RcodeNotImplemented)
 
 	default:
-		err = d.dispatch(resp.RemoteAddr(), req, m, maxRecursionLevelDefault)
+		if cfg.DNSSECEnabled && d.isZoneApex(q.Name) && (qType == dns.TypeDNSKEY || qType == dns.TypeNSEC3PARAM) {
+			err = d.dnssecApexQuery(cfg, req, m)
+		} else {
+			err = d.dispatch(resp.RemoteAddr(), req, m, maxRecursionLevelDefault)
+		}
 		rCode := rCodeFromError(err)
 		if rCode == dns.RcodeNameError || errors.Is(err, errNoData) {
 			d.addSOA(cfg, m, q.Name)
@@ -552,6 +614,8 @@ func (d *DNSServer) handleQuery(resp dns.ResponseWriter, req *dns.Msg) {
 
 	d.trimDNSResponse(cfg, network, req, m)
 
+	d.signResponse(cfg, network, req, m)
+
 	if err := resp.WriteMsg(m); err != nil {
 		d.logger.Warn("failed to respond", "error", err)
 	}
@@ -559,7 +623,7 @@ func (d *DNSServer) handleQuery(resp dns.ResponseWriter, req *dns.Msg) {
 
 func (d *DNSServer) soa(cfg *dnsConfig, questionName string) *dns.SOA {
 	domain := d.domain
-	if d.altDomain != "" && strings.HasSuffix(questionName, "."+d.altDomain) {
+	if d.altDomain != "" && strings.HasSuffix("."+questionName, "."+d.altDomain) {
 		domain = d.altDomain
 	}
 
@@ -1203,21 +1267,28 @@ func indexRRs(rrs []dns.RR, index map[string]dns.RR) {
 // syncExtra takes a DNS response message and sets the extra data to the most
 // minimal set needed to cover the answer data. A pre-made index of RRs is given
 // so that can be re-used between calls. This assumes that the extra data is
-// only used to provide info for SRV records. If that's not the case, then this
-// will wipe out any additional data.
+// only used to provide info for SRV, SVCB and HTTPS records. If that's not the
+// case, then this will wipe out any additional data.
 func syncExtra(index map[string]dns.RR, resp *dns.Msg) {
 	extra := make([]dns.RR, 0, len(resp.Answer))
 	resolved := make(map[string]struct{}, len(resp.Answer))
 	for _, ansRR := range resp.Answer {
-		srv, ok := ansRR.(*dns.SRV)
-		if !ok {
+		var target string
+		switch rr := ansRR.(type) {
+		case *dns.SRV:
+			target = rr.Target
+		case *dns.SVCB:
+			target = rr.Target
+		case *dns.HTTPS:
+			target = rr.Target
+		default:
 			continue
 		}
 
 		// Note that we always use lower case when using the index so
 		// that compares are not case-sensitive. We don't alter the actual
 		// RRs we add into the extra section, however.
-		target := strings.ToLower(srv.Target)
+		target = strings.ToLower(target)
 
 	RESOLVE:
 		if _, ok := resolved[target]; ok {
@@ -1466,10 +1537,12 @@ func (d *DNSServer) serviceLookup(cfg *dnsConfig, lookup serviceLookup, req, res
 	ttl, _ := cfg.GetTTLForService(lookup.Service)
 
 	// Add various responses depending on the request
-	qType := req.Question[0].Qtype
-	if qType == dns.TypeSRV {
+	switch req.Question[0].Qtype {
+	case dns.TypeSRV:
 		d.serviceSRVRecords(cfg, lookup, out.Nodes, req, resp, ttl, lookup.MaxRecursionLevel)
-	} else {
+	case dns.TypeSVCB, dns.TypeHTTPS:
+		d.serviceSVCBRecords(cfg, lookup, out.Nodes, req, resp, ttl, lookup.MaxRecursionLevel)
+	default:
 		d.serviceNodeRecords(cfg, lookup, out.Nodes, req, resp, ttl, lookup.MaxRecursionLevel)
 	}
 
@@ -2063,14 +2136,70 @@ func (d *DNSServer) handleRecurse(resp dns.ResponseWriter, req *dns.Msg) {
 		network = "tcp"
 	}
 
-	// Recursively resolve
+	cache := d.agent.dnsRecursorCache
+	if cache.Enabled() {
+		if r, prefetch := cache.Get(req); r != nil {
+			metrics.IncrCounter([]string{"dns", "recursor_cache", "hit"}, 1)
+			if prefetch {
+				metrics.IncrCounter([]string{"dns", "recursor_cache", "prefetch"}, 1)
+				go d.prefetchRecurse(cfg, req.Copy(), network)
+			}
+
+			// The cached response may have been resolved for a client
+			// accepting larger UDP responses than this one.
+			if network == "udp" {
+				r.Truncate(clientUDPSize(req))
+			}
+			r.Compress = !cfg.DisableCompression
+
+			d.logger.Debug("recurse served question from cache", "question", q)
+			if err := resp.WriteMsg(r); err != nil {
+				d.logger.Warn("failed to respond", "error", err)
+			}
+			return
+		}
+		metrics.IncrCounter([]string{"dns", "recursor_cache", "miss"}, 1)
+	}
+
+	if r, ok := d.recurse(cfg, req, network); ok {
+		cache.Set(req, r)
+
+		// Compress the response; we don't know if the incoming
+		// response was compressed or not, so by not compressing
+		// we might generate an invalid packet on the way out.
+		r.Compress = !cfg.DisableCompression
+		if err := resp.WriteMsg(r); err != nil {
+			d.logger.Warn("failed to respond", "error", err)
+		}
+		return
+	}
+
+	// If all resolvers fail, return a SERVFAIL message
+	d.logger.Error("all resolvers failed for question from client",
+		"question", q,
+		"client", resp.RemoteAddr().String(),
+		"client_network", resp.RemoteAddr().Network(),
+	)
+	m := &dns.Msg{}
+	m.SetReply(req)
+	m.Compress = !cfg.DisableCompression
+	m.RecursionAvailable = true
+	m.SetRcode(req, dns.RcodeServerFailure)
+	if edns := req.IsEdns0(); edns != nil {
+		setEDNS(req, m, true)
+	}
+	resp.WriteMsg(m)
+}
+
+// recurse forwards the request to the configured recursors, in the order of
+// the recursor strategy, and returns the first valid response. It returns
+// false if all of the recursors failed.
+func (d *DNSServer) recurse(cfg *dnsConfig, req *dns.Msg, network string) (*dns.Msg, bool) {
+	q := req.Question[0]
 	c := &dns.Client{Net: network, Timeout: cfg.RecursorTimeout}
-	var r *dns.Msg
-	var rtt time.Duration
-	var err error
 	for _, idx := range cfg.RecursorStrategy.Indexes(len(cfg.Recursors)) {
 		recursor := cfg.Recursors[idx]
-		r, rtt, err = c.Exchange(req, recursor)
+		r, rtt, err := c.Exchange(req, recursor)
 		// Check if the response is valid and has the desired Response code
 		if r != nil && (r.Rcode != dns.RcodeSuccess && r.Rcode != dns.RcodeNameError) {
 			d.logger.Debug("recurse failed for question",
@@ -2083,40 +2212,33 @@ func (d *DNSServer) handleRecurse(resp dns.ResponseWriter, req *dns.Msg) {
 			// we move forward onto the next one else the loop ends
 			continue
 		} else if err == nil || (r != nil && r.Truncated) {
-			// Compress the response; we don't know if the incoming
-			// response was compressed or not, so by not compressing
-			// we might generate an invalid packet on the way out.
-			r.Compress = !cfg.DisableCompression
-
-			// Forward the response
 			d.logger.Debug("recurse succeeded for question",
 				"question", q,
 				"rtt", rtt,
 				"recursor", recursor,
 			)
-			if err := resp.WriteMsg(r); err != nil {
-				d.logger.Warn("failed to respond", "error", err)
-			}
-			return
+			return r, true
 		}
 		d.logger.Error("recurse failed", "error", err)
 	}
+	return nil, false
+}
 
-	// If all resolvers fail, return a SERVFAIL message
-	d.logger.Error("all resolvers failed for question from client",
-		"question", q,
-		"client", resp.RemoteAddr().String(),
-		"client_network", resp.RemoteAddr().Network(),
-	)
-	m := &dns.Msg{}
-	m.SetReply(req)
-	m.Compress = !cfg.DisableCompression
-	m.RecursionAvailable = true
-	m.SetRcode(req, dns.RcodeServerFailure)
-	if edns := req.IsEdns0(); edns != nil {
-		setEDNS(req, m, true)
+// prefetchRecurse refreshes a recursor cache entry which is about to expire,
+// so that popular names keep being served from the cache.
+func (d *DNSServer) prefetchRecurse(cfg *dnsConfig, req *dns.Msg, network string) {
+	req.Id = dns.Id()
+	if r, ok := d.recurse(cfg, req, network); ok {
+		d.agent.dnsRecursorCache.Set(req, r)
 	}
-	resp.WriteMsg(m)
+}
+
+// clientUDPSize returns the largest UDP response the client accepts.
+func clientUDPSize(req *dns.Msg) int {
+	if edns := req.IsEdns0(); edns != nil && edns.UDPSize() > dns.MinMsgSize {
+		return int(edns.UDPSize())
+	}
+	return dns.MinMsgSize
 }
 
 // resolveCNAME is used to recursively resolve CNAME records
@@ -0,0 +1,309 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package dns
+
+import (
+	"container/list"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/miekg/dns"
+)
+
+const (
+	// maxRecursorCacheTTL caps how long a positive response is cached for,
+	// regardless of the TTL the recursor returned.
+	maxRecursorCacheTTL = 24 * time.Hour
+
+	// maxRecursorNegativeCacheTTL caps how long NXDOMAIN and NODATA responses
+	// are cached for, RFC 2308 section 5 recommends between one and three
+	// hours.
+	maxRecursorNegativeCacheTTL = 3 * time.Hour
+
+	// recursorCacheEntryOverhead approximates the memory used by an entry on
+	// top of its wire-format message, so that caches full of tiny responses
+	// are still bounded by the configured size.
+	recursorCacheEntryOverhead = 256
+
+	// recursorPrefetchHits is the number of times an entry has to be served
+	// from the cache before it's considered popular enough to prefetch.
+	recursorPrefetchHits = 2
+
+	// recursorPrefetchMinTTL is the shortest TTL that is prefetched, entries
+	// with shorter TTLs expire too quickly for prefetching to pay off.
+	recursorPrefetchMinTTL = 10 * time.Second
+)
+
+// RecursorCache caches the responses of the DNS recursors, honoring the TTLs
+// of the records they return. NXDOMAIN and NODATA responses are cached for
+// the negative TTL of the SOA record in their authority section, as described
+// in RFC 2308. The cache is bounded by the approximate size of the cached
+// messages and evicts the least recently used entries first.
+//
+// A cache with a maximum size of 0 is disabled and caches nothing.
+type RecursorCache struct {
+	lock     sync.Mutex
+	maxSize  int
+	prefetch bool
+	size     int
+	entries  map[recursorCacheKey]*list.Element
+	lru      *list.List
+
+	// now is used to determine the age of entries, it's overridden in tests.
+	now func() time.Time
+}
+
+type recursorCacheKey struct {
+	name  string
+	qtype uint16
+	class uint16
+
+	// do and cd are part of the key because they change which records the
+	// recursor returns.
+	do bool
+	cd bool
+}
+
+type recursorCacheEntry struct {
+	key         recursorCacheKey
+	msg         *dns.Msg
+	size        int
+	stored      time.Time
+	ttl         time.Duration
+	hits        int
+	prefetching bool
+}
+
+// NewRecursorCache returns a cache bounded by maxSize bytes. If prefetch is
+// true, popular entries are reported as needing a refresh shortly before they
+// expire.
+func NewRecursorCache(maxSize int, prefetch bool) *RecursorCache {
+	return &RecursorCache{
+		maxSize:  maxSize,
+		prefetch: prefetch,
+		entries:  make(map[recursorCacheKey]*list.Element),
+		lru:      list.New(),
+		now:      time.Now,
+	}
+}
+
+// Enabled returns whether the cache is enabled.
+func (c *RecursorCache) Enabled() bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.maxSize > 0
+}
+
+// Reload updates the size and prefetch setting of the cache, evicting entries
+// if it was shrunk.
+func (c *RecursorCache) Reload(maxSize int, prefetch bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.maxSize = maxSize
+	c.prefetch = prefetch
+	c.evict()
+}
+
+// Len returns the number of cached responses.
+func (c *RecursorCache) Len() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.lru.Len()
+}
+
+// Get returns the cached response for the request, or nil if there isn't
+// one. The TTLs of the returned records are decremented by the time the
+// response spent in the cache. If prefetch is true the entry is about to
+// expire and the caller should query the recursors again and store the
+// response with Set, only one caller is asked to do so per entry.
+func (c *RecursorCache) Get(req *dns.Msg) (resp *dns.Msg, prefetch bool) {
+	key, ok := recursorCacheKeyFor(req)
+	if !ok {
+		return nil, false
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	elem, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	entry := elem.Value.(*recursorCacheEntry)
+
+	age := c.now().Sub(entry.stored)
+	if age >= entry.ttl {
+		c.remove(elem)
+		return nil, false
+	}
+	c.lru.MoveToFront(elem)
+	entry.hits++
+
+	resp = entry.msg.Copy()
+	resp.Id = req.Id
+	// Echo the question as asked, so clients randomizing the case of the
+	// name (draft-vixie-dnsext-dns0x20) still accept the response.
+	resp.Question = req.Question
+
+	elapsed := uint32(age / time.Second)
+	for _, section := range [][]dns.RR{resp.Answer, resp.Ns, resp.Extra} {
+		for _, rr := range section {
+			hdr := rr.Header()
+			if hdr.Ttl > elapsed {
+				hdr.Ttl -= elapsed
+			} else {
+				hdr.Ttl = 0
+			}
+		}
+	}
+
+	// The recursor's EDNS options were negotiated with the client which
+	// caused the response to be cached, so replace them with ones matching
+	// this request.
+	if edns := req.IsEdns0(); edns != nil {
+		resp.SetEdns0(edns.UDPSize(), edns.Do())
+	}
+
+	if c.prefetch && !entry.prefetching && entry.hits >= recursorPrefetchHits &&
+		entry.ttl >= recursorPrefetchMinTTL && entry.ttl-age <= entry.ttl/10 {
+		entry.prefetching = true
+		prefetch = true
+	}
+	return resp, prefetch
+}
+
+// Set caches the recursor's response to the request, if it's cacheable.
+func (c *RecursorCache) Set(req, resp *dns.Msg) {
+	key, ok := recursorCacheKeyFor(req)
+	if !ok || resp.Truncated {
+		return
+	}
+	ttl, ok := recursorCacheTTL(resp)
+	if !ok {
+		return
+	}
+
+	msg := resp.Copy()
+	msg.Extra = withoutOPT(msg.Extra)
+	entry := &recursorCacheEntry{
+		key:    key,
+		msg:    msg,
+		size:   msg.Len() + len(key.name) + recursorCacheEntryOverhead,
+		stored: c.now(),
+		ttl:    ttl,
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if entry.size > c.maxSize {
+		return
+	}
+	if elem, ok := c.entries[key]; ok {
+		c.remove(elem)
+	}
+	c.entries[key] = c.lru.PushFront(entry)
+	c.size += entry.size
+	c.evict()
+}
+
+// evict removes the least recently used entries until the cache fits in its
+// maximum size. It must be called with the lock held.
+func (c *RecursorCache) evict() {
+	for c.size > c.maxSize {
+		c.remove(c.lru.Back())
+	}
+}
+
+// remove must be called with the lock held.
+func (c *RecursorCache) remove(elem *list.Element) {
+	entry := c.lru.Remove(elem).(*recursorCacheEntry)
+	delete(c.entries, entry.key)
+	c.size -= entry.size
+}
+
+// recursorCacheKeyFor returns the cache key for the request, or false if the
+// response to the request can't be cached.
+func recursorCacheKeyFor(req *dns.Msg) (recursorCacheKey, bool) {
+	if len(req.Question) != 1 {
+		return recursorCacheKey{}, false
+	}
+	q := req.Question[0]
+	key := recursorCacheKey{
+		name:  strings.ToLower(q.Name),
+		qtype: q.Qtype,
+		class: q.Qclass,
+		cd:    req.CheckingDisabled,
+	}
+	if edns := req.IsEdns0(); edns != nil {
+		// Responses to queries with a client subnet may be tailored to that
+		// subnet, so they can't be shared with other clients.
+		for _, opt := range edns.Option {
+			if opt.Option() == dns.EDNS0SUBNET {
+				return recursorCacheKey{}, false
+			}
+		}
+		key.do = edns.Do()
+	}
+	return key, true
+}
+
+// recursorCacheTTL returns how long the response can be cached for, or false
+// if it can't be cached at all.
+func recursorCacheTTL(resp *dns.Msg) (time.Duration, bool) {
+	switch {
+	case resp.Rcode == dns.RcodeNameError,
+		resp.Rcode == dns.RcodeSuccess && len(resp.Answer) == 0:
+		// Negative responses are cached for the minimum of the SOA record's
+		// TTL and its MINIMUM field (RFC 2308 section 5). Negative responses
+		// without an SOA record must not be cached.
+		for _, rr := range resp.Ns {
+			if soa, ok := rr.(*dns.SOA); ok {
+				ttl := soa.Hdr.Ttl
+				if soa.Minttl < ttl {
+					ttl = soa.Minttl
+				}
+				return capTTL(ttl, maxRecursorNegativeCacheTTL)
+			}
+		}
+		return 0, false
+
+	case resp.Rcode == dns.RcodeSuccess:
+		var ttl uint32
+		first := true
+		for _, section := range [][]dns.RR{resp.Answer, resp.Ns, resp.Extra} {
+			for _, rr := range section {
+				if rr.Header().Rrtype == dns.TypeOPT {
+					continue
+				}
+				if first || rr.Header().Ttl < ttl {
+					ttl, first = rr.Header().Ttl, false
+				}
+			}
+		}
+		return capTTL(ttl, maxRecursorCacheTTL)
+
+	default:
+		return 0, false
+	}
+}
+
+func capTTL(ttl uint32, max time.Duration) (time.Duration, bool) {
+	d := time.Duration(ttl) * time.Second
+	if d > max {
+		d = max
+	}
+	return d, d > 0
+}
+
+func withoutOPT(rrs []dns.RR) []dns.RR {
+	out := rrs[:0]
+	for _, rr := range rrs {
+		if rr.Header().Rrtype != dns.TypeOPT {
+			out = append(out, rr)
+		}
+	}
+	return out
+}
@@ -0,0 +1,235 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package dns
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/miekg/dns"
+	"github.com/stretchr/testify/require"
+)
+
+func testRecursorCache(maxSize int) (*RecursorCache, *time.Time) {
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := NewRecursorCache(maxSize, true)
+	c.now = func() time.Time { return now }
+	return c, &now
+}
+
+func testRecursorQuery(name string) *dns.Msg {
+	req := new(dns.Msg)
+	req.SetQuestion(name, dns.TypeA)
+	return req
+}
+
+func testRecursorAnswer(req *dns.Msg, ttl uint32) *dns.Msg {
+	resp := new(dns.Msg)
+	resp.SetReply(req)
+	resp.Answer = []dns.RR{&dns.A{
+		Hdr: dns.RR_Header{Name: req.Question[0].Name, Rrtype: dns.TypeA, Class: dns.ClassINET, Ttl: ttl},
+		A:   net.ParseIP("1.2.3.4"),
+	}}
+	return resp
+}
+
+func testRecursorNegativeAnswer(req *dns.Msg, rcode int, soaTTL, minTTL uint32) *dns.Msg {
+	resp := new(dns.Msg)
+	resp.SetRcode(req, rcode)
+	resp.Ns = []dns.RR{&dns.SOA{
+		Hdr:    dns.RR_Header{Name: "com.", Rrtype: dns.TypeSOA, Class: dns.ClassINET, Ttl: soaTTL},
+		Ns:     "a.gtld-servers.net.",
+		Mbox:   "nstld.verisign-grs.com.",
+		Minttl: minTTL,
+	}}
+	return resp
+}
+
+func TestRecursorCache_TTL(t *testing.T) {
+	c, now := testRecursorCache(1 << 20)
+
+	req := testRecursorQuery("apple.com.")
+	c.Set(req, testRecursorAnswer(req, 60))
+
+	*now = now.Add(20 * time.Second)
+	req = testRecursorQuery("APPLE.com.")
+	resp, _ := c.Get(req)
+	require.NotNil(t, resp)
+	require.Equal(t, req.Id, resp.Id)
+	require.Equal(t, "APPLE.com.", resp.Question[0].Name)
+	require.Equal(t, uint32(40), resp.Answer[0].Header().Ttl)
+
+	*now = now.Add(40 * time.Second)
+	resp, _ = c.Get(req)
+	require.Nil(t, resp)
+	require.Equal(t, 0, c.Len())
+}
+
+func TestRecursorCache_Negative(t *testing.T) {
+	c, now := testRecursorCache(1 << 20)
+
+	// NXDOMAIN is cached for the minimum of the SOA TTL and MINIMUM field.
+	nxdomain := testRecursorQuery("missing.com.")
+	c.Set(nxdomain, testRecursorNegativeAnswer(nxdomain, dns.RcodeNameError, 900, 60))
+
+	// NODATA is cached the same way.
+	nodata := testRecursorQuery("nodata.com.")
+	c.Set(nodata, testRecursorNegativeAnswer(nodata, dns.RcodeSuccess, 30, 600))
+
+	// Negative responses without an SOA record aren't cached.
+	noSOA := testRecursorQuery("nosoa.com.")
+	resp := new(dns.Msg)
+	resp.SetRcode(noSOA, dns.RcodeNameError)
+	c.Set(noSOA, resp)
+
+	// Nor are failures.
+	servfail := testRecursorQuery("servfail.com.")
+	resp = new(dns.Msg)
+	resp.SetRcode(servfail, dns.RcodeServerFailure)
+	c.Set(servfail, resp)
+
+	require.Equal(t, 2, c.Len())
+
+	*now = now.Add(29 * time.Second)
+	resp, _ = c.Get(nxdomain)
+	require.NotNil(t, resp)
+	require.Equal(t, dns.RcodeNameError, resp.Rcode)
+	resp, _ = c.Get(nodata)
+	require.NotNil(t, resp)
+	require.Equal(t, uint32(1), resp.Ns[0].Header().Ttl)
+
+	*now = now.Add(time.Second)
+	resp, _ = c.Get(nodata)
+	require.Nil(t, resp)
+
+	*now = now.Add(30 * time.Second)
+	resp, _ = c.Get(nxdomain)
+	require.Nil(t, resp)
+}
+
+func TestRecursorCache_Uncacheable(t *testing.T) {
+	c, _ := testRecursorCache(1 << 20)
+
+	// Zero TTLs must not be cached.
+	req := testRecursorQuery("zero.com.")
+	c.Set(req, testRecursorAnswer(req, 0))
+
+	// Truncated responses are incomplete.
+	req = testRecursorQuery("truncated.com.")
+	resp := testRecursorAnswer(req, 60)
+	resp.Truncated = true
+	c.Set(req, resp)
+
+	// Responses to queries with a client subnet may be specific to it.
+	req = testRecursorQuery("subnet.com.")
+	req.SetEdns0(4096, false)
+	opt := req.IsEdns0()
+	opt.Option = append(opt.Option, &dns.EDNS0_SUBNET{
+		Code:          dns.EDNS0SUBNET,
+		Family:        1,
+		SourceNetmask: 24,
+		Address:       net.ParseIP("10.0.0.0").To4(),
+	})
+	c.Set(req, testRecursorAnswer(req, 60))
+
+	require.Equal(t, 0, c.Len())
+}
+
+func TestRecursorCache_EDNS(t *testing.T) {
+	c, _ := testRecursorCache(1 << 20)
+
+	req := testRecursorQuery("apple.com.")
+	req.SetEdns0(4096, true)
+	resp := testRecursorAnswer(req, 60)
+	resp.SetEdns0(1232, true)
+	c.Set(req, resp)
+
+	// The DO bit is part of the key.
+	cached, _ := c.Get(testRecursorQuery("apple.com."))
+	require.Nil(t, cached)
+
+	// The OPT record matches the request rather than the cached response.
+	req = testRecursorQuery("apple.com.")
+	req.SetEdns0(2048, true)
+	cached, _ = c.Get(req)
+	require.NotNil(t, cached)
+	opt := cached.IsEdns0()
+	require.NotNil(t, opt)
+	require.Equal(t, uint16(2048), opt.UDPSize())
+	require.True(t, opt.Do())
+}
+
+func TestRecursorCache_Eviction(t *testing.T) {
+	req := testRecursorQuery("a.com.")
+	entrySize := testRecursorAnswer(req, 60).Len() + len("a.com.") + recursorCacheEntryOverhead
+	c, _ := testRecursorCache(2 * entrySize)
+
+	for _, name := range []string{"a.com.", "b.com."} {
+		req := testRecursorQuery(name)
+		c.Set(req, testRecursorAnswer(req, 60))
+	}
+
+	// Using a.com makes b.com the least recently used entry.
+	resp, _ := c.Get(testRecursorQuery("a.com."))
+	require.NotNil(t, resp)
+
+	req = testRecursorQuery("c.com.")
+	c.Set(req, testRecursorAnswer(req, 60))
+	require.Equal(t, 2, c.Len())
+
+	resp, _ = c.Get(testRecursorQuery("b.com."))
+	require.Nil(t, resp)
+	resp, _ = c.Get(testRecursorQuery("a.com."))
+	require.NotNil(t, resp)
+
+	// Shrinking the cache evicts entries, and a size of 0 disables it.
+	c.Reload(entrySize, true)
+	require.Equal(t, 1, c.Len())
+	c.Reload(0, true)
+	require.Equal(t, 0, c.Len())
+	require.False(t, c.Enabled())
+
+	req = testRecursorQuery("d.com.")
+	c.Set(req, testRecursorAnswer(req, 60))
+	require.Equal(t, 0, c.Len())
+}
+
+func TestRecursorCache_Prefetch(t *testing.T) {
+	c, now := testRecursorCache(1 << 20)
+
+	req := testRecursorQuery("apple.com.")
+	c.Set(req, testRecursorAnswer(req, 100))
+
+	// Popular entries are prefetched once they are within 10% of their TTL.
+	_, prefetch := c.Get(req)
+	require.False(t, prefetch)
+
+	*now = now.Add(91 * time.Second)
+	_, prefetch = c.Get(req)
+	require.True(t, prefetch)
+
+	// Only one caller is asked to prefetch.
+	_, prefetch = c.Get(req)
+	require.False(t, prefetch)
+
+	// Until the entry is refreshed.
+	c.Set(req, testRecursorAnswer(req, 100))
+	c.Get(req)
+	*now = now.Add(95 * time.Second)
+	_, prefetch = c.Get(req)
+	require.True(t, prefetch)
+
+	// Entries which aren't popular aren't prefetched.
+	req = testRecursorQuery("banana.com.")
+	c.Set(req, testRecursorAnswer(req, 100))
+	*now = now.Add(95 * time.Second)
+	_, prefetch = c.Get(req)
+	require.False(t, prefetch)
+
+	// Nor is anything when prefetching is disabled.
+	c.Reload(1<<20, false)
+	_, prefetch = c.Get(req)
+	require.False(t, prefetch)
+}
@@ -0,0 +1,375 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package agent
+
+import (
+	"context"
+	"crypto"
+	"encoding/base32"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/miekg/dns"
+
+	cachetype "github.com/hashicorp/consul/agent/cache-types"
+	"github.com/hashicorp/consul/agent/structs"
+)
+
+const (
+	// dnssecKeyTTL is the TTL of the DNSKEY records. The servers publish new
+	// keys for longer than this before they use them, so it can't be
+	// increased without also increasing their pre-publish delay.
+	dnssecKeyTTL = time.Hour
+
+	// dnssecSignatureInception backdates the signatures to account for
+	// resolvers whose clock is behind.
+	dnssecSignatureInception = time.Hour
+
+	// dnssecSignatureValidity is how long signatures are valid for. The
+	// servers keep publishing retired keys for as long, so that all the
+	// signatures made with them remain verifiable.
+	dnssecSignatureValidity = 24 * time.Hour
+)
+
+var (
+	// dnssecApexTypes are the types listed by the NSEC3 records matching the
+	// apex of the zone.
+	dnssecApexTypes = []uint16{dns.TypeNS, dns.TypeSOA, dns.TypeRRSIG, dns.TypeDNSKEY, dns.TypeNSEC3PARAM}
+
+	// dnssecNameTypes are the types listed by the NSEC3 records matching the
+	// other names. The records of a name depend on the query, so this lists
+	// all the types which are served.
+	dnssecNameTypes = []uint16{dns.TypeA, dns.TypeTXT, dns.TypeAAAA, dns.TypeSRV, dns.TypeRRSIG, dns.TypeSVCB, dns.TypeHTTPS}
+
+	nsec3HashEncoding = base32.HexEncoding.WithPadding(base32.NoPadding)
+)
+
+// dnssecKey is a DNSSEC key ready to be used by the DNS server.
+type dnssecKey struct {
+	active bool
+
+	// dnskey is the DNSKEY record of the key, without an owner name since
+	// the same keys are used for the domain and the alt domain.
+	dnskey *dns.DNSKEY
+	signer crypto.Signer
+}
+
+// dnssecKeyring holds the parsed DNSSEC keys, parsing them again only when
+// they changed.
+type dnssecKeyring struct {
+	lock  sync.Mutex
+	index uint64
+	keys  []*dnssecKey
+}
+
+// dnssecKeys returns the DNSSEC keys currently published. The keys are
+// managed by the servers of the primary datacenter and fetched through the
+// agent cache, which keeps them up to date with a blocking query.
+func (d *DNSServer) dnssecKeys() ([]*dnssecKey, error) {
+	req := &structs.DCSpecificRequest{
+		Datacenter:   d.agent.config.PrimaryDatacenter,
+		QueryOptions: structs.QueryOptions{Token: d.agent.tokens.AgentToken()},
+	}
+	raw, meta, err := d.agent.cache.Get(context.TODO(), cachetype.DNSSECKeysName, req)
+	if err != nil {
+		return nil, err
+	}
+	reply, ok := raw.(*structs.IndexedDNSSECKeys)
+	if !ok {
+		return nil, fmt.Errorf("internal error: response type not correct")
+	}
+
+	d.dnssec.lock.Lock()
+	defer d.dnssec.lock.Unlock()
+	if d.dnssec.keys != nil && d.dnssec.index == meta.Index {
+		return d.dnssec.keys, nil
+	}
+
+	keys := make([]*dnssecKey, 0, len(reply.Keys))
+	for _, k := range reply.Keys {
+		dnskey := &dns.DNSKEY{
+			Hdr:       dns.RR_Header{Rrtype: dns.TypeDNSKEY, Class: dns.ClassINET, Ttl: uint32(dnssecKeyTTL / time.Second)},
+			Flags:     dns.ZONE | dns.SEP,
+			Protocol:  3,
+			Algorithm: k.Algorithm,
+			PublicKey: k.PublicKey,
+		}
+		privateKey, err := dnskey.NewPrivateKey(k.PrivateKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse DNSSEC key %s: %w", k.ID, err)
+		}
+		signer, ok := privateKey.(crypto.Signer)
+		if !ok {
+			return nil, fmt.Errorf("DNSSEC key %s can't be used for signing", k.ID)
+		}
+		keys = append(keys, &dnssecKey{active: k.Active, dnskey: dnskey, signer: signer})
+	}
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("no DNSSEC keys available")
+	}
+
+	d.dnssec.index, d.dnssec.keys = meta.Index, keys
+	return keys, nil
+}
+
+// dnssecApexQuery answers DNSKEY and NSEC3PARAM queries for the apex of the
+// zone.
+func (d *DNSServer) dnssecApexQuery(cfg *dnsConfig, req, resp *dns.Msg) error {
+	q := req.Question[0]
+	zone := d.getResponseDomain(q.Name)
+
+	switch q.Qtype {
+	case dns.TypeDNSKEY:
+		keys, err := d.dnssecKeys()
+		if err != nil {
+			return err
+		}
+		for _, key := range keys {
+			dnskey := *key.dnskey
+			dnskey.Hdr.Name = zone
+			resp.Answer = append(resp.Answer, &dnskey)
+		}
+
+	case dns.TypeNSEC3PARAM:
+		resp.Answer = append(resp.Answer, &dns.NSEC3PARAM{
+			Hdr:  dns.RR_Header{Name: zone, Rrtype: dns.TypeNSEC3PARAM, Class: dns.ClassINET, Ttl: cfg.SOAConfig.Minttl},
+			Hash: dns.SHA1,
+		})
+	}
+	return nil
+}
+
+// isZoneApex returns whether the name is the apex of the domain or the alt
+// domain.
+func (d *DNSServer) isZoneApex(name string) bool {
+	return strings.EqualFold(name, d.domain) || (d.altDomain != "." && strings.EqualFold(name, d.altDomain))
+}
+
+// signResponse signs the records of the response when the client asked for
+// DNSSEC records by setting the DO bit. Negative responses get NSEC3 records
+// proving that the name or the records don't exist.
+//
+// The NSEC3 records are minimally covering "white lies" (RFC 7129 section
+// 5.2), which cover only the hash of the name queried, as the names of the
+// zone are synthesized from the catalog and can't be listed ahead of time.
+func (d *DNSServer) signResponse(cfg *dnsConfig, network string, req, resp *dns.Msg) {
+	edns := req.IsEdns0()
+	if !cfg.DNSSECEnabled || edns == nil || !edns.Do() {
+		return
+	}
+
+	keys, err := d.dnssecKeys()
+	if err != nil {
+		d.logger.Warn("Unable to sign DNS response", "error", err)
+		return
+	}
+
+	q := req.Question[0]
+	zone := d.getResponseDomain(q.Name)
+
+	switch {
+	case resp.Rcode == dns.RcodeNameError && d.isZoneApex(q.Name):
+		// The apex always exists, the denial of existence proofs can't
+		// show otherwise.
+		resp.Rcode = dns.RcodeSuccess
+		resp.Ns = append(resp.Ns, nsec3Matching(q.Name, zone, withoutType(dnssecApexTypes, q.Qtype), cfg.SOAConfig.Minttl))
+
+	case resp.Rcode == dns.RcodeNameError:
+		// The closest encloser is assumed to be the parent of the name, which
+		// proves that neither the name nor a wildcard at the closest encloser
+		// exist (RFC 5155 section 7.2.2).
+		encloser := parentName(q.Name)
+		types := dnssecNameTypes
+		if d.isZoneApex(encloser) {
+			types = dnssecApexTypes
+		}
+		resp.Ns = append(resp.Ns,
+			nsec3Matching(encloser, zone, types, cfg.SOAConfig.Minttl),
+			nsec3Covering(q.Name, zone, cfg.SOAConfig.Minttl),
+			nsec3Covering("*."+encloser, zone, cfg.SOAConfig.Minttl),
+		)
+
+	case resp.Rcode == dns.RcodeSuccess && len(resp.Answer) == 0:
+		types := dnssecNameTypes
+		if d.isZoneApex(q.Name) {
+			types = dnssecApexTypes
+		}
+		resp.Ns = append(resp.Ns, nsec3Matching(q.Name, zone, withoutType(types, q.Qtype), cfg.SOAConfig.Minttl))
+	}
+
+	now := time.Now()
+	inception := uint32(now.Add(-dnssecSignatureInception).Unix())
+	expiration := uint32(now.Add(dnssecSignatureValidity).Unix())
+
+	sign := func(rrs []dns.RR) []dns.RR {
+		for _, rrset := range dnssecRRsets(rrs, zone) {
+			for _, key := range keys {
+				// The DNSKEY RRset is also signed by the keys which are published
+				// but not active, so that it can be validated using whichever key
+				// the DS record of the parent zone refers to during a rotation.
+				if !key.active && rrset[0].Header().Rrtype != dns.TypeDNSKEY {
+					continue
+				}
+				sig := &dns.RRSIG{
+					Hdr:        dns.RR_Header{Ttl: rrset[0].Header().Ttl},
+					KeyTag:     key.dnskey.KeyTag(),
+					SignerName: zone,
+					Algorithm:  key.dnskey.Algorithm,
+					Inception:  inception,
+					Expiration: expiration,
+				}
+				if err := sig.Sign(key.signer, rrset); err != nil {
+					d.logger.Warn("Unable to sign DNS records", "name", rrset[0].Header().Name, "error", err)
+					continue
+				}
+				rrs = append(rrs, sig)
+			}
+		}
+		return rrs
+	}
+	resp.Answer = sign(resp.Answer)
+	resp.Ns = sign(resp.Ns)
+
+	// The OPT record is kept last, after the signatures of the additional
+	// records.
+	opt := resp.IsEdns0()
+	extra := make([]dns.RR, 0, len(resp.Extra))
+	for _, rr := range resp.Extra {
+		if rr != opt {
+			extra = append(extra, rr)
+		}
+	}
+	resp.Extra = sign(extra)
+	if opt != nil {
+		opt.SetDo()
+		resp.Extra = append(resp.Extra, opt)
+	}
+
+	// The signatures may make the response larger than the client accepts.
+	if network != "tcp" {
+		if size := clientUDPSize(req); resp.Len() > size {
+			resp.Truncate(size)
+		}
+	}
+}
+
+// dnssecRRsets groups the records of the zone into RRsets, which are signed
+// as a whole. The TTLs of the records of each RRset are set to the lowest of
+// them since they must be identical (RFC 2181 section 5.2).
+func dnssecRRsets(rrs []dns.RR, zone string) [][]dns.RR {
+	type rrsetKey struct {
+		name  string
+		class uint16
+		typ   uint16
+	}
+
+	var keys []rrsetKey
+	rrsets := make(map[rrsetKey][]dns.RR)
+	for _, rr := range rrs {
+		hdr := rr.Header()
+		if hdr.Rrtype == dns.TypeOPT || hdr.Rrtype == dns.TypeRRSIG || !dns.IsSubDomain(zone, strings.ToLower(hdr.Name)) {
+			continue
+		}
+		key := rrsetKey{name: strings.ToLower(hdr.Name), class: hdr.Class, typ: hdr.Rrtype}
+		if _, ok := rrsets[key]; !ok {
+			keys = append(keys, key)
+		}
+		rrsets[key] = append(rrsets[key], rr)
+	}
+
+	out := make([][]dns.RR, 0, len(keys))
+	for _, key := range keys {
+		rrset := rrsets[key]
+		ttl := rrset[0].Header().Ttl
+		for _, rr := range rrset {
+			if rr.Header().Ttl < ttl {
+				ttl = rr.Header().Ttl
+			}
+		}
+		for _, rr := range rrset {
+			rr.Header().Ttl = ttl
+		}
+		out = append(out, rrset)
+	}
+	return out
+}
+
+// nsec3Matching returns an NSEC3 record proving that the name exists and only
+// has records of the given types.
+func nsec3Matching(name, zone string, types []uint16, ttl uint32) *dns.NSEC3 {
+	hash := nsec3Hash(name)
+	return newNSEC3(hash, nsec3HashAdd(hash, 1), zone, types, ttl)
+}
+
+// nsec3Covering returns an NSEC3 record proving that the name doesn't exist,
+// by covering the interval between the hash of the name minus one and plus
+// one.
+func nsec3Covering(name, zone string, ttl uint32) *dns.NSEC3 {
+	hash := nsec3Hash(name)
+	return newNSEC3(nsec3HashAdd(hash, -1), nsec3HashAdd(hash, 1), zone, nil, ttl)
+}
+
+func newNSEC3(owner, next []byte, zone string, types []uint16, ttl uint32) *dns.NSEC3 {
+	sorted := append([]uint16(nil), types...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	return &dns.NSEC3{
+		Hdr: dns.RR_Header{
+			Name:   strings.ToLower(nsec3HashEncoding.EncodeToString(owner)) + "." + zone,
+			Rrtype: dns.TypeNSEC3,
+			Class:  dns.ClassINET,
+			Ttl:    ttl,
+		},
+		Hash:       dns.SHA1,
+		HashLength: uint8(len(next)),
+		NextDomain: nsec3HashEncoding.EncodeToString(next),
+		TypeBitMap: sorted,
+	}
+}
+
+// nsec3Hash returns the NSEC3 hash of the name, without salt nor additional
+// iterations as recommended by RFC 9276.
+func nsec3Hash(name string) []byte {
+	hash, _ := nsec3HashEncoding.DecodeString(dns.HashName(name, dns.SHA1, 0, ""))
+	return hash
+}
+
+// nsec3HashAdd returns the hash plus delta, wrapping around like the hashed
+// names of the zone.
+func nsec3HashAdd(hash []byte, delta int) []byte {
+	out := append([]byte(nil), hash...)
+	for i := len(out) - 1; i >= 0; i-- {
+		sum := int(out[i]) + delta
+		out[i] = byte(sum)
+		switch {
+		case sum > 0xff:
+			delta = 1
+		case sum < 0:
+			delta = -1
+		default:
+			return out
+		}
+	}
+	return out
+}
+
+func parentName(name string) string {
+	if i, end := dns.NextLabel(name, 0); !end {
+		return name[i:]
+	}
+	return "."
+}
+
+func withoutType(types []uint16, qtype uint16) []uint16 {
+	out := make([]uint16, 0, len(types))
+	for _, t := range types {
+		if t != qtype {
+			out = append(out, t)
+		}
+	}
+	return out
+}
@@ -0,0 +1,283 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/miekg/dns"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/consul/agent/consul"
+	"github.com/hashicorp/consul/agent/structs"
+	"github.com/hashicorp/consul/sdk/testutil/retry"
+	"github.com/hashicorp/consul/testrpc"
+)
+
+func TestDNS_DNSSEC(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+	a := NewTestAgent(t, `
+		dns_config {
+			dnssec {
+				enabled = true
+			}
+		}
+	`)
+	defer a.Shutdown()
+	testrpc.WaitForLeader(t, a.RPC, "dc1")
+
+	args := &structs.RegisterRequest{
+		Datacenter: "dc1",
+		Node:       "foo",
+		Address:    "127.0.0.1",
+	}
+	var out struct{}
+	require.NoError(t, a.RPC(context.Background(), "Catalog.Register", args, &out))
+
+	query := func(t require.TestingT, name string, qtype uint16, do bool) *dns.Msg {
+		m := new(dns.Msg)
+		m.SetQuestion(name, qtype)
+		m.SetEdns0(4096, do)
+		in, _, err := new(dns.Client).Exchange(m, a.DNSAddr())
+		require.NoError(t, err)
+		return in
+	}
+
+	// The keys are generated by the leader shortly after it's elected.
+	var dnskeys []dns.RR
+	retry.Run(t, func(r *retry.R) {
+		in := query(r, "consul.", dns.TypeDNSKEY, true)
+		require.Equal(r, dns.RcodeSuccess, in.Rcode)
+		dnskeys = rrsOfType(in.Answer, dns.TypeDNSKEY)
+		require.Len(r, dnskeys, 1)
+	})
+	dnskey := dnskeys[0].(*dns.DNSKEY)
+	require.Equal(t, "consul.", dnskey.Hdr.Name)
+	require.Equal(t, uint16(dns.ZONE|dns.SEP), dnskey.Flags)
+
+	// verify checks that each RRset of the section is signed by the key.
+	verify := func(t *testing.T, section []dns.RR) {
+		t.Helper()
+		sigs := rrsOfType(section, dns.TypeRRSIG)
+		require.NotEmpty(t, sigs)
+		for _, rrset := range dnssecRRsets(section, "consul.") {
+			var signed bool
+			for _, rr := range sigs {
+				sig := rr.(*dns.RRSIG)
+				if sig.TypeCovered != rrset[0].Header().Rrtype || sig.Hdr.Name != rrset[0].Header().Name {
+					continue
+				}
+				require.Equal(t, dnskey.KeyTag(), sig.KeyTag)
+				require.Equal(t, "consul.", sig.SignerName)
+				require.True(t, sig.ValidityPeriod(time.Now()))
+				require.NoError(t, sig.Verify(dnskey, rrset))
+				signed = true
+			}
+			require.True(t, signed, "%s %s isn't signed", rrset[0].Header().Name, dns.Type(rrset[0].Header().Rrtype))
+		}
+	}
+
+	t.Run("dnskey", func(t *testing.T) {
+		in := query(t, "consul.", dns.TypeDNSKEY, true)
+		verify(t, in.Answer)
+		require.True(t, in.IsEdns0().Do())
+	})
+
+	t.Run("positive answer", func(t *testing.T) {
+		in := query(t, "foo.node.consul.", dns.TypeA, true)
+		require.Equal(t, dns.RcodeSuccess, in.Rcode)
+		require.Len(t, rrsOfType(in.Answer, dns.TypeA), 1)
+		verify(t, in.Answer)
+	})
+
+	t.Run("soa", func(t *testing.T) {
+		in := query(t, "consul.", dns.TypeSOA, true)
+		verify(t, in.Answer)
+		verify(t, in.Ns)
+	})
+
+	t.Run("nsec3param", func(t *testing.T) {
+		in := query(t, "consul.", dns.TypeNSEC3PARAM, true)
+		require.Len(t, rrsOfType(in.Answer, dns.TypeNSEC3PARAM), 1)
+		verify(t, in.Answer)
+	})
+
+	t.Run("nxdomain", func(t *testing.T) {
+		in := query(t, "nope.node.consul.", dns.TypeA, true)
+		require.Equal(t, dns.RcodeNameError, in.Rcode)
+		verify(t, in.Ns)
+
+		nsec3s := rrsOfType(in.Ns, dns.TypeNSEC3)
+		require.Len(t, nsec3s, 3)
+		matches := func(name string) bool {
+			for _, rr := range nsec3s {
+				if rr.(*dns.NSEC3).Match(name) {
+					return true
+				}
+			}
+			return false
+		}
+		covers := func(name string) bool {
+			for _, rr := range nsec3s {
+				if rr.(*dns.NSEC3).Cover(name) {
+					return true
+				}
+			}
+			return false
+		}
+		require.True(t, matches("node.consul."))
+		require.True(t, covers("nope.node.consul."))
+		require.True(t, covers("*.node.consul."))
+		require.False(t, covers("foo.node.consul."))
+	})
+
+	t.Run("nodata", func(t *testing.T) {
+		in := query(t, "foo.node.consul.", dns.TypeAAAA, true)
+		require.Equal(t, dns.RcodeSuccess, in.Rcode)
+		require.Empty(t, in.Answer)
+		verify(t, in.Ns)
+
+		nsec3s := rrsOfType(in.Ns, dns.TypeNSEC3)
+		require.Len(t, nsec3s, 1)
+		nsec3 := nsec3s[0].(*dns.NSEC3)
+		require.True(t, nsec3.Match("foo.node.consul."))
+		require.NotContains(t, nsec3.TypeBitMap, dns.TypeAAAA)
+		require.Contains(t, nsec3.TypeBitMap, dns.TypeA)
+	})
+
+	t.Run("without the DO bit", func(t *testing.T) {
+		in := query(t, "foo.node.consul.", dns.TypeA, false)
+		require.Empty(t, rrsOfType(in.Answer, dns.TypeRRSIG))
+		require.False(t, in.IsEdns0().Do())
+	})
+}
+
+func TestDNS_DNSSEC_Rotation(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+	a := NewTestAgent(t, `
+		dns_config {
+			dnssec {
+				enabled = true
+			}
+		}
+	`)
+	defer a.Shutdown()
+	testrpc.WaitForLeader(t, a.RPC, "dc1")
+
+	server, ok := a.delegate.(*consul.Server)
+	require.True(t, ok)
+
+	// Replace the keys with a retired key, whose DS record the parent zone may
+	// still refer to, and the active key replacing it.
+	now := time.Now()
+	newKey := func(t *testing.T) (*structs.DNSSECKey, *dns.DNSKEY) {
+		dnskey := &dns.DNSKEY{
+			Hdr:       dns.RR_Header{Name: "consul.", Rrtype: dns.TypeDNSKEY, Class: dns.ClassINET},
+			Flags:     dns.ZONE | dns.SEP,
+			Protocol:  3,
+			Algorithm: dns.ECDSAP256SHA256,
+		}
+		privateKey, err := dnskey.Generate(256)
+		require.NoError(t, err)
+		return &structs.DNSSECKey{
+			ID:         dnskey.PublicKey,
+			KeyTag:     dnskey.KeyTag(),
+			Algorithm:  dnskey.Algorithm,
+			PublicKey:  dnskey.PublicKey,
+			PrivateKey: dnskey.PrivateKeyString(privateKey),
+			CreatedAt:  now,
+		}, dnskey
+	}
+	retired, retiredDNSKEY := newKey(t)
+	retired.ActivatedAt, retired.RetiredAt = now.Add(-time.Hour), now
+	active, activeDNSKEY := newKey(t)
+	active.Active, active.ActivatedAt = true, now
+
+	raw, err := json.Marshal([]*structs.DNSSECKey{retired, active})
+	require.NoError(t, err)
+	require.NoError(t, server.SetSystemMetadataKey(structs.SystemMetadataDNSSECKeys, string(raw)))
+
+	query := func(t require.TestingT, name string, qtype uint16) *dns.Msg {
+		m := new(dns.Msg)
+		m.SetQuestion(name, qtype)
+		m.SetEdns0(4096, true)
+		in, _, err := new(dns.Client).Exchange(m, a.DNSAddr())
+		require.NoError(t, err)
+		return in
+	}
+
+	var in *dns.Msg
+	retry.Run(t, func(r *retry.R) {
+		in = query(r, "consul.", dns.TypeDNSKEY)
+		require.Len(r, rrsOfType(in.Answer, dns.TypeDNSKEY), 2)
+	})
+
+	// The DNSKEY RRset is signed by both keys.
+	dnskeys := rrsOfType(in.Answer, dns.TypeDNSKEY)
+	sigs := rrsOfType(in.Answer, dns.TypeRRSIG)
+	require.Len(t, sigs, 2)
+	for _, key := range []*dns.DNSKEY{retiredDNSKEY, activeDNSKEY} {
+		var signed bool
+		for _, rr := range sigs {
+			if sig := rr.(*dns.RRSIG); sig.KeyTag == key.KeyTag() {
+				require.NoError(t, sig.Verify(key, dnskeys))
+				signed = true
+			}
+		}
+		require.True(t, signed, "DNSKEY RRset isn't signed by key %d", key.KeyTag())
+	}
+
+	// Other RRsets are only signed by the active key.
+	in = query(t, "consul.", dns.TypeSOA)
+	sigs = rrsOfType(in.Answer, dns.TypeRRSIG)
+	require.Len(t, sigs, 1)
+	require.Equal(t, activeDNSKEY.KeyTag(), sigs[0].(*dns.RRSIG).KeyTag)
+}
+
+func TestDNS_DNSSEC_Disabled(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+	a := NewTestAgent(t, "")
+	defer a.Shutdown()
+	testrpc.WaitForLeader(t, a.RPC, "dc1")
+
+	m := new(dns.Msg)
+	m.SetQuestion("consul.", dns.TypeSOA)
+	m.SetEdns0(4096, true)
+	in, _, err := new(dns.Client).Exchange(m, a.DNSAddr())
+	require.NoError(t, err)
+	require.NotEmpty(t, in.Answer)
+	require.Empty(t, rrsOfType(in.Answer, dns.TypeRRSIG))
+}
+
+func TestNSEC3HashAdd(t *testing.T) {
+	require.Equal(t, []byte{0x01, 0x00}, nsec3HashAdd([]byte{0x00, 0xff}, 1))
+	require.Equal(t, []byte{0x00, 0xff}, nsec3HashAdd([]byte{0x01, 0x00}, -1))
+	require.Equal(t, []byte{0x00, 0x00}, nsec3HashAdd([]byte{0xff, 0xff}, 1))
+	require.Equal(t, []byte{0xff, 0xff}, nsec3HashAdd([]byte{0x00, 0x00}, -1))
+}
+
+func rrsOfType(rrs []dns.RR, rrtype uint16) []dns.RR {
+	var out []dns.RR
+	for _, rr := range rrs {
+		if rr.Header().Rrtype == rrtype {
+			out = append(out, rr)
+		}
+	}
+	return out
+}
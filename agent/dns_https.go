@@ -0,0 +1,217 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package agent
+
+import (
+	"crypto/tls"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/miekg/dns"
+
+	"github.com/hashicorp/consul/tlsutil"
+)
+
+const (
+	// dohPath is the path DNS-over-HTTPS queries are served on, it's the path
+	// used in the examples in RFC 8484 and expected by most clients.
+	dohPath = "/dns-query"
+
+	// dohMediaType is the media type of DNS-over-HTTPS requests and responses.
+	dohMediaType = "application/dns-message"
+
+	// dohReadTimeout bounds how long a DNS-over-HTTPS client has to send its
+	// request, so idle clients can't hold connections open indefinitely.
+	dohReadTimeout = 10 * time.Second
+
+	// dohWriteTimeout bounds how long a query can take to be answered,
+	// including the time to resolve it and to write the response, so slow
+	// clients can't hold connections open indefinitely either.
+	dohWriteTimeout = 30 * time.Second
+
+	// dohIdleTimeout is how long a keep-alive connection is kept open waiting
+	// for the next request.
+	dohIdleTimeout = 60 * time.Second
+)
+
+// dnsTLSConfig returns the TLS config for the DNS-over-TLS server. It uses the
+// HTTPS certificates but negotiates the "dot" ALPN protocol (RFC 7858) rather
+// than HTTP.
+func dnsTLSConfig(c *tlsutil.Configurator) *tls.Config {
+	config := c.IncomingHTTPSConfig()
+	config.NextProtos = []string{"dot"}
+	config.GetConfigForClient = func(*tls.ClientHelloInfo) (*tls.Config, error) {
+		return dnsTLSConfig(c), nil
+	}
+	return config
+}
+
+// listenAndServeHTTPS serves DNS-over-HTTPS (RFC 8484) queries on the given
+// address. The queries are dispatched to the same handlers as the other
+// protocols.
+func (d *DNSServer) listenAndServeHTTPS(addr string, notif func()) error {
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(dohPath, d.handleHTTPS)
+
+	tlsConfig := d.agent.tlsConfigurator.IncomingHTTPSConfig()
+	d.httpServer = &http.Server{
+		Addr:         ln.Addr().String(),
+		Handler:      mux,
+		TLSConfig:    tlsConfig,
+		ReadTimeout:  dohReadTimeout,
+		WriteTimeout: dohWriteTimeout,
+		IdleTimeout:  dohIdleTimeout,
+	}
+
+	if notif != nil {
+		notif()
+	}
+
+	err = d.httpServer.Serve(tls.NewListener(ln, tlsConfig))
+	if errors.Is(err, http.ErrServerClosed) {
+		return nil
+	}
+	return err
+}
+
+// handleHTTPS handles a DNS-over-HTTPS query, which is either a GET request
+// with the base64url-encoded DNS message in the "dns" query parameter, or a
+// POST request with the DNS message as its body.
+func (d *DNSServer) handleHTTPS(w http.ResponseWriter, r *http.Request) {
+	var (
+		packed []byte
+		err    error
+	)
+	switch r.Method {
+	case http.MethodGet:
+		query := r.URL.Query().Get("dns")
+		if query == "" {
+			http.Error(w, "missing dns query parameter", http.StatusBadRequest)
+			return
+		}
+		packed, err = base64.RawURLEncoding.DecodeString(query)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("invalid dns query parameter: %v", err), http.StatusBadRequest)
+			return
+		}
+
+	case http.MethodPost:
+		if contentType := r.Header.Get("Content-Type"); contentType != dohMediaType {
+			http.Error(w, fmt.Sprintf("unsupported content type %q", contentType), http.StatusUnsupportedMediaType)
+			return
+		}
+		packed, err = io.ReadAll(http.MaxBytesReader(w, r.Body, dns.MaxMsgSize))
+		if err != nil {
+			http.Error(w, fmt.Sprintf("failed to read request body: %v", err), http.StatusBadRequest)
+			return
+		}
+
+	default:
+		w.Header().Set("Allow", "GET, POST")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	req := new(dns.Msg)
+	if err := req.Unpack(packed); err != nil {
+		http.Error(w, fmt.Sprintf("invalid dns message: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	// Responses are never truncated as they would be for UDP, because the
+	// handlers treat TCP remote addresses as stream-based transports.
+	resp := &dohResponseWriter{remoteAddr: tcpAddrFromString(r.RemoteAddr)}
+	if addr, ok := r.Context().Value(http.LocalAddrContextKey).(net.Addr); ok {
+		resp.localAddr = addr
+	}
+	d.mux.ServeDNS(resp, req)
+
+	if resp.msg == nil {
+		http.Error(w, "no dns response", http.StatusInternalServerError)
+		return
+	}
+	packed, err = resp.msg.Pack()
+	if err != nil {
+		d.logger.Error("failed to pack DNS-over-HTTPS response", "error", err)
+		http.Error(w, "failed to pack dns response", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", dohMediaType)
+	if ttl, ok := minTTL(resp.msg); ok {
+		w.Header().Set("Cache-Control", fmt.Sprintf("max-age=%d", ttl))
+	}
+	if _, err := w.Write(packed); err != nil {
+		d.logger.Warn("failed to respond", "error", err)
+	}
+}
+
+// minTTL returns the smallest TTL of the message's records, which RFC 8484
+// requires the freshness lifetime of the HTTP response to be bounded by.
+func minTTL(msg *dns.Msg) (uint32, bool) {
+	var (
+		ttl   uint32
+		found bool
+	)
+	for _, section := range [][]dns.RR{msg.Answer, msg.Ns, msg.Extra} {
+		for _, rr := range section {
+			if rr.Header().Rrtype == dns.TypeOPT {
+				continue
+			}
+			if !found || rr.Header().Ttl < ttl {
+				ttl, found = rr.Header().Ttl, true
+			}
+		}
+	}
+	return ttl, found
+}
+
+func tcpAddrFromString(addr string) net.Addr {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return &net.TCPAddr{}
+	}
+	p, _ := net.LookupPort("tcp", port)
+	return &net.TCPAddr{IP: net.ParseIP(host), Port: p}
+}
+
+// dohResponseWriter implements dns.ResponseWriter by keeping hold of the
+// response, so it can be written as the body of the HTTP response.
+type dohResponseWriter struct {
+	localAddr  net.Addr
+	remoteAddr net.Addr
+	msg        *dns.Msg
+}
+
+func (w *dohResponseWriter) LocalAddr() net.Addr  { return w.localAddr }
+func (w *dohResponseWriter) RemoteAddr() net.Addr { return w.remoteAddr }
+
+func (w *dohResponseWriter) WriteMsg(msg *dns.Msg) error {
+	w.msg = msg
+	return nil
+}
+
+func (w *dohResponseWriter) Write(b []byte) (int, error) {
+	msg := new(dns.Msg)
+	if err := msg.Unpack(b); err != nil {
+		return 0, err
+	}
+	w.msg = msg
+	return len(b), nil
+}
+
+func (w *dohResponseWriter) Close() error        { return nil }
+func (w *dohResponseWriter) TsigStatus() error   { return nil }
+func (w *dohResponseWriter) TsigTimersOnly(bool) {}
+func (w *dohResponseWriter) Hijack()             {}
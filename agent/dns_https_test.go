@@ -0,0 +1,143 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package agent
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/miekg/dns"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/consul/sdk/freeport"
+	"github.com/hashicorp/consul/sdk/testutil"
+	"github.com/hashicorp/consul/testrpc"
+	"github.com/hashicorp/consul/tlsutil"
+)
+
+func TestDNS_EncryptedTransports(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+
+	// The checked-in test certificates have expired, so generate new ones.
+	signer, _, err := tlsutil.GeneratePrivateKey()
+	require.NoError(t, err)
+
+	ca, _, err := tlsutil.GenerateCA(tlsutil.CAOpts{Signer: signer})
+	require.NoError(t, err)
+
+	cert, privateKey, err := tlsutil.GenerateCert(tlsutil.CertOpts{
+		Signer:      signer,
+		CA:          ca,
+		Name:        "Test Cert Name",
+		Days:        365,
+		DNSNames:    []string{"consul.test"},
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	})
+	require.NoError(t, err)
+
+	certsDir := testutil.TempDir(t, "dns-tls")
+	certFile := filepath.Join(certsDir, "cert.pem")
+	keyFile := filepath.Join(certsDir, "key.pem")
+	require.NoError(t, os.WriteFile(certFile, []byte(cert), 0600))
+	require.NoError(t, os.WriteFile(keyFile, []byte(privateKey), 0600))
+
+	ports := freeport.GetN(t, 2)
+	a := StartTestAgent(t, TestAgent{
+		HCL: fmt.Sprintf(`
+			ports {
+				dns_tls = %d
+				dns_https = %d
+			}
+			tls {
+				defaults {
+					cert_file = %q
+					key_file = %q
+				}
+			}
+		`, ports[0], ports[1], certFile, keyFile),
+	})
+	defer a.Shutdown()
+	testrpc.WaitForLeader(t, a.RPC, "dc1")
+
+	pool := x509.NewCertPool()
+	require.True(t, pool.AppendCertsFromPEM([]byte(ca)))
+	tlsConfig := &tls.Config{RootCAs: pool, ServerName: "consul.test"}
+
+	question := new(dns.Msg)
+	question.SetQuestion(a.Config.NodeName+".node.consul.", dns.TypeA)
+
+	requireNodeAnswer := func(t *testing.T, in *dns.Msg) {
+		t.Helper()
+		require.Len(t, in.Answer, 1)
+		aRec, ok := in.Answer[0].(*dns.A)
+		require.True(t, ok, "answer is not an A record")
+		require.Equal(t, "127.0.0.1", aRec.A.String())
+	}
+
+	t.Run("tls", func(t *testing.T) {
+		c := &dns.Client{Net: "tcp-tls", TLSConfig: tlsConfig}
+		in, _, err := c.Exchange(question, fmt.Sprintf("127.0.0.1:%d", ports[0]))
+		require.NoError(t, err)
+		requireNodeAnswer(t, in)
+	})
+
+	client := &http.Client{Transport: &http.Transport{TLSClientConfig: tlsConfig}}
+	url := fmt.Sprintf("https://127.0.0.1:%d/dns-query", ports[1])
+
+	packed, err := question.Pack()
+	require.NoError(t, err)
+
+	readAnswer := func(t *testing.T, rsp *http.Response) *dns.Msg {
+		t.Helper()
+		defer rsp.Body.Close()
+
+		require.Equal(t, http.StatusOK, rsp.StatusCode)
+		require.Equal(t, "application/dns-message", rsp.Header.Get("Content-Type"))
+
+		body, err := io.ReadAll(rsp.Body)
+		require.NoError(t, err)
+
+		in := new(dns.Msg)
+		require.NoError(t, in.Unpack(body))
+		return in
+	}
+
+	t.Run("https post", func(t *testing.T) {
+		rsp, err := client.Post(url, "application/dns-message", bytes.NewReader(packed))
+		require.NoError(t, err)
+		requireNodeAnswer(t, readAnswer(t, rsp))
+	})
+
+	t.Run("https get", func(t *testing.T) {
+		rsp, err := client.Get(url + "?dns=" + base64.RawURLEncoding.EncodeToString(packed))
+		require.NoError(t, err)
+		requireNodeAnswer(t, readAnswer(t, rsp))
+	})
+
+	t.Run("https wrong content type", func(t *testing.T) {
+		rsp, err := client.Post(url, "text/plain", bytes.NewReader(packed))
+		require.NoError(t, err)
+		rsp.Body.Close()
+		require.Equal(t, http.StatusUnsupportedMediaType, rsp.StatusCode)
+	})
+
+	t.Run("https invalid message", func(t *testing.T) {
+		rsp, err := client.Get(url + "?dns=AAAA")
+		require.NoError(t, err)
+		rsp.Body.Close()
+		require.Equal(t, http.StatusBadRequest, rsp.StatusCode)
+	})
+}
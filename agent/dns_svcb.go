@@ -0,0 +1,195 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package agent
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/miekg/dns"
+
+	cachetype "github.com/hashicorp/consul/agent/cache-types"
+	"github.com/hashicorp/consul/agent/connect"
+	"github.com/hashicorp/consul/agent/structs"
+)
+
+// svcbKeyMeshSNI is the SvcParamKey of the SNI to use when connecting to a
+// service through the service mesh. There is no registered key for it, so a
+// key from the private use range of RFC 9460 section 14.3.1 is used.
+const svcbKeyMeshSNI dns.SVCBKey = 65280
+
+// serviceSVCBRecords is used to add the SVCB or HTTPS records for a service
+// lookup. The records have the same targets and ports as the SRV records, and
+// the addresses of the targets are added as additional records the same way.
+func (d *DNSServer) serviceSVCBRecords(cfg *dnsConfig, lookup serviceLookup, nodes structs.CheckServiceNodes, req, resp *dns.Msg, ttl time.Duration, maxRecursionLevel int) {
+	q := req.Question[0]
+	srvReq := req.Copy()
+	srvReq.Question[0].Qtype = dns.TypeSRV
+
+	alpn := svcbALPN(d.lookupServiceProtocol(cfg, lookup))
+
+	var sni string
+	if lookup.Connect {
+		sni = d.meshSNI(lookup)
+	}
+
+	respDomain := d.getResponseDomain(q.Name)
+	handled := make(map[string]struct{})
+	for _, node := range nodes {
+		// Avoid duplicate entries, possible if a node has
+		// the same service the same port, etc.
+		serviceAddress := d.agent.TranslateServiceAddress(lookup.Datacenter, node.Service.Address, node.Service.TaggedAddresses, TranslateAddressAcceptAny)
+		servicePort := d.agent.TranslateServicePort(lookup.Datacenter, node.Service.Port, node.Service.TaggedAddresses)
+		tuple := fmt.Sprintf("%s:%s:%d", node.Node.Node, serviceAddress, servicePort)
+		if _, ok := handled[tuple]; ok {
+			continue
+		}
+		handled[tuple] = struct{}{}
+
+		answers, extra := d.nodeServiceRecords(lookup, node, srvReq, ttl, cfg, maxRecursionLevel)
+		for _, rr := range answers {
+			srv, ok := rr.(*dns.SRV)
+			if !ok {
+				continue
+			}
+
+			// The parameters must be in increasing order of their keys.
+			var params []dns.SVCBKeyValue
+			if len(alpn) > 0 {
+				params = append(params, &dns.SVCBAlpn{Alpn: alpn})
+			}
+			params = append(params, &dns.SVCBPort{Port: srv.Port})
+			ipv4, ipv6 := d.svcbHints(lookup, node)
+			if len(ipv4) > 0 {
+				params = append(params, &dns.SVCBIPv4Hint{Hint: ipv4})
+			}
+			if len(ipv6) > 0 {
+				params = append(params, &dns.SVCBIPv6Hint{Hint: ipv6})
+			}
+			if sni != "" {
+				params = append(params, &dns.SVCBLocal{KeyCode: svcbKeyMeshSNI, Data: []byte(sni)})
+			}
+
+			svcb := dns.SVCB{
+				Hdr: dns.RR_Header{
+					Name:   q.Name,
+					Rrtype: q.Qtype,
+					Class:  dns.ClassINET,
+					Ttl:    srv.Hdr.Ttl,
+				},
+				Priority: 1,
+				Target:   srv.Target,
+				Value:    params,
+			}
+			if q.Qtype == dns.TypeHTTPS {
+				resp.Answer = append(resp.Answer, &dns.HTTPS{SVCB: svcb})
+			} else {
+				resp.Answer = append(resp.Answer, &svcb)
+			}
+		}
+		resp.Extra = append(resp.Extra, extra...)
+
+		if cfg.NodeMetaTXT {
+			resp.Extra = append(resp.Extra, d.generateMeta(nodeCanonicalDNSName(lookup, node.Node.Node, respDomain), node.Node, ttl)...)
+		}
+	}
+}
+
+// svcbHints returns the IPv4 and IPv6 addresses of the service instance,
+// taking its tagged addresses into account.
+func (d *DNSServer) svcbHints(lookup serviceLookup, node structs.CheckServiceNode) (ipv4, ipv6 []net.IP) {
+	for _, accept := range []TranslateAddressAccept{TranslateAddressAcceptIPv4, TranslateAddressAcceptIPv6} {
+		addr := d.agent.TranslateServiceAddress(lookup.Datacenter, node.Service.Address, node.Service.TaggedAddresses, accept)
+		if addr == "" && node.Service.Address == "" {
+			addr = d.agent.TranslateAddress(node.Node.Datacenter, node.Node.Address, node.Node.TaggedAddresses, accept)
+		}
+
+		ip := net.ParseIP(addr)
+		switch {
+		case ip == nil:
+		case ip.To4() != nil:
+			ipv4 = append(ipv4, ip.To4())
+		default:
+			ipv6 = append(ipv6, ip)
+		}
+	}
+	return ipv4, ipv6
+}
+
+// lookupServiceProtocol returns the protocol of the service, as configured by
+// the service-defaults and proxy-defaults config entries. It returns an empty
+// string if the protocol is unknown.
+func (d *DNSServer) lookupServiceProtocol(cfg *dnsConfig, lookup serviceLookup) string {
+	// The config entries of peers aren't available.
+	if lookup.PeerName != "" {
+		return ""
+	}
+
+	args := structs.ServiceConfigRequest{
+		Name:           lookup.Service,
+		Datacenter:     lookup.Datacenter,
+		EnterpriseMeta: lookup.EnterpriseMeta,
+		QueryOptions: structs.QueryOptions{
+			Token:      d.agent.tokens.UserToken(),
+			AllowStale: cfg.AllowStale,
+		},
+	}
+
+	var out *structs.ServiceConfigResponse
+	if cfg.UseCache {
+		raw, _, err := d.agent.cache.Get(context.TODO(), cachetype.ResolvedServiceConfigName, &args)
+		if err != nil {
+			d.logger.Debug("Unable to resolve service config", "service", lookup.Service, "error", err)
+			return ""
+		}
+		reply, ok := raw.(*structs.ServiceConfigResponse)
+		if !ok {
+			return ""
+		}
+		out = reply
+	} else {
+		out = &structs.ServiceConfigResponse{}
+		if err := d.agent.RPC(context.TODO(), "ConfigEntry.ResolveServiceConfig", &args, out); err != nil {
+			d.logger.Debug("Unable to resolve service config", "service", lookup.Service, "error", err)
+			return ""
+		}
+	}
+
+	protocol, _ := out.ProxyConfig["protocol"].(string)
+	return protocol
+}
+
+// meshSNI returns the SNI of the service in the service mesh, or an empty
+// string if the trust domain of the mesh isn't known.
+func (d *DNSServer) meshSNI(lookup serviceLookup) string {
+	args := structs.DCSpecificRequest{
+		Datacenter:   d.agent.config.Datacenter,
+		QueryOptions: structs.QueryOptions{Token: d.agent.tokens.UserToken()},
+	}
+	raw, _, err := d.agent.cache.Get(context.TODO(), cachetype.ConnectCARootName, &args)
+	if err != nil {
+		d.logger.Debug("Unable to fetch the Connect CA roots", "error", err)
+		return ""
+	}
+	roots, ok := raw.(*structs.IndexedCARoots)
+	if !ok || roots.TrustDomain == "" {
+		return ""
+	}
+
+	return connect.ServiceSNI(lookup.Service, "", lookup.NamespaceOrDefault(), lookup.PartitionOrDefault(), lookup.Datacenter, roots.TrustDomain)
+}
+
+// svcbALPN returns the ALPN protocol IDs of a service protocol.
+func svcbALPN(protocol string) []string {
+	switch protocol {
+	case "http":
+		return []string{"http/1.1"}
+	case "http2", "grpc":
+		return []string{"h2"}
+	default:
+		return nil
+	}
+}
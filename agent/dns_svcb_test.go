@@ -0,0 +1,136 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package agent
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/miekg/dns"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/consul/agent/structs"
+	"github.com/hashicorp/consul/sdk/testutil/retry"
+	"github.com/hashicorp/consul/testrpc"
+)
+
+func TestDNS_ServiceLookup_SVCB(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+	a := NewTestAgent(t, "")
+	defer a.Shutdown()
+	testrpc.WaitForLeader(t, a.RPC, "dc1")
+
+	{
+		req := structs.ConfigEntryRequest{
+			Op:         structs.ConfigEntryUpsert,
+			Datacenter: "dc1",
+			Entry: &structs.ServiceConfigEntry{
+				Kind:     structs.ServiceDefaults,
+				Name:     "web",
+				Protocol: "http2",
+			},
+		}
+		var out bool
+		require.NoError(t, a.RPC(context.Background(), "ConfigEntry.Apply", req, &out))
+		require.True(t, out)
+	}
+
+	{
+		args := &structs.RegisterRequest{
+			Datacenter: "dc1",
+			Node:       "bar",
+			Address:    "127.0.0.1",
+			Service: &structs.NodeService{
+				Service: "web",
+				Port:    8080,
+				TaggedAddresses: map[string]structs.ServiceAddress{
+					structs.TaggedAddressLANIPv6: {Address: "::1", Port: 8080},
+				},
+			},
+		}
+		var out struct{}
+		require.NoError(t, a.RPC(context.Background(), "Catalog.Register", args, &out))
+	}
+
+	{
+		args := structs.TestRegisterRequestProxy(t)
+		args.Address = "127.0.0.55"
+		args.Service.Proxy.DestinationServiceName = "web"
+		args.Service.Address = ""
+		args.Service.Port = 21000
+		var out struct{}
+		require.NoError(t, a.RPC(context.Background(), "Catalog.Register", args, &out))
+	}
+
+	query := func(t require.TestingT, name string, qtype uint16) *dns.Msg {
+		m := new(dns.Msg)
+		m.SetQuestion(name, qtype)
+		in, _, err := new(dns.Client).Exchange(m, a.DNSAddr())
+		require.NoError(t, err)
+		return in
+	}
+
+	params := func(svcb *dns.SVCB) map[dns.SVCBKey]dns.SVCBKeyValue {
+		out := make(map[dns.SVCBKey]dns.SVCBKeyValue)
+		for _, kv := range svcb.Value {
+			out[kv.Key()] = kv
+		}
+		return out
+	}
+
+	t.Run("https", func(t *testing.T) {
+		in := query(t, "web.service.consul.", dns.TypeHTTPS)
+		require.Equal(t, dns.RcodeSuccess, in.Rcode)
+		require.Len(t, in.Answer, 1)
+
+		https, ok := in.Answer[0].(*dns.HTTPS)
+		require.True(t, ok, "answer is not an HTTPS record")
+		require.Equal(t, "web.service.consul.", https.Hdr.Name)
+		require.Equal(t, uint16(1), https.Priority)
+		// The target is the same as for SRV queries, which prefer the IPv6
+		// tagged address.
+		require.Equal(t, "00000000000000000000000000000001.addr.dc1.consul.", https.Target)
+
+		p := params(&https.SVCB)
+		require.Equal(t, []string{"h2"}, p[dns.SVCB_ALPN].(*dns.SVCBAlpn).Alpn)
+		require.Equal(t, uint16(8080), p[dns.SVCB_PORT].(*dns.SVCBPort).Port)
+		require.Equal(t, []net.IP{net.ParseIP("127.0.0.1").To4()}, p[dns.SVCB_IPV4HINT].(*dns.SVCBIPv4Hint).Hint)
+		require.Equal(t, []net.IP{net.ParseIP("::1")}, p[dns.SVCB_IPV6HINT].(*dns.SVCBIPv6Hint).Hint)
+		require.NotContains(t, p, svcbKeyMeshSNI)
+
+		require.Len(t, in.Extra, 1)
+		aaaaRec, ok := in.Extra[0].(*dns.AAAA)
+		require.True(t, ok)
+		require.Equal(t, https.Target, aaaaRec.Hdr.Name)
+	})
+
+	t.Run("connect svcb", func(t *testing.T) {
+		var in *dns.Msg
+		// The trust domain is known once the CA is initialized.
+		retry.Run(t, func(r *retry.R) {
+			in = query(r, "web.connect.consul.", dns.TypeSVCB)
+			require.Len(r, in.Answer, 1)
+			svcb, ok := in.Answer[0].(*dns.SVCB)
+			require.True(r, ok, "answer is not an SVCB record")
+			require.Contains(r, params(svcb), svcbKeyMeshSNI)
+		})
+
+		svcb := in.Answer[0].(*dns.SVCB)
+		p := params(svcb)
+		require.Equal(t, uint16(21000), p[dns.SVCB_PORT].(*dns.SVCBPort).Port)
+		require.Equal(t, []string{"h2"}, p[dns.SVCB_ALPN].(*dns.SVCBAlpn).Alpn)
+		sni := string(p[svcbKeyMeshSNI].(*dns.SVCBLocal).Data)
+		require.Regexp(t, `^web\.default\.dc1\.internal\.[0-9a-f-]+\.consul$`, sni)
+	})
+
+	t.Run("unknown service", func(t *testing.T) {
+		in := query(t, "nope.service.consul.", dns.TypeSVCB)
+		require.Equal(t, dns.RcodeNameError, in.Rcode)
+	})
+}
@@ -13,6 +13,7 @@ import (
 	"reflect"
 	"sort"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -4310,6 +4311,89 @@ func TestDNS_RecursorTimeout(t *testing.T) {
 
 }
 
+func TestDNS_Recurse_Cache(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+
+	var queries uint32
+	mux := dns.NewServeMux()
+	mux.HandleFunc(".", func(resp dns.ResponseWriter, req *dns.Msg) {
+		atomic.AddUint32(&queries, 1)
+
+		m := new(dns.Msg)
+		m.SetReply(req)
+		switch req.Question[0].Name {
+		case "apple.com.":
+			rr := dnsA("apple.com", "1.2.3.4")
+			rr.Hdr.Ttl = 300
+			m.Answer = []dns.RR{rr}
+		default:
+			m.Rcode = dns.RcodeNameError
+			m.Ns = []dns.RR{&dns.SOA{
+				Hdr:    dns.RR_Header{Name: "com.", Rrtype: dns.TypeSOA, Class: dns.ClassINET, Ttl: 900},
+				Ns:     "a.gtld-servers.net.",
+				Mbox:   "nstld.verisign-grs.com.",
+				Minttl: 60,
+			}}
+		}
+		resp.WriteMsg(m)
+	})
+	recursor := &dns.Server{Addr: "127.0.0.1:0", Net: "udp", Handler: mux}
+	up := make(chan struct{})
+	recursor.NotifyStartedFunc = func() { close(up) }
+	go recursor.ListenAndServe()
+	<-up
+	defer recursor.Shutdown()
+
+	a := NewTestAgent(t, `
+		recursors = ["`+recursor.PacketConn.LocalAddr().String()+`"]
+		dns_config {
+			recursor_cache_size_mb = 1
+		}
+	`)
+	defer a.Shutdown()
+	testrpc.WaitForLeader(t, a.RPC, "dc1")
+
+	exchange := func(name string) *dns.Msg {
+		t.Helper()
+		m := new(dns.Msg)
+		m.SetQuestion(name, dns.TypeA)
+		in, _, err := new(dns.Client).Exchange(m, a.DNSAddr())
+		require.NoError(t, err)
+		return in
+	}
+
+	for i := 0; i < 3; i++ {
+		in := exchange("apple.com.")
+		require.Equal(t, dns.RcodeSuccess, in.Rcode)
+		require.Len(t, in.Answer, 1)
+		require.LessOrEqual(t, in.Answer[0].Header().Ttl, uint32(300))
+	}
+	require.Equal(t, uint32(1), atomic.LoadUint32(&queries))
+
+	// The negative response is cached for the SOA's minimum TTL.
+	for i := 0; i < 3; i++ {
+		in := exchange("missing.com.")
+		require.Equal(t, dns.RcodeNameError, in.Rcode)
+		require.Len(t, in.Ns, 1)
+		require.LessOrEqual(t, in.Ns[0].Header().Ttl, uint32(900))
+	}
+	require.Equal(t, uint32(2), atomic.LoadUint32(&queries))
+
+	// Disabling the cache drops the cached responses.
+	newCfg := *a.Config
+	newCfg.DNSRecursorCacheSize = 0
+	require.NoError(t, a.reloadConfigInternal(&newCfg))
+	require.Equal(t, 0, a.dnsRecursorCache.Len())
+
+	exchange("apple.com.")
+	exchange("apple.com.")
+	require.Equal(t, uint32(4), atomic.LoadUint32(&queries))
+}
+
 func TestDNS_ServiceLookup_FilterCritical(t *testing.T) {
 	if testing.Short() {
 		t.Skip("too slow for testing.Short")
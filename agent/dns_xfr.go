@@ -0,0 +1,516 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package agent
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/miekg/dns"
+	"golang.org/x/sync/singleflight"
+
+	agentdns "github.com/hashicorp/consul/agent/dns"
+	"github.com/hashicorp/consul/agent/structs"
+)
+
+const (
+	// maxZoneHistory is the number of versions of the zone kept to answer
+	// IXFR requests. Secondaries which are further behind get the full zone.
+	maxZoneHistory = 16
+
+	// zoneRebuildInterval is how long a built zone is reused before the
+	// catalog is read again, so that SOA queries and transfers from many
+	// secondaries don't each dump the catalog.
+	zoneRebuildInterval = time.Second
+
+	// zoneTransferChunkSize is the number of records sent in each message of
+	// a zone transfer.
+	zoneTransferChunkSize = 100
+)
+
+// dnsZone is a version of the zone of the DNS domain, as served by zone
+// transfers. Its serial is the catalog Raft index it was built from, unless
+// the zone changed without the catalog changing (e.g. the DNS config was
+// reloaded), in which case it's one more than the previous version's.
+type dnsZone struct {
+	domain string
+	soa    *dns.SOA
+
+	// records are the records of the zone, other than the SOA, sorted and
+	// without duplicates.
+	records []dns.RR
+
+	// hash is the hash of the content of the zone, its SOA (but for the
+	// serial) and records, to tell whether two versions differ.
+	hash [sha256.Size]byte
+
+	built time.Time
+}
+
+// dnsZoneHistory keeps the recent versions of the zone, so that IXFR requests
+// can be answered with the differences since the secondary's version.
+type dnsZoneHistory struct {
+	lock sync.Mutex
+
+	// zones are ordered from oldest to newest.
+	zones []*dnsZone
+
+	// builds deduplicates concurrent builds of the zone of each domain, which
+	// read the catalog without holding lock.
+	builds singleflight.Group
+
+	// backgroundBuilds is when each domain's zone was last rebuilt in the
+	// background, to limit how often SOA queries read the catalog.
+	backgroundBuilds map[string]time.Time
+}
+
+// invalidate makes the zones stale so that they're rebuilt on next use, e.g.
+// because the config they were built with changed. The versions are kept so
+// that the serials of the rebuilt zones keep increasing.
+func (h *dnsZoneHistory) invalidate() {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	for _, z := range h.zones {
+		z.built = time.Time{}
+	}
+	h.backgroundBuilds = nil
+}
+
+// startBackgroundBuild returns whether the zone of the domain should be
+// rebuilt in the background, which happens at most once per
+// zoneRebuildInterval, whether or not the builds succeed.
+func (h *dnsZoneHistory) startBackgroundBuild(domain string) bool {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	if time.Since(h.backgroundBuilds[domain]) < zoneRebuildInterval {
+		return false
+	}
+	if h.backgroundBuilds == nil {
+		h.backgroundBuilds = make(map[string]time.Time)
+	}
+	h.backgroundBuilds[domain] = time.Now()
+	return true
+}
+
+// latest returns the most recent version of the zone for the domain, if any,
+// and whether it was built too long ago to be reused.
+func (h *dnsZoneHistory) latest(domain string) (*dnsZone, bool) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	for i := len(h.zones) - 1; i >= 0; i-- {
+		if z := h.zones[i]; z.domain == domain {
+			return z, time.Since(z.built) >= zoneRebuildInterval
+		}
+	}
+	return nil, true
+}
+
+// add records a newly built version of the zone, and returns the version to
+// serve: the most recent one if the zone hasn't changed since.
+func (h *dnsZoneHistory) add(zone *dnsZone) *dnsZone {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	for i := len(h.zones) - 1; i >= 0; i-- {
+		if latest := h.zones[i]; latest.domain == zone.domain {
+			if latest.hash == zone.hash {
+				latest.built = zone.built
+				return latest
+			}
+			// The content changed, so secondaries must see a newer serial even
+			// if the catalog didn't change.
+			if !serialNewer(zone.soa.Serial, latest.soa.Serial) {
+				zone.soa.Serial = latest.soa.Serial + 1
+			}
+			break
+		}
+	}
+
+	h.zones = append(h.zones, zone)
+	if len(h.zones) > maxZoneHistory {
+		h.zones = h.zones[len(h.zones)-maxZoneHistory:]
+	}
+	return zone
+}
+
+// zoneTransferAllowed returns whether zone transfers are allowed for the
+// client.
+func zoneTransferAllowed(cfg *dnsConfig, remoteAddr net.Addr) bool {
+	var ip net.IP
+	switch v := remoteAddr.(type) {
+	case *net.UDPAddr:
+		ip = v.IP
+	case *net.TCPAddr:
+		ip = v.IP
+	default:
+		return false
+	}
+	for _, n := range cfg.ZoneTransferAllowedCIDRs {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// handleZoneTransfer answers AXFR (RFC 5936) and IXFR (RFC 1995) requests for
+// the zone of the DNS domain.
+func (d *DNSServer) handleZoneTransfer(cfg *dnsConfig, network string, resp dns.ResponseWriter, req *dns.Msg) {
+	q := req.Question[0]
+	reply := func(rcode int) {
+		m := new(dns.Msg)
+		m.SetRcode(req, rcode)
+		if err := resp.WriteMsg(m); err != nil {
+			d.logger.Warn("failed to respond", "error", err)
+		}
+	}
+
+	if !zoneTransferAllowed(cfg, resp.RemoteAddr()) {
+		d.logger.Warn("zone transfer refused for client", "client", resp.RemoteAddr().String())
+		reply(dns.RcodeRefused)
+		return
+	}
+
+	// Transfers are streams of messages, which DNS-over-HTTPS can't carry.
+	if _, ok := resp.(*dohResponseWriter); ok {
+		reply(dns.RcodeRefused)
+		return
+	}
+
+	domain := strings.ToLower(q.Name)
+	if domain != d.domain && domain != d.altDomain {
+		reply(dns.RcodeNotAuth)
+		return
+	}
+
+	var clientSOA *dns.SOA
+	if q.Qtype == dns.TypeIXFR {
+		if len(req.Ns) > 0 {
+			clientSOA, _ = req.Ns[0].(*dns.SOA)
+		}
+		if clientSOA == nil {
+			reply(dns.RcodeFormatError)
+			return
+		}
+	} else if network != "tcp" {
+		// AXFR is only defined over TCP.
+		reply(dns.RcodeFormatError)
+		return
+	}
+
+	zone, old, err := d.zoneForTransfer(cfg, domain, clientSOA)
+	if err != nil {
+		d.logger.Error("failed to build zone for transfer", "error", err)
+		reply(dns.RcodeServerFailure)
+		return
+	}
+
+	var records []dns.RR
+	switch {
+	case clientSOA != nil && (network != "tcp" || !serialNewer(zone.soa.Serial, clientSOA.Serial)):
+		// The secondary is up to date, or the transfer was requested over
+		// UDP, in which case the single SOA tells it to retry over TCP.
+		records = []dns.RR{zone.soa}
+
+	case old != nil:
+		deleted, added := diffZones(old, zone)
+		records = append(records, zone.soa, old.soa)
+		records = append(records, deleted...)
+		records = append(records, zone.soa)
+		records = append(records, added...)
+		records = append(records, zone.soa)
+
+	default:
+		records = append(records, zone.soa)
+		records = append(records, zone.records...)
+		records = append(records, zone.soa)
+	}
+
+	for len(records) > 0 {
+		n := zoneTransferChunkSize
+		if n > len(records) {
+			n = len(records)
+		}
+
+		m := new(dns.Msg)
+		m.SetReply(req)
+		m.Authoritative = true
+		m.Compress = true
+		m.Answer = records[:n]
+		if err := resp.WriteMsg(m); err != nil {
+			d.logger.Warn("failed to respond", "error", err)
+			return
+		}
+		records = records[n:]
+	}
+}
+
+// zoneForTransfer returns the current version of the zone and, if the client
+// is at a version which is still in the history, that version.
+func (d *DNSServer) zoneForTransfer(cfg *dnsConfig, domain string, clientSOA *dns.SOA) (*dnsZone, *dnsZone, error) {
+	zone, err := d.currentZone(cfg, domain)
+	if err != nil || clientSOA == nil {
+		return zone, nil, err
+	}
+
+	d.zones.lock.Lock()
+	defer d.zones.lock.Unlock()
+	for _, z := range d.zones.zones {
+		if z.domain == domain && z.soa.Serial == clientSOA.Serial && z != zone {
+			return zone, z, nil
+		}
+	}
+	return zone, nil, nil
+}
+
+// currentZone returns the current version of the zone for the domain,
+// building it from the catalog unless it was built very recently.
+func (d *DNSServer) currentZone(cfg *dnsConfig, domain string) (*dnsZone, error) {
+	if zone, stale := d.zones.latest(domain); !stale {
+		return zone, nil
+	}
+
+	v, err, _ := d.zones.builds.Do(domain, func() (interface{}, error) {
+		zone, err := d.buildZone(cfg, domain)
+		if err != nil {
+			return nil, err
+		}
+		return d.zones.add(zone), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.(*dnsZone), nil
+}
+
+// cachedZone returns the most recently built version of the zone for the
+// domain, without waiting for the catalog to be read. If it's stale (or was
+// never built), the zone is rebuilt in the background, at most once per
+// zoneRebuildInterval.
+func (d *DNSServer) cachedZone(cfg *dnsConfig, domain string) *dnsZone {
+	zone, stale := d.zones.latest(domain)
+	if stale && d.zones.startBackgroundBuild(domain) {
+		go func() {
+			if _, err := d.currentZone(cfg, domain); err != nil {
+				d.logger.Warn("failed to build zone", "error", err)
+			}
+		}()
+	}
+	return zone
+}
+
+// buildZone builds the zone of the domain from the catalog of the local
+// datacenter. It contains the node records, and the A, AAAA and SRV records
+// of each service as they are returned for queries of the service.
+func (d *DNSServer) buildZone(cfg *dnsConfig, domain string) (*dnsZone, error) {
+	token := cfg.ZoneTransferToken
+	if token == "" {
+		token = d.agent.tokens.UserToken()
+	}
+
+	nodesArgs := structs.DCSpecificRequest{
+		Datacenter: cfg.Datacenter,
+		QueryOptions: structs.QueryOptions{
+			Token:      token,
+			AllowStale: cfg.AllowStale,
+		},
+		EnterpriseMeta: d.defaultEnterpriseMeta,
+	}
+	var nodes structs.IndexedNodes
+	if err := d.agent.RPC(context.Background(), "Catalog.ListNodes", &nodesArgs, &nodes); err != nil {
+		return nil, fmt.Errorf("failed to list nodes: %w", err)
+	}
+
+	servicesArgs := structs.ServiceDumpRequest{
+		Datacenter:     cfg.Datacenter,
+		ServiceKind:    structs.ServiceKindTypical,
+		UseServiceKind: true,
+		QueryOptions: structs.QueryOptions{
+			Token:      token,
+			AllowStale: cfg.AllowStale,
+		},
+		EnterpriseMeta: d.defaultEnterpriseMeta,
+	}
+	var services structs.IndexedNodesWithGateways
+	if err := d.agent.RPC(context.Background(), "Internal.ServiceDump", &servicesArgs, &services); err != nil {
+		return nil, fmt.Errorf("failed to dump services: %w", err)
+	}
+
+	index := nodes.Index
+	if services.Index > index {
+		index = services.Index
+	}
+
+	// The zone is served in full to secondaries, so it isn't subject to the
+	// limit on the number of records in responses. Names outside of the
+	// domain aren't part of the zone, so they aren't resolved.
+	zoneCfg := *cfg
+	zoneCfg.ARecordLimit = 0
+	zoneCfg.Recursors = nil
+
+	var records []dns.RR
+	for _, n := range nodes.Nodes {
+		if agentdns.InvalidNameRe.MatchString(n.Node) {
+			continue
+		}
+		lookup := serviceLookup{Datacenter: n.Datacenter, EnterpriseMeta: *n.GetEnterpriseMeta()}
+		name := dns.Fqdn(strings.ToLower(nodeCanonicalDNSName(lookup, n.Node, domain)))
+		if net.ParseIP(n.Address) == nil {
+			records = append(records, &dns.CNAME{
+				Hdr: dns.RR_Header{
+					Name:   name,
+					Rrtype: dns.TypeCNAME,
+					Class:  dns.ClassINET,
+					Ttl:    uint32(cfg.NodeTTL / time.Second),
+				},
+				Target: dns.Fqdn(n.Address),
+			})
+		} else {
+			records = append(records, d.makeRecordFromNode(n, dns.TypeANY, name, cfg.NodeTTL, 0)...)
+		}
+		if cfg.NodeMetaTXT {
+			records = append(records, d.generateMeta(name, n, cfg.NodeTTL)...)
+		}
+	}
+
+	byService := make(map[string]structs.CheckServiceNodes)
+	for _, n := range services.Nodes.Filter(cfg.OnlyPassing) {
+		byService[n.Service.Service] = append(byService[n.Service.Service], n)
+	}
+	for service, nodes := range byService {
+		if agentdns.InvalidNameRe.MatchString(service) {
+			continue
+		}
+
+		lookup := serviceLookup{
+			Datacenter:     cfg.Datacenter,
+			Service:        service,
+			EnterpriseMeta: d.defaultEnterpriseMeta,
+		}
+		name := dns.Fqdn(strings.ToLower(fmt.Sprintf("%s.service.%s.%s", service, cfg.Datacenter, domain)))
+		ttl, _ := cfg.GetTTLForService(service)
+
+		for _, qType := range []uint16{dns.TypeANY, dns.TypeSRV} {
+			req := new(dns.Msg)
+			req.SetQuestion(name, qType)
+			resp := new(dns.Msg)
+			if qType == dns.TypeSRV {
+				d.serviceSRVRecords(&zoneCfg, lookup, nodes, req, resp, ttl, maxRecursionLevelDefault)
+			} else {
+				d.serviceNodeRecords(&zoneCfg, lookup, nodes, req, resp, ttl, maxRecursionLevelDefault)
+			}
+			records = append(records, resp.Answer...)
+			records = append(records, resp.Extra...)
+		}
+
+		// The Consul servers are the name servers of the zone.
+		if service == structs.ConsulServiceName {
+			for _, n := range nodes {
+				records = append(records, &dns.NS{
+					Hdr: dns.RR_Header{
+						Name:   domain,
+						Rrtype: dns.TypeNS,
+						Class:  dns.ClassINET,
+						Ttl:    uint32(cfg.NodeTTL / time.Second),
+					},
+					Ns: dns.Fqdn(strings.ToLower(nodeCanonicalDNSName(lookup, n.Node.Node, domain))),
+				})
+			}
+		}
+	}
+
+	soa := d.soa(cfg, domain)
+	// Raft indexes only grow, so they satisfy the requirements of SOA
+	// serials, which wrap around using serial number arithmetic (RFC 1982).
+	soa.Serial = uint32(index)
+
+	zone := &dnsZone{
+		domain:  domain,
+		soa:     soa,
+		records: normalizeZoneRecords(domain, records),
+		built:   time.Now(),
+	}
+	zone.hash = hashZone(zone)
+	return zone, nil
+}
+
+// hashZone returns the hash of the content of the zone, which excludes the
+// serial of its SOA.
+func hashZone(zone *dnsZone) [sha256.Size]byte {
+	soa := *zone.soa
+	soa.Serial = 0
+
+	h := sha256.New()
+	fmt.Fprintln(h, soa.String())
+	for _, rr := range zone.records {
+		fmt.Fprintln(h, rr.String())
+	}
+
+	var sum [sha256.Size]byte
+	copy(sum[:], h.Sum(nil))
+	return sum
+}
+
+// normalizeZoneRecords removes the records which don't belong in the zone,
+// such as the targets of CNAMEs outside of the domain, and duplicates, and
+// sorts the rest so that versions of the zone can be compared.
+func normalizeZoneRecords(domain string, records []dns.RR) []dns.RR {
+	seen := make(map[string]dns.RR, len(records))
+	for _, rr := range records {
+		name := strings.ToLower(rr.Header().Name)
+		if name != domain && !strings.HasSuffix(name, "."+domain) {
+			continue
+		}
+		rr.Header().Name = name
+		seen[rr.String()] = rr
+	}
+
+	keys := make([]string, 0, len(seen))
+	for k := range seen {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	out := make([]dns.RR, 0, len(keys))
+	for _, k := range keys {
+		out = append(out, seen[k])
+	}
+	return out
+}
+
+// diffZones returns the records which were deleted from and added to the
+// zone between the two versions.
+func diffZones(old, new *dnsZone) (deleted, added []dns.RR) {
+	oldRecords := make(map[string]struct{}, len(old.records))
+	for _, rr := range old.records {
+		oldRecords[rr.String()] = struct{}{}
+	}
+	newRecords := make(map[string]struct{}, len(new.records))
+	for _, rr := range new.records {
+		newRecords[rr.String()] = struct{}{}
+		if _, ok := oldRecords[rr.String()]; !ok {
+			added = append(added, rr)
+		}
+	}
+	for _, rr := range old.records {
+		if _, ok := newRecords[rr.String()]; !ok {
+			deleted = append(deleted, rr)
+		}
+	}
+	return deleted, added
+}
+
+// serialNewer returns whether serial a is newer than b, using serial number
+// arithmetic (RFC 1982).
+func serialNewer(a, b uint32) bool {
+	return a != b && int32(a-b) > 0
+}
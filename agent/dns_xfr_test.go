@@ -0,0 +1,239 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package agent
+
+import (
+	"context"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/miekg/dns"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/consul/agent/structs"
+	"github.com/hashicorp/consul/sdk/testutil/retry"
+	"github.com/hashicorp/consul/testrpc"
+)
+
+func TestDNS_ZoneTransfer_Disabled(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+	a := NewTestAgent(t, "")
+	defer a.Shutdown()
+	testrpc.WaitForLeader(t, a.RPC, "dc1")
+
+	m := new(dns.Msg)
+	m.SetAxfr("consul.")
+	c := &dns.Client{Net: "tcp"}
+	in, _, err := c.Exchange(m, a.DNSAddr())
+	require.NoError(t, err)
+	require.Equal(t, dns.RcodeNotImplemented, in.Rcode)
+}
+
+func TestDNS_ZoneTransfer(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+	a := NewTestAgent(t, `
+		dns_config {
+			zone_transfer {
+				allowed_cidrs = ["127.0.0.0/8"]
+			}
+		}
+	`)
+	defer a.Shutdown()
+	testrpc.WaitForLeader(t, a.RPC, "dc1")
+
+	register := func(node, address, service string, port int) {
+		t.Helper()
+		args := &structs.RegisterRequest{
+			Datacenter: "dc1",
+			Node:       node,
+			Address:    address,
+			Service: &structs.NodeService{
+				Service: service,
+				Port:    port,
+			},
+		}
+		var out struct{}
+		require.NoError(t, a.RPC(context.Background(), "Catalog.Register", args, &out))
+	}
+	register("foo", "10.1.0.1", "web", 8080)
+	register("bar", "10.1.0.2", "web", 8080)
+
+	transfer := func(t require.TestingT, m *dns.Msg) []dns.RR {
+		tr := new(dns.Transfer)
+		envelopes, err := tr.In(m, a.DNSAddr())
+		require.NoError(t, err)
+
+		var records []dns.RR
+		for e := range envelopes {
+			require.NoError(t, e.Error)
+			records = append(records, e.RR...)
+		}
+		return records
+	}
+
+	requireRecord := func(t require.TestingT, records []dns.RR, want string) {
+		for _, rr := range records {
+			if rr.String() == want {
+				return
+			}
+		}
+		require.Fail(t, "record not found", "%q not in %v", want, records)
+	}
+
+	m := new(dns.Msg)
+	m.SetAxfr("consul.")
+	records := transfer(t, m)
+	require.GreaterOrEqual(t, len(records), 2)
+
+	soa, ok := records[0].(*dns.SOA)
+	require.True(t, ok, "transfer doesn't start with SOA")
+	require.Equal(t, soa.String(), records[len(records)-1].String())
+
+	requireRecord(t, records, "foo.node.dc1.consul.\t0\tIN\tA\t10.1.0.1")
+	requireRecord(t, records, "web.service.dc1.consul.\t0\tIN\tA\t10.1.0.1")
+	requireRecord(t, records, "web.service.dc1.consul.\t0\tIN\tA\t10.1.0.2")
+	requireRecord(t, records, "web.service.dc1.consul.\t0\tIN\tSRV\t1 1 8080 bar.node.dc1.consul.")
+	requireRecord(t, records, "consul.\t0\tIN\tNS\t"+strings.ToLower(a.Config.NodeName)+".node.dc1.consul.")
+
+	// The SOA serial of queries matches the zone, once the DNS server has
+	// built it in the background.
+	soaQuery := new(dns.Msg)
+	soaQuery.SetQuestion("consul.", dns.TypeSOA)
+	retry.Run(t, func(r *retry.R) {
+		in, _, err := new(dns.Client).Exchange(soaQuery, a.DNSAddr())
+		require.NoError(r, err)
+		require.Equal(r, soa.Serial, in.Answer[0].(*dns.SOA).Serial)
+	})
+
+	// A secondary which is up to date gets the SOA only.
+	m = new(dns.Msg)
+	m.SetIxfr("consul.", soa.Serial, soa.Ns, soa.Mbox)
+	records = transfer(t, m)
+	require.Len(t, records, 1)
+	require.Equal(t, soa.Serial, records[0].(*dns.SOA).Serial)
+
+	// Otherwise it gets the changes since its version.
+	register("baz", "10.1.0.3", "db", 5432)
+	retry.Run(t, func(r *retry.R) {
+		records = transfer(r, m)
+		require.Greater(r, len(records), 1)
+	})
+
+	newSOA, ok := records[0].(*dns.SOA)
+	require.True(t, ok, "transfer doesn't start with SOA")
+	require.True(t, serialNewer(newSOA.Serial, soa.Serial))
+	require.Equal(t, soa.Serial, records[1].(*dns.SOA).Serial)
+	require.Equal(t, newSOA.String(), records[len(records)-1].String())
+
+	var deleted, added []dns.RR
+	section := &deleted
+	for _, rr := range records[2 : len(records)-1] {
+		if rr.String() == newSOA.String() {
+			section = &added
+			continue
+		}
+		*section = append(*section, rr)
+	}
+	require.Empty(t, deleted)
+	requireRecord(t, added, "baz.node.dc1.consul.\t0\tIN\tA\t10.1.0.3")
+	requireRecord(t, added, "db.service.dc1.consul.\t0\tIN\tA\t10.1.0.3")
+	for _, rr := range added {
+		require.NotContains(t, rr.Header().Name, "web.service")
+	}
+
+	t.Run("udp ixfr", func(t *testing.T) {
+		m := new(dns.Msg)
+		m.SetIxfr("consul.", soa.Serial, soa.Ns, soa.Mbox)
+		in, _, err := new(dns.Client).Exchange(m, a.DNSAddr())
+		require.NoError(t, err)
+		require.Len(t, in.Answer, 1)
+		require.Equal(t, newSOA.Serial, in.Answer[0].(*dns.SOA).Serial)
+	})
+
+	t.Run("not the zone apex", func(t *testing.T) {
+		m := new(dns.Msg)
+		m.SetAxfr("service.consul.")
+		in, _, err := (&dns.Client{Net: "tcp"}).Exchange(m, a.DNSAddr())
+		require.NoError(t, err)
+		require.Equal(t, dns.RcodeNotAuth, in.Rcode)
+	})
+
+	t.Run("client not allowed", func(t *testing.T) {
+		newCfg := *a.Config
+		newCfg.DNSZoneTransferAllowedCIDRs = []*net.IPNet{{IP: net.ParseIP("10.0.0.0"), Mask: net.CIDRMask(8, 32)}}
+		require.NoError(t, a.reloadConfigInternal(&newCfg))
+
+		m := new(dns.Msg)
+		m.SetAxfr("consul.")
+		in, _, err := (&dns.Client{Net: "tcp"}).Exchange(m, a.DNSAddr())
+		require.NoError(t, err)
+		require.Equal(t, dns.RcodeRefused, in.Rcode)
+	})
+}
+
+func TestDNSZoneHistory_Add(t *testing.T) {
+	t.Parallel()
+
+	newZone := func(serial uint32, ttl uint32) *dnsZone {
+		zone := &dnsZone{
+			domain: "consul.",
+			soa: &dns.SOA{
+				Hdr:    dns.RR_Header{Name: "consul.", Rrtype: dns.TypeSOA, Class: dns.ClassINET},
+				Serial: serial,
+			},
+			records: []dns.RR{&dns.A{
+				Hdr: dns.RR_Header{Name: "foo.node.consul.", Rrtype: dns.TypeA, Class: dns.ClassINET, Ttl: ttl},
+				A:   net.ParseIP("10.1.0.1"),
+			}},
+			built: time.Now(),
+		}
+		zone.hash = hashZone(zone)
+		return zone
+	}
+
+	var h dnsZoneHistory
+	first := h.add(newZone(10, 0))
+	require.Equal(t, uint32(10), first.soa.Serial)
+
+	// An identical zone is the same version, even if the catalog changed.
+	require.Same(t, first, h.add(newZone(10, 0)))
+	require.Same(t, first, h.add(newZone(11, 0)))
+
+	// A zone whose content changed without the catalog changing gets a newer
+	// serial.
+	second := h.add(newZone(10, 30))
+	require.Equal(t, uint32(11), second.soa.Serial)
+
+	third := h.add(newZone(20, 60))
+	require.Equal(t, uint32(20), third.soa.Serial)
+	require.Len(t, h.zones, 3)
+}
+
+func TestDNSZoneHistory_StartBackgroundBuild(t *testing.T) {
+	t.Parallel()
+
+	var h dnsZoneHistory
+	require.True(t, h.startBackgroundBuild("consul."))
+	require.True(t, h.startBackgroundBuild("alt."))
+
+	// Rebuilds are limited to one per interval, whether or not they succeed.
+	require.False(t, h.startBackgroundBuild("consul."))
+
+	h.backgroundBuilds["consul."] = time.Now().Add(-zoneRebuildInterval)
+	require.True(t, h.startBackgroundBuild("consul."))
+
+	// Invalidating the zones allows them to be rebuilt straight away.
+	h.invalidate()
+	require.True(t, h.startBackgroundBuild("consul."))
+}
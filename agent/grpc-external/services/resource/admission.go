@@ -0,0 +1,68 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/hashicorp/consul/internal/resource"
+	"github.com/hashicorp/consul/internal/storage"
+	"github.com/hashicorp/consul/proto-public/pbresource"
+)
+
+// admit runs the registration's admission hook for a resource that is about to
+// be written.
+func (s *Server) admit(ctx context.Context, reg *resource.Registration, res *pbresource.Resource) error {
+	err := reg.Admit(ctx, admissionReader{s}, res)
+	switch {
+	case err == nil:
+		return nil
+	case isGRPCStatusError(err):
+		return err
+	default:
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+}
+
+// admissionReader implements resource.Reader for admission hooks. Reads are
+// strongly consistent so that hooks don't reject writes based on stale data,
+// and aren't subject to the caller's ACLs because hooks only use them to
+// check the integrity of the write.
+type admissionReader struct{ s *Server }
+
+func (r admissionReader) Read(ctx context.Context, id *pbresource.ID) (*pbresource.Resource, error) {
+	res, err := r.s.readStored(ctx, storage.StrongConsistency, id)
+	switch {
+	case errors.Is(err, storage.ErrNotFound):
+		return nil, status.Error(codes.NotFound, err.Error())
+	case errors.As(err, &storage.GroupVersionMismatchError{}):
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	case err != nil:
+		return nil, status.Errorf(codes.Internal, "failed read: %v", err)
+	}
+	return r.s.convert(res, id.Type)
+}
+
+func (r admissionReader) List(ctx context.Context, typ *pbresource.Type, tenancy *pbresource.Tenancy, namePrefix string) ([]*pbresource.Resource, error) {
+	resources, err := r.s.Backend.List(ctx, storage.StrongConsistency, storage.UnversionedTypeFrom(typ), tenancy, namePrefix)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed list: %v", err)
+	}
+
+	result := make([]*pbresource.Resource, 0, len(resources))
+	for _, res := range resources {
+		if !resource.CanConvert(r.s.Registry, res.Id.Type, typ) {
+			continue
+		}
+		if res, err = r.s.convert(res, typ); err != nil {
+			return nil, err
+		}
+		result = append(result, res)
+	}
+	return result, nil
+}
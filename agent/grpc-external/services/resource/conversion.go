@@ -0,0 +1,40 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/hashicorp/consul/internal/resource"
+	"github.com/hashicorp/consul/internal/storage"
+	"github.com/hashicorp/consul/proto-public/pbresource"
+)
+
+// readStored reads the resource with the given ID. Unlike Backend.Read, if the
+// resource is stored at a different GroupVersion that can be converted to the
+// requested one, it's returned as-is rather than as a GroupVersionMismatchError.
+// Callers must use convert before returning it to the user.
+func (s *Server) readStored(ctx context.Context, consistency storage.ReadConsistency, id *pbresource.ID) (*pbresource.Resource, error) {
+	res, err := s.Backend.Read(ctx, consistency, id)
+
+	var mismatch storage.GroupVersionMismatchError
+	if errors.As(err, &mismatch) && resource.CanConvert(s.Registry, mismatch.Stored.Id.Type, id.Type) {
+		return mismatch.Stored, nil
+	}
+	return res, err
+}
+
+// convert the given resource to the requested type, using the conversions
+// registered for it.
+func (s *Server) convert(res *pbresource.Resource, typ *pbresource.Type) (*pbresource.Resource, error) {
+	converted, err := resource.Convert(s.Registry, res, typ)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to convert resource: %v", err)
+	}
+	return converted, nil
+}
@@ -6,11 +6,16 @@ package resource
 import (
 	"context"
 	"errors"
+	"time"
 
+	"github.com/oklog/ulid/v2"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
 
 	"github.com/hashicorp/consul/acl"
+	"github.com/hashicorp/consul/internal/resource"
 	"github.com/hashicorp/consul/internal/storage"
 	"github.com/hashicorp/consul/proto-public/pbresource"
 )
@@ -21,6 +26,9 @@ import (
 // - Delete of a previously deleted or non-existent resource is a no-op to support idempotency.
 // - Errors with Aborted if the requested Version does not match the stored Version.
 // - Errors with PermissionDenied if ACL check fails
+// - Writes a tombstone so owned resources are garbage collected in the background.
+// - With PROPAGATION_FOREGROUND, a resource that owns other resources is marked for deletion, and deleted by the garbage collector once they are gone.
+// - Resources with finalizers are marked for deletion rather than deleted, and are deleted once their finalizers are removed.
 //
 // TODO(spatel): Move docs to the proto file
 func (s *Server) Delete(ctx context.Context, req *pbresource.DeleteRequest) (*pbresource.DeleteResponse, error) {
@@ -47,28 +55,82 @@ func (s *Server) Delete(ctx context.Context, req *pbresource.DeleteRequest) (*pb
 	}
 
 	// The storage backend requires a Version and Uid to delete a resource based
-	// on CAS semantics. When either are not provided, the resource must be read
-	// with a strongly consistent read to retrieve either or both.
+	// on CAS semantics, and we need to know whether the resource has finalizers,
+	// so the resource must be read with a strongly consistent read.
 	//
 	// n.b.: There is a chance DeleteCAS may fail with a storage.ErrCASFailure
 	// if an update occurs between the Read and DeleteCAS. Consider refactoring
 	// to use retryCAS() similar to the Write endpoint to close this gap.
+	existing, err := s.readStored(ctx, storage.StrongConsistency, req.Id)
+	switch {
+	case err == nil:
+	case errors.Is(err, storage.ErrNotFound):
+		// Deletes are idempotent so no-op when not found
+		return &pbresource.DeleteResponse{}, nil
+	case errors.As(err, &storage.GroupVersionMismatchError{}):
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	default:
+		return nil, status.Errorf(codes.Internal, "failed read: %v", err)
+	}
+
+	// A previous incarnation of the resource has already been deleted.
+	if req.Id.Uid != "" && req.Id.Uid != existing.Id.Uid {
+		return &pbresource.DeleteResponse{}, nil
+	}
+
+	deleteId := existing.Id
 	deleteVersion := req.Version
-	deleteId := req.Id
-	if deleteVersion == "" || deleteId.Uid == "" {
-		existing, err := s.Backend.Read(ctx, storage.StrongConsistency, req.Id)
+	// Only use the stored version if the user isn't performing a CAS delete.
+	if deleteVersion == "" {
+		deleteVersion = existing.Version
+	}
+
+	// The resource is already waiting for its finalizers to be removed.
+	if resource.HasFinalizers(existing) && resource.IsMarkedForDeletion(existing) {
+		return &pbresource.DeleteResponse{}, nil
+	}
+
+	// Check the version before deleting the owned resources, as a CAS failure of
+	// the owner's delete would come too late to leave them in place.
+	if req.Version != "" && req.Version != existing.Version {
+		return nil, status.Error(codes.Aborted, storage.ErrCASFailure.Error())
+	}
+
+	if req.Propagation == pbresource.DeleteRequest_PROPAGATION_FOREGROUND {
+		owned, err := s.Backend.OwnerReferences(ctx, deleteId)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to list owned resources: %v", err)
+		}
+		if len(owned) != 0 {
+			if err := s.authorizeOwned(ctx, authz, owned); err != nil {
+				return nil, err
+			}
+			return s.markForForegroundDeletion(ctx, existing, deleteVersion)
+		}
+	}
+
+	// Resources with finalizers are marked for deletion instead, and deleted once
+	// their finalizers have been removed (see maybeDeleteFinalized).
+	if resource.HasFinalizers(existing) {
+		_, err = s.Backend.WriteCAS(ctx, markedForDeletion(existing, deleteVersion))
 		switch {
 		case err == nil:
-			deleteVersion = existing.Version
-			deleteId = existing.Id
-		case errors.Is(err, storage.ErrNotFound):
-			// Deletes are idempotent so no-op when not found
 			return &pbresource.DeleteResponse{}, nil
+		case errors.Is(err, storage.ErrCASFailure):
+			return nil, status.Error(codes.Aborted, err.Error())
 		default:
-			return nil, status.Errorf(codes.Internal, "failed read: %v", err)
+			return nil, status.Errorf(codes.Internal, "failed to mark resource for deletion: %v", err)
 		}
 	}
 
+	// The tombstone is written before the resource is deleted so that a failure
+	// between the two operations can't leave behind orphaned resources. If the
+	// delete fails, the garbage collector will discard the tombstone when it sees
+	// the owner still exists.
+	if err := s.maybeWriteTombstone(ctx, deleteId); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to write tombstone: %v", err)
+	}
+
 	err = s.Backend.DeleteCAS(ctx, deleteId, deleteVersion)
 	switch {
 	case err == nil:
@@ -80,6 +142,134 @@ func (s *Server) Delete(ctx context.Context, req *pbresource.DeleteRequest) (*pb
 	}
 }
 
+// markedForDeletion returns a copy of the given resource, which has finalizers,
+// marked for deletion. It must be written with the given version.
+func markedForDeletion(res *pbresource.Resource, version string) *pbresource.Resource {
+	marked := clone(res)
+	marked.Version = version
+	marked.Metadata[resource.DeletionTimestampKey] = time.Now().UTC().Format(time.RFC3339)
+	return marked
+}
+
+// maybeDeleteFinalized deletes the given resource if it's marked for deletion
+// and its finalizers have all been removed.
+func (s *Server) maybeDeleteFinalized(ctx context.Context, res *pbresource.Resource) error {
+	if !resource.IsMarkedForDeletion(res) || resource.HasFinalizers(res) {
+		return nil
+	}
+
+	if err := s.maybeWriteTombstone(ctx, res.Id); err != nil {
+		return status.Errorf(codes.Internal, "failed to write tombstone: %v", err)
+	}
+
+	// A CAS failure means the resource has been changed concurrently. As only
+	// the finalizers of a resource that is marked for deletion can be changed,
+	// that write will have deleted it instead.
+	err := s.Backend.DeleteCAS(ctx, res.Id, res.Version)
+	switch {
+	case err == nil, errors.Is(err, storage.ErrCASFailure):
+		return nil
+	default:
+		return status.Errorf(codes.Internal, "failed delete: %v", err)
+	}
+}
+
+// maybeWriteTombstone writes a tombstone for the resource with the given ID,
+// unless the resource is itself a tombstone or a tombstone already exists
+// (e.g. because the user is retrying a failed delete).
+func (s *Server) maybeWriteTombstone(ctx context.Context, owner *pbresource.ID) error {
+	if proto.Equal(owner.Type, resource.TypeV1Tombstone) {
+		return nil
+	}
+
+	tombstone, err := newTombstone(owner)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.Backend.WriteCAS(ctx, tombstone)
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, storage.ErrCASFailure), errors.Is(err, storage.ErrWrongUid):
+		// A tombstone for this incarnation of the owner already exists.
+		return nil
+	default:
+		return err
+	}
+}
+
+// newTombstone returns a tombstone resource for the given owner.
+func newTombstone(owner *pbresource.ID) (*pbresource.Resource, error) {
+	data, err := anypb.New(&pbresource.Tombstone{Owner: owner})
+	if err != nil {
+		return nil, err
+	}
+
+	return &pbresource.Resource{
+		Id: &pbresource.ID{
+			Type:    resource.TypeV1Tombstone,
+			Tenancy: owner.Tenancy,
+			Name:    resource.TombstoneNameFor(owner),
+			Uid:     ulid.Make().String(),
+		},
+		Generation: ulid.Make().String(),
+		Data:       data,
+	}, nil
+}
+
+// markForForegroundDeletion marks the given resource for deletion, with the
+// foreground deletion finalizer that the garbage collector removes once the
+// resources it owns have been deleted. The tombstone is written first, as it's
+// what the garbage collector reconciles.
+func (s *Server) markForForegroundDeletion(ctx context.Context, res *pbresource.Resource, version string) (*pbresource.DeleteResponse, error) {
+	if err := s.maybeWriteTombstone(ctx, res.Id); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to write tombstone: %v", err)
+	}
+
+	marked := clone(res)
+	resource.AddFinalizer(marked, resource.ForegroundDeletionFinalizer)
+
+	_, err := s.Backend.WriteCAS(ctx, markedForDeletion(marked, version))
+	switch {
+	case err == nil:
+		return &pbresource.DeleteResponse{}, nil
+	case errors.Is(err, storage.ErrCASFailure):
+		return nil, status.Error(codes.Aborted, err.Error())
+	default:
+		return nil, status.Errorf(codes.Internal, "failed to mark resource for deletion: %v", err)
+	}
+}
+
+// authorizeOwned checks the caller may delete the given owned resources, and
+// the resources owned by them, and so on, as they will be deleted on the
+// caller's behalf by a foreground delete.
+func (s *Server) authorizeOwned(ctx context.Context, authz acl.Authorizer, owned []*pbresource.ID) error {
+	for _, id := range owned {
+		reg, err := s.resolveType(id.Type)
+		if err != nil {
+			return status.Errorf(codes.FailedPrecondition, "cannot delete owned resource: %v", err)
+		}
+
+		err = reg.ACLs.Write(authz, id)
+		switch {
+		case acl.IsErrPermissionDenied(err):
+			return status.Error(codes.PermissionDenied, err.Error())
+		case err != nil:
+			return status.Errorf(codes.Internal, "failed write acl: %v", err)
+		}
+
+		children, err := s.Backend.OwnerReferences(ctx, id)
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to list owned resources: %v", err)
+		}
+		if err := s.authorizeOwned(ctx, authz, children); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func validateDeleteRequest(req *pbresource.DeleteRequest) error {
 	if req.Id == nil {
 		return status.Errorf(codes.InvalidArgument, "id is required")
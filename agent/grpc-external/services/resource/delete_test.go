@@ -7,15 +7,18 @@ import (
 	"context"
 	"testing"
 
+	"github.com/oklog/ulid/v2"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
 	"github.com/hashicorp/consul/acl/resolver"
+	"github.com/hashicorp/consul/internal/resource"
 	"github.com/hashicorp/consul/internal/resource/demo"
 	"github.com/hashicorp/consul/internal/storage"
 	"github.com/hashicorp/consul/proto-public/pbresource"
+	"github.com/hashicorp/consul/proto/private/prototest"
 )
 
 func TestDelete_InputValidation(t *testing.T) {
@@ -105,6 +108,7 @@ func TestDelete_ACLs(t *testing.T) {
 
 			artist, err := demo.GenerateV2Artist()
 			require.NoError(t, err)
+			artist.Id.Uid = ulid.Make().String()
 
 			artist, err = server.Backend.WriteCAS(context.Background(), artist)
 			require.NoError(t, err)
@@ -176,6 +180,265 @@ func TestDelete_VersionMismatch(t *testing.T) {
 	require.Error(t, err)
 	require.Equal(t, codes.Aborted.String(), status.Code(err).String())
 	require.ErrorContains(t, err, "CAS operation failed")
+
+	// the version must also be respected when deleting by name
+	id := clone(rsp.Resource.Id)
+	id.Uid = ""
+	_, err = client.Delete(ctx, &pbresource.DeleteRequest{Id: id, Version: "non-existent-version"})
+	require.Error(t, err)
+	require.Equal(t, codes.Aborted.String(), status.Code(err).String())
+}
+
+func TestDelete_WritesTombstone(t *testing.T) {
+	t.Parallel()
+
+	server, client, ctx := testDeps(t)
+	demo.Register(server.Registry)
+
+	artist, err := demo.GenerateV2Artist()
+	require.NoError(t, err)
+
+	rsp, err := client.Write(ctx, &pbresource.WriteRequest{Resource: artist})
+	require.NoError(t, err)
+	artist = rsp.Resource
+
+	_, err = client.Delete(ctx, &pbresource.DeleteRequest{Id: artist.Id})
+	require.NoError(t, err)
+
+	tombstone, err := server.Backend.Read(ctx, storage.StrongConsistency, &pbresource.ID{
+		Type:    resource.TypeV1Tombstone,
+		Tenancy: artist.Id.Tenancy,
+		Name:    resource.TombstoneNameFor(artist.Id),
+	})
+	require.NoError(t, err)
+
+	var data pbresource.Tombstone
+	require.NoError(t, tombstone.Data.UnmarshalTo(&data))
+	prototest.AssertDeepEqual(t, artist.Id, data.Owner)
+
+	// Deleting the tombstone must not write another tombstone.
+	_, err = client.Delete(ctx, &pbresource.DeleteRequest{Id: tombstone.Id})
+	require.NoError(t, err)
+
+	tombstones, err := server.Backend.List(ctx, storage.StrongConsistency, storage.UnversionedTypeFrom(resource.TypeV1Tombstone), artist.Id.Tenancy, "")
+	require.NoError(t, err)
+	require.Empty(t, tombstones)
+}
+
+func TestDelete_ForegroundPropagation(t *testing.T) {
+	t.Parallel()
+
+	server, client, ctx := testDeps(t)
+	demo.Register(server.Registry)
+
+	artist, err := demo.GenerateV2Artist()
+	require.NoError(t, err)
+
+	rsp, err := client.Write(ctx, &pbresource.WriteRequest{Resource: artist})
+	require.NoError(t, err)
+	artist = rsp.Resource
+
+	album, err := demo.GenerateV2Album(artist.Id)
+	require.NoError(t, err)
+
+	rsp, err = client.Write(ctx, &pbresource.WriteRequest{Resource: album})
+	require.NoError(t, err)
+	album = rsp.Resource
+
+	_, err = client.Delete(ctx, &pbresource.DeleteRequest{
+		Id:          artist.Id,
+		Propagation: pbresource.DeleteRequest_PROPAGATION_FOREGROUND,
+	})
+	require.NoError(t, err)
+
+	// The artist is marked for deletion, and left for the garbage collector to
+	// delete once the album is gone.
+	marked, err := server.Backend.Read(ctx, storage.StrongConsistency, artist.Id)
+	require.NoError(t, err)
+	require.True(t, resource.IsMarkedForDeletion(marked))
+	require.True(t, resource.HasFinalizer(marked, resource.ForegroundDeletionFinalizer))
+
+	_, err = server.Backend.Read(ctx, storage.StrongConsistency, album.Id)
+	require.NoError(t, err)
+
+	_, err = server.Backend.Read(ctx, storage.StrongConsistency, &pbresource.ID{
+		Type:    resource.TypeV1Tombstone,
+		Tenancy: artist.Id.Tenancy,
+		Name:    resource.TombstoneNameFor(artist.Id),
+	})
+	require.NoError(t, err)
+}
+
+func TestDelete_ForegroundPropagation_NoOwnedResources(t *testing.T) {
+	t.Parallel()
+
+	server, client, ctx := testDeps(t)
+	demo.Register(server.Registry)
+
+	artist, err := demo.GenerateV2Artist()
+	require.NoError(t, err)
+
+	rsp, err := client.Write(ctx, &pbresource.WriteRequest{Resource: artist})
+	require.NoError(t, err)
+	artist = rsp.Resource
+
+	_, err = client.Delete(ctx, &pbresource.DeleteRequest{
+		Id:          artist.Id,
+		Propagation: pbresource.DeleteRequest_PROPAGATION_FOREGROUND,
+	})
+	require.NoError(t, err)
+
+	_, err = server.Backend.Read(ctx, storage.StrongConsistency, artist.Id)
+	require.ErrorIs(t, err, storage.ErrNotFound)
+}
+
+func TestDelete_ForegroundPropagation_VersionMismatch(t *testing.T) {
+	t.Parallel()
+
+	server, client, ctx := testDeps(t)
+	demo.Register(server.Registry)
+
+	artist, err := demo.GenerateV2Artist()
+	require.NoError(t, err)
+
+	rsp, err := client.Write(ctx, &pbresource.WriteRequest{Resource: artist})
+	require.NoError(t, err)
+	artist = rsp.Resource
+
+	album, err := demo.GenerateV2Album(artist.Id)
+	require.NoError(t, err)
+
+	rsp, err = client.Write(ctx, &pbresource.WriteRequest{Resource: album})
+	require.NoError(t, err)
+	album = rsp.Resource
+
+	_, err = client.Delete(ctx, &pbresource.DeleteRequest{
+		Id:          artist.Id,
+		Version:     "non-existent-version",
+		Propagation: pbresource.DeleteRequest_PROPAGATION_FOREGROUND,
+	})
+	require.Error(t, err)
+	require.Equal(t, codes.Aborted.String(), status.Code(err).String())
+
+	// Neither resource should have been deleted.
+	_, err = server.Backend.Read(ctx, storage.StrongConsistency, album.Id)
+	require.NoError(t, err)
+
+	_, err = server.Backend.Read(ctx, storage.StrongConsistency, artist.Id)
+	require.NoError(t, err)
+}
+
+func TestDelete_Finalizers(t *testing.T) {
+	t.Parallel()
+
+	server, client, ctx := testDeps(t)
+	demo.Register(server.Registry)
+
+	artist, err := demo.GenerateV2Artist()
+	require.NoError(t, err)
+	resource.AddFinalizer(artist, "first")
+	resource.AddFinalizer(artist, "second")
+
+	rsp, err := client.Write(ctx, &pbresource.WriteRequest{Resource: artist})
+	require.NoError(t, err)
+	artist = rsp.Resource
+
+	// The artist is marked for deletion rather than deleted.
+	_, err = client.Delete(ctx, &pbresource.DeleteRequest{Id: artist.Id})
+	require.NoError(t, err)
+
+	readRsp, err := client.Read(ctx, &pbresource.ReadRequest{Id: artist.Id})
+	require.NoError(t, err)
+	marked := readRsp.Resource
+	require.True(t, resource.IsMarkedForDeletion(marked))
+	require.Equal(t, []string{"first", "second"}, resource.Finalizers(marked))
+
+	// No tombstone is written until the artist is actually deleted.
+	tombstoneId := &pbresource.ID{
+		Type:    resource.TypeV1Tombstone,
+		Tenancy: artist.Id.Tenancy,
+		Name:    resource.TombstoneNameFor(artist.Id),
+	}
+	_, err = server.Backend.Read(ctx, storage.StrongConsistency, tombstoneId)
+	require.ErrorIs(t, err, storage.ErrNotFound)
+
+	// Deleting it again is a no-op.
+	_, err = client.Delete(ctx, &pbresource.DeleteRequest{Id: artist.Id})
+	require.NoError(t, err)
+
+	readRsp, err = client.Read(ctx, &pbresource.ReadRequest{Id: artist.Id})
+	require.NoError(t, err)
+	prototest.AssertDeepEqual(t, marked, readRsp.Resource)
+
+	// Only finalizers can be removed.
+	_, err = client.Write(ctx, &pbresource.WriteRequest{Resource: modifyArtist(t, marked)})
+	require.Error(t, err)
+	require.Equal(t, codes.InvalidArgument.String(), status.Code(err).String())
+	require.ErrorContains(t, err, "only finalizers can be removed")
+
+	added := clone(marked)
+	resource.AddFinalizer(added, "third")
+	_, err = client.Write(ctx, &pbresource.WriteRequest{Resource: added})
+	require.Error(t, err)
+	require.Equal(t, codes.InvalidArgument.String(), status.Code(err).String())
+
+	// Removing a finalizer leaves the artist in place.
+	resource.RemoveFinalizer(marked, "first")
+	rsp, err = client.Write(ctx, &pbresource.WriteRequest{Resource: marked})
+	require.NoError(t, err)
+	marked = rsp.Resource
+	require.True(t, resource.IsMarkedForDeletion(marked))
+
+	// Removing the last finalizer deletes it.
+	resource.RemoveFinalizer(marked, "second")
+	_, err = client.Write(ctx, &pbresource.WriteRequest{Resource: marked})
+	require.NoError(t, err)
+
+	_, err = server.Backend.Read(ctx, storage.StrongConsistency, artist.Id)
+	require.ErrorIs(t, err, storage.ErrNotFound)
+
+	_, err = server.Backend.Read(ctx, storage.StrongConsistency, tombstoneId)
+	require.NoError(t, err)
+}
+
+func TestDelete_ForegroundPropagation_ACLs(t *testing.T) {
+	t.Parallel()
+
+	server, client, ctx := testDeps(t)
+	demo.Register(server.Registry)
+
+	artist, err := demo.GenerateV2Artist()
+	require.NoError(t, err)
+	artist.Id.Uid = ulid.Make().String()
+
+	artist, err = server.Backend.WriteCAS(ctx, artist)
+	require.NoError(t, err)
+
+	album, err := demo.GenerateV2Album(artist.Id)
+	require.NoError(t, err)
+
+	album, err = server.Backend.WriteCAS(ctx, album)
+	require.NoError(t, err)
+
+	// Caller may delete the artist, but not the album it owns.
+	mockACLResolver := &MockACLResolver{}
+	mockACLResolver.On("ResolveTokenAndDefaultMeta", mock.Anything, mock.Anything, mock.Anything).
+		Return(AuthorizerFrom(t, demo.ArtistV2WritePolicy), nil)
+	server.ACLResolver = mockACLResolver
+
+	_, err = client.Delete(ctx, &pbresource.DeleteRequest{
+		Id:          artist.Id,
+		Propagation: pbresource.DeleteRequest_PROPAGATION_FOREGROUND,
+	})
+	require.Error(t, err)
+	require.Equal(t, codes.PermissionDenied.String(), status.Code(err).String())
+
+	// Neither resource should have been deleted.
+	_, err = server.Backend.Read(ctx, storage.StrongConsistency, album.Id)
+	require.NoError(t, err)
+
+	_, err = server.Backend.Read(ctx, storage.StrongConsistency, artist.Id)
+	require.NoError(t, err)
 }
 
 func testDeps(t *testing.T) (*Server, pbresource.ResourceServiceClient, context.Context) {
@@ -5,11 +5,14 @@ package resource
 
 import (
 	"context"
+	"errors"
 
+	"github.com/hashicorp/go-bexpr"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
 	"github.com/hashicorp/consul/acl"
+	"github.com/hashicorp/consul/internal/resource"
 	"github.com/hashicorp/consul/internal/storage"
 	"github.com/hashicorp/consul/proto-public/pbresource"
 )
@@ -35,33 +38,114 @@ func (s *Server) List(ctx context.Context, req *pbresource.ListRequest) (*pbreso
 		return nil, status.Errorf(codes.Internal, "failed list acl: %v", err)
 	}
 
-	resources, err := s.Backend.List(
+	filter, err := validateListFilters(reg, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// The storage backend evaluates the filter against the stored data, so when
+	// resources stored at other GroupVersions may be converted to the requested
+	// one, we must evaluate it ourselves after conversion instead.
+	opts := storage.ListOptions{
+		PageSize:      int(req.PageSize),
+		PageToken:     req.PageToken,
+		LabelSelector: req.LabelSelector,
+		Filter:        req.Filter,
+	}
+	convertible := len(reg.Conversions) != 0
+	if convertible {
+		opts.Filter = ""
+	}
+
+	resources, nextPageToken, err := s.Backend.ListPage(
 		ctx,
 		readConsistencyFrom(ctx),
 		storage.UnversionedTypeFrom(req.Type),
 		req.Tenancy,
 		req.NamePrefix,
+		opts,
 	)
-	if err != nil {
+	switch {
+	case errors.Is(err, storage.ErrInvalidListOptions):
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	case err != nil:
 		return nil, status.Errorf(codes.Internal, "failed list: %v", err)
 	}
 
 	result := make([]*pbresource.Resource, 0)
-	for _, resource := range resources {
-		// filter out non-matching GroupVersion
-		if resource.Id.Type.GroupVersion != req.Type.GroupVersion {
-			continue
+	for _, res := range resources {
+		// convert resources stored at another GroupVersion, or filter them out if
+		// there's no conversion.
+		if res.Id.Type.GroupVersion != req.Type.GroupVersion {
+			if !resource.CanConvert(s.Registry, res.Id.Type, req.Type) {
+				continue
+			}
+			if res, err = s.convert(res, req.Type); err != nil {
+				return nil, err
+			}
+		}
+
+		if convertible && filter != nil {
+			match, err := matchesFilter(filter, res)
+			if err != nil {
+				return nil, err
+			}
+			if !match {
+				continue
+			}
 		}
 
 		// filter out items that don't pass read ACLs
-		err = reg.ACLs.Read(authz, resource.Id)
+		err = reg.ACLs.Read(authz, res.Id)
 		switch {
 		case acl.IsErrPermissionDenied(err):
 			continue
 		case err != nil:
 			return nil, status.Errorf(codes.Internal, "failed read acl: %v", err)
 		}
-		result = append(result, resource)
+		result = append(result, res)
+	}
+	return &pbresource.ListResponse{Resources: result, NextPageToken: nextPageToken}, nil
+}
+
+// validateListFilters checks the label selector and filter expression are
+// valid, and that the filter only refers to fields of the requested type's
+// data, before we hand them to the storage backend (which ignores resources
+// the filter cannot be evaluated against). It returns an evaluator for the
+// filter, or nil if there isn't one.
+func validateListFilters(reg *resource.Registration, req *pbresource.ListRequest) (*bexpr.Evaluator, error) {
+	if _, err := storage.ParseLabelSelector(req.LabelSelector); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid label_selector: %v", err)
+	}
+
+	if req.Filter == "" {
+		return nil, nil
+	}
+
+	filter, err := bexpr.CreateEvaluatorForType(req.Filter, nil, reg.Proto)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid filter: %v", err)
+	}
+	return filter, nil
+}
+
+// matchesFilter returns whether the data of the given resource, which must be
+// of the requested type, matches the filter.
+func matchesFilter(filter *bexpr.Evaluator, res *pbresource.Resource) (bool, error) {
+	if res.Data == nil {
+		return false, nil
+	}
+
+	data, err := res.Data.UnmarshalNew()
+	if err != nil {
+		return false, status.Errorf(codes.Internal, "failed to decode data: %v", err)
+	}
+
+	match, err := filter.Evaluate(data)
+	if err != nil {
+		// Mirror the storage backend, which doesn't match resources the filter
+		// can't be evaluated against.
+		return false, nil
 	}
-	return &pbresource.ListResponse{Resources: result}, nil
+	return match, nil
 }
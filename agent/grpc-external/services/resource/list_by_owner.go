@@ -0,0 +1,98 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/hashicorp/consul/acl"
+	"github.com/hashicorp/consul/internal/storage"
+	"github.com/hashicorp/consul/proto-public/pbresource"
+)
+
+// ListByOwner returns the resources owned by the resource with the given ID.
+//
+// The owner ID must include a Uid, because ownership is tied to a specific
+// "incarnation" of the owner. The owner doesn't need to still exist, which
+// makes it possible to find (and garbage collect) resources whose owner has
+// been deleted.
+func (s *Server) ListByOwner(ctx context.Context, req *pbresource.ListByOwnerRequest) (*pbresource.ListByOwnerResponse, error) {
+	if err := validateListByOwnerRequest(req); err != nil {
+		return nil, err
+	}
+
+	reg, err := s.resolveType(req.Owner.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	authz, err := s.getAuthorizer(tokenFromContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	// check acls
+	err = reg.ACLs.Read(authz, req.Owner)
+	switch {
+	case acl.IsErrPermissionDenied(err):
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	case err != nil:
+		return nil, status.Errorf(codes.Internal, "failed read acl: %v", err)
+	}
+
+	children, err := s.Backend.OwnerReferences(ctx, req.Owner)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed list by owner: %v", err)
+	}
+
+	result := make([]*pbresource.Resource, 0, len(children))
+	for _, childId := range children {
+		childReg, err := s.resolveType(childId.Type)
+		if err != nil {
+			// The child's type may have been unregistered since it was written
+			// (e.g. a feature was disabled), so skip rather than fail.
+			continue
+		}
+
+		// filter out items that don't pass read ACLs
+		err = childReg.ACLs.Read(authz, childId)
+		switch {
+		case acl.IsErrPermissionDenied(err):
+			continue
+		case err != nil:
+			return nil, status.Errorf(codes.Internal, "failed read acl: %v", err)
+		}
+
+		child, err := s.Backend.Read(ctx, readConsistencyFrom(ctx), childId)
+		switch {
+		case err == nil:
+			result = append(result, child)
+		case errors.Is(err, storage.ErrNotFound):
+			// OwnerReferences and Read may race with a concurrent delete.
+			continue
+		default:
+			return nil, status.Errorf(codes.Internal, "failed read: %v", err)
+		}
+	}
+	return &pbresource.ListByOwnerResponse{Resources: result}, nil
+}
+
+func validateListByOwnerRequest(req *pbresource.ListByOwnerRequest) error {
+	if req.Owner == nil {
+		return status.Errorf(codes.InvalidArgument, "owner is required")
+	}
+
+	if err := validateId(req.Owner, "owner"); err != nil {
+		return err
+	}
+
+	if req.Owner.Uid == "" {
+		return status.Errorf(codes.InvalidArgument, "owner.uid is required")
+	}
+	return nil
+}
@@ -0,0 +1,158 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"context"
+	"testing"
+
+	"github.com/oklog/ulid/v2"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/hashicorp/consul/acl/resolver"
+	"github.com/hashicorp/consul/internal/resource/demo"
+	"github.com/hashicorp/consul/proto-public/pbresource"
+	"github.com/hashicorp/consul/proto/private/prototest"
+)
+
+func TestListByOwner_InputValidation(t *testing.T) {
+	server := testServer(t)
+	client := testClient(t, server)
+
+	demo.Register(server.Registry)
+
+	testCases := map[string]func(*pbresource.ListByOwnerRequest){
+		"no owner":   func(req *pbresource.ListByOwnerRequest) { req.Owner = nil },
+		"no type":    func(req *pbresource.ListByOwnerRequest) { req.Owner.Type = nil },
+		"no tenancy": func(req *pbresource.ListByOwnerRequest) { req.Owner.Tenancy = nil },
+		"no name":    func(req *pbresource.ListByOwnerRequest) { req.Owner.Name = "" },
+		"no uid":     func(req *pbresource.ListByOwnerRequest) { req.Owner.Uid = "" },
+	}
+	for desc, modFn := range testCases {
+		t.Run(desc, func(t *testing.T) {
+			res, err := demo.GenerateV2Artist()
+			require.NoError(t, err)
+			res.Id.Uid = "bogus"
+
+			req := &pbresource.ListByOwnerRequest{Owner: res.Id}
+			modFn(req)
+
+			_, err = client.ListByOwner(testContext(t), req)
+			require.Error(t, err)
+			require.Equal(t, codes.InvalidArgument.String(), status.Code(err).String())
+		})
+	}
+}
+
+func TestListByOwner_TypeNotRegistered(t *testing.T) {
+	_, client, ctx := testDeps(t)
+
+	artist, err := demo.GenerateV2Artist()
+	require.NoError(t, err)
+	artist.Id.Uid = "bogus"
+
+	_, err = client.ListByOwner(ctx, &pbresource.ListByOwnerRequest{Owner: artist.Id})
+	require.Error(t, err)
+	require.Equal(t, codes.InvalidArgument.String(), status.Code(err).String())
+	require.Contains(t, err.Error(), "resource type demo.v2.artist not registered")
+}
+
+func TestListByOwner_Success(t *testing.T) {
+	server, client, ctx := testDeps(t)
+	demo.Register(server.Registry)
+
+	artist, err := demo.GenerateV2Artist()
+	require.NoError(t, err)
+
+	rsp, err := client.Write(ctx, &pbresource.WriteRequest{Resource: artist})
+	require.NoError(t, err)
+	artist = rsp.Resource
+
+	// No owned resources yet.
+	listRsp, err := client.ListByOwner(ctx, &pbresource.ListByOwnerRequest{Owner: artist.Id})
+	require.NoError(t, err)
+	require.Empty(t, listRsp.Resources)
+
+	album, err := demo.GenerateV2Album(artist.Id)
+	require.NoError(t, err)
+
+	rsp, err = client.Write(ctx, &pbresource.WriteRequest{Resource: album})
+	require.NoError(t, err)
+	album = rsp.Resource
+
+	listRsp, err = client.ListByOwner(ctx, &pbresource.ListByOwnerRequest{Owner: artist.Id})
+	require.NoError(t, err)
+	prototest.AssertElementsMatch(t, []*pbresource.Resource{album}, listRsp.Resources)
+
+	// Owned resources can still be listed after the owner has been deleted.
+	_, err = client.Delete(ctx, &pbresource.DeleteRequest{Id: artist.Id})
+	require.NoError(t, err)
+
+	listRsp, err = client.ListByOwner(ctx, &pbresource.ListByOwnerRequest{Owner: artist.Id})
+	require.NoError(t, err)
+	prototest.AssertElementsMatch(t, []*pbresource.Resource{album}, listRsp.Resources)
+}
+
+func TestListByOwner_ACLs(t *testing.T) {
+	type testCase struct {
+		authz    resolver.Result
+		assertFn func(*pbresource.ListByOwnerResponse, error)
+	}
+	testcases := map[string]testCase{
+		"owner read denied": {
+			authz: AuthorizerFrom(t, demo.ArtistV1ReadPolicy),
+			assertFn: func(_ *pbresource.ListByOwnerResponse, err error) {
+				require.Error(t, err)
+				require.Equal(t, codes.PermissionDenied.String(), status.Code(err).String())
+			},
+		},
+		"owned resources filtered": {
+			authz: AuthorizerFrom(t, demo.ArtistV2ReadPolicy),
+			assertFn: func(rsp *pbresource.ListByOwnerResponse, err error) {
+				require.NoError(t, err)
+				require.Empty(t, rsp.Resources)
+			},
+		},
+		"owned resources allowed": {
+			authz: AuthorizerFrom(t, demo.ArtistV2ReadPolicy, `key_prefix "resource/demo.v2.album/" { policy = "read" }`),
+			assertFn: func(rsp *pbresource.ListByOwnerResponse, err error) {
+				require.NoError(t, err)
+				require.Len(t, rsp.Resources, 1)
+			},
+		},
+	}
+
+	for desc, tc := range testcases {
+		t.Run(desc, func(t *testing.T) {
+			server := testServer(t)
+			client := testClient(t, server)
+
+			mockACLResolver := &MockACLResolver{}
+			mockACLResolver.On("ResolveTokenAndDefaultMeta", mock.Anything, mock.Anything, mock.Anything).
+				Return(tc.authz, nil)
+			server.ACLResolver = mockACLResolver
+			demo.Register(server.Registry)
+
+			artist, err := demo.GenerateV2Artist()
+			require.NoError(t, err)
+			artist.Id.Uid = ulid.Make().String()
+
+			artist, err = server.Backend.WriteCAS(context.Background(), artist)
+			require.NoError(t, err)
+
+			album, err := demo.GenerateV2Album(artist.Id)
+			require.NoError(t, err)
+
+			_, err = server.Backend.WriteCAS(context.Background(), album)
+			require.NoError(t, err)
+
+			// exercise ACL
+			rsp, err := client.ListByOwner(testContext(t), &pbresource.ListByOwnerRequest{Owner: artist.Id})
+			tc.assertFn(rsp, err)
+		})
+	}
+}
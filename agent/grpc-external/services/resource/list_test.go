@@ -10,9 +10,11 @@ import (
 
 	"github.com/hashicorp/consul/acl"
 	"github.com/hashicorp/consul/agent/grpc-external/testutils"
+	"github.com/hashicorp/consul/internal/resource"
 	"github.com/hashicorp/consul/internal/resource/demo"
 	"github.com/hashicorp/consul/internal/storage"
 	"github.com/hashicorp/consul/proto-public/pbresource"
+	pbdemov2 "github.com/hashicorp/consul/proto/private/pbdemo/v2"
 	"github.com/hashicorp/consul/proto/private/prototest"
 
 	"github.com/stretchr/testify/mock"
@@ -20,6 +22,7 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/anypb"
 )
 
 func TestList_TypeNotFound(t *testing.T) {
@@ -109,6 +112,63 @@ func TestList_GroupVersionMismatch(t *testing.T) {
 	}
 }
 
+func TestList_Conversion(t *testing.T) {
+	for desc, tc := range listTestCases() {
+		t.Run(desc, func(t *testing.T) {
+			server := testServer(t)
+			demo.Register(server.Registry)
+			client := testClient(t, server)
+
+			stored, err := server.Backend.WriteCAS(tc.ctx, generateV1Album(t, server.Registry))
+			require.NoError(t, err)
+
+			rsp, err := client.List(tc.ctx, &pbresource.ListRequest{
+				Type:    demo.TypeV2Album,
+				Tenancy: stored.Id.Tenancy,
+			})
+			require.NoError(t, err)
+
+			expected, err := resource.Convert(server.Registry, stored, demo.TypeV2Album)
+			require.NoError(t, err)
+			prototest.AssertElementsMatch(t, []*pbresource.Resource{expected}, rsp.Resources)
+		})
+	}
+}
+
+func TestList_ConversionAndFilter(t *testing.T) {
+	server := testServer(t)
+	demo.Register(server.Registry)
+	client := testClient(t, server)
+	ctx := testContext(t)
+
+	stored, err := server.Backend.WriteCAS(ctx, generateV1Album(t, server.Registry))
+	require.NoError(t, err)
+
+	expected, err := resource.Convert(server.Registry, stored, demo.TypeV2Album)
+	require.NoError(t, err)
+
+	var album pbdemov2.Album
+	require.NoError(t, expected.Data.UnmarshalTo(&album))
+
+	// The filter refers to v2 fields, so it must be evaluated against the
+	// converted data rather than the stored v1 data.
+	rsp, err := client.List(ctx, &pbresource.ListRequest{
+		Type:    demo.TypeV2Album,
+		Tenancy: stored.Id.Tenancy,
+		Filter:  fmt.Sprintf("Title == %q", album.Title),
+	})
+	require.NoError(t, err)
+	prototest.AssertElementsMatch(t, []*pbresource.Resource{expected}, rsp.Resources)
+
+	rsp, err = client.List(ctx, &pbresource.ListRequest{
+		Type:    demo.TypeV2Album,
+		Tenancy: stored.Id.Tenancy,
+		Filter:  fmt.Sprintf("Title != %q", album.Title),
+	})
+	require.NoError(t, err)
+	require.Empty(t, rsp.Resources)
+}
+
 func TestList_VerifyReadConsistencyArg(t *testing.T) {
 	// Uses a mockBackend instead of the inmem Backend to verify the ReadConsistency argument is set correctly.
 	for desc, tc := range listTestCases() {
@@ -121,14 +181,14 @@ func TestList_VerifyReadConsistencyArg(t *testing.T) {
 			artist, err := demo.GenerateV2Artist()
 			require.NoError(t, err)
 
-			mockBackend.On("List", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
-				Return([]*pbresource.Resource{artist}, nil)
+			mockBackend.On("ListPage", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+				Return([]*pbresource.Resource{artist}, "", nil)
 			client := testClient(t, server)
 
 			rsp, err := client.List(tc.ctx, &pbresource.ListRequest{Type: artist.Id.Type, Tenancy: artist.Id.Tenancy, NamePrefix: ""})
 			require.NoError(t, err)
 			prototest.AssertDeepEqual(t, artist, rsp.Resources[0])
-			mockBackend.AssertCalled(t, "List", mock.Anything, tc.consistency, mock.Anything, mock.Anything, mock.Anything)
+			mockBackend.AssertCalled(t, "ListPage", mock.Anything, tc.consistency, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
 		})
 	}
 }
@@ -174,6 +234,143 @@ func TestList_ACL_ListAllowed_ReadAllowed(t *testing.T) {
 	prototest.AssertDeepEqual(t, artist, rsp.Resources[0])
 }
 
+func TestList_Pagination(t *testing.T) {
+	server := testServer(t)
+	demo.Register(server.Registry)
+	client := testClient(t, server)
+	ctx := testContext(t)
+
+	expected := make([]*pbresource.Resource, 5)
+	for i := 0; i < len(expected); i++ {
+		artist, err := demo.GenerateV2Artist()
+		require.NoError(t, err)
+		artist.Id.Name = fmt.Sprintf("artist-%d", i)
+
+		rsp, err := client.Write(ctx, &pbresource.WriteRequest{Resource: artist})
+		require.NoError(t, err)
+		expected[i] = rsp.Resource
+	}
+
+	var (
+		pages  int
+		token  string
+		actual []*pbresource.Resource
+	)
+	for {
+		rsp, err := client.List(ctx, &pbresource.ListRequest{
+			Type:      demo.TypeV2Artist,
+			Tenancy:   demo.TenancyDefault,
+			PageSize:  2,
+			PageToken: token,
+		})
+		require.NoError(t, err)
+		require.LessOrEqual(t, len(rsp.Resources), 2)
+
+		pages++
+		actual = append(actual, rsp.Resources...)
+
+		if rsp.NextPageToken == "" {
+			break
+		}
+		token = rsp.NextPageToken
+	}
+	require.Equal(t, 3, pages)
+	prototest.AssertDeepEqual(t, expected, actual)
+}
+
+func TestList_LabelSelectorAndFilter(t *testing.T) {
+	server := testServer(t)
+	demo.Register(server.Registry)
+	client := testClient(t, server)
+	ctx := testContext(t)
+
+	writeArtist := func(name string, genre pbdemov2.Genre, metadata map[string]string) *pbresource.Resource {
+		data, err := anypb.New(&pbdemov2.Artist{Name: name, Genre: genre})
+		require.NoError(t, err)
+
+		rsp, err := client.Write(ctx, &pbresource.WriteRequest{
+			Resource: &pbresource.Resource{
+				Id: &pbresource.ID{
+					Type:    demo.TypeV2Artist,
+					Tenancy: demo.TenancyDefault,
+					Name:    name,
+				},
+				Metadata: metadata,
+				Data:     data,
+			},
+		})
+		require.NoError(t, err)
+		return rsp.Resource
+	}
+
+	jazz := writeArtist("jazz", pbdemov2.Genre_GENRE_JAZZ, map[string]string{"tier": "gold"})
+	blues := writeArtist("blues", pbdemov2.Genre_GENRE_BLUES, map[string]string{"tier": "silver"})
+	disco := writeArtist("disco", pbdemov2.Genre_GENRE_DISCO, nil)
+
+	testCases := map[string]struct {
+		labelSelector string
+		filter        string
+		expected      []*pbresource.Resource
+	}{
+		"label equals": {
+			labelSelector: "tier=gold",
+			expected:      []*pbresource.Resource{jazz},
+		},
+		"label in": {
+			labelSelector: "tier in (gold, silver)",
+			expected:      []*pbresource.Resource{jazz, blues},
+		},
+		"label not exists": {
+			labelSelector: "!tier",
+			expected:      []*pbresource.Resource{disco},
+		},
+		"filter": {
+			filter:   fmt.Sprintf("Genre == %d", pbdemov2.Genre_GENRE_BLUES),
+			expected: []*pbresource.Resource{blues},
+		},
+		"label and filter": {
+			labelSelector: "tier",
+			filter:        `Name != "jazz"`,
+			expected:      []*pbresource.Resource{blues},
+		},
+	}
+	for desc, tc := range testCases {
+		t.Run(desc, func(t *testing.T) {
+			rsp, err := client.List(ctx, &pbresource.ListRequest{
+				Type:          demo.TypeV2Artist,
+				Tenancy:       demo.TenancyDefault,
+				LabelSelector: tc.labelSelector,
+				Filter:        tc.filter,
+			})
+			require.NoError(t, err)
+			prototest.AssertElementsMatch(t, tc.expected, rsp.Resources)
+		})
+	}
+}
+
+func TestList_InvalidListOptions(t *testing.T) {
+	server := testServer(t)
+	demo.Register(server.Registry)
+	client := testClient(t, server)
+
+	testCases := map[string]*pbresource.ListRequest{
+		"invalid label selector": {LabelSelector: "tier in gold"},
+		"invalid filter syntax":  {Filter: "Name =="},
+		"unknown filter field":   {Filter: `Title == "foo"`},
+		"invalid page token":     {PageToken: "not a token"},
+	}
+	for desc, req := range testCases {
+		t.Run(desc, func(t *testing.T) {
+			req.Type = demo.TypeV2Artist
+			req.Tenancy = demo.TenancyDefault
+
+			_, err := client.List(testContext(t), req)
+			require.Error(t, err)
+			require.Equal(t, codes.InvalidArgument.String(), status.Code(err).String())
+		})
+	}
+}
+
 // roundtrip a List which attempts to return a single resource
 func roundTripList(t *testing.T, authz acl.Authorizer) (*pbresource.Resource, *pbresource.ListResponse, error) {
 	server := testServer(t)
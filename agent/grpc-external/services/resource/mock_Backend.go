@@ -56,6 +56,39 @@ func (_m *MockBackend) List(ctx context.Context, consistency storage.ReadConsist
 	return r0, r1
 }
 
+// ListPage provides a mock function with given fields: ctx, consistency, resType, tenancy, namePrefix, opts
+func (_m *MockBackend) ListPage(ctx context.Context, consistency storage.ReadConsistency, resType storage.UnversionedType, tenancy *pbresource.Tenancy, namePrefix string, opts storage.ListOptions) ([]*pbresource.Resource, string, error) {
+	ret := _m.Called(ctx, consistency, resType, tenancy, namePrefix, opts)
+
+	var r0 []*pbresource.Resource
+	var r1 string
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, storage.ReadConsistency, storage.UnversionedType, *pbresource.Tenancy, string, storage.ListOptions) ([]*pbresource.Resource, string, error)); ok {
+		return rf(ctx, consistency, resType, tenancy, namePrefix, opts)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, storage.ReadConsistency, storage.UnversionedType, *pbresource.Tenancy, string, storage.ListOptions) []*pbresource.Resource); ok {
+		r0 = rf(ctx, consistency, resType, tenancy, namePrefix, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*pbresource.Resource)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, storage.ReadConsistency, storage.UnversionedType, *pbresource.Tenancy, string, storage.ListOptions) string); ok {
+		r1 = rf(ctx, consistency, resType, tenancy, namePrefix, opts)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, storage.ReadConsistency, storage.UnversionedType, *pbresource.Tenancy, string, storage.ListOptions) error); ok {
+		r2 = rf(ctx, consistency, resType, tenancy, namePrefix, opts)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // OwnerReferences provides a mock function with given fields: ctx, id
 func (_m *MockBackend) OwnerReferences(ctx context.Context, id *pbresource.ID) ([]*pbresource.ID, error) {
 	ret := _m.Called(ctx, id)
@@ -108,6 +141,32 @@ func (_m *MockBackend) Read(ctx context.Context, consistency storage.ReadConsist
 	return r0, r1
 }
 
+// Txn provides a mock function with given fields: ctx, ops
+func (_m *MockBackend) Txn(ctx context.Context, ops []storage.TxnOp) ([]*pbresource.Resource, error) {
+	ret := _m.Called(ctx, ops)
+
+	var r0 []*pbresource.Resource
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []storage.TxnOp) ([]*pbresource.Resource, error)); ok {
+		return rf(ctx, ops)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []storage.TxnOp) []*pbresource.Resource); ok {
+		r0 = rf(ctx, ops)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*pbresource.Resource)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []storage.TxnOp) error); ok {
+		r1 = rf(ctx, ops)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // WatchList provides a mock function with given fields: ctx, resType, tenancy, namePrefix
 func (_m *MockBackend) WatchList(ctx context.Context, resType storage.UnversionedType, tenancy *pbresource.Tenancy, namePrefix string) (storage.Watch, error) {
 	ret := _m.Called(ctx, resType, tenancy, namePrefix)
@@ -134,6 +193,32 @@ func (_m *MockBackend) WatchList(ctx context.Context, resType storage.Unversione
 	return r0, r1
 }
 
+// WatchListFrom provides a mock function with given fields: ctx, resType, tenancy, namePrefix, index
+func (_m *MockBackend) WatchListFrom(ctx context.Context, resType storage.UnversionedType, tenancy *pbresource.Tenancy, namePrefix string, index uint64) (storage.Watch, error) {
+	ret := _m.Called(ctx, resType, tenancy, namePrefix, index)
+
+	var r0 storage.Watch
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, storage.UnversionedType, *pbresource.Tenancy, string, uint64) (storage.Watch, error)); ok {
+		return rf(ctx, resType, tenancy, namePrefix, index)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, storage.UnversionedType, *pbresource.Tenancy, string, uint64) storage.Watch); ok {
+		r0 = rf(ctx, resType, tenancy, namePrefix, index)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(storage.Watch)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, storage.UnversionedType, *pbresource.Tenancy, string, uint64) error); ok {
+		r1 = rf(ctx, resType, tenancy, namePrefix, index)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // WriteCAS provides a mock function with given fields: ctx, res
 func (_m *MockBackend) WriteCAS(ctx context.Context, res *pbresource.Resource) (*pbresource.Resource, error) {
 	ret := _m.Called(ctx, res)
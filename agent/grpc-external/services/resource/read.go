@@ -40,9 +40,13 @@ func (s *Server) Read(ctx context.Context, req *pbresource.ReadRequest) (*pbreso
 		return nil, status.Errorf(codes.Internal, "failed read acl: %v", err)
 	}
 
-	resource, err := s.Backend.Read(ctx, readConsistencyFrom(ctx), req.Id)
+	resource, err := s.readStored(ctx, readConsistencyFrom(ctx), req.Id)
 	switch {
 	case err == nil:
+		resource, err = s.convert(resource, req.Id.Type)
+		if err != nil {
+			return nil, err
+		}
 		return &pbresource.ReadResponse{Resource: resource}, nil
 	case errors.Is(err, storage.ErrNotFound):
 		return nil, status.Error(codes.NotFound, err.Error())
@@ -118,6 +118,30 @@ func TestRead_GroupVersionMismatch(t *testing.T) {
 	}
 }
 
+func TestRead_Conversion(t *testing.T) {
+	for desc, tc := range readTestCases() {
+		t.Run(desc, func(t *testing.T) {
+			server := testServer(t)
+
+			demo.Register(server.Registry)
+			client := testClient(t, server)
+
+			stored, err := server.Backend.WriteCAS(tc.ctx, generateV1Album(t, server.Registry))
+			require.NoError(t, err)
+
+			id := clone(stored.Id)
+			id.Type = demo.TypeV2Album
+
+			rsp, err := client.Read(tc.ctx, &pbresource.ReadRequest{Id: id})
+			require.NoError(t, err)
+
+			expected, err := resource.Convert(server.Registry, stored, demo.TypeV2Album)
+			require.NoError(t, err)
+			prototest.AssertDeepEqual(t, expected, rsp.Resource)
+		})
+	}
+}
+
 func TestRead_Success(t *testing.T) {
 	for desc, tc := range readTestCases() {
 		t.Run(desc, func(t *testing.T) {
@@ -5,12 +5,15 @@ package resource
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/anypb"
 
 	"github.com/hashicorp/go-uuid"
@@ -20,6 +23,7 @@ import (
 	"github.com/hashicorp/consul/agent/grpc-external/testutils"
 	"github.com/hashicorp/consul/agent/structs"
 	"github.com/hashicorp/consul/internal/resource"
+	"github.com/hashicorp/consul/internal/resource/demo"
 	"github.com/hashicorp/consul/internal/storage/inmem"
 	"github.com/hashicorp/consul/proto-public/pbresource"
 	pbdemov2 "github.com/hashicorp/consul/proto/private/pbdemo/v2"
@@ -107,3 +111,45 @@ func modifyArtist(t *testing.T, res *pbresource.Resource) *pbresource.Resource {
 	res.Data = data
 	return res
 }
+
+// typeAdmittedAlbum is used to test admission hooks. Its registration rejects
+// albums owned by an artist that doesn't exist.
+var typeAdmittedAlbum = &pbresource.Type{
+	Group:        "demo",
+	GroupVersion: "v2",
+	Kind:         "admittedalbum",
+}
+
+func registerAdmittedAlbum(registry resource.Registry) {
+	registry.Register(resource.Registration{
+		Type:  typeAdmittedAlbum,
+		Proto: &pbdemov2.Album{},
+		Admit: func(ctx context.Context, reader resource.Reader, res *pbresource.Resource) error {
+			if res.Owner == nil {
+				return errors.New("album must have an owner")
+			}
+
+			_, err := reader.Read(ctx, res.Owner)
+			if status.Code(err) == codes.NotFound {
+				return status.Errorf(codes.FailedPrecondition, "artist %q does not exist", res.Owner.Name)
+			}
+			return err
+		},
+	})
+}
+
+// generateV1Album generates a random Album resource at the v1 GroupVersion,
+// which can be converted to and from v2.
+func generateV1Album(t *testing.T, registry resource.Registry) *pbresource.Resource {
+	t.Helper()
+
+	artist, err := demo.GenerateV2Artist()
+	require.NoError(t, err)
+
+	album, err := demo.GenerateV2Album(artist.Id)
+	require.NoError(t, err)
+
+	album, err = resource.Convert(registry, album, demo.TypeV1Album)
+	require.NoError(t, err)
+	return album
+}
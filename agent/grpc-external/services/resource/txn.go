@@ -0,0 +1,313 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/hashicorp/consul/acl"
+	"github.com/hashicorp/consul/internal/resource"
+	"github.com/hashicorp/consul/internal/storage"
+	"github.com/hashicorp/consul/proto-public/pbresource"
+)
+
+// Txn applies a batch of writes and deletes atomically.
+// - Each operation has the same semantics, ACL checks, and hooks as the equivalent Write or Delete.
+// - A resource may only be targeted by one operation in the transaction.
+// - Deletes must use the default (background) propagation.
+// - Admission hooks see the state of the world before the transaction is applied.
+// - Errors with Aborted if any requested Version does not match the stored Version.
+// - CAS failures are retried if none of the operations specify a Version.
+func (s *Server) Txn(ctx context.Context, req *pbresource.TxnRequest) (*pbresource.TxnResponse, error) {
+	if err := validateTxnRequest(req); err != nil {
+		return nil, err
+	}
+
+	authz, err := s.getAuthorizer(tokenFromContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	for i, op := range req.Operations {
+		if err := s.checkTxnOp(ctx, authz, op); err != nil {
+			return nil, txnOpError(i, err)
+		}
+	}
+
+	var results []*pbresource.TxnResult
+	err = s.retryCAS(ctx, txnVersion(req), func() error {
+		var err error
+		results, err = s.applyTxn(ctx, req)
+		return err
+	})
+
+	switch {
+	case errors.Is(err, storage.ErrCASFailure):
+		return nil, status.Error(codes.Aborted, err.Error())
+	case errors.Is(err, storage.ErrWrongUid):
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	case errors.As(err, &storage.GroupVersionMismatchError{}):
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	case isGRPCStatusError(err):
+		return nil, err
+	case err != nil:
+		return nil, status.Errorf(codes.Internal, "failed to apply transaction: %v", err.Error())
+	}
+
+	for _, result := range results {
+		if result.Resource == nil {
+			continue
+		}
+		if err := s.maybeDeleteFinalized(ctx, result.Resource); err != nil {
+			return nil, err
+		}
+	}
+	return &pbresource.TxnResponse{Results: results}, nil
+}
+
+// checkTxnOp runs the ACL checks and, for writes, the validation, mutation, and
+// admission hooks for the given operation.
+//
+// n.b. admission hooks see the state of the world before the transaction, so
+// can't rely on resources written by other operations in the same transaction.
+func (s *Server) checkTxnOp(ctx context.Context, authz acl.Authorizer, op *pbresource.TxnOp) error {
+	switch op := op.Op.(type) {
+	case *pbresource.TxnOp_Write:
+		reg, err := s.resolveType(op.Write.Resource.Id.Type)
+		if err != nil {
+			return err
+		}
+		if err := checkWrite(authz, reg, op.Write.Resource); err != nil {
+			return err
+		}
+		return s.admit(ctx, reg, op.Write.Resource)
+
+	case *pbresource.TxnOp_Delete:
+		reg, err := s.resolveType(op.Delete.Id.Type)
+		if err != nil {
+			return err
+		}
+
+		err = reg.ACLs.Write(authz, op.Delete.Id)
+		switch {
+		case acl.IsErrPermissionDenied(err):
+			return status.Error(codes.PermissionDenied, err.Error())
+		case err != nil:
+			return status.Errorf(codes.Internal, "failed write acl: %v", err)
+		}
+	}
+	return nil
+}
+
+// applyTxn reads the current version of each of the transaction's resources,
+// and applies the resulting operations (plus tombstones for deleted resources)
+// in a single storage backend transaction.
+func (s *Server) applyTxn(ctx context.Context, req *pbresource.TxnRequest) ([]*pbresource.TxnResult, error) {
+	var (
+		ops []storage.TxnOp
+
+		// opIndex maps the backend operations to the request's operations.
+		opIndex []int
+
+		// resultIndex maps the request's write operations to the backend results.
+		resultIndex = make(map[int]int)
+	)
+	for i, op := range req.Operations {
+		switch op := op.Op.(type) {
+		case *pbresource.TxnOp_Write:
+			input, err := s.prepareWrite(ctx, op.Write.Resource)
+			if err != nil {
+				return nil, txnOpError(i, err)
+			}
+
+			resultIndex[i] = len(ops)
+			ops = append(ops, storage.TxnOp{Write: input})
+			opIndex = append(opIndex, i)
+
+		case *pbresource.TxnOp_Delete:
+			existing, err := s.readStored(ctx, storage.StrongConsistency, op.Delete.Id)
+			switch {
+			case err == nil:
+			case errors.Is(err, storage.ErrNotFound):
+				// Deletes are idempotent so no-op when not found
+				continue
+			case errors.As(err, &storage.GroupVersionMismatchError{}):
+				return nil, txnOpError(i, status.Error(codes.InvalidArgument, err.Error()))
+			default:
+				return nil, txnOpError(i, status.Errorf(codes.Internal, "failed read: %v", err))
+			}
+
+			// A previous incarnation of the resource has already been deleted.
+			if op.Delete.Id.Uid != "" && op.Delete.Id.Uid != existing.Id.Uid {
+				continue
+			}
+
+			deleteId, deleteVersion := existing.Id, op.Delete.Version
+			if deleteVersion == "" {
+				deleteVersion = existing.Version
+			}
+
+			// Resources with finalizers are marked for deletion instead, unless
+			// they're already waiting for their finalizers to be removed.
+			if resource.HasFinalizers(existing) {
+				if !resource.IsMarkedForDeletion(existing) {
+					ops = append(ops, storage.TxnOp{Write: markedForDeletion(existing, deleteVersion)})
+					opIndex = append(opIndex, i)
+				}
+				continue
+			}
+
+			tombstone, err := s.txnTombstone(ctx, deleteId)
+			if err != nil {
+				return nil, txnOpError(i, status.Errorf(codes.Internal, "failed to write tombstone: %v", err))
+			}
+			if tombstone != nil {
+				ops = append(ops, storage.TxnOp{Write: tombstone})
+				opIndex = append(opIndex, i)
+			}
+
+			ops = append(ops, storage.TxnOp{Delete: deleteId, DeleteVersion: deleteVersion})
+			opIndex = append(opIndex, i)
+		}
+	}
+
+	var written []*pbresource.Resource
+	if len(ops) != 0 {
+		var err error
+		written, err = s.Backend.Txn(ctx, ops)
+
+		var txnErr storage.TxnError
+		switch {
+		case errors.As(err, &txnErr):
+			return nil, storage.TxnError{Index: opIndex[txnErr.Index], Err: txnErr.Err}
+		case err != nil:
+			return nil, err
+		}
+	}
+
+	results := make([]*pbresource.TxnResult, len(req.Operations))
+	for i := range req.Operations {
+		results[i] = &pbresource.TxnResult{}
+		if idx, ok := resultIndex[i]; ok {
+			results[i].Resource = written[idx]
+		}
+	}
+	return results, nil
+}
+
+// txnTombstone returns the tombstone that must be written when deleting the
+// resource with the given ID, or nil if none is required because the resource
+// is itself a tombstone or a tombstone for it already exists.
+//
+// Unlike maybeWriteTombstone, the tombstone is written as part of the same
+// transaction as the delete, so an existing tombstone must be skipped rather
+// than causing the whole transaction to fail its CAS check.
+func (s *Server) txnTombstone(ctx context.Context, owner *pbresource.ID) (*pbresource.Resource, error) {
+	if proto.Equal(owner.Type, resource.TypeV1Tombstone) {
+		return nil, nil
+	}
+
+	tombstone, err := newTombstone(owner)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = s.Backend.Read(ctx, storage.StrongConsistency, &pbresource.ID{
+		Type:    tombstone.Id.Type,
+		Tenancy: tombstone.Id.Tenancy,
+		Name:    tombstone.Id.Name,
+	})
+	switch {
+	case err == nil:
+		return nil, nil
+	case errors.Is(err, storage.ErrNotFound):
+		return tombstone, nil
+	default:
+		return nil, err
+	}
+}
+
+// txnVersion returns the first version given in the transaction's operations,
+// or an empty string if none of them are CAS operations.
+func txnVersion(req *pbresource.TxnRequest) string {
+	for _, op := range req.Operations {
+		switch op := op.Op.(type) {
+		case *pbresource.TxnOp_Write:
+			if op.Write.Resource.Version != "" {
+				return op.Write.Resource.Version
+			}
+		case *pbresource.TxnOp_Delete:
+			if op.Delete.Version != "" {
+				return op.Delete.Version
+			}
+		}
+	}
+	return ""
+}
+
+// txnOpError identifies the operation that caused the given error.
+func txnOpError(idx int, err error) error {
+	if s, ok := status.FromError(err); ok && err != nil {
+		return status.Errorf(s.Code(), "operations[%d]: %s", idx, s.Message())
+	}
+	return storage.TxnError{Index: idx, Err: err}
+}
+
+func validateTxnRequest(req *pbresource.TxnRequest) error {
+	if len(req.Operations) == 0 {
+		return status.Error(codes.InvalidArgument, "operations is required")
+	}
+
+	seen := make(map[string]struct{}, len(req.Operations))
+	for i, op := range req.Operations {
+		var id *pbresource.ID
+		switch op := op.GetOp().(type) {
+		case *pbresource.TxnOp_Write:
+			if op.Write == nil {
+				return status.Errorf(codes.InvalidArgument, "operations[%d].write is required", i)
+			}
+			if err := validateWriteRequest(op.Write); err != nil {
+				return txnOpError(i, err)
+			}
+			id = op.Write.Resource.Id
+
+		case *pbresource.TxnOp_Delete:
+			if op.Delete == nil {
+				return status.Errorf(codes.InvalidArgument, "operations[%d].delete is required", i)
+			}
+			if err := validateDeleteRequest(op.Delete); err != nil {
+				return txnOpError(i, err)
+			}
+			if op.Delete.Propagation == pbresource.DeleteRequest_PROPAGATION_FOREGROUND {
+				return status.Errorf(codes.InvalidArgument, "operations[%d]: foreground propagation is not supported in transactions", i)
+			}
+			id = op.Delete.Id
+
+		default:
+			return status.Errorf(codes.InvalidArgument, "operations[%d].op is required", i)
+		}
+
+		// Resources are stored without their GroupVersion, so two operations that
+		// differ only in GroupVersion target the same resource.
+		key := fmt.Sprintf("%s/%s/%s/%s/%s/%s",
+			id.Type.Group,
+			id.Type.Kind,
+			id.Tenancy.Partition,
+			id.Tenancy.PeerName,
+			id.Tenancy.Namespace,
+			id.Name,
+		)
+		if _, ok := seen[key]; ok {
+			return status.Errorf(codes.InvalidArgument, "operations[%d]: resource is already targeted by another operation", i)
+		}
+		seen[key] = struct{}{}
+	}
+	return nil
+}
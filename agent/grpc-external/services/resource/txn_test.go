@@ -0,0 +1,272 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/hashicorp/consul/internal/resource"
+	"github.com/hashicorp/consul/internal/resource/demo"
+	"github.com/hashicorp/consul/internal/storage"
+	"github.com/hashicorp/consul/proto-public/pbresource"
+	pbdemov2 "github.com/hashicorp/consul/proto/private/pbdemo/v2"
+	"github.com/hashicorp/consul/proto/private/prototest"
+)
+
+func TestTxn_InputValidation(t *testing.T) {
+	server, client, ctx := testDeps(t)
+	demo.Register(server.Registry)
+
+	artist, err := demo.GenerateV2Artist()
+	require.NoError(t, err)
+
+	writeOp := func(res *pbresource.Resource) *pbresource.TxnOp {
+		return &pbresource.TxnOp{Op: &pbresource.TxnOp_Write{Write: &pbresource.WriteRequest{Resource: res}}}
+	}
+	deleteOp := func(req *pbresource.DeleteRequest) *pbresource.TxnOp {
+		return &pbresource.TxnOp{Op: &pbresource.TxnOp_Delete{Delete: req}}
+	}
+
+	testCases := map[string][]*pbresource.TxnOp{
+		"no operations": nil,
+		"no op":         {{}},
+		"no write resource": {
+			writeOp(nil),
+		},
+		"invalid delete": {
+			deleteOp(&pbresource.DeleteRequest{}),
+		},
+		"foreground propagation": {
+			deleteOp(&pbresource.DeleteRequest{
+				Id:          artist.Id,
+				Propagation: pbresource.DeleteRequest_PROPAGATION_FOREGROUND,
+			}),
+		},
+		"duplicate resource": {
+			writeOp(artist),
+			deleteOp(&pbresource.DeleteRequest{Id: artist.Id}),
+		},
+	}
+	for desc, ops := range testCases {
+		t.Run(desc, func(t *testing.T) {
+			_, err := client.Txn(ctx, &pbresource.TxnRequest{Operations: ops})
+			require.Error(t, err)
+			require.Equal(t, codes.InvalidArgument.String(), status.Code(err).String())
+		})
+	}
+}
+
+func TestTxn_ACLs(t *testing.T) {
+	server := testServer(t)
+	client := testClient(t, server)
+
+	mockACLResolver := &MockACLResolver{}
+	mockACLResolver.On("ResolveTokenAndDefaultMeta", mock.Anything, mock.Anything, mock.Anything).
+		Return(AuthorizerFrom(t, demo.ArtistV2WritePolicy), nil)
+	server.ACLResolver = mockACLResolver
+	demo.Register(server.Registry)
+
+	artist, err := demo.GenerateV2Artist()
+	require.NoError(t, err)
+
+	album, err := demo.GenerateV2Album(artist.Id)
+	require.NoError(t, err)
+
+	// The token can write artists but not albums, so no operations are applied.
+	_, err = client.Txn(testContext(t), &pbresource.TxnRequest{
+		Operations: []*pbresource.TxnOp{
+			{Op: &pbresource.TxnOp_Write{Write: &pbresource.WriteRequest{Resource: artist}}},
+			{Op: &pbresource.TxnOp_Write{Write: &pbresource.WriteRequest{Resource: album}}},
+		},
+	})
+	require.Error(t, err)
+	require.Equal(t, codes.PermissionDenied.String(), status.Code(err).String())
+	require.Contains(t, err.Error(), "operations[1]")
+
+	_, err = server.Backend.Read(testContext(t), storage.StrongConsistency, artist.Id)
+	require.ErrorIs(t, err, storage.ErrNotFound)
+}
+
+func TestTxn_Success(t *testing.T) {
+	server, client, ctx := testDeps(t)
+	demo.Register(server.Registry)
+
+	artist, err := demo.GenerateV2Artist()
+	require.NoError(t, err)
+	rsp, err := client.Write(ctx, &pbresource.WriteRequest{Resource: artist})
+	require.NoError(t, err)
+	artist = rsp.Resource
+
+	// Generated artists have random names, so give them distinct ones.
+	deleted, err := demo.GenerateV2Artist()
+	require.NoError(t, err)
+	deleted.Id.Name = "deleted"
+	rsp, err = client.Write(ctx, &pbresource.WriteRequest{Resource: deleted})
+	require.NoError(t, err)
+	deleted = rsp.Resource
+
+	created, err := demo.GenerateV2Artist()
+	require.NoError(t, err)
+	created.Id.Name = "created"
+
+	// The mutate hook sets the genre to disco when unspecified.
+	var createdData pbdemov2.Artist
+	require.NoError(t, created.Data.UnmarshalTo(&createdData))
+	createdData.Genre = pbdemov2.Genre_GENRE_UNSPECIFIED
+	require.NoError(t, created.Data.MarshalFrom(&createdData))
+
+	txnRsp, err := client.Txn(ctx, &pbresource.TxnRequest{
+		Operations: []*pbresource.TxnOp{
+			{Op: &pbresource.TxnOp_Write{Write: &pbresource.WriteRequest{Resource: created}}},
+			{Op: &pbresource.TxnOp_Delete{Delete: &pbresource.DeleteRequest{Id: deleted.Id}}},
+			{Op: &pbresource.TxnOp_Write{Write: &pbresource.WriteRequest{Resource: modifyArtist(t, artist)}}},
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, txnRsp.Results, 3)
+
+	require.NotEmpty(t, txnRsp.Results[0].Resource.Id.Uid)
+	require.NotEmpty(t, txnRsp.Results[0].Resource.Version)
+	require.NoError(t, txnRsp.Results[0].Resource.Data.UnmarshalTo(&createdData))
+	require.Equal(t, pbdemov2.Genre_GENRE_DISCO, createdData.Genre)
+
+	require.Nil(t, txnRsp.Results[1].Resource)
+
+	require.Equal(t, artist.Id.Uid, txnRsp.Results[2].Resource.Id.Uid)
+	require.NotEqual(t, artist.Version, txnRsp.Results[2].Resource.Version)
+	require.NotEqual(t, artist.Generation, txnRsp.Results[2].Resource.Generation)
+
+	for _, result := range []*pbresource.TxnResult{txnRsp.Results[0], txnRsp.Results[2]} {
+		readRsp, err := client.Read(ctx, &pbresource.ReadRequest{Id: result.Resource.Id})
+		require.NoError(t, err)
+		prototest.AssertDeepEqual(t, result.Resource, readRsp.Resource)
+	}
+
+	_, err = client.Read(ctx, &pbresource.ReadRequest{Id: deleted.Id})
+	require.Equal(t, codes.NotFound.String(), status.Code(err).String())
+
+	// A tombstone is written for the deleted resource in the same transaction.
+	_, err = server.Backend.Read(ctx, storage.StrongConsistency, &pbresource.ID{
+		Type:    resource.TypeV1Tombstone,
+		Tenancy: deleted.Id.Tenancy,
+		Name:    resource.TombstoneNameFor(deleted.Id),
+	})
+	require.NoError(t, err)
+
+	// Deleting a resource that doesn't exist is a no-op.
+	txnRsp, err = client.Txn(ctx, &pbresource.TxnRequest{
+		Operations: []*pbresource.TxnOp{
+			{Op: &pbresource.TxnOp_Delete{Delete: &pbresource.DeleteRequest{Id: deleted.Id}}},
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, txnRsp.Results, 1)
+}
+
+func TestTxn_Admit(t *testing.T) {
+	server, client, ctx := testDeps(t)
+	demo.Register(server.Registry)
+	registerAdmittedAlbum(server.Registry)
+
+	artist, err := demo.GenerateV2Artist()
+	require.NoError(t, err)
+
+	album, err := demo.GenerateV2Album(artist.Id)
+	require.NoError(t, err)
+	album.Id.Type = typeAdmittedAlbum
+
+	// Admission hooks see the state of the world before the transaction, so the
+	// album is rejected even though its artist is written by the same
+	// transaction.
+	_, err = client.Txn(ctx, &pbresource.TxnRequest{
+		Operations: []*pbresource.TxnOp{
+			{Op: &pbresource.TxnOp_Write{Write: &pbresource.WriteRequest{Resource: artist}}},
+			{Op: &pbresource.TxnOp_Write{Write: &pbresource.WriteRequest{Resource: album}}},
+		},
+	})
+	require.Error(t, err)
+	require.Equal(t, codes.FailedPrecondition.String(), status.Code(err).String())
+	require.ErrorContains(t, err, "operations[1]")
+
+	_, err = client.Read(ctx, &pbresource.ReadRequest{Id: artist.Id})
+	require.Equal(t, codes.NotFound.String(), status.Code(err).String())
+}
+
+func TestTxn_Finalizers(t *testing.T) {
+	server, client, ctx := testDeps(t)
+	demo.Register(server.Registry)
+
+	artist, err := demo.GenerateV2Artist()
+	require.NoError(t, err)
+	resource.AddFinalizer(artist, "test")
+	rsp, err := client.Write(ctx, &pbresource.WriteRequest{Resource: artist})
+	require.NoError(t, err)
+	artist = rsp.Resource
+
+	// Deleting the artist marks it for deletion.
+	_, err = client.Txn(ctx, &pbresource.TxnRequest{
+		Operations: []*pbresource.TxnOp{
+			{Op: &pbresource.TxnOp_Delete{Delete: &pbresource.DeleteRequest{Id: artist.Id}}},
+		},
+	})
+	require.NoError(t, err)
+
+	readRsp, err := client.Read(ctx, &pbresource.ReadRequest{Id: artist.Id})
+	require.NoError(t, err)
+	require.True(t, resource.IsMarkedForDeletion(readRsp.Resource))
+
+	// Removing the finalizer deletes it.
+	artist = readRsp.Resource
+	resource.RemoveFinalizer(artist, "test")
+	_, err = client.Txn(ctx, &pbresource.TxnRequest{
+		Operations: []*pbresource.TxnOp{
+			{Op: &pbresource.TxnOp_Write{Write: &pbresource.WriteRequest{Resource: artist}}},
+		},
+	})
+	require.NoError(t, err)
+
+	_, err = server.Backend.Read(ctx, storage.StrongConsistency, artist.Id)
+	require.ErrorIs(t, err, storage.ErrNotFound)
+}
+
+func TestTxn_CASFailure(t *testing.T) {
+	server, client, ctx := testDeps(t)
+	demo.Register(server.Registry)
+
+	artist, err := demo.GenerateV2Artist()
+	require.NoError(t, err)
+	rsp, err := client.Write(ctx, &pbresource.WriteRequest{Resource: artist})
+	require.NoError(t, err)
+	artist = rsp.Resource
+
+	created, err := demo.GenerateV2Artist()
+	require.NoError(t, err)
+	created.Id.Name = "created"
+
+	stale := modifyArtist(t, artist)
+	stale.Version = "wrong-version"
+
+	_, err = client.Txn(ctx, &pbresource.TxnRequest{
+		Operations: []*pbresource.TxnOp{
+			{Op: &pbresource.TxnOp_Write{Write: &pbresource.WriteRequest{Resource: created}}},
+			{Op: &pbresource.TxnOp_Write{Write: &pbresource.WriteRequest{Resource: stale}}},
+		},
+	})
+	require.Error(t, err)
+	require.Equal(t, codes.Aborted.String(), status.Code(err).String())
+	require.Contains(t, err.Error(), "operation 1")
+
+	// None of the operations were applied.
+	_, err = client.Read(ctx, &pbresource.ReadRequest{Id: created.Id})
+	require.Equal(t, codes.NotFound.String(), status.Code(err).String())
+
+	readRsp, err := client.Read(ctx, &pbresource.ReadRequest{Id: artist.Id})
+	require.NoError(t, err)
+	prototest.AssertDeepEqual(t, artist, readRsp.Resource)
+}
@@ -8,6 +8,7 @@ import (
 	"google.golang.org/grpc/status"
 
 	"github.com/hashicorp/consul/acl"
+	"github.com/hashicorp/consul/internal/resource"
 	"github.com/hashicorp/consul/internal/storage"
 	"github.com/hashicorp/consul/proto-public/pbresource"
 )
@@ -34,11 +35,12 @@ func (s *Server) WatchList(req *pbresource.WatchListRequest, stream pbresource.R
 	}
 
 	unversionedType := storage.UnversionedTypeFrom(req.Type)
-	watch, err := s.Backend.WatchList(
+	watch, err := s.Backend.WatchListFrom(
 		stream.Context(),
 		unversionedType,
 		req.Tenancy,
 		req.NamePrefix,
+		req.Index,
 	)
 	if err != nil {
 		return err
@@ -51,11 +53,31 @@ func (s *Server) WatchList(req *pbresource.WatchListRequest, stream pbresource.R
 			return status.Errorf(codes.Internal, "failed next: %v", err)
 		}
 
-		// drop group versions that don't match
-		if event.Resource.Id.Type.GroupVersion != req.Type.GroupVersion {
+		// reset events don't refer to a resource
+		if event.Operation == pbresource.WatchEvent_OPERATION_RESET {
+			if err = stream.Send(event); err != nil {
+				return err
+			}
 			continue
 		}
 
+		// convert group versions that don't match, or drop them if there's no
+		// conversion
+		if event.Resource.Id.Type.GroupVersion != req.Type.GroupVersion {
+			if !resource.CanConvert(s.Registry, event.Resource.Id.Type, req.Type) {
+				continue
+			}
+			converted, err := s.convert(event.Resource, req.Type)
+			if err != nil {
+				return err
+			}
+			event = &pbresource.WatchEvent{
+				Operation: event.Operation,
+				Resource:  converted,
+				Index:     event.Index,
+			}
+		}
+
 		// filter out items that don't pass read ACLs
 		err = reg.ACLs.Read(authz, event.Resource.Id)
 		switch {
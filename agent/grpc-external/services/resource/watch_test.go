@@ -7,11 +7,13 @@ import (
 	"context"
 	"errors"
 	"io"
+	"math"
 	"testing"
 	"time"
 
 	"github.com/hashicorp/consul/acl"
 	"github.com/hashicorp/consul/agent/grpc-external/testutils"
+	"github.com/hashicorp/consul/internal/resource"
 	"github.com/hashicorp/consul/internal/resource/demo"
 	"github.com/hashicorp/consul/proto-public/pbresource"
 	"github.com/hashicorp/consul/proto/private/prototest"
@@ -123,6 +125,82 @@ func TestWatchList_GroupVersionMismatch(t *testing.T) {
 	mustGetNoResource(t, rspCh)
 }
 
+func TestWatchList_Conversion(t *testing.T) {
+	t.Parallel()
+
+	server := testServer(t)
+	demo.Register(server.Registry)
+	client := testClient(t, server)
+	ctx := context.Background()
+
+	stream, err := client.WatchList(ctx, &pbresource.WatchListRequest{
+		Type:    demo.TypeV2Album,
+		Tenancy: demo.TenancyDefault,
+	})
+	require.NoError(t, err)
+	rspCh := handleResourceStream(t, stream)
+
+	stored, err := server.Backend.WriteCAS(ctx, generateV1Album(t, server.Registry))
+	require.NoError(t, err)
+
+	expected, err := resource.Convert(server.Registry, stored, demo.TypeV2Album)
+	require.NoError(t, err)
+
+	rsp := mustGetResource(t, rspCh)
+	require.Equal(t, pbresource.WatchEvent_OPERATION_UPSERT, rsp.Operation)
+	prototest.AssertDeepEqual(t, expected, rsp.Resource)
+}
+
+func TestWatchList_Resume(t *testing.T) {
+	t.Parallel()
+
+	server := testServer(t)
+	client := testClient(t, server)
+	demo.Register(server.Registry)
+	ctx := context.Background()
+
+	watchFrom := func(index uint64) <-chan resourceOrError {
+		stream, err := client.WatchList(testContext(t), &pbresource.WatchListRequest{
+			Type:    demo.TypeV2Artist,
+			Tenancy: demo.TenancyDefault,
+			Index:   index,
+		})
+		require.NoError(t, err)
+		return handleResourceStream(t, stream)
+	}
+
+	artist, err := demo.GenerateV2Artist()
+	require.NoError(t, err)
+	r1, err := server.Backend.WriteCAS(ctx, artist)
+	require.NoError(t, err)
+
+	// verify the watch is reset if the index is unknown
+	rspCh := watchFrom(math.MaxUint64)
+	rsp := mustGetResource(t, rspCh)
+	require.Equal(t, pbresource.WatchEvent_OPERATION_RESET, rsp.Operation)
+
+	rsp = mustGetResource(t, rspCh)
+	require.Equal(t, pbresource.WatchEvent_OPERATION_UPSERT, rsp.Operation)
+	prototest.AssertDeepEqual(t, r1, rsp.Resource)
+	lastIndex := rsp.Index
+
+	// update and delete the artist while disconnected
+	r2, err := server.Backend.WriteCAS(ctx, modifyArtist(t, r1))
+	require.NoError(t, err)
+	require.NoError(t, server.Backend.DeleteCAS(ctx, r2.Id, r2.Version))
+
+	// verify only the missed events are replayed
+	rspCh = watchFrom(lastIndex)
+	rsp = mustGetResource(t, rspCh)
+	require.Equal(t, pbresource.WatchEvent_OPERATION_UPSERT, rsp.Operation)
+	prototest.AssertDeepEqual(t, r2, rsp.Resource)
+	require.Greater(t, rsp.Index, lastIndex)
+
+	rsp = mustGetResource(t, rspCh)
+	require.Equal(t, pbresource.WatchEvent_OPERATION_DELETE, rsp.Operation)
+	mustGetNoResource(t, rspCh)
+}
+
 // N.B. Uses key ACLs for now. See demo.Register()
 func TestWatchList_ACL_ListDenied(t *testing.T) {
 	t.Parallel()
@@ -37,6 +37,10 @@ import (
 // to keep them separate.
 var errUseWriteStatus = status.Error(codes.InvalidArgument, "resource.status can only be set using the WriteStatus endpoint")
 
+// errSetDeletionTimestamp is returned when the user attempts to mark a resource
+// for deletion, or change its deletion timestamp, using the Write endpoint.
+var errSetDeletionTimestamp = status.Errorf(codes.InvalidArgument, "metadata.%s can only be set using the Delete endpoint", resource.DeletionTimestampKey)
+
 func (s *Server) Write(ctx context.Context, req *pbresource.WriteRequest) (*pbresource.WriteResponse, error) {
 	if err := validateWriteRequest(req); err != nil {
 		return nil, err
@@ -52,33 +56,12 @@ func (s *Server) Write(ctx context.Context, req *pbresource.WriteRequest) (*pbre
 		return nil, err
 	}
 
-	// check acls
-	err = reg.ACLs.Write(authz, req.Resource.Id)
-	switch {
-	case acl.IsErrPermissionDenied(err):
-		return nil, status.Error(codes.PermissionDenied, err.Error())
-	case err != nil:
-		return nil, status.Errorf(codes.Internal, "failed write acl: %v", err)
-	}
-
-	// Check the user sent the correct type of data.
-	if !req.Resource.Data.MessageIs(reg.Proto) {
-		got := strings.TrimPrefix(req.Resource.Data.TypeUrl, "type.googleapis.com/")
-
-		return nil, status.Errorf(
-			codes.InvalidArgument,
-			"resource.data is of wrong type (expected=%q, got=%q)",
-			reg.Proto.ProtoReflect().Descriptor().FullName(),
-			got,
-		)
-	}
-
-	if err = reg.Validate(req.Resource); err != nil {
-		return nil, status.Error(codes.InvalidArgument, err.Error())
+	if err = checkWrite(authz, reg, req.Resource); err != nil {
+		return nil, err
 	}
 
-	if err = reg.Mutate(req.Resource); err != nil {
-		return nil, status.Errorf(codes.Internal, "failed mutate hook: %v", err.Error())
+	if err = s.admit(ctx, reg, req.Resource); err != nil {
+		return nil, err
 	}
 
 	// At the storage backend layer, all writes are CAS operations.
@@ -93,80 +76,11 @@ func (s *Server) Write(ctx context.Context, req *pbresource.WriteRequest) (*pbre
 	// we read the current version, and automatically retry if the CAS write fails.
 	var result *pbresource.Resource
 	err = s.retryCAS(ctx, req.Resource.Version, func() error {
-		input := clone(req.Resource)
-
-		// We read with EventualConsistency here because:
-		//
-		//	- In the common case, individual resources are written infrequently, and
-		//	  when using the Raft backend followers are generally within a few hundred
-		//	  milliseconds of the leader, so the first read will probably return the
-		//	  current version.
-		//
-		//	- StrongConsistency is expensive. In the Raft backend, it involves a round
-		//	  of heartbeats to verify cluster leadership (in addition to the write's
-		//	  log replication).
-		//
-		//	- CAS failures will be retried by retryCAS anyway. So the read-modify-write
-		//	  cycle should eventually succeed.
-		existing, err := s.Backend.Read(ctx, storage.EventualConsistency, input.Id)
-		switch {
-		// Create path.
-		case errors.Is(err, storage.ErrNotFound):
-			input.Id.Uid = ulid.Make().String()
-
-			// Prevent setting statuses in this endpoint.
-			if len(input.Status) != 0 {
-				return errUseWriteStatus
-			}
-
-			// TODO(spatel): Revisit owner<->resource tenancy rules post-1.16
-
-		// Update path.
-		case err == nil:
-			// Use the stored ID because it includes the Uid.
-			//
-			// Generally, users won't provide the Uid but controllers will, because
-			// controllers need to operate on a specific "incarnation" of a resource
-			// as opposed to an older/newer resource with the same name, whereas users
-			// just want to update the current resource.
-			input.Id = existing.Id
-
-			// User is doing a non-CAS write, use the current version.
-			if input.Version == "" {
-				input.Version = existing.Version
-			}
-
-			// Check the stored version matches the user-given version.
-			//
-			// Although CAS operations are implemented "for real" at the storage backend
-			// layer, we must check the version here too to prevent a scenario where:
-			//
-			//	- Current resource version is `v2`
-			//	- User passes version `v2`
-			//	- Read returns stale version `v1`
-			//	- We carry `v1`'s statuses over (effectively overwriting `v2`'s statuses)
-			//	- CAS operation succeeds anyway because user-given version is current
-			if input.Version != existing.Version {
-				return storage.ErrCASFailure
-			}
-
-			// Owner can only be set on creation. Enforce immutability.
-			if !proto.Equal(input.Owner, existing.Owner) {
-				return status.Errorf(codes.InvalidArgument, "owner cannot be changed")
-			}
-
-			// Carry over status and prevent updates
-			if input.Status == nil {
-				input.Status = existing.Status
-			} else if !resource.EqualStatus(input.Status, existing.Status) {
-				return errUseWriteStatus
-			}
-
-		default:
+		input, err := s.prepareWrite(ctx, req.Resource)
+		if err != nil {
 			return err
 		}
 
-		input.Generation = ulid.Make().String()
 		result, err = s.Backend.WriteCAS(ctx, input)
 		return err
 	})
@@ -176,14 +90,228 @@ func (s *Server) Write(ctx context.Context, req *pbresource.WriteRequest) (*pbre
 		return nil, status.Error(codes.Aborted, err.Error())
 	case errors.Is(err, storage.ErrWrongUid):
 		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	case errors.As(err, &storage.GroupVersionMismatchError{}):
+		return nil, status.Error(codes.InvalidArgument, err.Error())
 	case isGRPCStatusError(err):
 		return nil, err
 	case err != nil:
 		return nil, status.Errorf(codes.Internal, "failed to write resource: %v", err.Error())
 	}
+
+	// Removing the last finalizer from a resource that is marked for deletion
+	// completes its deletion.
+	if err := s.maybeDeleteFinalized(ctx, result); err != nil {
+		return nil, err
+	}
 	return &pbresource.WriteResponse{Resource: result}, nil
 }
 
+// checkWrite runs the ACL, type, and validation checks and the mutation hook
+// for a resource that is about to be written.
+func checkWrite(authz acl.Authorizer, reg *resource.Registration, res *pbresource.Resource) error {
+	// check acls
+	err := reg.ACLs.Write(authz, res.Id)
+	switch {
+	case acl.IsErrPermissionDenied(err):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case err != nil:
+		return status.Errorf(codes.Internal, "failed write acl: %v", err)
+	}
+
+	// Check the user sent the correct type of data.
+	if !res.Data.MessageIs(reg.Proto) {
+		got := strings.TrimPrefix(res.Data.TypeUrl, "type.googleapis.com/")
+
+		return status.Errorf(
+			codes.InvalidArgument,
+			"resource.data is of wrong type (expected=%q, got=%q)",
+			reg.Proto.ProtoReflect().Descriptor().FullName(),
+			got,
+		)
+	}
+
+	if err = reg.Validate(res); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if err = reg.Mutate(res); err != nil {
+		return status.Errorf(codes.Internal, "failed mutate hook: %v", err.Error())
+	}
+	return nil
+}
+
+// prepareWrite reads the current version of the given resource and returns a
+// copy of it that is ready to be passed to the storage backend (i.e. with the
+// Uid, Version, and statuses carried over and a new Generation).
+func (s *Server) prepareWrite(ctx context.Context, res *pbresource.Resource) (*pbresource.Resource, error) {
+	input := clone(res)
+
+	// We read with EventualConsistency here because:
+	//
+	//	- In the common case, individual resources are written infrequently, and
+	//	  when using the Raft backend followers are generally within a few hundred
+	//	  milliseconds of the leader, so the first read will probably return the
+	//	  current version.
+	//
+	//	- StrongConsistency is expensive. In the Raft backend, it involves a round
+	//	  of heartbeats to verify cluster leadership (in addition to the write's
+	//	  log replication).
+	//
+	//	- CAS failures will be retried by retryCAS anyway. So the read-modify-write
+	//	  cycle should eventually succeed.
+	existing, err := s.readStored(ctx, storage.EventualConsistency, input.Id)
+	switch {
+	// Create path.
+	case errors.Is(err, storage.ErrNotFound):
+		input.Id.Uid = ulid.Make().String()
+
+		// Prevent setting statuses in this endpoint.
+		if len(input.Status) != 0 {
+			return nil, errUseWriteStatus
+		}
+
+		// Resources can only be marked for deletion by the Delete endpoint.
+		if resource.IsMarkedForDeletion(input) {
+			return nil, errSetDeletionTimestamp
+		}
+
+		// TODO(spatel): Revisit owner<->resource tenancy rules post-1.16
+
+	// Update path.
+	case err == nil:
+		// Use the stored ID because it includes the Uid.
+		//
+		// Generally, users won't provide the Uid but controllers will, because
+		// controllers need to operate on a specific "incarnation" of a resource
+		// as opposed to an older/newer resource with the same name, whereas users
+		// just want to update the current resource.
+		//
+		// The resource may be stored at another GroupVersion, in which case it's
+		// rewritten at the requested one.
+		typ := input.Id.Type
+		input.Id = clone(existing.Id)
+		input.Id.Type = typ
+
+		// User is doing a non-CAS write, use the current version.
+		if input.Version == "" {
+			input.Version = existing.Version
+		}
+
+		// Check the stored version matches the user-given version.
+		//
+		// Although CAS operations are implemented "for real" at the storage backend
+		// layer, we must check the version here too to prevent a scenario where:
+		//
+		//	- Current resource version is `v2`
+		//	- User passes version `v2`
+		//	- Read returns stale version `v1`
+		//	- We carry `v1`'s statuses over (effectively overwriting `v2`'s statuses)
+		//	- CAS operation succeeds anyway because user-given version is current
+		if input.Version != existing.Version {
+			return nil, storage.ErrCASFailure
+		}
+
+		// Owner can only be set on creation. Enforce immutability.
+		if !proto.Equal(input.Owner, existing.Owner) {
+			return nil, status.Errorf(codes.InvalidArgument, "owner cannot be changed")
+		}
+
+		// Carry over status and prevent updates
+		if input.Status == nil {
+			input.Status = existing.Status
+		} else if !resource.EqualStatus(input.Status, existing.Status) {
+			return nil, errUseWriteStatus
+		}
+
+		if err := s.checkDeletionMark(input, existing); err != nil {
+			return nil, err
+		}
+
+	default:
+		return nil, err
+	}
+
+	input.Generation = ulid.Make().String()
+	return input, nil
+}
+
+// checkDeletionMark prevents the write from marking the resource for deletion
+// and, if it's already marked for deletion, from making any change other than
+// removing finalizers.
+func (s *Server) checkDeletionMark(input, existing *pbresource.Resource) error {
+	if !resource.IsMarkedForDeletion(existing) {
+		if resource.IsMarkedForDeletion(input) {
+			return errSetDeletionTimestamp
+		}
+		return nil
+	}
+
+	existing, err := s.convert(existing, input.Id.Type)
+	if err != nil {
+		return err
+	}
+
+	// Carry over the deletion timestamp if the user didn't provide it.
+	deletionTimestamp, ok := input.Metadata[resource.DeletionTimestampKey]
+	if !ok {
+		if input.Metadata == nil {
+			input.Metadata = make(map[string]string)
+		}
+		deletionTimestamp = existing.Metadata[resource.DeletionTimestampKey]
+		input.Metadata[resource.DeletionTimestampKey] = deletionTimestamp
+	}
+	if deletionTimestamp != existing.Metadata[resource.DeletionTimestampKey] {
+		return errSetDeletionTimestamp
+	}
+
+	errMarked := status.Error(codes.InvalidArgument, "resource is marked for deletion, only finalizers can be removed")
+
+	if !equalIgnoringFinalizers(input.Metadata, existing.Metadata) {
+		return errMarked
+	}
+	for _, f := range resource.Finalizers(input) {
+		if !resource.HasFinalizer(existing, f) {
+			return errMarked
+		}
+	}
+
+	inputData, err := input.Data.UnmarshalNew()
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "failed to decode resource.data: %v", err)
+	}
+	existingData, err := existing.Data.UnmarshalNew()
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to decode stored data: %v", err)
+	}
+	if !proto.Equal(inputData, existingData) {
+		return errMarked
+	}
+	return nil
+}
+
+// equalIgnoringFinalizers returns whether the given resource metadata are equal
+// other than their finalizers.
+func equalIgnoringFinalizers(a, b map[string]string) bool {
+	count := func(m map[string]string) int {
+		if _, ok := m[resource.FinalizerKey]; ok {
+			return len(m) - 1
+		}
+		return len(m)
+	}
+	if count(a) != count(b) {
+		return false
+	}
+	for k, v := range a {
+		if k == resource.FinalizerKey {
+			continue
+		}
+		if bv, ok := b[k]; !ok || bv != v {
+			return false
+		}
+	}
+	return true
+}
+
 // retryCAS retries the given operation with exponential backoff if the user
 // didn't provide a version. This is intended to hide failures when the user
 // isn't intentionally performing a CAS operation (all writes are, by design,
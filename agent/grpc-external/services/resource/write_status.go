@@ -7,6 +7,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -14,6 +15,7 @@ import (
 	"github.com/oklog/ulid/v2"
 
 	"github.com/hashicorp/consul/acl"
+	"github.com/hashicorp/consul/internal/resource"
 	"github.com/hashicorp/consul/internal/storage"
 	"github.com/hashicorp/consul/proto-public/pbresource"
 )
@@ -63,22 +65,23 @@ func (s *Server) WriteStatus(ctx context.Context, req *pbresource.WriteStatusReq
 	//	  racing with a user's write of the same status.
 	var result *pbresource.Resource
 	err = s.retryCAS(ctx, req.Version, func() error {
-		resource, err := s.Backend.Read(ctx, storage.EventualConsistency, req.Id)
+		// The status is written to the resource at the GroupVersion it's stored at.
+		res, err := s.readStored(ctx, storage.EventualConsistency, req.Id)
 		if err != nil {
 			return err
 		}
 
-		if req.Version != "" && req.Version != resource.Version {
+		if req.Version != "" && req.Version != res.Version {
 			return storage.ErrCASFailure
 		}
 
-		resource = clone(resource)
-		if resource.Status == nil {
-			resource.Status = make(map[string]*pbresource.Status)
+		res = clone(res)
+		if res.Status == nil {
+			res.Status = make(map[string]*pbresource.Status)
 		}
-		resource.Status[req.Key] = req.Status
+		res.Status[req.Key] = resource.RecordTransitions(res.Status[req.Key], req.Status, time.Now())
 
-		result, err = s.Backend.WriteCAS(ctx, resource)
+		result, err = s.Backend.WriteCAS(ctx, res)
 		return err
 	})
 
@@ -87,10 +90,16 @@ func (s *Server) WriteStatus(ctx context.Context, req *pbresource.WriteStatusReq
 		return nil, status.Error(codes.NotFound, err.Error())
 	case errors.Is(err, storage.ErrCASFailure):
 		return nil, status.Error(codes.Aborted, err.Error())
+	case errors.As(err, &storage.GroupVersionMismatchError{}):
+		return nil, status.Error(codes.InvalidArgument, err.Error())
 	case err != nil:
 		return nil, status.Errorf(codes.Internal, "failed to write resource: %v", err.Error())
 	}
 
+	result, err = s.convert(result, req.Id.Type)
+	if err != nil {
+		return nil, err
+	}
 	return &pbresource.WriteStatusResponse{Resource: result}, nil
 }
 
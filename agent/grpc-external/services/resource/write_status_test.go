@@ -17,6 +17,7 @@ import (
 	"github.com/hashicorp/consul/internal/resource"
 	"github.com/hashicorp/consul/internal/resource/demo"
 	"github.com/hashicorp/consul/proto-public/pbresource"
+	"github.com/hashicorp/consul/proto/private/prototest"
 )
 
 func TestWriteStatus_ACL(t *testing.T) {
@@ -144,6 +145,54 @@ func TestWriteStatus_Success(t *testing.T) {
 	}
 }
 
+func TestWriteStatus_Transitions(t *testing.T) {
+	server, client, ctx := testDeps(t)
+	demo.Register(server.Registry)
+
+	res, err := demo.GenerateV2Artist()
+	require.NoError(t, err)
+
+	writeRsp, err := client.Write(ctx, &pbresource.WriteRequest{Resource: res})
+	require.NoError(t, err)
+	res = writeRsp.Resource
+
+	req := validWriteStatusRequest(t, res)
+	rsp, err := client.WriteStatus(ctx, req)
+	require.NoError(t, err)
+
+	artistStatus := rsp.Resource.Status[req.Key]
+	transitionTime := artistStatus.Conditions[0].LastTransitionTime
+	require.NotNil(t, transitionTime)
+	require.Len(t, artistStatus.History, 1)
+
+	// Rewriting the condition with the same state keeps its transition time.
+	req = validWriteStatusRequest(t, rsp.Resource)
+	req.Status.Conditions[0].Message = "Something else"
+	rsp, err = client.WriteStatus(ctx, req)
+	require.NoError(t, err)
+
+	artistStatus = rsp.Resource.Status[req.Key]
+	prototest.AssertDeepEqual(t, transitionTime, artistStatus.Conditions[0].LastTransitionTime)
+	require.Len(t, artistStatus.History, 1)
+
+	// Changing its state records a transition.
+	req = validWriteStatusRequest(t, rsp.Resource)
+	req.Status.Conditions[0].State = pbresource.Condition_STATE_FALSE
+	rsp, err = client.WriteStatus(ctx, req)
+	require.NoError(t, err)
+
+	artistStatus = rsp.Resource.Status[req.Key]
+	require.Len(t, artistStatus.History, 2)
+	require.Equal(t, pbresource.Condition_STATE_TRUE, artistStatus.History[1].PreviousState)
+	require.Equal(t, pbresource.Condition_STATE_FALSE, artistStatus.History[1].State)
+	prototest.AssertDeepEqual(t, artistStatus.History[1].Time, artistStatus.Conditions[0].LastTransitionTime)
+
+	// The history can be read back.
+	readRsp, err := client.Read(ctx, &pbresource.ReadRequest{Id: res.Id})
+	require.NoError(t, err)
+	prototest.AssertDeepEqual(t, artistStatus, readRsp.Resource.Status[req.Key])
+}
+
 func TestWriteStatus_CASFailure(t *testing.T) {
 	server := testServer(t)
 	client := testClient(t, server)
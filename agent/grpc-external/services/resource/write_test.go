@@ -16,9 +16,11 @@ import (
 	"google.golang.org/protobuf/types/known/anypb"
 
 	"github.com/hashicorp/consul/acl/resolver"
+	"github.com/hashicorp/consul/internal/resource"
 	"github.com/hashicorp/consul/internal/resource/demo"
 	"github.com/hashicorp/consul/internal/storage"
 	"github.com/hashicorp/consul/proto-public/pbresource"
+	pbdemov1 "github.com/hashicorp/consul/proto/private/pbdemo/v1"
 	pbdemov2 "github.com/hashicorp/consul/proto/private/pbdemo/v2"
 )
 
@@ -392,6 +394,47 @@ func TestWrite_Update_NoUid(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestWrite_Update_Conversion(t *testing.T) {
+	server := testServer(t)
+	client := testClient(t, server)
+
+	demo.Register(server.Registry)
+
+	stored, err := server.Backend.WriteCAS(testContext(t), generateV1Album(t, server.Registry))
+	require.NoError(t, err)
+
+	// Updating the resource at v2 rewrites it at v2.
+	res, err := resource.Convert(server.Registry, stored, demo.TypeV2Album)
+	require.NoError(t, err)
+	res.Id.Uid = ""
+
+	rsp, err := client.Write(testContext(t), &pbresource.WriteRequest{Resource: res})
+	require.NoError(t, err)
+	require.Equal(t, demo.TypeV2Album.GroupVersion, rsp.Resource.Id.Type.GroupVersion)
+	require.Equal(t, stored.Id.Uid, rsp.Resource.Id.Uid)
+	require.NotEqual(t, stored.Version, rsp.Resource.Version)
+
+	_, err = server.Backend.Read(testContext(t), storage.EventualConsistency, rsp.Resource.Id)
+	require.NoError(t, err)
+
+	// Writing an artist over one stored at a version without a conversion fails.
+	artist, err := demo.GenerateV2Artist()
+	require.NoError(t, err)
+
+	_, err = server.Backend.WriteCAS(testContext(t), artist)
+	require.NoError(t, err)
+
+	v1Artist := clone(artist)
+	v1Artist.Id.Type = demo.TypeV1Artist
+	v1Artist.Data, err = anypb.New(&pbdemov1.Artist{Name: "Korn"})
+	require.NoError(t, err)
+
+	_, err = client.Write(testContext(t), &pbresource.WriteRequest{Resource: v1Artist})
+	require.Error(t, err)
+	require.Equal(t, codes.InvalidArgument.String(), status.Code(err).String())
+	require.Contains(t, err.Error(), "resource was requested with GroupVersion")
+}
+
 func TestWrite_NonCASUpdate_Success(t *testing.T) {
 	server := testServer(t)
 	client := testClient(t, server)
@@ -491,6 +534,95 @@ func TestWrite_Owner_Immutable(t *testing.T) {
 	require.ErrorContains(t, err, "owner cannot be changed")
 }
 
+func TestWrite_Admit(t *testing.T) {
+	server, client, ctx := testDeps(t)
+	demo.Register(server.Registry)
+	registerAdmittedAlbum(server.Registry)
+
+	artist, err := demo.GenerateV2Artist()
+	require.NoError(t, err)
+
+	album, err := demo.GenerateV2Album(artist.Id)
+	require.NoError(t, err)
+	album.Id.Type = typeAdmittedAlbum
+
+	// The artist doesn't exist yet.
+	_, err = client.Write(ctx, &pbresource.WriteRequest{Resource: album})
+	require.Error(t, err)
+	require.Equal(t, codes.FailedPrecondition.String(), status.Code(err).String())
+	require.ErrorContains(t, err, "does not exist")
+
+	rsp, err := client.Write(ctx, &pbresource.WriteRequest{Resource: artist})
+	require.NoError(t, err)
+	artist = rsp.Resource
+
+	album.Owner = artist.Id
+	_, err = client.Write(ctx, &pbresource.WriteRequest{Resource: album})
+	require.NoError(t, err)
+
+	// Errors that aren't gRPC statuses are returned as InvalidArgument.
+	album.Owner = nil
+	album.Id.Name = "no-owner"
+	_, err = client.Write(ctx, &pbresource.WriteRequest{Resource: album})
+	require.Error(t, err)
+	require.Equal(t, codes.InvalidArgument.String(), status.Code(err).String())
+	require.ErrorContains(t, err, "album must have an owner")
+}
+
+func TestWrite_Admit_Reference(t *testing.T) {
+	server, client, ctx := testDeps(t)
+	demo.Register(server.Registry)
+
+	influence, err := demo.GenerateV2Artist()
+	require.NoError(t, err)
+
+	artist, err := demo.GenerateV2Artist()
+	require.NoError(t, err)
+	artist.Id.Name = "influenced-" + artist.Id.Name
+
+	var data pbdemov2.Artist
+	require.NoError(t, artist.Data.UnmarshalTo(&data))
+	data.InfluencedBy = resource.Reference(influence.Id, "")
+	require.NoError(t, artist.Data.MarshalFrom(&data))
+
+	// The referenced artist doesn't exist yet.
+	_, err = client.Write(ctx, &pbresource.WriteRequest{Resource: artist})
+	require.Error(t, err)
+	require.Equal(t, codes.FailedPrecondition.String(), status.Code(err).String())
+	require.ErrorContains(t, err, "does not exist")
+
+	_, err = client.Write(ctx, &pbresource.WriteRequest{Resource: influence})
+	require.NoError(t, err)
+
+	_, err = client.Write(ctx, &pbresource.WriteRequest{Resource: artist})
+	require.NoError(t, err)
+}
+
+func TestWrite_DeletionTimestamp(t *testing.T) {
+	server, client, ctx := testDeps(t)
+	demo.Register(server.Registry)
+
+	artist, err := demo.GenerateV2Artist()
+	require.NoError(t, err)
+	artist.Metadata = map[string]string{resource.DeletionTimestampKey: "2023-01-01T00:00:00Z"}
+
+	// Resources can't be created marked for deletion.
+	_, err = client.Write(ctx, &pbresource.WriteRequest{Resource: artist})
+	require.Error(t, err)
+	require.Equal(t, codes.InvalidArgument.String(), status.Code(err).String())
+
+	// Or marked for deletion using the Write endpoint.
+	delete(artist.Metadata, resource.DeletionTimestampKey)
+	rsp, err := client.Write(ctx, &pbresource.WriteRequest{Resource: artist})
+	require.NoError(t, err)
+
+	artist = rsp.Resource
+	artist.Metadata = map[string]string{resource.DeletionTimestampKey: "2023-01-01T00:00:00Z"}
+	_, err = client.Write(ctx, &pbresource.WriteRequest{Resource: artist})
+	require.Error(t, err)
+	require.Equal(t, codes.InvalidArgument.String(), status.Code(err).String())
+}
+
 type blockOnceBackend struct {
 	storage.Backend
 
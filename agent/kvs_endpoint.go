@@ -10,6 +10,7 @@ import (
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/consul/agent/structs"
 	"github.com/hashicorp/consul/api"
@@ -38,6 +39,9 @@ func (s *HTTPHandlers) KVSEndpoint(resp http.ResponseWriter, req *http.Request)
 		if keyList {
 			return s.KVSGetKeys(resp, req, &args)
 		}
+		if _, ok := params["revisions"]; ok {
+			return s.KVSGetRevisions(resp, req, &args)
+		}
 		return s.KVSGet(resp, req, &args)
 	case "PUT":
 		return s.KVSPut(resp, req, &args)
@@ -59,6 +63,18 @@ func (s *HTTPHandlers) KVSGet(resp http.ResponseWriter, req *http.Request, args
 		return nil, HTTPError{StatusCode: http.StatusBadRequest, Reason: "Missing key name"}
 	}
 
+	// Check for a point-in-time read
+	if _, ok := params["at-index"]; ok {
+		if method != "KVS.Get" {
+			return nil, HTTPError{StatusCode: http.StatusBadRequest, Reason: "Cannot use at-index with recurse"}
+		}
+		index, err := strconv.ParseUint(params.Get("at-index"), 10, 64)
+		if err != nil || index == 0 {
+			return nil, HTTPError{StatusCode: http.StatusBadRequest, Reason: fmt.Sprintf("Invalid at-index %q: must be a positive index", params.Get("at-index"))}
+		}
+		args.AtIndex = index
+	}
+
 	// Do not allow wildcard NS on GET reqs
 	if method == "KVS.Get" {
 		if err := s.parseEntMetaNoWildcard(req, &args.EnterpriseMeta); err != nil {
@@ -70,9 +86,15 @@ func (s *HTTPHandlers) KVSGet(resp http.ResponseWriter, req *http.Request, args
 		}
 	}
 
-	// Make the RPC
+	// Make the RPC, blocking lists may be served by the streaming backend
 	var out structs.IndexedDirEntries
-	if err := s.agent.RPC(req.Context(), method, args, &out); err != nil {
+	if method == "KVS.List" {
+		var err error
+		out, _, err = s.agent.rpcClientKV.List(req.Context(), *args)
+		if err != nil {
+			return nil, err
+		}
+	} else if err := s.agent.RPC(req.Context(), method, args, &out); err != nil {
 		return nil, err
 	}
 	setMeta(resp, &out.QueryMeta)
@@ -104,6 +126,37 @@ func (s *HTTPHandlers) KVSGet(resp http.ResponseWriter, req *http.Request, args
 	return out.Entries, nil
 }
 
+// KVSGetRevisions handles a GET request for the history of a key
+func (s *HTTPHandlers) KVSGetRevisions(resp http.ResponseWriter, req *http.Request, args *structs.KeyRequest) (interface{}, error) {
+	if args.Key == "" {
+		return nil, HTTPError{StatusCode: http.StatusBadRequest, Reason: "Missing key name"}
+	}
+	params := req.URL.Query()
+	if _, ok := params["recurse"]; ok {
+		return nil, HTTPError{StatusCode: http.StatusBadRequest, Reason: "Cannot use revisions with recurse"}
+	}
+	if _, ok := params["at-index"]; ok {
+		return nil, HTTPError{StatusCode: http.StatusBadRequest, Reason: "Cannot use revisions with at-index"}
+	}
+	if err := s.parseEntMetaNoWildcard(req, &args.EnterpriseMeta); err != nil {
+		return nil, err
+	}
+
+	// Make the RPC
+	var out structs.IndexedDirEntryRevisions
+	if err := s.agent.RPC(req.Context(), "KVS.History", args, &out); err != nil {
+		return nil, err
+	}
+	setMeta(resp, &out.QueryMeta)
+
+	// Check if we get a not found
+	if len(out.Revisions) == 0 {
+		resp.WriteHeader(http.StatusNotFound)
+		return nil, nil
+	}
+	return out.Revisions, nil
+}
+
 // KVSGetKeys handles a GET request for keys
 func (s *HTTPHandlers) KVSGetKeys(resp http.ResponseWriter, req *http.Request, args *structs.KeyRequest) (interface{}, error) {
 	if err := s.parseEntMeta(req, &args.EnterpriseMeta); err != nil {
@@ -184,6 +237,15 @@ func (s *HTTPHandlers) KVSPut(resp http.ResponseWriter, req *http.Request, args
 		applyReq.DirEnt.Flags = flagVal
 	}
 
+	// Check for a TTL
+	if _, ok := params["ttl"]; ok {
+		ttl, err := time.ParseDuration(params.Get("ttl"))
+		if err != nil || ttl < 0 {
+			return nil, HTTPError{StatusCode: http.StatusBadRequest, Reason: fmt.Sprintf("Invalid TTL %q: must be a non-negative duration, such as \"30s\"", params.Get("ttl"))}
+		}
+		applyReq.DirEnt.ExpirationTTL = ttl
+	}
+
 	// Check for cas value
 	if _, ok := params["cas"]; ok {
 		casVal, err := strconv.ParseUint(params.Get("cas"), 10, 64)
@@ -10,7 +10,11 @@ import (
 	"net/http/httptest"
 	"reflect"
 	"testing"
+	"time"
 
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/consul/sdk/testutil/retry"
 	"github.com/hashicorp/consul/testrpc"
 
 	"github.com/hashicorp/consul/agent/structs"
@@ -484,6 +488,213 @@ func TestKVSEndpoint_GET_Raw(t *testing.T) {
 	}
 }
 
+func TestKVSEndpoint_PUT_TTL(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+	a := NewTestAgent(t, "")
+	defer a.Shutdown()
+	testrpc.WaitForTestAgent(t, a.RPC, "dc1")
+
+	for _, ttl := range []string{"foo", "-1s"} {
+		req, _ := http.NewRequest("PUT", "/v1/kv/test?ttl="+ttl, bytes.NewBufferString("test"))
+		resp := httptest.NewRecorder()
+		_, err := a.srv.KVSEndpoint(resp, req)
+		require.Error(t, err)
+		httpErr, ok := err.(HTTPError)
+		require.True(t, ok, "expected an HTTPError, got %T", err)
+		require.Equal(t, http.StatusBadRequest, httpErr.StatusCode)
+		require.Contains(t, httpErr.Reason, "Invalid TTL")
+	}
+
+	start := time.Now()
+	req, _ := http.NewRequest("PUT", "/v1/kv/test?ttl=1s", bytes.NewBufferString("test"))
+	resp := httptest.NewRecorder()
+	obj, err := a.srv.KVSEndpoint(resp, req)
+	require.NoError(t, err)
+	require.True(t, obj.(bool))
+
+	req, _ = http.NewRequest("GET", "/v1/kv/test", nil)
+	resp = httptest.NewRecorder()
+	obj, err = a.srv.KVSEndpoint(resp, req)
+	require.NoError(t, err)
+	d := obj.(structs.DirEntries)[0]
+	require.NotNil(t, d.ExpirationTime)
+	require.WithinDuration(t, start.Add(time.Second), *d.ExpirationTime, time.Second)
+
+	// The entry is deleted once it expires.
+	retry.Run(t, func(r *retry.R) {
+		req, _ := http.NewRequest("GET", "/v1/kv/test", nil)
+		resp := httptest.NewRecorder()
+		obj, err := a.srv.KVSEndpoint(resp, req)
+		require.NoError(r, err)
+		require.Nil(r, obj)
+		require.Equal(r, http.StatusNotFound, resp.Code)
+	})
+}
+
+func TestKVSEndpoint_GET_Revisions(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+	a := NewTestAgent(t, `kv_revisions { "config/" = 2 }`)
+	defer a.Shutdown()
+	testrpc.WaitForTestAgent(t, a.RPC, "dc1")
+
+	var indexes []uint64
+	for _, value := range []string{"one", "two", "three"} {
+		req, _ := http.NewRequest("PUT", "/v1/kv/config/app", bytes.NewBufferString(value))
+		resp := httptest.NewRecorder()
+		obj, err := a.srv.KVSEndpoint(resp, req)
+		require.NoError(t, err)
+		require.True(t, obj.(bool))
+
+		req, _ = http.NewRequest("GET", "/v1/kv/config/app", nil)
+		resp = httptest.NewRecorder()
+		obj, err = a.srv.KVSEndpoint(resp, req)
+		require.NoError(t, err)
+		indexes = append(indexes, obj.(structs.DirEntries)[0].ModifyIndex)
+	}
+
+	// The history lists the current entry and the retained revisions.
+	req, _ := http.NewRequest("GET", "/v1/kv/config/app?revisions", nil)
+	resp := httptest.NewRecorder()
+	obj, err := a.srv.KVSEndpoint(resp, req)
+	require.NoError(t, err)
+	assertIndex(t, resp)
+	history := obj.(structs.DirEntryRevisions)
+	require.Len(t, history, 3)
+	require.Equal(t, []byte("three"), history[0].Value)
+	require.Zero(t, history[0].ReplacedIndex)
+	require.Equal(t, []byte("two"), history[1].Value)
+	require.Equal(t, indexes[2], history[1].ReplacedIndex)
+	require.Equal(t, []byte("one"), history[2].Value)
+
+	// Previous revisions can be read at the index they were current.
+	req, _ = http.NewRequest("GET", fmt.Sprintf("/v1/kv/config/app?at-index=%d", indexes[1]), nil)
+	resp = httptest.NewRecorder()
+	obj, err = a.srv.KVSEndpoint(resp, req)
+	require.NoError(t, err)
+	require.Equal(t, []byte("two"), obj.(structs.DirEntries)[0].Value)
+
+	req, _ = http.NewRequest("GET", fmt.Sprintf("/v1/kv/config/app?at-index=%d&raw", indexes[0]), nil)
+	resp = httptest.NewRecorder()
+	_, err = a.srv.KVSEndpoint(resp, req)
+	require.NoError(t, err)
+	require.Equal(t, "one", resp.Body.String())
+
+	req, _ = http.NewRequest("GET", fmt.Sprintf("/v1/kv/config/app?at-index=%d", indexes[0]-1), nil)
+	resp = httptest.NewRecorder()
+	obj, err = a.srv.KVSEndpoint(resp, req)
+	require.NoError(t, err)
+	require.Nil(t, obj)
+	require.Equal(t, http.StatusNotFound, resp.Code)
+
+	// Keys outside of the configured prefixes don't retain revisions.
+	req, _ = http.NewRequest("PUT", "/v1/kv/other", bytes.NewBufferString("one"))
+	_, err = a.srv.KVSEndpoint(httptest.NewRecorder(), req)
+	require.NoError(t, err)
+	req, _ = http.NewRequest("PUT", "/v1/kv/other", bytes.NewBufferString("two"))
+	_, err = a.srv.KVSEndpoint(httptest.NewRecorder(), req)
+	require.NoError(t, err)
+	req, _ = http.NewRequest("GET", "/v1/kv/other?revisions", nil)
+	obj, err = a.srv.KVSEndpoint(httptest.NewRecorder(), req)
+	require.NoError(t, err)
+	require.Len(t, obj.(structs.DirEntryRevisions), 1)
+
+	// Unknown keys have no history.
+	req, _ = http.NewRequest("GET", "/v1/kv/missing?revisions", nil)
+	resp = httptest.NewRecorder()
+	obj, err = a.srv.KVSEndpoint(resp, req)
+	require.NoError(t, err)
+	require.Nil(t, obj)
+	require.Equal(t, http.StatusNotFound, resp.Code)
+
+	for _, query := range []string{"revisions&recurse", "revisions&at-index=1", "at-index=1&recurse", "at-index=foo", "at-index=0"} {
+		req, _ := http.NewRequest("GET", "/v1/kv/config/app?"+query, nil)
+		_, err := a.srv.KVSEndpoint(httptest.NewRecorder(), req)
+		require.Error(t, err, query)
+		httpErr, ok := err.(HTTPError)
+		require.True(t, ok, "expected an HTTPError, got %T", err)
+		require.Equal(t, http.StatusBadRequest, httpErr.StatusCode, query)
+	}
+}
+
+func TestKVSEndpoint_GET_Recurse_Streaming(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+	a := NewTestAgent(t, `
+		rpc { enable_streaming = true }
+		use_streaming_backend = true
+	`)
+	defer a.Shutdown()
+	testrpc.WaitForTestAgent(t, a.RPC, "dc1")
+
+	put := func(key, value string) {
+		t.Helper()
+		req, _ := http.NewRequest("PUT", "/v1/kv/"+key, bytes.NewBufferString(value))
+		obj, err := a.srv.KVSEndpoint(httptest.NewRecorder(), req)
+		require.NoError(t, err)
+		require.True(t, obj.(bool))
+	}
+	put("foo/a", "a")
+	put("foo/b", "b")
+	put("bar", "bar")
+
+	// Non-blocking queries use the RPC backend.
+	req, _ := http.NewRequest("GET", "/v1/kv/foo/?recurse", nil)
+	resp := httptest.NewRecorder()
+	obj, err := a.srv.KVSEndpoint(resp, req)
+	require.NoError(t, err)
+	require.Equal(t, "blocking-query", resp.Header().Get("X-Consul-Query-Backend"))
+	require.Len(t, obj.(structs.DirEntries), 2)
+
+	// Blocking queries are served by the streaming backend.
+	req, _ = http.NewRequest("GET", "/v1/kv/foo/?recurse&index=1", nil)
+	resp = httptest.NewRecorder()
+	obj, err = a.srv.KVSEndpoint(resp, req)
+	require.NoError(t, err)
+	assertIndex(t, resp)
+	require.Equal(t, "streaming", resp.Header().Get("X-Consul-Query-Backend"))
+	entries := obj.(structs.DirEntries)
+	require.Len(t, entries, 2)
+	require.Equal(t, "foo/a", entries[0].Key)
+	require.Equal(t, "foo/b", entries[1].Key)
+	index := resp.Header().Get("X-Consul-Index")
+
+	// Changes to the keys under the prefix unblock the query.
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		put("bar", "bar2")
+		put("foo/c", "c")
+	}()
+	req, _ = http.NewRequest("GET", "/v1/kv/foo/?recurse&index="+index, nil)
+	resp = httptest.NewRecorder()
+	obj, err = a.srv.KVSEndpoint(resp, req)
+	require.NoError(t, err)
+	require.Equal(t, "streaming", resp.Header().Get("X-Consul-Query-Backend"))
+	require.Len(t, obj.(structs.DirEntries), 3)
+
+	// Deleting the keys under the prefix empties the result.
+	index = resp.Header().Get("X-Consul-Index")
+	req, _ = http.NewRequest("DELETE", "/v1/kv/foo/?recurse", nil)
+	_, err = a.srv.KVSEndpoint(httptest.NewRecorder(), req)
+	require.NoError(t, err)
+	req, _ = http.NewRequest("GET", "/v1/kv/foo/?recurse&index="+index, nil)
+	resp = httptest.NewRecorder()
+	obj, err = a.srv.KVSEndpoint(resp, req)
+	require.NoError(t, err)
+	require.Nil(t, obj)
+	require.Equal(t, http.StatusNotFound, resp.Code)
+}
+
 func TestKVSEndpoint_PUT_ConflictingFlags(t *testing.T) {
 	if testing.Short() {
 		t.Skip("too slow for testing.Short")
@@ -12,6 +12,7 @@ import (
 	"sync"
 	"sync/atomic"
 	"time"
+	"unicode/utf8"
 
 	"github.com/hashicorp/consul/acl/resolver"
 	"github.com/hashicorp/consul/lib/stringslice"
@@ -57,6 +58,7 @@ const fullSyncReadMaxStale = 2 * time.Second
 // Config is the configuration for the State.
 type Config struct {
 	AdvertiseAddr       string
+	CheckHistorySize    int
 	CheckUpdateInterval time.Duration
 	Datacenter          string
 	DiscardCheckOutput  bool
@@ -203,6 +205,9 @@ type State struct {
 	checks       map[structs.CheckID]*CheckState
 	checkAliases map[structs.ServiceID]map[structs.CheckID]chan<- struct{}
 
+	// checkHistory tracks the recent results of the local checks.
+	checkHistory map[structs.CheckID]*checkHistory
+
 	// metadata tracks the node metadata fields
 	metadata map[string]string
 
@@ -219,6 +224,10 @@ type State struct {
 	// but the same mechanism could be used for other state changes. Any
 	// future notifications should re-use this mechanism.
 	notifyHandlers map[chan<- struct{}]struct{}
+
+	// checkNotifyHandlers is a map of registered channel listeners that are
+	// sent messages whenever a check is added, updated or removed.
+	checkNotifyHandlers map[chan<- struct{}]struct{}
 }
 
 // NewState creates a new local state for the agent.
@@ -229,9 +238,11 @@ func NewState(c Config, logger hclog.Logger, tokens *token.Store) *State {
 		services:            make(map[structs.ServiceID]*ServiceState),
 		checks:              make(map[structs.CheckID]*CheckState),
 		checkAliases:        make(map[structs.ServiceID]map[structs.CheckID]chan<- struct{}),
+		checkHistory:        make(map[structs.CheckID]*checkHistory),
 		metadata:            make(map[string]string),
 		tokens:              tokens,
 		notifyHandlers:      make(map[chan<- struct{}]struct{}),
+		checkNotifyHandlers: make(map[chan<- struct{}]struct{}),
 		agentEnterpriseMeta: *structs.NodeEnterpriseMetaInPartition(c.Partition),
 	}
 	l.SetDiscardCheckOutput(c.DiscardCheckOutput)
@@ -655,6 +666,9 @@ func (l *State) removeCheckLocked(id structs.CheckID) error {
 
 	// If this is a check for an aliased service, then notify the waiters.
 	l.notifyIfAliased(c.Check.CompoundServiceID())
+	l.notifyCheckHandlers()
+
+	delete(l.checkHistory, id)
 
 	// To remove the check on the server we need the token.
 	// Therefore, we mark the service as deleted and keep the
@@ -666,7 +680,8 @@ func (l *State) removeCheckLocked(id structs.CheckID) error {
 	return nil
 }
 
-// UpdateCheck is used to update the status of a check
+// UpdateCheck is used to update the status of a check. The status is also
+// recorded in the history of the check.
 func (l *State) UpdateCheck(id structs.CheckID, status, output string) {
 	l.Lock()
 	defer l.Unlock()
@@ -676,6 +691,41 @@ func (l *State) UpdateCheck(id structs.CheckID, status, output string) {
 		return
 	}
 
+	l.recordCheckResult(id, status, output, 0)
+	l.updateCheckLocked(id, c, status, output)
+}
+
+// RecordCheckResult records the result of a run of a check, which took latency
+// to run, in the history of the check without updating its status.
+func (l *State) RecordCheckResult(id structs.CheckID, status, output string, latency time.Duration) {
+	l.Lock()
+	defer l.Unlock()
+
+	c := l.checks[id]
+	if c == nil || c.Deleted {
+		return
+	}
+	l.recordCheckResult(id, status, output, latency)
+}
+
+// UpdateCheckStatus is used to update the status of a check without recording
+// it in the history of the check, e.g. because it was already recorded with
+// RecordCheckResult, or to restore the status of a check after it has been
+// registered again.
+func (l *State) UpdateCheckStatus(id structs.CheckID, status, output string) {
+	l.Lock()
+	defer l.Unlock()
+
+	c := l.checks[id]
+	if c == nil || c.Deleted {
+		return
+	}
+	l.updateCheckLocked(id, c, status, output)
+}
+
+// updateCheckLocked updates the status of a check.
+// This method is not synchronized and the lock must already be held.
+func (l *State) updateCheckLocked(id structs.CheckID, c *CheckState, status, output string) {
 	if l.discardCheckOutput.Load().(bool) {
 		output = ""
 	}
@@ -698,10 +748,9 @@ func (l *State) UpdateCheck(id structs.CheckID, status, output string) {
 	// Ensure we only mutate a copy of the check state and put the finalized
 	// version into the checks map when complete.
 	//
-	// Note that we are relying upon the earlier deferred mutex unlock to
-	// happen AFTER this defer. As per the Go spec this is true, but leaving
-	// this note here for the future in case of any refactorings which may not
-	// notice this relationship.
+	// Note that we are relying upon the caller holding the lock until AFTER
+	// this defer runs, leaving this note here for the future in case of any
+	// refactorings which may not notice this relationship.
 	c = c.Clone()
 	defer func(c *CheckState) {
 		l.checks[id] = c
@@ -737,6 +786,7 @@ func (l *State) UpdateCheck(id structs.CheckID, status, output string) {
 
 	// If this is a check for an aliased service, then notify the waiters.
 	l.notifyIfAliased(c.Check.CompoundServiceID())
+	l.notifyCheckHandlers()
 
 	// Update status and mark out of sync
 	c.Check.Status = status
@@ -745,6 +795,77 @@ func (l *State) UpdateCheck(id structs.CheckID, status, output string) {
 	l.TriggerSyncChanges()
 }
 
+// recordCheckResult adds a result to the history of a check.
+// This method is not synchronized and the lock must already be held.
+func (l *State) recordCheckResult(id structs.CheckID, status, output string, latency time.Duration) {
+	if l.config.CheckHistorySize <= 0 {
+		return
+	}
+	h := l.checkHistory[id]
+	if h == nil {
+		h = &checkHistory{results: make([]structs.CheckResult, 0, l.config.CheckHistorySize)}
+		l.checkHistory[id] = h
+	}
+	h.add(structs.CheckResult{
+		Timestamp: time.Now(),
+		Status:    status,
+		Output:    truncateCheckOutput(output, structs.CheckResultMaxOutputSize),
+		Latency:   latency,
+	})
+}
+
+// CheckHistory returns the recent results of a local check, from the oldest
+// to the most recent one, or nil if the check doesn't exist.
+func (l *State) CheckHistory(id structs.CheckID) []structs.CheckResult {
+	l.RLock()
+	defer l.RUnlock()
+
+	c := l.checks[id]
+	if c == nil || c.Deleted {
+		return nil
+	}
+	h := l.checkHistory[id]
+	if h == nil {
+		return []structs.CheckResult{}
+	}
+	return h.list()
+}
+
+// checkHistory is a ring buffer of the recent results of a check.
+type checkHistory struct {
+	results []structs.CheckResult
+	next    int
+}
+
+// add records a result, replacing the oldest one once the buffer is full.
+func (h *checkHistory) add(r structs.CheckResult) {
+	if len(h.results) < cap(h.results) {
+		h.results = append(h.results, r)
+		return
+	}
+	h.results[h.next] = r
+	h.next = (h.next + 1) % len(h.results)
+}
+
+// list returns a copy of the results from the oldest to the most recent one.
+func (h *checkHistory) list() []structs.CheckResult {
+	results := make([]structs.CheckResult, 0, len(h.results))
+	results = append(results, h.results[h.next:]...)
+	return append(results, h.results[:h.next]...)
+}
+
+// truncateCheckOutput truncates output to at most max bytes without
+// splitting a UTF-8 character.
+func truncateCheckOutput(output string, max int) string {
+	if len(output) <= max {
+		return output
+	}
+	for max > 0 && !utf8.RuneStart(output[max]) {
+		max--
+	}
+	return output[:max]
+}
+
 // Check returns the locally registered check that the
 // agent is aware of and are being kept in sync with the server
 func (l *State) Check(id structs.CheckID) *structs.HealthCheck {
@@ -844,6 +965,7 @@ func (l *State) setCheckStateLocked(c *CheckState) {
 
 	// If this is a check for an aliased service, then notify the waiters.
 	l.notifyIfAliased(c.Check.CompoundServiceID())
+	l.notifyCheckHandlers()
 
 	l.TriggerSyncChanges()
 }
@@ -955,6 +1077,34 @@ func (l *State) StopNotify(ch chan<- struct{}) {
 	delete(l.notifyHandlers, ch)
 }
 
+// NotifyChecks will register a channel to receive messages when a check is
+// added, updated or removed. As with Notify, the channel must have a buffer.
+func (l *State) NotifyChecks(ch chan<- struct{}) {
+	l.Lock()
+	defer l.Unlock()
+	l.checkNotifyHandlers[ch] = struct{}{}
+}
+
+// StopNotifyChecks will deregister a channel receiving check change
+// notifications. Pair this with all calls to NotifyChecks to clean up state.
+func (l *State) StopNotifyChecks(ch chan<- struct{}) {
+	l.Lock()
+	defer l.Unlock()
+	delete(l.checkNotifyHandlers, ch)
+}
+
+// notifyCheckHandlers notifies the channels registered with NotifyChecks. This
+// must be called with the lock held.
+func (l *State) notifyCheckHandlers() {
+	for ch := range l.checkNotifyHandlers {
+		// Do not block
+		select {
+		case ch <- struct{}{}:
+		default:
+		}
+	}
+}
+
 // Metadata returns the local node metadata fields that the
 // agent is aware of and are being kept in sync with the server
 func (l *State) Metadata() map[string]string {
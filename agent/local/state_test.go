@@ -10,6 +10,7 @@ import (
 	"os"
 	"path/filepath"
 	"sort"
+	"strings"
 	"testing"
 	"time"
 
@@ -2189,6 +2190,75 @@ func TestAgent_CheckCriticalTime(t *testing.T) {
 	}
 }
 
+func TestState_CheckHistory(t *testing.T) {
+	t.Parallel()
+	cfg := loadRuntimeConfig(t, `bind_addr = "127.0.0.1" data_dir = "dummy" node_name = "dummy" check_history_size = 3`)
+	l := local.NewState(agent.LocalConfig(cfg), nil, new(token.Store))
+	l.TriggerSyncChanges = func() {}
+
+	id := structs.NewCheckID("mem", nil)
+	require.Nil(t, l.CheckHistory(id))
+
+	require.NoError(t, l.AddCheck(&structs.HealthCheck{CheckID: "mem", Status: api.HealthCritical}, "", false))
+	require.Empty(t, l.CheckHistory(id))
+	require.NotNil(t, l.CheckHistory(id))
+
+	// Results are recorded without updating the status, idempotent ones too.
+	l.RecordCheckResult(id, api.HealthPassing, "ok", time.Millisecond)
+	l.RecordCheckResult(id, api.HealthPassing, "ok", 2*time.Millisecond)
+	require.Equal(t, api.HealthCritical, l.Check(id).Status)
+	history := l.CheckHistory(id)
+	require.Len(t, history, 2)
+	require.Equal(t, api.HealthPassing, history[0].Status)
+	require.Equal(t, "ok", history[0].Output)
+	require.Equal(t, time.Millisecond, history[0].Latency)
+	require.Equal(t, 2*time.Millisecond, history[1].Latency)
+	require.False(t, history[1].Timestamp.Before(history[0].Timestamp))
+
+	// The oldest results are dropped once the history is full.
+	l.UpdateCheck(id, api.HealthWarning, "slow")
+	l.UpdateCheck(id, api.HealthCritical, strings.Repeat("é", structs.CheckResultMaxOutputSize))
+	history = l.CheckHistory(id)
+	require.Len(t, history, 3)
+	require.Equal(t, 2*time.Millisecond, history[0].Latency)
+	require.Equal(t, api.HealthWarning, history[1].Status)
+	require.Equal(t, time.Duration(0), history[1].Latency)
+	require.Equal(t, api.HealthCritical, history[2].Status)
+	require.Equal(t, strings.Repeat("é", structs.CheckResultMaxOutputSize/2), history[2].Output)
+
+	// The check output is kept in the history even if it's discarded.
+	l.SetDiscardCheckOutput(true)
+	l.UpdateCheck(id, api.HealthPassing, "back")
+	history = l.CheckHistory(id)
+	require.Equal(t, "back", history[2].Output)
+	require.Equal(t, "", l.Check(id).Output)
+
+	// Updating only the status of the check isn't a result.
+	l.UpdateCheckStatus(id, api.HealthWarning, "restored")
+	require.Equal(t, history, l.CheckHistory(id))
+	require.Equal(t, api.HealthWarning, l.Check(id).Status)
+
+	require.NoError(t, l.RemoveCheck(id))
+	require.Nil(t, l.CheckHistory(id))
+
+	// The history starts over when the check is added again.
+	require.NoError(t, l.AddCheck(&structs.HealthCheck{CheckID: "mem", Status: api.HealthCritical}, "", false))
+	require.Empty(t, l.CheckHistory(id))
+}
+
+func TestState_CheckHistory_Disabled(t *testing.T) {
+	t.Parallel()
+	cfg := loadRuntimeConfig(t, `bind_addr = "127.0.0.1" data_dir = "dummy" node_name = "dummy" check_history_size = 0`)
+	l := local.NewState(agent.LocalConfig(cfg), nil, new(token.Store))
+	l.TriggerSyncChanges = func() {}
+
+	id := structs.NewCheckID("mem", nil)
+	require.NoError(t, l.AddCheck(&structs.HealthCheck{CheckID: "mem", Status: api.HealthCritical}, "", false))
+	l.UpdateCheck(id, api.HealthPassing, "ok")
+	require.Empty(t, l.CheckHistory(id))
+	require.Equal(t, api.HealthPassing, l.Check(id).Status)
+}
+
 func TestAgent_AddCheckFailure(t *testing.T) {
 	t.Parallel()
 	cfg := loadRuntimeConfig(t, `bind_addr = "127.0.0.1" data_dir = "dummy" node_name = "dummy"`)
@@ -112,6 +112,7 @@ var rpcRateLimitSpecs = map[string]rate.OperationSpec{
 
 	"KVS.Apply":    {Type: rate.OperationTypeWrite, Category: rate.OperationCategoryKV},
 	"KVS.Get":      {Type: rate.OperationTypeRead, Category: rate.OperationCategoryKV},
+	"KVS.History":  {Type: rate.OperationTypeRead, Category: rate.OperationCategoryKV},
 	"KVS.List":     {Type: rate.OperationTypeRead, Category: rate.OperationCategoryKV},
 	"KVS.ListKeys": {Type: rate.OperationTypeRead, Category: rate.OperationCategoryKV},
 
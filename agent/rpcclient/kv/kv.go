@@ -0,0 +1,74 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package kv
+
+import (
+	"context"
+
+	"github.com/hashicorp/consul/agent/cache"
+	"github.com/hashicorp/consul/agent/rpcclient"
+	"github.com/hashicorp/consul/agent/structs"
+	"github.com/hashicorp/consul/agent/submatview"
+	"github.com/hashicorp/consul/proto/private/pbsubscribe"
+)
+
+// Client provides access to KV data.
+type Client struct {
+	rpcclient.Client
+}
+
+// List returns the KV entries whose key starts with the key of the request.
+// Blocking queries are served from a materialized view of the KV topic when
+// the streaming backend is enabled.
+func (c *Client) List(
+	ctx context.Context,
+	req structs.KeyRequest,
+) (structs.IndexedDirEntries, cache.ResultMeta, error) {
+	if c.UseStreamingBackend && req.QueryOptions.MinQueryIndex > 0 {
+		c.QueryOptionDefaults(&req.QueryOptions)
+
+		result, err := c.ViewStore.Get(ctx, c.newKVRequest(req))
+		if err != nil {
+			return structs.IndexedDirEntries{}, cache.ResultMeta{}, err
+		}
+		meta := cache.ResultMeta{Index: result.Index, Hit: result.Cached}
+		return *result.Value.(*structs.IndexedDirEntries), meta, err
+	}
+
+	var out structs.IndexedDirEntries
+	err := c.NetRPC.RPC(ctx, "KVS.List", &req, &out)
+	return out, cache.ResultMeta{}, err
+}
+
+func (c *Client) newKVRequest(req structs.KeyRequest) kvRequest {
+	return kvRequest{
+		KeyRequest: req,
+		deps:       c.MaterializerDeps,
+	}
+}
+
+var _ submatview.Request = (*kvRequest)(nil)
+
+type kvRequest struct {
+	structs.KeyRequest
+	deps rpcclient.MaterializerDeps
+}
+
+func (r kvRequest) CacheInfo() cache.RequestInfo {
+	return r.KeyRequest.CacheInfo()
+}
+
+func (r kvRequest) Type() string {
+	return "agent.rpcclient.kv.kvRequest"
+}
+
+func (r kvRequest) NewMaterializer() (submatview.Materializer, error) {
+	deps := submatview.Deps{
+		View:    NewKVView(r.KeyRequest),
+		Logger:  r.deps.Logger,
+		Request: NewMaterializerRequest(r.KeyRequest),
+	}
+
+	return submatview.NewRPCMaterializer(pbsubscribe.NewStateChangeSubscriptionClient(r.deps.Conn), deps), nil
+}
@@ -0,0 +1,137 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package kv
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/consul/acl"
+	"github.com/hashicorp/consul/agent/structs"
+	"github.com/hashicorp/consul/agent/submatview"
+	"github.com/hashicorp/consul/proto/private/pbsubscribe"
+)
+
+// NewMaterializerRequest returns a function that builds the subscription to
+// the KV entries whose key starts with the key of the request. The empty
+// prefix is subscribed to with the wildcard subject.
+func NewMaterializerRequest(keyReq structs.KeyRequest) func(index uint64) *pbsubscribe.SubscribeRequest {
+	return func(index uint64) *pbsubscribe.SubscribeRequest {
+		req := &pbsubscribe.SubscribeRequest{
+			Topic:      pbsubscribe.Topic_KV,
+			Token:      keyReq.Token,
+			Datacenter: keyReq.Datacenter,
+			Index:      index,
+		}
+		if keyReq.Key == "" {
+			req.Subject = &pbsubscribe.SubscribeRequest_WildcardSubject{
+				WildcardSubject: true,
+			}
+		} else {
+			req.Subject = &pbsubscribe.SubscribeRequest_NamedSubject{
+				NamedSubject: &pbsubscribe.NamedSubject{
+					Key:       keyReq.Key,
+					Namespace: keyReq.EnterpriseMeta.NamespaceOrEmpty(),
+					Partition: keyReq.EnterpriseMeta.PartitionOrEmpty(),
+				},
+			}
+		}
+		return req
+	}
+}
+
+// NewKVView returns a KVView for the KV entries whose key starts with the key
+// of the request.
+func NewKVView(req structs.KeyRequest) *KVView {
+	view := &KVView{prefix: req.Key, entMeta: req.EnterpriseMeta}
+	view.Reset()
+	return view
+}
+
+var _ submatview.View = (*KVView)(nil)
+
+// KVView implements submatview.View for storing the view state of the KV
+// entries under a prefix, indexed by key.
+type KVView struct {
+	prefix  string
+	entMeta acl.EnterpriseMeta
+	state   map[string]*structs.DirEntry
+}
+
+// Update implements View
+func (v *KVView) Update(events []*pbsubscribe.Event) error {
+	for _, event := range events {
+		update := event.GetKV()
+		if update == nil {
+			return fmt.Errorf("unexpected event type for KV view: %T",
+				event.GetPayload())
+		}
+
+		entry := pbsubscribe.KVEntryToStructs(update.Entry)
+		if entry == nil {
+			return fmt.Errorf("KV entry was unexpectedly nil")
+		}
+
+		// The subscriptions to all the keys receive the events of all the
+		// partitions and namespaces.
+		if !strings.HasPrefix(entry.Key, v.prefix) || !v.matchesEnterpriseMeta(entry) {
+			continue
+		}
+
+		switch update.Op {
+		case pbsubscribe.KVUpdate_Upsert:
+			v.state[entry.Key] = entry
+		case pbsubscribe.KVUpdate_Delete:
+			delete(v.state, entry.Key)
+		}
+	}
+	return nil
+}
+
+func (v *KVView) matchesEnterpriseMeta(entry *structs.DirEntry) bool {
+	partition := v.entMeta.PartitionOrDefault()
+	namespace := v.entMeta.NamespaceOrDefault()
+
+	if partition != acl.WildcardName && !acl.EqualPartitions(partition, entry.PartitionOrDefault()) {
+		return false
+	}
+	if namespace != acl.WildcardName && !acl.EqualNamespaces(namespace, entry.NamespaceOrDefault()) {
+		return false
+	}
+	return true
+}
+
+// Result returns the structs.IndexedDirEntries stored by this view, sorted by
+// key like the entries returned by the KVS.List endpoint.
+func (v *KVView) Result(index uint64) interface{} {
+	// Must provide non-zero index to prevent blocking, like KVS.List.
+	if index == 0 {
+		index = 1
+	}
+
+	result := structs.IndexedDirEntries{
+		QueryMeta: structs.QueryMeta{
+			Index:   index,
+			Backend: structs.QueryBackendStreaming,
+		},
+	}
+	if len(v.state) == 0 {
+		return &result
+	}
+
+	result.Entries = make(structs.DirEntries, 0, len(v.state))
+	for _, entry := range v.state {
+		result.Entries = append(result.Entries, entry)
+	}
+	sort.Slice(result.Entries, func(i, j int) bool {
+		return result.Entries[i].Key < result.Entries[j].Key
+	})
+	return &result
+}
+
+// Reset implements View
+func (v *KVView) Reset() {
+	v.state = make(map[string]*structs.DirEntry)
+}
@@ -0,0 +1,97 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package kv
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/consul/agent/structs"
+	"github.com/hashicorp/consul/proto/private/pbsubscribe"
+	"github.com/hashicorp/consul/sdk/testutil"
+)
+
+func kvEvent(index uint64, op pbsubscribe.KVUpdate_UpdateOp, key, value string) *pbsubscribe.Event {
+	return &pbsubscribe.Event{
+		Index: index,
+		Payload: &pbsubscribe.Event_KV{
+			KV: &pbsubscribe.KVUpdate{
+				Op: op,
+				Entry: &pbsubscribe.KVEntry{
+					Key:         key,
+					Value:       []byte(value),
+					ModifyIndex: index,
+				},
+			},
+		},
+	}
+}
+
+func resultKeys(t *testing.T, view *KVView, index uint64) []string {
+	t.Helper()
+
+	result := view.Result(index)
+	resp, ok := result.(*structs.IndexedDirEntries)
+	require.Truef(t, ok, "expected IndexedDirEntries, got: %T", result)
+	require.Equal(t, index, resp.QueryMeta.Index)
+	require.Equal(t, structs.QueryBackendStreaming, resp.QueryMeta.Backend)
+
+	var keys []string
+	for _, entry := range resp.Entries {
+		keys = append(keys, entry.Key)
+	}
+	return keys
+}
+
+func TestKVView(t *testing.T) {
+	view := NewKVView(structs.KeyRequest{Key: "foo/"})
+
+	testutil.RunStep(t, "initial state", func(t *testing.T) {
+		require.Empty(t, resultKeys(t, view, 1))
+
+		// A zero index is never returned.
+		resp := view.Result(0).(*structs.IndexedDirEntries)
+		require.Equal(t, uint64(1), resp.Index)
+	})
+
+	testutil.RunStep(t, "upsert events", func(t *testing.T) {
+		err := view.Update([]*pbsubscribe.Event{
+			kvEvent(2, pbsubscribe.KVUpdate_Upsert, "foo/b", "b"),
+			kvEvent(2, pbsubscribe.KVUpdate_Upsert, "foo/a", "a"),
+			kvEvent(2, pbsubscribe.KVUpdate_Upsert, "bar", "bar"),
+		})
+		require.NoError(t, err)
+		require.Equal(t, []string{"foo/a", "foo/b"}, resultKeys(t, view, 2))
+
+		err = view.Update([]*pbsubscribe.Event{
+			kvEvent(3, pbsubscribe.KVUpdate_Upsert, "foo/a", "a2"),
+		})
+		require.NoError(t, err)
+		resp := view.Result(3).(*structs.IndexedDirEntries)
+		require.Equal(t, "a2", string(resp.Entries[0].Value))
+		require.Equal(t, uint64(3), resp.Entries[0].ModifyIndex)
+	})
+
+	testutil.RunStep(t, "delete event", func(t *testing.T) {
+		err := view.Update([]*pbsubscribe.Event{
+			kvEvent(4, pbsubscribe.KVUpdate_Delete, "foo/b", ""),
+		})
+		require.NoError(t, err)
+		require.Equal(t, []string{"foo/a"}, resultKeys(t, view, 4))
+	})
+
+	testutil.RunStep(t, "reset", func(t *testing.T) {
+		view.Reset()
+		require.Empty(t, resultKeys(t, view, 5))
+	})
+
+	testutil.RunStep(t, "unexpected event", func(t *testing.T) {
+		err := view.Update([]*pbsubscribe.Event{{
+			Index:   6,
+			Payload: &pbsubscribe.Event_ServiceHealth{},
+		}})
+		require.Error(t, err)
+	})
+}
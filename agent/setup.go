@@ -429,6 +429,7 @@ func getPrometheusDefs(cfg *config.RuntimeConfig, isServer bool) ([]prometheus.G
 		consul.FederationStateSummaries,
 		consul.IntentionSummaries,
 		consul.KVSummaries,
+		consul.KVSTTLSummaries,
 		consul.LeaderSummaries,
 		consul.PreparedQuerySummaries,
 		consul.RPCSummaries,
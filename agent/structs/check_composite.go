@@ -0,0 +1,338 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package structs
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/consul/api"
+	"github.com/hashicorp/consul/lib"
+	"github.com/hashicorp/consul/types"
+)
+
+// validCompositeInputName matches the names of the inputs of composite
+// checks, which are used as identifiers in their expression.
+var validCompositeInputName = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
+
+// CompositeCheckInput is an input of a composite check. It's either the
+// instances of a service, or the checks of a node, optionally restricted to
+// a single check. A check ID without a node refers to a check of the local
+// node.
+type CompositeCheckInput struct {
+	// Name identifies the input in the expression and the output of the
+	// composite check.
+	Name string
+
+	Service    string
+	Node       string
+	CheckID    types.CheckID
+	Datacenter string
+
+	// MinPassing is the number of instances of the service that must be
+	// passing for the input to be passing. Defaults to 1.
+	MinPassing int
+}
+
+func (i *CompositeCheckInput) UnmarshalJSON(data []byte) error {
+	type Alias CompositeCheckInput
+	aux := &struct {
+		CheckIDSnake    types.CheckID `json:"check_id"`
+		MinPassingSnake int           `json:"min_passing"`
+
+		*Alias
+	}{
+		Alias: (*Alias)(i),
+	}
+	if err := lib.UnmarshalJSON(data, aux); err != nil {
+		return err
+	}
+	if i.CheckID == "" {
+		i.CheckID = aux.CheckIDSnake
+	}
+	if i.MinPassing == 0 {
+		i.MinPassing = aux.MinPassingSnake
+	}
+	return nil
+}
+
+// IsService checks if the input watches the instances of a service.
+func (i *CompositeCheckInput) IsService() bool {
+	return i.Service != ""
+}
+
+// validateComposite checks the inputs and the expression of composite checks.
+func (c *CheckType) validateComposite() error {
+	if !c.IsComposite() {
+		if c.CompositeExpression != "" || c.CompositeMinPassing != 0 {
+			return fmt.Errorf("CompositeExpression and CompositeMinPassing require CompositeInputs to be set")
+		}
+		return nil
+	}
+
+	names := make(map[string]struct{}, len(c.CompositeInputs))
+	for _, input := range c.CompositeInputs {
+		if !validCompositeInputName.MatchString(input.Name) || isCompositeOperator(input.Name) {
+			return fmt.Errorf("Invalid composite input name %q: names must be made of letters, digits, '_' and '-' and can't be 'and', 'or' or 'not'", input.Name)
+		}
+		if _, ok := names[input.Name]; ok {
+			return fmt.Errorf("Duplicate composite input name %q", input.Name)
+		}
+		names[input.Name] = struct{}{}
+
+		if input.IsService() == (input.Node != "" || input.CheckID != "") {
+			return fmt.Errorf("Composite input %q must set either Service or Node/CheckID", input.Name)
+		}
+		if input.Node == "" && input.CheckID != "" && input.CheckID == c.CheckID {
+			return fmt.Errorf("Composite input %q cannot refer to the composite check itself", input.Name)
+		}
+		if input.MinPassing < 0 {
+			return fmt.Errorf("MinPassing of composite input %q must be positive", input.Name)
+		}
+		if input.MinPassing > 0 && !input.IsService() {
+			return fmt.Errorf("MinPassing of composite input %q is only supported for service inputs", input.Name)
+		}
+	}
+
+	if c.CompositeExpression != "" && c.CompositeMinPassing != 0 {
+		return fmt.Errorf("CompositeExpression and CompositeMinPassing cannot both be specified")
+	}
+	if c.CompositeMinPassing < 0 || c.CompositeMinPassing > len(c.CompositeInputs) {
+		return fmt.Errorf("CompositeMinPassing must be between 0 and the number of composite inputs")
+	}
+	if c.CompositeExpression != "" {
+		expr, err := ParseCompositeCheckExpr(c.CompositeExpression)
+		if err != nil {
+			return fmt.Errorf("Invalid CompositeExpression: %v", err)
+		}
+		for _, name := range expr.Names() {
+			if _, ok := names[name]; !ok {
+				return fmt.Errorf("Invalid CompositeExpression: unknown input %q", name)
+			}
+		}
+	}
+	return nil
+}
+
+// CompositeCheckExpr is a boolean expression over the inputs of a composite
+// check, such as "db && (cache-a || cache-b)".
+//
+// Expressions are evaluated over the status of the inputs rather than over
+// booleans: "and" is the worst status of its operands, "or" is the best one
+// and "not" turns passing into critical and critical into passing, leaving
+// warning as is.
+type CompositeCheckExpr struct {
+	root compositeNode
+}
+
+// ParseCompositeCheckExpr parses the expression of a composite check. It
+// supports the "&&", "||" and "!" operators, their "and", "or" and "not"
+// spellings, and parentheses. Other words are the names of inputs.
+func ParseCompositeCheckExpr(expr string) (*CompositeCheckExpr, error) {
+	tokens, err := compositeTokens(expr)
+	if err != nil {
+		return nil, err
+	}
+	p := &compositeParser{tokens: tokens}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("unexpected %q", p.tokens[p.pos])
+	}
+	return &CompositeCheckExpr{root: root}, nil
+}
+
+// Names returns the names of the inputs referenced by the expression, in the
+// order they first appear.
+func (e *CompositeCheckExpr) Names() []string {
+	var names []string
+	seen := make(map[string]struct{})
+	var walk func(n compositeNode)
+	walk = func(n compositeNode) {
+		switch n := n.(type) {
+		case compositeName:
+			if _, ok := seen[string(n)]; !ok {
+				seen[string(n)] = struct{}{}
+				names = append(names, string(n))
+			}
+		case compositeNot:
+			walk(n.operand)
+		case compositeBinary:
+			walk(n.left)
+			walk(n.right)
+		}
+	}
+	walk(e.root)
+	return names
+}
+
+// Eval returns the status of the expression given the status of each input.
+// Statuses other than passing and warning are considered critical.
+func (e *CompositeCheckExpr) Eval(status func(name string) string) string {
+	switch e.root.eval(status) {
+	case compositePassing:
+		return api.HealthPassing
+	case compositeWarning:
+		return api.HealthWarning
+	default:
+		return api.HealthCritical
+	}
+}
+
+const (
+	compositeCritical = iota
+	compositeWarning
+	compositePassing
+)
+
+type compositeNode interface {
+	eval(status func(name string) string) int
+}
+
+type compositeName string
+
+func (n compositeName) eval(status func(name string) string) int {
+	switch status(string(n)) {
+	case api.HealthPassing:
+		return compositePassing
+	case api.HealthWarning:
+		return compositeWarning
+	default:
+		return compositeCritical
+	}
+}
+
+type compositeNot struct {
+	operand compositeNode
+}
+
+func (n compositeNot) eval(status func(name string) string) int {
+	return compositePassing - n.operand.eval(status)
+}
+
+type compositeBinary struct {
+	and         bool
+	left, right compositeNode
+}
+
+func (n compositeBinary) eval(status func(name string) string) int {
+	l, r := n.left.eval(status), n.right.eval(status)
+	if (n.and && r < l) || (!n.and && r > l) {
+		return r
+	}
+	return l
+}
+
+type compositeParser struct {
+	tokens []string
+	pos    int
+}
+
+func (p *compositeParser) next() string {
+	if p.pos >= len(p.tokens) {
+		return ""
+	}
+	return p.tokens[p.pos]
+}
+
+func (p *compositeParser) parseOr() (compositeNode, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for tok := p.next(); tok == "||" || strings.EqualFold(tok, "or"); tok = p.next() {
+		p.pos++
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = compositeBinary{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *compositeParser) parseAnd() (compositeNode, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for tok := p.next(); tok == "&&" || strings.EqualFold(tok, "and"); tok = p.next() {
+		p.pos++
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = compositeBinary{and: true, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *compositeParser) parseUnary() (compositeNode, error) {
+	tok := p.next()
+	switch {
+	case tok == "":
+		return nil, fmt.Errorf("unexpected end of expression")
+	case tok == "!" || strings.EqualFold(tok, "not"):
+		p.pos++
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return compositeNot{operand: operand}, nil
+	case tok == "(":
+		p.pos++
+		n, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.next() != ")" {
+			return nil, fmt.Errorf("missing )")
+		}
+		p.pos++
+		return n, nil
+	case validCompositeInputName.MatchString(tok) && !isCompositeOperator(tok):
+		p.pos++
+		return compositeName(tok), nil
+	default:
+		return nil, fmt.Errorf("unexpected %q", tok)
+	}
+}
+
+// compositeTokens splits an expression into operators, parentheses and words.
+func compositeTokens(expr string) ([]string, error) {
+	var tokens []string
+	for i := 0; i < len(expr); {
+		switch c := expr[i]; {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '(' || c == ')' || c == '!':
+			tokens = append(tokens, expr[i:i+1])
+			i++
+		case strings.HasPrefix(expr[i:], "&&") || strings.HasPrefix(expr[i:], "||"):
+			tokens = append(tokens, expr[i:i+2])
+			i += 2
+		case isCompositeWordChar(c):
+			j := i
+			for j < len(expr) && isCompositeWordChar(expr[j]) {
+				j++
+			}
+			tokens = append(tokens, expr[i:j])
+			i = j
+		default:
+			return nil, fmt.Errorf("unexpected character %q at offset %d", c, i)
+		}
+	}
+	return tokens, nil
+}
+
+func isCompositeWordChar(c byte) bool {
+	return c == '_' || c == '-' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
+
+func isCompositeOperator(word string) bool {
+	return strings.EqualFold(word, "and") || strings.EqualFold(word, "or") || strings.EqualFold(word, "not")
+}
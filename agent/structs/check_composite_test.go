@@ -0,0 +1,194 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package structs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/consul/api"
+)
+
+func TestParseCompositeCheckExpr(t *testing.T) {
+	statuses := map[string]string{
+		"p": api.HealthPassing,
+		"w": api.HealthWarning,
+		"c": api.HealthCritical,
+		"m": api.HealthMaint,
+	}
+
+	tests := []struct {
+		expr   string
+		names  []string
+		status string
+	}{
+		{expr: "p", names: []string{"p"}, status: api.HealthPassing},
+		{expr: "m", names: []string{"m"}, status: api.HealthCritical},
+		{expr: "p && w", names: []string{"p", "w"}, status: api.HealthWarning},
+		{expr: "p and c", names: []string{"p", "c"}, status: api.HealthCritical},
+		{expr: "c || w", names: []string{"c", "w"}, status: api.HealthWarning},
+		{expr: "c OR p", names: []string{"c", "p"}, status: api.HealthPassing},
+		{expr: "!c", names: []string{"c"}, status: api.HealthPassing},
+		{expr: "not p", names: []string{"p"}, status: api.HealthCritical},
+		{expr: "!w", names: []string{"w"}, status: api.HealthWarning},
+		// "and" binds tighter than "or".
+		{expr: "p || c && c", names: []string{"p", "c"}, status: api.HealthPassing},
+		{expr: "(p || c) && c", names: []string{"p", "c"}, status: api.HealthCritical},
+		{expr: "!(c && p) && (w || c)", names: []string{"c", "p", "w"}, status: api.HealthWarning},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			expr, err := ParseCompositeCheckExpr(tt.expr)
+			require.NoError(t, err)
+			require.Equal(t, tt.names, expr.Names())
+			require.Equal(t, tt.status, expr.Eval(func(name string) string { return statuses[name] }))
+		})
+	}
+
+	for expr, msg := range map[string]string{
+		"":          "unexpected end of expression",
+		"a &&":      "unexpected end of expression",
+		"(a || b":   "missing )",
+		"a b":       `unexpected "b"`,
+		"a & b":     "unexpected character",
+		"a || ) b":  `unexpected ")"`,
+		"and && b":  `unexpected "and"`,
+		"a == b":    "unexpected character",
+		"not":       "unexpected end of expression",
+		"a || (b)!": `unexpected "!"`,
+	} {
+		_, err := ParseCompositeCheckExpr(expr)
+		require.Error(t, err, expr)
+		require.Contains(t, err.Error(), msg, expr)
+	}
+}
+
+func TestCheckType_Validate_Composite(t *testing.T) {
+	inputs := []CompositeCheckInput{
+		{Name: "db", Service: "db", MinPassing: 2},
+		{Name: "cache", Node: "node1", CheckID: "redis"},
+		{Name: "local", CheckID: "disk"},
+	}
+
+	valid := []*CheckType{
+		{CompositeInputs: inputs},
+		{CompositeInputs: inputs, CompositeMinPassing: 2},
+		{CompositeInputs: inputs, CompositeExpression: "db && (cache || !local)"},
+	}
+	for _, chk := range valid {
+		require.NoError(t, chk.Validate())
+		require.Equal(t, "composite", chk.Type())
+	}
+
+	tests := []struct {
+		desc string
+		chk  *CheckType
+		err  string
+	}{
+		{
+			desc: "expression without inputs",
+			chk:  &CheckType{TTL: time.Second, CompositeExpression: "db"},
+			err:  "CompositeExpression and CompositeMinPassing require CompositeInputs to be set",
+		},
+		{
+			desc: "with an interval check",
+			chk:  &CheckType{HTTP: "http://foo/bar", Interval: time.Second, CompositeInputs: inputs},
+			err:  "Interval cannot be set for Composite checks",
+		},
+		{
+			desc: "with a TTL",
+			chk:  &CheckType{TTL: time.Second, CompositeInputs: inputs},
+			err:  "TTL must be not be set for Composite checks",
+		},
+		{
+			desc: "with an alias",
+			chk:  &CheckType{AliasService: "web", CompositeInputs: inputs},
+			err:  "AliasNode and AliasService cannot be set for Composite checks",
+		},
+		{
+			desc: "invalid input name",
+			chk:  &CheckType{CompositeInputs: []CompositeCheckInput{{Name: "db.1", Service: "db"}}},
+			err:  `Invalid composite input name "db.1"`,
+		},
+		{
+			desc: "operator as input name",
+			chk:  &CheckType{CompositeInputs: []CompositeCheckInput{{Name: "Not", Service: "db"}}},
+			err:  `Invalid composite input name "Not"`,
+		},
+		{
+			desc: "duplicate input name",
+			chk:  &CheckType{CompositeInputs: []CompositeCheckInput{{Name: "db", Service: "db"}, {Name: "db", Node: "node1"}}},
+			err:  `Duplicate composite input name "db"`,
+		},
+		{
+			desc: "service and node",
+			chk:  &CheckType{CompositeInputs: []CompositeCheckInput{{Name: "db", Service: "db", Node: "node1"}}},
+			err:  `Composite input "db" must set either Service or Node/CheckID`,
+		},
+		{
+			desc: "empty input",
+			chk:  &CheckType{CompositeInputs: []CompositeCheckInput{{Name: "db"}}},
+			err:  `Composite input "db" must set either Service or Node/CheckID`,
+		},
+		{
+			desc: "input referring to the check itself",
+			chk:  &CheckType{CheckID: "composite", CompositeInputs: []CompositeCheckInput{{Name: "self", CheckID: "composite"}}},
+			err:  `Composite input "self" cannot refer to the composite check itself`,
+		},
+		{
+			desc: "min passing on a node",
+			chk:  &CheckType{CompositeInputs: []CompositeCheckInput{{Name: "db", Node: "node1", MinPassing: 1}}},
+			err:  `MinPassing of composite input "db" is only supported for service inputs`,
+		},
+		{
+			desc: "expression and min passing",
+			chk:  &CheckType{CompositeInputs: inputs, CompositeExpression: "db", CompositeMinPassing: 1},
+			err:  "CompositeExpression and CompositeMinPassing cannot both be specified",
+		},
+		{
+			desc: "min passing above the number of inputs",
+			chk:  &CheckType{CompositeInputs: inputs, CompositeMinPassing: 4},
+			err:  "CompositeMinPassing must be between 0 and the number of composite inputs",
+		},
+		{
+			desc: "invalid expression",
+			chk:  &CheckType{CompositeInputs: inputs, CompositeExpression: "db &&"},
+			err:  "Invalid CompositeExpression: unexpected end of expression",
+		},
+		{
+			desc: "unknown input in expression",
+			chk:  &CheckType{CompositeInputs: inputs, CompositeExpression: "db && web"},
+			err:  `Invalid CompositeExpression: unknown input "web"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			err := tt.chk.Validate()
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tt.err)
+		})
+	}
+}
+
+func TestCheckType_UnmarshalJSON_Composite(t *testing.T) {
+	var chk CheckType
+	require.NoError(t, chk.UnmarshalJSON([]byte(`{
+		"composite_inputs": [
+			{"name": "db", "service": "db", "datacenter": "dc2", "min_passing": 2},
+			{"Name": "cache", "Node": "node1", "CheckID": "redis"},
+			{"name": "local", "check_id": "disk"}
+		],
+		"composite_expression": "db && cache",
+		"composite_min_passing": 0
+	}`)))
+	require.Equal(t, []CompositeCheckInput{
+		{Name: "db", Service: "db", Datacenter: "dc2", MinPassing: 2},
+		{Name: "cache", Node: "node1", CheckID: "redis"},
+		{Name: "local", CheckID: "disk"},
+	}, chk.CompositeInputs)
+	require.Equal(t, "db && cache", chk.CompositeExpression)
+	require.True(t, chk.IsComposite())
+}
@@ -35,6 +35,13 @@ type CheckDefinition struct {
 	Method                         string
 	Body                           string
 	DisableRedirects               bool
+	ResponseBodyRegex              string
+	ResponseJSONPath               string
+	ResponseJSONValue              string
+	ResponseHeaders                map[string]string
+	PassingStatusCodes             []string
+	WarningStatusCodes             []string
+	CriticalStatusCodes            []string
 	TCP                            string
 	UDP                            string
 	Interval                       time.Duration
@@ -43,10 +50,20 @@ type CheckDefinition struct {
 	GRPC                           string
 	GRPCUseTLS                     bool
 	OSService                      string
+	Redis                          string
+	PostgreSQL                     string
+	MySQL                          string
+	Memcached                      string
+	TLS                            string
+	TLSCAFile                      string
+	TLSExpiryWarningDays           int
 	TLSServerName                  string
 	TLSSkipVerify                  bool
 	AliasNode                      string
 	AliasService                   string
+	CompositeInputs                []CompositeCheckInput
+	CompositeExpression            string
+	CompositeMinPassing            int
 	Timeout                        time.Duration
 	TTL                            time.Duration
 	SuccessBeforePassing           int
@@ -80,6 +97,22 @@ func (t *CheckDefinition) UnmarshalJSON(data []byte) (err error) {
 		ServiceIDSnake                      string      `json:"service_id"`
 		H2PingUseTLSSnake                   bool        `json:"h2ping_use_tls"`
 		DisableRedirectsSnake               bool        `json:"disable_redirects"`
+		TLSCAFileSnake                      string      `json:"tls_ca_file"`
+		TLSExpiryWarningDaysSnake           int         `json:"tls_expiry_warning_days"`
+
+		// HTTP check response assertions
+		ResponseBodyRegexSnake   string            `json:"response_body_regex"`
+		ResponseJSONPathSnake    string            `json:"response_json_path"`
+		ResponseJSONValueSnake   string            `json:"response_json_value"`
+		ResponseHeadersSnake     map[string]string `json:"response_headers"`
+		PassingStatusCodesSnake  []string          `json:"passing_status_codes"`
+		WarningStatusCodesSnake  []string          `json:"warning_status_codes"`
+		CriticalStatusCodesSnake []string          `json:"critical_status_codes"`
+
+		// Composite checks
+		CompositeInputsSnake     []CompositeCheckInput `json:"composite_inputs"`
+		CompositeExpressionSnake string                `json:"composite_expression"`
+		CompositeMinPassingSnake int                   `json:"composite_min_passing"`
 
 		*Alias
 	}{
@@ -128,6 +161,42 @@ func (t *CheckDefinition) UnmarshalJSON(data []byte) (err error) {
 	if aux.DisableRedirectsSnake {
 		t.DisableRedirects = aux.DisableRedirectsSnake
 	}
+	if t.TLSCAFile == "" {
+		t.TLSCAFile = aux.TLSCAFileSnake
+	}
+	if t.TLSExpiryWarningDays == 0 {
+		t.TLSExpiryWarningDays = aux.TLSExpiryWarningDaysSnake
+	}
+	if t.ResponseBodyRegex == "" {
+		t.ResponseBodyRegex = aux.ResponseBodyRegexSnake
+	}
+	if t.ResponseJSONPath == "" {
+		t.ResponseJSONPath = aux.ResponseJSONPathSnake
+	}
+	if t.ResponseJSONValue == "" {
+		t.ResponseJSONValue = aux.ResponseJSONValueSnake
+	}
+	if len(t.ResponseHeaders) == 0 {
+		t.ResponseHeaders = aux.ResponseHeadersSnake
+	}
+	if len(t.PassingStatusCodes) == 0 {
+		t.PassingStatusCodes = aux.PassingStatusCodesSnake
+	}
+	if len(t.WarningStatusCodes) == 0 {
+		t.WarningStatusCodes = aux.WarningStatusCodesSnake
+	}
+	if len(t.CriticalStatusCodes) == 0 {
+		t.CriticalStatusCodes = aux.CriticalStatusCodesSnake
+	}
+	if len(t.CompositeInputs) == 0 {
+		t.CompositeInputs = aux.CompositeInputsSnake
+	}
+	if t.CompositeExpression == "" {
+		t.CompositeExpression = aux.CompositeExpressionSnake
+	}
+	if t.CompositeMinPassing == 0 {
+		t.CompositeMinPassing = aux.CompositeMinPassingSnake
+	}
 
 	if (aux.H2PING != "" && !aux.H2PingUseTLSSnake) || (aux.H2PING == "" && aux.H2PingUseTLSSnake) {
 		t.H2PingUseTLS = aux.H2PingUseTLSSnake
@@ -209,6 +278,9 @@ func (c *CheckDefinition) CheckType() *CheckType {
 		ScriptArgs:                     c.ScriptArgs,
 		AliasNode:                      c.AliasNode,
 		AliasService:                   c.AliasService,
+		CompositeInputs:                c.CompositeInputs,
+		CompositeExpression:            c.CompositeExpression,
+		CompositeMinPassing:            c.CompositeMinPassing,
 		HTTP:                           c.HTTP,
 		H2PING:                         c.H2PING,
 		H2PingUseTLS:                   c.H2PingUseTLS,
@@ -218,6 +290,13 @@ func (c *CheckDefinition) CheckType() *CheckType {
 		Method:                         c.Method,
 		Body:                           c.Body,
 		DisableRedirects:               c.DisableRedirects,
+		ResponseBodyRegex:              c.ResponseBodyRegex,
+		ResponseJSONPath:               c.ResponseJSONPath,
+		ResponseJSONValue:              c.ResponseJSONValue,
+		ResponseHeaders:                c.ResponseHeaders,
+		PassingStatusCodes:             c.PassingStatusCodes,
+		WarningStatusCodes:             c.WarningStatusCodes,
+		CriticalStatusCodes:            c.CriticalStatusCodes,
 		OutputMaxSize:                  c.OutputMaxSize,
 		TCP:                            c.TCP,
 		UDP:                            c.UDP,
@@ -225,6 +304,13 @@ func (c *CheckDefinition) CheckType() *CheckType {
 		DockerContainerID:              c.DockerContainerID,
 		Shell:                          c.Shell,
 		OSService:                      c.OSService,
+		Redis:                          c.Redis,
+		PostgreSQL:                     c.PostgreSQL,
+		MySQL:                          c.MySQL,
+		Memcached:                      c.Memcached,
+		TLS:                            c.TLS,
+		TLSCAFile:                      c.TLSCAFile,
+		TLSExpiryWarningDays:           c.TLSExpiryWarningDays,
 		TLSServerName:                  c.TLSServerName,
 		TLSSkipVerify:                  c.TLSSkipVerify,
 		Timeout:                        c.Timeout,
@@ -34,7 +34,11 @@ func (w *walker) Struct(reflect.Value) error {
 
 func (w *walker) StructField(f reflect.StructField, v reflect.Value) error {
 	if !f.Anonymous {
-		w.fields[f.Name] = v
+		// Keep the outermost field when nested structs, such as the inputs
+		// of composite checks, have fields of the same name.
+		if _, ok := w.fields[f.Name]; !ok {
+			w.fields[f.Name] = v
+		}
 		return nil
 	}
 	return reflectwalk.SkipEntry
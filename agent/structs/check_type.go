@@ -5,7 +5,11 @@ package structs
 
 import (
 	"fmt"
+	"net"
 	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/consul/lib"
@@ -15,10 +19,12 @@ import (
 type CheckTypes []*CheckType
 
 // CheckType is used to create either the CheckMonitor or the CheckTTL.
-// The following types are supported: Script, HTTP, TCP, Docker, TTL, GRPC, Alias, H2PING. Script,
-// HTTP, Docker, TCP, GRPC, and H2PING all require Interval. Only one of the types may
+// The following types are supported: Script, HTTP, TCP, Docker, TTL, GRPC, Alias, Composite, H2PING, TLS,
+// Redis, PostgreSQL, MySQL, Memcached. Script, HTTP, Docker, TCP, GRPC, H2PING, TLS and the
+// datastore checks all require Interval. Only one of the types may
 // to be provided: TTL or Script/Interval or HTTP/Interval or TCP/Interval or
-// Docker/Interval or GRPC/Interval or AliasService or H2PING/Interval.
+// Docker/Interval or GRPC/Interval or AliasService or CompositeInputs or H2PING/Interval or TLS/Interval
+// or Redis/Interval or PostgreSQL/Interval or MySQL/Interval or Memcached/Interval.
 // Since types like CheckHTTP and CheckGRPC derive from CheckType, there are
 // helper conversion methods that do the reverse conversion. ie. checkHTTP.CheckType()
 type CheckType struct {
@@ -41,16 +47,33 @@ type CheckType struct {
 	Method                 string
 	Body                   string
 	DisableRedirects       bool
+	ResponseBodyRegex      string
+	ResponseJSONPath       string
+	ResponseJSONValue      string
+	ResponseHeaders        map[string]string
+	PassingStatusCodes     []string
+	WarningStatusCodes     []string
+	CriticalStatusCodes    []string
 	TCP                    string
 	UDP                    string
 	Interval               time.Duration
 	AliasNode              string
 	AliasService           string
+	CompositeInputs        []CompositeCheckInput
+	CompositeExpression    string
+	CompositeMinPassing    int
 	DockerContainerID      string
 	Shell                  string
 	GRPC                   string
 	GRPCUseTLS             bool
 	OSService              string
+	Redis                  string
+	PostgreSQL             string
+	MySQL                  string
+	Memcached              string
+	TLS                    string
+	TLSCAFile              string
+	TLSExpiryWarningDays   int
 	TLSServerName          string
 	TLSSkipVerify          bool
 	Timeout                time.Duration
@@ -89,6 +112,22 @@ func (t *CheckType) UnmarshalJSON(data []byte) (err error) {
 		TLSSkipVerifySnake                  bool        `json:"tls_skip_verify"`
 		GRPCUseTLSSnake                     bool        `json:"grpc_use_tls"`
 		H2PingUseTLSSnake                   bool        `json:"h2ping_use_tls"`
+		TLSCAFileSnake                      string      `json:"tls_ca_file"`
+		TLSExpiryWarningDaysSnake           int         `json:"tls_expiry_warning_days"`
+
+		// HTTP check response assertions
+		ResponseBodyRegexSnake   string            `json:"response_body_regex"`
+		ResponseJSONPathSnake    string            `json:"response_json_path"`
+		ResponseJSONValueSnake   string            `json:"response_json_value"`
+		ResponseHeadersSnake     map[string]string `json:"response_headers"`
+		PassingStatusCodesSnake  []string          `json:"passing_status_codes"`
+		WarningStatusCodesSnake  []string          `json:"warning_status_codes"`
+		CriticalStatusCodesSnake []string          `json:"critical_status_codes"`
+
+		// Composite checks
+		CompositeInputsSnake     []CompositeCheckInput `json:"composite_inputs"`
+		CompositeExpressionSnake string                `json:"composite_expression"`
+		CompositeMinPassingSnake int                   `json:"composite_min_passing"`
 
 		// These are going to be ignored but since we are disallowing unknown fields
 		// during parsing we have to be explicit about parsing but not using these.
@@ -134,6 +173,42 @@ func (t *CheckType) UnmarshalJSON(data []byte) (err error) {
 	if aux.GRPCUseTLSSnake {
 		t.GRPCUseTLS = aux.GRPCUseTLSSnake
 	}
+	if t.TLSCAFile == "" {
+		t.TLSCAFile = aux.TLSCAFileSnake
+	}
+	if t.TLSExpiryWarningDays == 0 {
+		t.TLSExpiryWarningDays = aux.TLSExpiryWarningDaysSnake
+	}
+	if t.ResponseBodyRegex == "" {
+		t.ResponseBodyRegex = aux.ResponseBodyRegexSnake
+	}
+	if t.ResponseJSONPath == "" {
+		t.ResponseJSONPath = aux.ResponseJSONPathSnake
+	}
+	if t.ResponseJSONValue == "" {
+		t.ResponseJSONValue = aux.ResponseJSONValueSnake
+	}
+	if len(t.ResponseHeaders) == 0 {
+		t.ResponseHeaders = aux.ResponseHeadersSnake
+	}
+	if len(t.PassingStatusCodes) == 0 {
+		t.PassingStatusCodes = aux.PassingStatusCodesSnake
+	}
+	if len(t.WarningStatusCodes) == 0 {
+		t.WarningStatusCodes = aux.WarningStatusCodesSnake
+	}
+	if len(t.CriticalStatusCodes) == 0 {
+		t.CriticalStatusCodes = aux.CriticalStatusCodesSnake
+	}
+	if len(t.CompositeInputs) == 0 {
+		t.CompositeInputs = aux.CompositeInputsSnake
+	}
+	if t.CompositeExpression == "" {
+		t.CompositeExpression = aux.CompositeExpressionSnake
+	}
+	if t.CompositeMinPassing == 0 {
+		t.CompositeMinPassing = aux.CompositeMinPassingSnake
+	}
 	if aux.Interval != nil {
 		switch v := aux.Interval.(type) {
 		case string:
@@ -184,13 +259,14 @@ func (t *CheckType) UnmarshalJSON(data []byte) (err error) {
 
 // Validate returns an error message if the check is invalid
 func (c *CheckType) Validate() error {
-	intervalCheck := c.IsScript() || c.HTTP != "" || c.TCP != "" || c.UDP != "" || c.GRPC != "" || c.H2PING != "" || c.OSService != ""
+	intervalCheck := c.IsScript() || c.HTTP != "" || c.TCP != "" || c.UDP != "" || c.GRPC != "" || c.H2PING != "" || c.TLS != "" || c.OSService != "" ||
+		c.Redis != "" || c.PostgreSQL != "" || c.MySQL != "" || c.Memcached != ""
 
 	if c.Interval > 0 && c.TTL > 0 {
 		return fmt.Errorf("Interval and TTL cannot both be specified")
 	}
 	if intervalCheck && c.Interval <= 0 {
-		return fmt.Errorf("Interval must be > 0 for Script, HTTP, H2PING, TCP, UDP or OSService checks")
+		return fmt.Errorf("Interval must be > 0 for Script, HTTP, H2PING, TCP, UDP, TLS, Redis, PostgreSQL, MySQL, Memcached or OSService checks")
 	}
 	if intervalCheck && c.IsAlias() {
 		return fmt.Errorf("Interval cannot be set for Alias checks")
@@ -198,7 +274,16 @@ func (c *CheckType) Validate() error {
 	if c.IsAlias() && c.TTL > 0 {
 		return fmt.Errorf("TTL must be not be set for Alias checks")
 	}
-	if !intervalCheck && !c.IsAlias() && c.TTL <= 0 {
+	if intervalCheck && c.IsComposite() {
+		return fmt.Errorf("Interval cannot be set for Composite checks")
+	}
+	if c.IsComposite() && c.TTL > 0 {
+		return fmt.Errorf("TTL must be not be set for Composite checks")
+	}
+	if c.IsComposite() && c.IsAlias() {
+		return fmt.Errorf("AliasNode and AliasService cannot be set for Composite checks")
+	}
+	if !intervalCheck && !c.IsAlias() && !c.IsComposite() && c.TTL <= 0 {
 		return fmt.Errorf("TTL must be > 0 for TTL checks")
 	}
 	if c.OutputMaxSize < 0 {
@@ -207,10 +292,117 @@ func (c *CheckType) Validate() error {
 	if c.FailuresBeforeWarning > c.FailuresBeforeCritical {
 		return fmt.Errorf("FailuresBeforeWarning can't be higher than FailuresBeforeCritical")
 	}
+	if c.TLSExpiryWarningDays < 0 {
+		return fmt.Errorf("TLSExpiryWarningDays must be positive")
+	}
+	if err := c.validateDatastore(); err != nil {
+		return err
+	}
+	if err := c.validateHTTPAssertions(); err != nil {
+		return err
+	}
+	if err := c.validateComposite(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validateDatastore checks the addresses of datastore checks, which must be a
+// single host and port.
+func (c *CheckType) validateDatastore() error {
+	for _, f := range []struct{ name, addr string }{
+		{"Redis", c.Redis},
+		{"PostgreSQL", c.PostgreSQL},
+		{"MySQL", c.MySQL},
+		{"Memcached", c.Memcached},
+	} {
+		if f.addr == "" {
+			continue
+		}
+		if _, _, err := net.SplitHostPort(f.addr); err != nil {
+			return fmt.Errorf("Invalid %s address %q: must be host:port", f.name, f.addr)
+		}
+	}
+	return nil
+}
 
+// validateHTTPAssertions checks the assertions made on the response of HTTP
+// checks.
+func (c *CheckType) validateHTTPAssertions() error {
+	hasAssertions := c.ResponseBodyRegex != "" || c.ResponseJSONPath != "" || c.ResponseJSONValue != "" ||
+		len(c.ResponseHeaders) > 0 || len(c.PassingStatusCodes) > 0 || len(c.WarningStatusCodes) > 0 ||
+		len(c.CriticalStatusCodes) > 0
+	if !hasAssertions {
+		return nil
+	}
+	if c.HTTP == "" {
+		return fmt.Errorf("Response assertions are only supported for HTTP checks")
+	}
+	if c.ResponseBodyRegex != "" {
+		if _, err := regexp.Compile(c.ResponseBodyRegex); err != nil {
+			return fmt.Errorf("Invalid ResponseBodyRegex: %v", err)
+		}
+	}
+	if c.ResponseJSONValue != "" && c.ResponseJSONPath == "" {
+		return fmt.Errorf("ResponseJSONValue requires ResponseJSONPath to be set")
+	}
+	for field, codes := range map[string][]string{
+		"PassingStatusCodes":  c.PassingStatusCodes,
+		"WarningStatusCodes":  c.WarningStatusCodes,
+		"CriticalStatusCodes": c.CriticalStatusCodes,
+	} {
+		if _, err := ParseHTTPStatusCodeRanges(codes); err != nil {
+			return fmt.Errorf("Invalid %s: %v", field, err)
+		}
+	}
 	return nil
 }
 
+// HTTPStatusCodeRange is an inclusive range of HTTP status codes.
+type HTTPStatusCodeRange struct {
+	Min int
+	Max int
+}
+
+// Contains returns true if the status code is in the range.
+func (r HTTPStatusCodeRange) Contains(code int) bool {
+	return code >= r.Min && code <= r.Max
+}
+
+// ParseHTTPStatusCodeRanges parses status codes, such as "200", and ranges of
+// status codes, such as "200-299", as used in the status codes of HTTP checks.
+func ParseHTTPStatusCodeRanges(codes []string) ([]HTTPStatusCodeRange, error) {
+	var ranges []HTTPStatusCodeRange
+	for _, code := range codes {
+		lo, hi, isRange := strings.Cut(strings.TrimSpace(code), "-")
+		if !isRange {
+			hi = lo
+		}
+		min, err := parseHTTPStatusCode(lo)
+		if err != nil {
+			return nil, err
+		}
+		max, err := parseHTTPStatusCode(hi)
+		if err != nil {
+			return nil, err
+		}
+		if min > max {
+			return nil, fmt.Errorf("invalid status code range %q", code)
+		}
+		ranges = append(ranges, HTTPStatusCodeRange{Min: min, Max: max})
+	}
+	return ranges, nil
+}
+
+func parseHTTPStatusCode(s string) (int, error) {
+	code, err := strconv.Atoi(strings.TrimSpace(s))
+	if err != nil || code < 100 || code > 599 {
+		return 0, fmt.Errorf("invalid status code %q", s)
+	}
+	return code, nil
+}
+
 // Empty checks if the CheckType has no fields defined. Empty checks parsed from json configs are filtered out
 func (c *CheckType) Empty() bool {
 	return reflect.DeepEqual(c, &CheckType{})
@@ -221,6 +413,11 @@ func (c *CheckType) IsAlias() bool {
 	return c.AliasNode != "" || c.AliasService != ""
 }
 
+// IsComposite checks if this is a composite check.
+func (c *CheckType) IsComposite() bool {
+	return len(c.CompositeInputs) > 0
+}
+
 // IsScript checks if this is a check that execs some kind of script.
 func (c *CheckType) IsScript() bool {
 	return len(c.ScriptArgs) > 0
@@ -265,6 +462,31 @@ func (c *CheckType) IsH2PING() bool {
 	return c.H2PING != "" && c.Interval > 0
 }
 
+// IsTLS checks if this is a TLS type
+func (c *CheckType) IsTLS() bool {
+	return c.TLS != "" && c.Interval > 0
+}
+
+// IsRedis checks if this is a Redis type
+func (c *CheckType) IsRedis() bool {
+	return c.Redis != "" && c.Interval > 0
+}
+
+// IsPostgreSQL checks if this is a PostgreSQL type
+func (c *CheckType) IsPostgreSQL() bool {
+	return c.PostgreSQL != "" && c.Interval > 0
+}
+
+// IsMySQL checks if this is a MySQL type
+func (c *CheckType) IsMySQL() bool {
+	return c.MySQL != "" && c.Interval > 0
+}
+
+// IsMemcached checks if this is a Memcached type
+func (c *CheckType) IsMemcached() bool {
+	return c.Memcached != "" && c.Interval > 0
+}
+
 // IsOSService checks if this is a WindowsService/systemd type
 func (c *CheckType) IsOSService() bool {
 	return c.OSService != "" && c.Interval > 0
@@ -284,12 +506,24 @@ func (c *CheckType) Type() string {
 		return "udp"
 	case c.IsAlias():
 		return "alias"
+	case c.IsComposite():
+		return "composite"
 	case c.IsDocker():
 		return "docker"
 	case c.IsScript():
 		return "script"
 	case c.IsH2PING():
 		return "h2ping"
+	case c.IsTLS():
+		return "tls"
+	case c.IsRedis():
+		return "redis"
+	case c.IsPostgreSQL():
+		return "postgresql"
+	case c.IsMySQL():
+		return "mysql"
+	case c.IsMemcached():
+		return "memcached"
 	case c.IsOSService():
 		return "os_service"
 	default:
@@ -0,0 +1,80 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package structs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseHTTPStatusCodeRanges(t *testing.T) {
+	ranges, err := ParseHTTPStatusCodeRanges([]string{"200-299", "304", " 400 - 404 "})
+	require.NoError(t, err)
+	require.Equal(t, []HTTPStatusCodeRange{
+		{Min: 200, Max: 299},
+		{Min: 304, Max: 304},
+		{Min: 400, Max: 404},
+	}, ranges)
+	require.True(t, ranges[0].Contains(204))
+	require.False(t, ranges[1].Contains(305))
+
+	for _, codes := range [][]string{
+		{"2xx"},
+		{"299-200"},
+		{"200-"},
+		{"99"},
+		{"600"},
+	} {
+		_, err := ParseHTTPStatusCodeRanges(codes)
+		require.Error(t, err, codes)
+	}
+}
+
+func TestCheckType_UnmarshalJSON_ResponseAssertions(t *testing.T) {
+	var chk CheckType
+	require.NoError(t, chk.UnmarshalJSON([]byte(`{
+		"http": "http://foo/bar",
+		"response_body_regex": "ok",
+		"response_json_path": "$.status",
+		"response_json_value": "healthy",
+		"response_headers": {"Content-Type": "application/json"},
+		"passing_status_codes": ["200-299"],
+		"warning_status_codes": ["429"],
+		"critical_status_codes": ["500-599"]
+	}`)))
+	require.Equal(t, "ok", chk.ResponseBodyRegex)
+	require.Equal(t, "$.status", chk.ResponseJSONPath)
+	require.Equal(t, "healthy", chk.ResponseJSONValue)
+	require.Equal(t, map[string]string{"Content-Type": "application/json"}, chk.ResponseHeaders)
+	require.Equal(t, []string{"200-299"}, chk.PassingStatusCodes)
+	require.Equal(t, []string{"429"}, chk.WarningStatusCodes)
+	require.Equal(t, []string{"500-599"}, chk.CriticalStatusCodes)
+}
+
+func TestCheckType_Validate_Datastore(t *testing.T) {
+	for typ, chk := range map[string]*CheckType{
+		"redis":      {Redis: "localhost:6379", Interval: time.Second},
+		"postgresql": {PostgreSQL: "localhost:5432", Interval: time.Second},
+		"mysql":      {MySQL: "[::1]:3306", Interval: time.Second},
+		"memcached":  {Memcached: "127.0.0.1:11211", Interval: time.Second},
+	} {
+		require.NoError(t, chk.Validate(), typ)
+		require.Equal(t, typ, chk.Type())
+	}
+
+	err := (&CheckType{Redis: "localhost:6379"}).Validate()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Interval must be > 0")
+
+	err = (&CheckType{MySQL: "localhost", Interval: time.Second}).Validate()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `Invalid MySQL address "localhost": must be host:port`)
+
+	var chk CheckType
+	require.NoError(t, chk.UnmarshalJSON([]byte(`{"postgresql": "db:5432", "interval": "10s"}`)))
+	require.Equal(t, "db:5432", chk.PostgreSQL)
+	require.True(t, chk.IsPostgreSQL())
+}
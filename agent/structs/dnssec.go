@@ -0,0 +1,68 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package structs
+
+import (
+	"time"
+)
+
+// DNSSECKey is a key used by the agents to sign their DNS responses. The keys
+// are managed by the leader of the primary datacenter, and are stored as JSON
+// in the system metadata under SystemMetadataDNSSECKeys.
+//
+// Keys go through the following states during a rotation:
+//
+//   - Pending: the key is published in the DNSKEY RRset but isn't used for
+//     signing yet, so that resolvers learn about it before any signatures
+//     made with it are served.
+//   - Active: the key signs responses.
+//   - Retired: the key is still published, so that signatures made with it
+//     which are cached by resolvers remain valid, until it's removed.
+type DNSSECKey struct {
+	// ID is a unique identifier for the key.
+	ID string
+
+	// KeyTag is the key tag of the DNSKEY record, as defined in RFC 4034
+	// appendix B.
+	KeyTag uint16
+
+	// Algorithm is the DNSSEC algorithm number of the key.
+	Algorithm uint8
+
+	// PublicKey is the base64 encoded public key, as found in the DNSKEY
+	// record.
+	PublicKey string
+
+	// PrivateKey is the private key in the BIND private key format.
+	PrivateKey string `json:",omitempty"`
+
+	// Active is true if the key is used to sign responses.
+	Active bool
+
+	// CreatedAt is when the key was generated and published.
+	CreatedAt time.Time
+
+	// ActivatedAt is when the key started being used for signing.
+	ActivatedAt time.Time
+
+	// RetiredAt is when the key stopped being used for signing.
+	RetiredAt time.Time
+}
+
+// Pending returns whether the key has been published but isn't used for
+// signing yet.
+func (k *DNSSECKey) Pending() bool {
+	return !k.Active && k.RetiredAt.IsZero()
+}
+
+// Retired returns whether the key was used for signing but has been replaced.
+func (k *DNSSECKey) Retired() bool {
+	return !k.Active && !k.RetiredAt.IsZero()
+}
+
+// IndexedDNSSECKeys is the response to a request for the DNSSEC keys.
+type IndexedDNSSECKeys struct {
+	Keys []*DNSSECKey
+	QueryMeta
+}
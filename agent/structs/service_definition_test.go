@@ -47,6 +47,10 @@ func TestAgentStructs_CheckTypes(t *testing.T) {
 		{&CheckType{HTTP: "http://foo/baz"}, fmt.Errorf("Interval must be > 0 for Script, HTTP, or TCP checks"), "Missing interval"},
 		{&CheckType{TTL: -1}, fmt.Errorf("TTL must be > 0 for TTL checks"), "Negative TTL"},
 		{&CheckType{TTL: 20 * time.Second, Interval: 10 * time.Second}, fmt.Errorf("Interval and TTL cannot both be specified"), "Interval and TTL both set"},
+		{&CheckType{HTTP: "http://foo/baz", Interval: 10 * time.Second, ResponseBodyRegex: "("}, fmt.Errorf("Invalid ResponseBodyRegex"), "Invalid response body regex"},
+		{&CheckType{HTTP: "http://foo/baz", Interval: 10 * time.Second, PassingStatusCodes: []string{"299-200"}}, fmt.Errorf("Invalid PassingStatusCodes"), "Invalid passing status codes"},
+		{&CheckType{HTTP: "http://foo/baz", Interval: 10 * time.Second, ResponseJSONValue: "ok"}, fmt.Errorf("ResponseJSONValue requires ResponseJSONPath to be set"), "JSON value without a path"},
+		{&CheckType{TCP: "foo:80", Interval: 10 * time.Second, ResponseBodyRegex: "ok"}, fmt.Errorf("Response assertions are only supported for HTTP checks"), "Response assertions on a TCP check"},
 	}
 	for _, tc := range cases {
 		t.Run(tc.desc, func(t *testing.T) {
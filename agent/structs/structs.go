@@ -89,6 +89,7 @@ const (
 	RaftLogVerifierCheckpoint                   = 41 // Only used for log verifier, no-op on FSM.
 	ResourceOperationType                       = 42
 	UpdateVirtualIPRequestType                  = 43
+	KVSRevisionRequestType                      = 44 // FSM snapshots only.
 )
 
 const (
@@ -158,6 +159,7 @@ var requestTypeStrings = map[MessageType]string{
 	RaftLogVerifierCheckpoint:       "RaftLogVerifierCheckpoint",
 	ResourceOperationType:           "Resource",
 	UpdateVirtualIPRequestType:      "UpdateManualVirtualIPRequestType",
+	KVSRevisionRequestType:          "KVSRevision", // FSM snapshots only.
 }
 
 const (
@@ -2025,6 +2027,24 @@ func (c *HealthCheck) CheckType() *CheckType {
 // HealthChecks is a collection of HealthCheck structs.
 type HealthChecks []*HealthCheck
 
+// CheckResult is a result of a check recorded in its history by the agent.
+type CheckResult struct {
+	Timestamp time.Time
+	Status    string
+
+	// Output is the output of the check, truncated to
+	// CheckResultMaxOutputSize bytes.
+	Output string
+
+	// Latency is the time it took to run the check, or zero for checks
+	// that aren't run by the agent such as TTL checks.
+	Latency time.Duration
+}
+
+// CheckResultMaxOutputSize is the maximum size of the output of a check
+// recorded in its history.
+const CheckResultMaxOutputSize = 1024
+
 // CheckServiceNode is used to provide the node, its service
 // definition, as well as a HealthCheck that is associated.
 type CheckServiceNode struct {
@@ -2589,6 +2609,21 @@ type DirEntry struct {
 	Value     []byte
 	Session   string `json:",omitempty"`
 
+	// ExpirationTime is the time after which the leader deletes the entry.
+	// Entries without an expiration time never expire.
+	ExpirationTime *time.Time `json:",omitempty"`
+
+	// ExpirationTTL is a convenience field for setting ExpirationTime to the
+	// time of the write plus ExpirationTTL. It is cleared and used to
+	// initialize ExpirationTime by the leader before the write is committed,
+	// so that all servers agree on the expiration time.
+	ExpirationTTL time.Duration `json:",omitempty"`
+
+	// RetainRevisions is the number of previous revisions of the entry that
+	// are kept in the state store. It is set by the leader from the
+	// kv_revisions configuration before the write is committed.
+	RetainRevisions int `json:",omitempty"`
+
 	acl.EnterpriseMeta `bexpr:"-"`
 	RaftIndex
 }
@@ -2596,11 +2631,14 @@ type DirEntry struct {
 // Returns a clone of the given directory entry.
 func (d *DirEntry) Clone() *DirEntry {
 	return &DirEntry{
-		LockIndex: d.LockIndex,
-		Key:       d.Key,
-		Flags:     d.Flags,
-		Value:     d.Value,
-		Session:   d.Session,
+		LockIndex:       d.LockIndex,
+		Key:             d.Key,
+		Flags:           d.Flags,
+		Value:           d.Value,
+		Session:         d.Session,
+		ExpirationTime:  d.ExpirationTime,
+		ExpirationTTL:   d.ExpirationTTL,
+		RetainRevisions: d.RetainRevisions,
 		RaftIndex: RaftIndex{
 			CreateIndex: d.CreateIndex,
 			ModifyIndex: d.ModifyIndex,
@@ -2614,7 +2652,15 @@ func (d *DirEntry) Equal(o *DirEntry) bool {
 		d.Key == o.Key &&
 		d.Flags == o.Flags &&
 		bytes.Equal(d.Value, o.Value) &&
-		d.Session == o.Session
+		d.Session == o.Session &&
+		d.HasExpirationTime() == o.HasExpirationTime() &&
+		(!d.HasExpirationTime() || d.ExpirationTime.Equal(*o.ExpirationTime)) &&
+		d.RetainRevisions == o.RetainRevisions
+}
+
+// HasExpirationTime returns true if the entry expires.
+func (d *DirEntry) HasExpirationTime() bool {
+	return d.ExpirationTime != nil && !d.ExpirationTime.IsZero()
 }
 
 // IDValue implements the state.singleValueID interface for indexing.
@@ -2624,6 +2670,26 @@ func (d *DirEntry) IDValue() string {
 
 type DirEntries []*DirEntry
 
+// DirEntryRevision is a previous revision of a directory entry, that was
+// replaced or deleted at ReplacedIndex.
+type DirEntryRevision struct {
+	DirEntry
+
+	// ReplacedIndex is the index of the write that replaced or deleted
+	// this revision of the entry.
+	ReplacedIndex uint64
+
+	// Deleted is true if the entry was deleted at ReplacedIndex.
+	Deleted bool `json:",omitempty"`
+}
+
+// IDValue implements the state.singleValueID interface for indexing.
+func (d *DirEntryRevision) IDValue() string {
+	return d.Key
+}
+
+type DirEntryRevisions []*DirEntryRevision
+
 // KVSRequest is used to operate on the Key-Value store
 type KVSRequest struct {
 	Datacenter string
@@ -2640,6 +2706,11 @@ func (r *KVSRequest) RequestDatacenter() string {
 type KeyRequest struct {
 	Datacenter string
 	Key        string
+
+	// AtIndex is used to read the revision of the key that was current at
+	// the given index, if it was retained.
+	AtIndex uint64
+
 	acl.EnterpriseMeta
 	QueryOptions
 }
@@ -2648,6 +2719,31 @@ func (r *KeyRequest) RequestDatacenter() string {
 	return r.Datacenter
 }
 
+func (r *KeyRequest) CacheInfo() cache.RequestInfo {
+	info := cache.RequestInfo{
+		Token:          r.Token,
+		Datacenter:     r.Datacenter,
+		MinIndex:       r.MinQueryIndex,
+		Timeout:        r.MaxQueryTime,
+		MaxAge:         r.MaxAge,
+		MustRevalidate: r.MustRevalidate,
+	}
+
+	v, err := hashstructure.Hash([]interface{}{
+		r.Key,
+		r.AtIndex,
+		r.EnterpriseMeta,
+	}, nil)
+	if err == nil {
+		// If there is an error, we don't set the key. A blank key forces
+		// no cache for this request so the request is forwarded directly
+		// to the server.
+		info.Key = strconv.FormatUint(v, 10)
+	}
+
+	return info
+}
+
 // KeyListRequest is used to list keys
 type KeyListRequest struct {
 	Datacenter string
@@ -2666,6 +2762,11 @@ type IndexedDirEntries struct {
 	QueryMeta
 }
 
+type IndexedDirEntryRevisions struct {
+	Revisions DirEntryRevisions
+	QueryMeta
+}
+
 type IndexedKeyList struct {
 	Keys []string
 	QueryMeta
@@ -1824,12 +1824,15 @@ func (a aclAuthorizerCheckServiceNode) NodeRead(_ string, ctx *acl.AuthorizerCon
 }
 
 func TestStructs_DirEntry_Clone(t *testing.T) {
+	expires := time.Now().Add(time.Minute)
 	e := &DirEntry{
-		LockIndex: 5,
-		Key:       "hello",
-		Flags:     23,
-		Value:     []byte("this is a test"),
-		Session:   "session1",
+		LockIndex:       5,
+		Key:             "hello",
+		Flags:           23,
+		Value:           []byte("this is a test"),
+		Session:         "session1",
+		ExpirationTime:  &expires,
+		RetainRevisions: 3,
 		RaftIndex: RaftIndex{
 			CreateIndex: 1,
 			ModifyIndex: 2,
@@ -1847,6 +1850,33 @@ func TestStructs_DirEntry_Clone(t *testing.T) {
 	}
 }
 
+func TestStructs_DirEntry_Equal(t *testing.T) {
+	expires := time.Now().Add(time.Minute)
+	e := &DirEntry{
+		Key:            "hello",
+		Value:          []byte("this is a test"),
+		ExpirationTime: &expires,
+	}
+
+	other := e.Clone()
+	later := expires.Round(0)
+	other.ExpirationTime = &later
+	require.True(t, e.Equal(other))
+
+	later = expires.Add(time.Second)
+	require.False(t, e.Equal(other))
+
+	other.ExpirationTime = nil
+	require.False(t, e.Equal(other))
+	require.False(t, other.Equal(e))
+
+	e.ExpirationTime = nil
+	require.True(t, e.Equal(other))
+
+	other.RetainRevisions = 3
+	require.False(t, e.Equal(other))
+}
+
 func TestStructs_ValidateServiceAndNodeMetadata(t *testing.T) {
 	tooMuchMeta := make(map[string]string)
 	for i := 0; i < metaMaxKeyPairs+1; i++ {
@@ -72,6 +72,10 @@ type TestAgent struct {
 	// one.
 	UseGRPCTLS bool
 
+	// DevMode, if true, will start the agent in development mode (e.g. with the
+	// demo resource types registered).
+	DevMode bool
+
 	// dns is a reference to the first started DNS endpoint.
 	// It is valid after Start().
 	dns *DNSServer
@@ -194,6 +198,7 @@ func (a *TestAgent) Start(t *testing.T) error {
 	testHCLConfig := TestConfigHCL(NodeID())
 	loader := func(source config.Source) (config.LoadResult, error) {
 		opts := config.LoadOpts{
+			DevMode:       &a.DevMode,
 			DefaultConfig: source,
 			HCL:           []string{testHCLConfig, portsConfig, a.HCL, hclDataDir},
 			Overrides: []config.Source{
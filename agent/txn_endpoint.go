@@ -147,14 +147,27 @@ func (s *HTTPHandlers) convertOps(resp http.ResponseWriter, req *http.Request) (
 				writes++
 			}
 
+			var ttl time.Duration
+			if in.KV.TTL != "" {
+				var err error
+				ttl, err = time.ParseDuration(in.KV.TTL)
+				if err != nil || ttl < 0 {
+					return nil, 0, HTTPError{
+						StatusCode: http.StatusBadRequest,
+						Reason:     fmt.Sprintf("Invalid TTL %q for key %q: must be a non-negative duration, such as \"30s\"", in.KV.TTL, in.KV.Key),
+					}
+				}
+			}
+
 			out := &structs.TxnOp{
 				KV: &structs.TxnKVOp{
 					Verb: verb,
 					DirEnt: structs.DirEntry{
-						Key:     in.KV.Key,
-						Value:   in.KV.Value,
-						Flags:   in.KV.Flags,
-						Session: in.KV.Session,
+						Key:           in.KV.Key,
+						Value:         in.KV.Value,
+						Flags:         in.KV.Flags,
+						Session:       in.KV.Session,
+						ExpirationTTL: ttl,
 						EnterpriseMeta: acl.NewEnterpriseMetaWithPartition(
 							in.KV.Partition,
 							in.KV.Namespace,
@@ -518,6 +518,63 @@ func TestTxnEndpoint_KV_Actions(t *testing.T) {
 	})
 }
 
+func TestTxnEndpoint_KV_TTL(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+	a := NewTestAgent(t, "")
+	defer a.Shutdown()
+	testrpc.WaitForTestAgent(t, a.RPC, "dc1")
+
+	// Invalid TTLs are rejected.
+	buf := bytes.NewBufferString(`
+ [
+     {
+         "KV": {
+             "Verb": "set",
+             "Key": "key",
+             "Value": "aGVsbG8gd29ybGQ=",
+             "TTL": "soon"
+         }
+     }
+ ]
+ `)
+	req, _ := http.NewRequest("PUT", "/v1/txn", buf)
+	resp := httptest.NewRecorder()
+	_, err := a.srv.Txn(resp, req)
+	require.True(t, isHTTPBadRequest(err), fmt.Sprintf("Expected bad request HTTP error but got %v", err))
+	require.Contains(t, err.Error(), `Invalid TTL "soon" for key "key"`)
+
+	// The TTL is converted to an expiration time.
+	start := time.Now()
+	buf = bytes.NewBufferString(`
+ [
+     {
+         "KV": {
+             "Verb": "set",
+             "Key": "key",
+             "Value": "aGVsbG8gd29ybGQ=",
+             "TTL": "1h"
+         }
+     }
+ ]
+ `)
+	req, _ = http.NewRequest("PUT", "/v1/txn", buf)
+	resp = httptest.NewRecorder()
+	obj, err := a.srv.Txn(resp, req)
+	require.NoError(t, err)
+	require.Equal(t, 200, resp.Code)
+
+	txnResp, ok := obj.(structs.TxnResponse)
+	require.True(t, ok, "bad type: %T", obj)
+	require.Len(t, txnResp.Results, 1)
+	expires := txnResp.Results[0].KV.ExpirationTime
+	require.NotNil(t, expires)
+	require.WithinDuration(t, start.Add(time.Hour), *expires, time.Minute)
+}
+
 func TestTxnEndpoint_UpdateCheck(t *testing.T) {
 	if testing.Short() {
 		t.Skip("too slow for testing.Short")
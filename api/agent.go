@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"time"
 )
 
 // ServiceKind is the kind of service being registered.
@@ -76,6 +77,15 @@ type AgentCheck struct {
 	Partition   string `json:",omitempty"`
 }
 
+// AgentCheckResult is a recent result of a check, as recorded in its history
+// by the agent.
+type AgentCheckResult struct {
+	Timestamp time.Time
+	Status    string
+	Output    string
+	Latency   time.Duration
+}
+
 // AgentWeights represent optional weights for a service
 type AgentWeights struct {
 	Passing int
@@ -330,33 +340,50 @@ type AgentCheckRegistration struct {
 
 // AgentServiceCheck is used to define a node or service level check
 type AgentServiceCheck struct {
-	CheckID                string              `json:",omitempty"`
-	Name                   string              `json:",omitempty"`
-	Args                   []string            `json:"ScriptArgs,omitempty"`
-	DockerContainerID      string              `json:",omitempty"`
-	Shell                  string              `json:",omitempty"` // Only supported for Docker.
-	Interval               string              `json:",omitempty"`
-	Timeout                string              `json:",omitempty"`
-	TTL                    string              `json:",omitempty"`
-	HTTP                   string              `json:",omitempty"`
-	Header                 map[string][]string `json:",omitempty"`
-	Method                 string              `json:",omitempty"`
-	Body                   string              `json:",omitempty"`
-	TCP                    string              `json:",omitempty"`
-	UDP                    string              `json:",omitempty"`
-	Status                 string              `json:",omitempty"`
-	Notes                  string              `json:",omitempty"`
-	TLSServerName          string              `json:",omitempty"`
-	TLSSkipVerify          bool                `json:",omitempty"`
-	GRPC                   string              `json:",omitempty"`
-	GRPCUseTLS             bool                `json:",omitempty"`
-	H2PING                 string              `json:",omitempty"`
-	H2PingUseTLS           bool                `json:",omitempty"`
-	AliasNode              string              `json:",omitempty"`
-	AliasService           string              `json:",omitempty"`
-	SuccessBeforePassing   int                 `json:",omitempty"`
-	FailuresBeforeWarning  int                 `json:",omitempty"`
-	FailuresBeforeCritical int                 `json:",omitempty"`
+	CheckID                string                `json:",omitempty"`
+	Name                   string                `json:",omitempty"`
+	Args                   []string              `json:"ScriptArgs,omitempty"`
+	DockerContainerID      string                `json:",omitempty"`
+	Shell                  string                `json:",omitempty"` // Only supported for Docker.
+	Interval               string                `json:",omitempty"`
+	Timeout                string                `json:",omitempty"`
+	TTL                    string                `json:",omitempty"`
+	HTTP                   string                `json:",omitempty"`
+	Header                 map[string][]string   `json:",omitempty"`
+	Method                 string                `json:",omitempty"`
+	Body                   string                `json:",omitempty"`
+	ResponseBodyRegex      string                `json:",omitempty"`
+	ResponseJSONPath       string                `json:",omitempty"`
+	ResponseJSONValue      string                `json:",omitempty"`
+	ResponseHeaders        map[string]string     `json:",omitempty"`
+	PassingStatusCodes     []string              `json:",omitempty"`
+	WarningStatusCodes     []string              `json:",omitempty"`
+	CriticalStatusCodes    []string              `json:",omitempty"`
+	TCP                    string                `json:",omitempty"`
+	UDP                    string                `json:",omitempty"`
+	Status                 string                `json:",omitempty"`
+	Notes                  string                `json:",omitempty"`
+	TLSServerName          string                `json:",omitempty"`
+	TLSSkipVerify          bool                  `json:",omitempty"`
+	GRPC                   string                `json:",omitempty"`
+	GRPCUseTLS             bool                  `json:",omitempty"`
+	H2PING                 string                `json:",omitempty"`
+	H2PingUseTLS           bool                  `json:",omitempty"`
+	Redis                  string                `json:",omitempty"`
+	PostgreSQL             string                `json:",omitempty"`
+	MySQL                  string                `json:",omitempty"`
+	Memcached              string                `json:",omitempty"`
+	TLS                    string                `json:",omitempty"`
+	TLSCAFile              string                `json:",omitempty"`
+	TLSExpiryWarningDays   int                   `json:",omitempty"`
+	AliasNode              string                `json:",omitempty"`
+	AliasService           string                `json:",omitempty"`
+	CompositeInputs        []CompositeCheckInput `json:",omitempty"`
+	CompositeExpression    string                `json:",omitempty"`
+	CompositeMinPassing    int                   `json:",omitempty"`
+	SuccessBeforePassing   int                   `json:",omitempty"`
+	FailuresBeforeWarning  int                   `json:",omitempty"`
+	FailuresBeforeCritical int                   `json:",omitempty"`
 
 	// In Consul 0.7 and later, checks that are associated with a service
 	// may also contain this optional DeregisterCriticalServiceAfter field,
@@ -368,6 +395,21 @@ type AgentServiceCheck struct {
 }
 type AgentServiceChecks []*AgentServiceCheck
 
+// CompositeCheckInput is an input of a composite check: either the instances
+// of a service or the checks of a node, optionally restricted to a single
+// check. A check ID without a node refers to a check of the agent's node.
+type CompositeCheckInput struct {
+	Name       string
+	Service    string `json:",omitempty"`
+	Node       string `json:",omitempty"`
+	CheckID    string `json:",omitempty"`
+	Datacenter string `json:",omitempty"`
+
+	// MinPassing is the number of instances of the service that must be
+	// passing for the input to be passing. Defaults to 1.
+	MinPassing int `json:",omitempty"`
+}
+
 // AgentToken is used when updating ACL tokens for an agent.
 type AgentToken struct {
 	Token string
@@ -1001,6 +1043,26 @@ func (a *Agent) CheckDeregisterOpts(checkID string, q *QueryOptions) error {
 	return nil
 }
 
+// CheckHistory returns the recent results of a local check, from the oldest
+// to the most recent one.
+func (a *Agent) CheckHistory(checkID string, q *QueryOptions) ([]*AgentCheckResult, error) {
+	r := a.c.newRequest("GET", "/v1/agent/check/"+checkID+"/history")
+	r.setQueryOptions(q)
+	_, resp, err := a.c.doRequest(r)
+	if err != nil {
+		return nil, err
+	}
+	defer closeResponseBody(resp)
+	if err := requireOK(resp); err != nil {
+		return nil, err
+	}
+	var out []*AgentCheckResult
+	if err := decodeBody(resp, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Join is used to instruct the agent to attempt a join to
 // another cluster member
 func (a *Agent) Join(addr string, wan bool) error {
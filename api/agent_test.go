@@ -1057,6 +1057,38 @@ func TestAPI_AgentChecks(t *testing.T) {
 	}
 }
 
+func TestAPI_AgentCheckHistory(t *testing.T) {
+	t.Parallel()
+	c, s := makeClient(t)
+	defer s.Stop()
+
+	agent := c.Agent()
+
+	reg := &AgentCheckRegistration{
+		Name: "foo",
+	}
+	reg.TTL = "15s"
+	require.NoError(t, agent.CheckRegister(reg))
+
+	require.NoError(t, agent.UpdateTTL("foo", "first", HealthPassing))
+	require.NoError(t, agent.UpdateTTL("foo", "second", HealthWarning))
+
+	history, err := agent.CheckHistory("foo", nil)
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	require.Equal(t, HealthPassing, history[0].Status)
+	require.Equal(t, "first", history[0].Output)
+	require.Equal(t, HealthWarning, history[1].Status)
+	require.Equal(t, "second", history[1].Output)
+	require.False(t, history[1].Timestamp.Before(history[0].Timestamp))
+
+	_, err = agent.CheckHistory("bar", nil)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "404")
+
+	require.NoError(t, agent.CheckDeregister("foo"))
+}
+
 func TestAPI_AgentChecksWithFilterOpts(t *testing.T) {
 	t.Parallel()
 	c, s := makeClient(t)
@@ -10,6 +10,7 @@ import (
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 )
 
 // KVPair is used to represent a single K/V entry
@@ -44,6 +45,15 @@ type KVPair struct {
 	// session ID.
 	Session string
 
+	// ExpirationTime is the time after which the entry is deleted, if any.
+	// This is a read-only field.
+	ExpirationTime *time.Time `json:",omitempty"`
+
+	// ExpirationTTL is used when writing the entry to delete it once the TTL
+	// has elapsed. Writing the entry again without an ExpirationTTL clears
+	// its expiration time.
+	ExpirationTTL time.Duration `json:",omitempty"`
+
 	// Namespace is the namespace the KVPair is associated with
 	// Namespacing is a Consul Enterprise feature.
 	Namespace string `json:",omitempty"`
@@ -56,6 +66,19 @@ type KVPair struct {
 // KVPairs is a list of KVPair objects
 type KVPairs []*KVPair
 
+// KVRevision is a revision of a K/V entry, as returned in the history of a
+// key.
+type KVRevision struct {
+	KVPair
+
+	// ReplacedIndex is the index at which this revision of the entry was
+	// replaced or deleted. It is zero for the current entry.
+	ReplacedIndex uint64
+
+	// Deleted is true if the key was deleted at ReplacedIndex.
+	Deleted bool `json:",omitempty"`
+}
+
 // KV is used to manipulate the K/V API
 type KV struct {
 	c *Client
@@ -88,6 +111,51 @@ func (k *KV) Get(key string, q *QueryOptions) (*KVPair, *QueryMeta, error) {
 	return nil, qm, nil
 }
 
+// GetAtIndex is used to lookup the revision of a key that was current at the
+// given index. The returned pointer to the KVPair will be nil if the key did
+// not exist at that index, or if that revision of the key is not retained by
+// the servers.
+func (k *KV) GetAtIndex(key string, index uint64, q *QueryOptions) (*KVPair, *QueryMeta, error) {
+	params := map[string]string{"at-index": strconv.FormatUint(index, 10)}
+	resp, qm, err := k.getInternal(key, params, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	if resp == nil {
+		return nil, qm, nil
+	}
+	defer closeResponseBody(resp)
+
+	var entries []*KVPair
+	if err := decodeBody(resp, &entries); err != nil {
+		return nil, nil, err
+	}
+	if len(entries) > 0 {
+		return entries[0], qm, nil
+	}
+	return nil, qm, nil
+}
+
+// History is used to lookup the current entry of a key followed by the
+// previous revisions of the key that are retained by the servers, newest
+// first. The returned list is empty if the key has no history.
+func (k *KV) History(key string, q *QueryOptions) ([]*KVRevision, *QueryMeta, error) {
+	resp, qm, err := k.getInternal(key, map[string]string{"revisions": ""}, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	if resp == nil {
+		return nil, qm, nil
+	}
+	defer closeResponseBody(resp)
+
+	var revisions []*KVRevision
+	if err := decodeBody(resp, &revisions); err != nil {
+		return nil, nil, err
+	}
+	return revisions, qm, nil
+}
+
 // List is used to lookup all keys under a prefix
 func (k *KV) List(prefix string, q *QueryOptions) (KVPairs, *QueryMeta, error) {
 	resp, qm, err := k.getInternal(prefix, map[string]string{"recurse": ""}, q)
@@ -158,48 +226,60 @@ func (k *KV) getInternal(key string, params map[string]string, q *QueryOptions)
 }
 
 // Put is used to write a new value. Only the
-// Key, Flags and Value is respected.
+// Key, Flags, Value and ExpirationTTL is respected.
 func (k *KV) Put(p *KVPair, q *WriteOptions) (*WriteMeta, error) {
 	params := make(map[string]string, 1)
 	if p.Flags != 0 {
 		params["flags"] = strconv.FormatUint(p.Flags, 10)
 	}
+	if p.ExpirationTTL != 0 {
+		params["ttl"] = p.ExpirationTTL.String()
+	}
 	_, wm, err := k.put(p.Key, params, p.Value, q)
 	return wm, err
 }
 
 // CAS is used for a Check-And-Set operation. The Key,
-// ModifyIndex, Flags and Value are respected. Returns true
+// ModifyIndex, Flags, Value and ExpirationTTL are respected. Returns true
 // on success or false on failures.
 func (k *KV) CAS(p *KVPair, q *WriteOptions) (bool, *WriteMeta, error) {
 	params := make(map[string]string, 2)
 	if p.Flags != 0 {
 		params["flags"] = strconv.FormatUint(p.Flags, 10)
 	}
+	if p.ExpirationTTL != 0 {
+		params["ttl"] = p.ExpirationTTL.String()
+	}
 	params["cas"] = strconv.FormatUint(p.ModifyIndex, 10)
 	return k.put(p.Key, params, p.Value, q)
 }
 
 // Acquire is used for a lock acquisition operation. The Key,
-// Flags, Value and Session are respected. Returns true
+// Flags, Value, Session and ExpirationTTL are respected. Returns true
 // on success or false on failures.
 func (k *KV) Acquire(p *KVPair, q *WriteOptions) (bool, *WriteMeta, error) {
 	params := make(map[string]string, 2)
 	if p.Flags != 0 {
 		params["flags"] = strconv.FormatUint(p.Flags, 10)
 	}
+	if p.ExpirationTTL != 0 {
+		params["ttl"] = p.ExpirationTTL.String()
+	}
 	params["acquire"] = p.Session
 	return k.put(p.Key, params, p.Value, q)
 }
 
 // Release is used for a lock release operation. The Key,
-// Flags, Value and Session are respected. Returns true
+// Flags, Value, Session and ExpirationTTL are respected. Returns true
 // on success or false on failures.
 func (k *KV) Release(p *KVPair, q *WriteOptions) (bool, *WriteMeta, error) {
 	params := make(map[string]string, 2)
 	if p.Flags != 0 {
 		params["flags"] = strconv.FormatUint(p.Flags, 10)
 	}
+	if p.ExpirationTTL != 0 {
+		params["ttl"] = p.ExpirationTTL.String()
+	}
 	params["release"] = p.Session
 	return k.put(p.Key, params, p.Value, q)
 }
@@ -305,3 +385,71 @@ func (k *KV) Txn(txn KVTxnOps, q *QueryOptions) (bool, *KVTxnResponse, *QueryMet
 	}
 	return respOk, &kvResp, qm, nil
 }
+
+// casIndex is used for a Check-And-Set operation which also returns the
+// ModifyIndex the key was written at. The Key, ModifyIndex, Flags and Value
+// are respected. It goes through the transaction endpoint since the KV
+// endpoint only reports whether the write succeeded.
+func (k *KV) casIndex(p *KVPair, q *QueryOptions) (uint64, bool, error) {
+	op := &KVTxnOp{
+		Verb:  KVCAS,
+		Key:   p.Key,
+		Value: p.Value,
+		Flags: p.Flags,
+		Index: p.ModifyIndex,
+	}
+	if q != nil {
+		op.Namespace = q.Namespace
+		op.Partition = q.Partition
+	}
+
+	ok, resp, _, err := k.c.txn(TxnOps{&TxnOp{KV: op}}, q)
+	if err != nil {
+		return 0, false, err
+	}
+	if !ok || len(resp.Results) == 0 || resp.Results[0].KV == nil {
+		return 0, false, nil
+	}
+	return resp.Results[0].KV.ModifyIndex, true, nil
+}
+
+// acquireIndex is used for a lock acquisition operation which also returns
+// the ModifyIndex the key was written at. The Key, Flags, Value, Session and
+// ExpirationTTL are respected. Like casIndex, it goes through the transaction
+// endpoint, which enforces the lock-delay of the key the same way.
+func (k *KV) acquireIndex(p *KVPair, q *QueryOptions) (uint64, bool, error) {
+	op := &KVTxnOp{
+		Verb:    KVLock,
+		Key:     p.Key,
+		Value:   p.Value,
+		Flags:   p.Flags,
+		Session: p.Session,
+	}
+	if p.ExpirationTTL != 0 {
+		op.TTL = p.ExpirationTTL.String()
+	}
+	if q != nil {
+		op.Namespace = q.Namespace
+		op.Partition = q.Partition
+	}
+
+	ok, resp, _, err := k.c.txn(TxnOps{&TxnOp{KV: op}}, q)
+	if err != nil {
+		return 0, false, err
+	}
+	if !ok {
+		// Only report that the lock wasn't acquired when it's held by another
+		// session or under a lock-delay, any other failure such as an invalid
+		// session is an error like with Acquire.
+		for _, txnErr := range resp.Errors {
+			if !strings.HasPrefix(txnErr.What, "failed to lock key") {
+				return 0, false, fmt.Errorf("%s", txnErr.What)
+			}
+		}
+		return 0, false, nil
+	}
+	if len(resp.Results) == 0 || resp.Results[0].KV == nil {
+		return 0, false, nil
+	}
+	return resp.Results[0].KV.ModifyIndex, true, nil
+}
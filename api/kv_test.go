@@ -11,6 +11,9 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/consul/sdk/testutil"
+	"github.com/hashicorp/consul/sdk/testutil/retry"
 )
 
 func TestAPI_ClientPutGetDelete(t *testing.T) {
@@ -228,6 +231,89 @@ func TestAPI_ClientCAS(t *testing.T) {
 	}
 }
 
+func TestAPI_ClientPut_TTL(t *testing.T) {
+	t.Parallel()
+	c, s := makeClient(t)
+	defer s.Stop()
+
+	s.WaitForSerfCheck(t)
+
+	kv := c.KV()
+
+	key := testKey()
+	start := time.Now()
+	p := &KVPair{Key: key, Value: []byte("test"), ExpirationTTL: 500 * time.Millisecond}
+	_, err := kv.Put(p, nil)
+	require.NoError(t, err)
+
+	pair, _, err := kv.Get(key, nil)
+	require.NoError(t, err)
+	require.NotNil(t, pair)
+	require.NotNil(t, pair.ExpirationTime)
+	require.WithinDuration(t, start.Add(500*time.Millisecond), *pair.ExpirationTime, time.Second)
+
+	// The pair is deleted once it expires.
+	retry.Run(t, func(r *retry.R) {
+		pair, _, err := kv.Get(key, nil)
+		require.NoError(r, err)
+		require.Nil(r, pair)
+	})
+}
+
+func TestAPI_ClientHistory(t *testing.T) {
+	t.Parallel()
+	c, s := makeClientWithConfig(t, nil, func(conf *testutil.TestServerConfig) {
+		conf.KVRevisions = map[string]int{"": 2}
+	})
+	defer s.Stop()
+
+	s.WaitForSerfCheck(t)
+
+	kv := c.KV()
+
+	key := testKey()
+	var indexes []uint64
+	for _, value := range []string{"one", "two", "three"} {
+		_, err := kv.Put(&KVPair{Key: key, Value: []byte(value)}, nil)
+		require.NoError(t, err)
+
+		pair, _, err := kv.Get(key, nil)
+		require.NoError(t, err)
+		indexes = append(indexes, pair.ModifyIndex)
+	}
+
+	history, meta, err := kv.History(key, nil)
+	require.NoError(t, err)
+	require.NotZero(t, meta.LastIndex)
+	require.Len(t, history, 3)
+	require.Equal(t, []byte("three"), history[0].Value)
+	require.Zero(t, history[0].ReplacedIndex)
+	require.Equal(t, []byte("two"), history[1].Value)
+	require.Equal(t, indexes[2], history[1].ReplacedIndex)
+	require.Equal(t, []byte("one"), history[2].Value)
+
+	pair, _, err := kv.GetAtIndex(key, indexes[1], nil)
+	require.NoError(t, err)
+	require.NotNil(t, pair)
+	require.Equal(t, []byte("two"), pair.Value)
+
+	pair, _, err = kv.GetAtIndex(key, indexes[0]-1, nil)
+	require.NoError(t, err)
+	require.Nil(t, pair)
+
+	_, err = kv.Delete(key, nil)
+	require.NoError(t, err)
+	history, _, err = kv.History(key, nil)
+	require.NoError(t, err)
+	require.Len(t, history, 3)
+	require.True(t, history[0].Deleted)
+	require.Equal(t, []byte("three"), history[0].Value)
+
+	history, _, err = kv.History(testKey(), nil)
+	require.NoError(t, err)
+	require.Empty(t, history)
+}
+
 func TestAPI_ClientWatchGet(t *testing.T) {
 	t.Parallel()
 	c, s := makeClient(t)
@@ -67,6 +67,7 @@ type Lock struct {
 	isHeld       bool
 	sessionRenew chan struct{}
 	lockSession  string
+	fencingToken uint64
 	l            sync.Mutex
 }
 
@@ -210,7 +211,9 @@ WAIT:
 		return nil, ErrLockConflict
 	}
 	locked := false
+	var token uint64
 	if pair != nil && pair.Session == l.lockSession {
+		token = pair.ModifyIndex
 		goto HELD
 	}
 	if pair != nil && pair.Session != "" {
@@ -218,10 +221,11 @@ WAIT:
 		goto WAIT
 	}
 
-	// Try to acquire the lock
+	// Try to acquire the lock, keeping the index of the write as the
+	// fencing token
 	pair = l.lockEntry(l.lockSession)
 
-	locked, _, err = kv.Acquire(pair, &wOpts)
+	token, locked, err = kv.acquireIndex(pair, &QueryOptions{Namespace: l.opts.Namespace})
 	if err != nil {
 		return nil, fmt.Errorf("failed to acquire lock: %v", err)
 	}
@@ -252,6 +256,8 @@ WAIT:
 	}
 
 HELD:
+	l.fencingToken = token
+
 	// Watch to ensure we maintain leadership
 	leaderCh := make(chan struct{})
 	go l.monitorLock(l.lockSession, leaderCh)
@@ -277,6 +283,7 @@ func (l *Lock) Unlock() error {
 
 	// Set that we no longer own the lock
 	l.isHeld = false
+	l.fencingToken = 0
 
 	// Stop the session renew
 	if l.sessionRenew != nil {
@@ -301,6 +308,21 @@ func (l *Lock) Unlock() error {
 	return nil
 }
 
+// FencingToken returns a token which increases every time the lock is
+// acquired. It can be passed along to the systems protected by the lock so
+// that they reject the requests of a previous holder which still believes to
+// hold the lock. The token is the ModifyIndex of the lock key at the time the
+// lock was acquired. It is an error to call this if the lock is not held.
+func (l *Lock) FencingToken() (uint64, error) {
+	l.l.Lock()
+	defer l.l.Unlock()
+
+	if !l.isHeld {
+		return 0, ErrLockNotHeld
+	}
+	return l.fencingToken, nil
+}
+
 // Destroy is used to cleanup the lock entry. It is not necessary
 // to invoke. It will fail if the lock is in use.
 func (l *Lock) Destroy() error {
@@ -587,3 +587,75 @@ func TestAPI_LockOneShot(t *testing.T) {
 		t.Fatalf("should be leader")
 	}
 }
+
+func TestAPI_LockFencingToken(t *testing.T) {
+	t.Parallel()
+	c, s := makeClientWithoutConnect(t)
+	defer s.Stop()
+
+	lock, session := createTestLock(t, c, "test/lock")
+	defer session.Destroy(lock.opts.Session, nil)
+
+	// Not held yet
+	if _, err := lock.FencingToken(); err != ErrLockNotHeld {
+		t.Fatalf("err: %v", err)
+	}
+
+	if _, err := lock.Lock(nil); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	token, err := lock.FencingToken()
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+
+	// The token is the index the lock was acquired at
+	pair, _, err := c.KV().Get("test/lock", nil)
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if token == 0 || token != pair.ModifyIndex {
+		t.Fatalf("bad token: %d, ModifyIndex: %d", token, pair.ModifyIndex)
+	}
+
+	if err := lock.Unlock(); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if _, err := lock.FencingToken(); err != ErrLockNotHeld {
+		t.Fatalf("err: %v", err)
+	}
+
+	// The next holder gets a greater token
+	lock2, session := createTestLock(t, c, "test/lock")
+	defer session.Destroy(lock2.opts.Session, nil)
+
+	if _, err := lock2.Lock(nil); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	defer lock2.Unlock()
+
+	token2, err := lock2.FencingToken()
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if token2 <= token {
+		t.Fatalf("token did not increase: %d <= %d", token2, token)
+	}
+}
+
+func TestAPI_LockInvalidSession(t *testing.T) {
+	t.Parallel()
+	c, s := makeClientWithoutConnect(t)
+	defer s.Stop()
+
+	lock, session := createTestLock(t, c, "test/lock")
+	if _, err := session.Destroy(lock.opts.Session, nil); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+
+	// The acquisition fails rather than waiting for the lock
+	_, err := lock.Lock(nil)
+	if err == nil || !strings.Contains(err.Error(), "invalid session") {
+		t.Fatalf("err: %v", err)
+	}
+}
@@ -0,0 +1,643 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultRWLockSessionName is the Session Name we assign if none is provided
+	DefaultRWLockSessionName = "Consul API RWLock"
+
+	// DefaultRWLockSessionTTL is the default session TTL if no Session is provided
+	// when creating a new RWLock. This is used because we do not have another
+	// other check to depend upon.
+	DefaultRWLockSessionTTL = "15s"
+
+	// DefaultRWLockWaitTime is how long we block for at a time to check if lock
+	// acquisition is possible. This affects the minimum time it takes to cancel
+	// a RWLock acquisition.
+	DefaultRWLockWaitTime = 15 * time.Second
+
+	// RWLockFlagValue is a magic flag we set to indicate a key
+	// is being used for a reader/writer lock. It is used to detect a
+	// potential conflict with a lock or a semaphore.
+	RWLockFlagValue = 0x8a3c4f1e97b2d065
+)
+
+var (
+	// ErrRWLockHeld is returned if we attempt to double lock
+	ErrRWLockHeld = fmt.Errorf("RWLock already held")
+
+	// ErrRWLockNotHeld is returned if we attempt to unlock a reader/writer
+	// lock that we do not hold in the given mode.
+	ErrRWLockNotHeld = fmt.Errorf("RWLock not held")
+
+	// ErrRWLockInUse is returned if we attempt to destroy a reader/writer
+	// lock that is in use.
+	ErrRWLockInUse = fmt.Errorf("RWLock in use")
+
+	// ErrRWLockConflict is returned if the flags on a key
+	// used for a reader/writer lock do not match expectation
+	ErrRWLockConflict = fmt.Errorf("Existing key does not match RWLock use")
+)
+
+// RWLock is used to implement a distributed reader/writer lock using the
+// Consul KV primitives. Any number of readers can hold the lock at the same
+// time, or a single writer. Writers are preferred: once a writer waits for
+// the lock, new readers wait until it has acquired and released it.
+//
+// Like a Semaphore, contenders write an entry under the prefix which is
+// bound to their session, and coordinate through a lock entry stored under
+// the DefaultSemaphoreKey of the prefix.
+type RWLock struct {
+	c    *Client
+	opts *RWLockOptions
+
+	isHeld       bool
+	isShared     bool
+	sessionRenew chan struct{}
+	lockSession  string
+	fencingToken uint64
+	l            sync.Mutex
+}
+
+// RWLockOptions is used to parameterize the RWLock
+type RWLockOptions struct {
+	Prefix           string        // Must be set and have write permissions
+	Value            []byte        // Optional, value to associate with the contender entry
+	Session          string        // Optional, created if not specified
+	SessionName      string        // Optional, defaults to DefaultRWLockSessionName
+	SessionTTL       string        // Optional, defaults to DefaultRWLockSessionTTL
+	MonitorRetries   int           // Optional, defaults to 0 which means no retries
+	MonitorRetryTime time.Duration // Optional, defaults to DefaultMonitorRetryTime
+	LockWaitTime     time.Duration // Optional, defaults to DefaultRWLockWaitTime
+	LockTryOnce      bool          // Optional, defaults to false which means try forever
+	Namespace        string        `json:",omitempty"` // Optional, defaults to API client config, namespace of ACL token, or "default" namespace
+}
+
+// rwLock is written under the DefaultSemaphoreKey and
+// is used to coordinate between all the contenders.
+type rwLock struct {
+	// Writer is the session ID of the writer holding the lock, if any.
+	Writer string `json:",omitempty"`
+
+	// Readers is the set of the session IDs of the readers holding the lock.
+	Readers map[string]bool
+
+	// Waiting is the set of the session IDs of the writers waiting for the
+	// lock. New readers don't acquire the lock while it is not empty.
+	Waiting map[string]bool
+}
+
+// RWLockPrefix is used to create a RWLock which will operate at the given
+// KV prefix. The prefix must have write privileges.
+func (c *Client) RWLockPrefix(prefix string) (*RWLock, error) {
+	opts := &RWLockOptions{
+		Prefix: prefix,
+	}
+	return c.RWLockOpts(opts)
+}
+
+// RWLockOpts is used to create a RWLock with the given options. The prefix
+// must have write privileges. If a Session is not provided, one will be
+// created.
+func (c *Client) RWLockOpts(opts *RWLockOptions) (*RWLock, error) {
+	if opts.Prefix == "" {
+		return nil, fmt.Errorf("missing prefix")
+	}
+	if opts.SessionName == "" {
+		opts.SessionName = DefaultRWLockSessionName
+	}
+	if opts.SessionTTL == "" {
+		opts.SessionTTL = DefaultRWLockSessionTTL
+	} else {
+		if _, err := time.ParseDuration(opts.SessionTTL); err != nil {
+			return nil, fmt.Errorf("invalid SessionTTL: %v", err)
+		}
+	}
+	if opts.MonitorRetryTime == 0 {
+		opts.MonitorRetryTime = DefaultMonitorRetryTime
+	}
+	if opts.LockWaitTime == 0 {
+		opts.LockWaitTime = DefaultRWLockWaitTime
+	}
+	l := &RWLock{
+		c:    c,
+		opts: opts,
+	}
+	return l, nil
+}
+
+// Lock attempts to acquire the lock exclusively, as a writer, and blocks
+// while doing so. Providing a non-nil stopCh can be used to abort the lock
+// attempt. Returns a channel that is closed if our lock is lost or an error.
+// This channel could be closed at any time due to session invalidation,
+// communication errors, operator intervention, etc. It is NOT safe to assume
+// that the lock is held until Unlock() unless the Session is specifically
+// created without any associated health checks. By default Consul sessions
+// prefer liveness over safety and an application must be able to handle the
+// lock being lost.
+func (l *RWLock) Lock(stopCh <-chan struct{}) (<-chan struct{}, error) {
+	return l.acquire(false, stopCh)
+}
+
+// RLock attempts to acquire the lock shared with other readers, and blocks
+// while doing so. It waits while a writer holds or waits for the lock. The
+// returned channel and the stopCh behave the same as for Lock().
+func (l *RWLock) RLock(stopCh <-chan struct{}) (<-chan struct{}, error) {
+	return l.acquire(true, stopCh)
+}
+
+func (l *RWLock) acquire(shared bool, stopCh <-chan struct{}) (<-chan struct{}, error) {
+	// Hold the lock as we try to acquire
+	l.l.Lock()
+	defer l.l.Unlock()
+
+	// Check if we already hold the lock
+	if l.isHeld {
+		return nil, ErrRWLockHeld
+	}
+
+	// Check if we need to create a session first
+	l.lockSession = l.opts.Session
+	if l.lockSession == "" {
+		sess, err := l.createSession()
+		if err != nil {
+			return nil, fmt.Errorf("failed to create session: %v", err)
+		}
+
+		l.sessionRenew = make(chan struct{})
+		l.lockSession = sess
+		session := l.c.Session()
+		go session.RenewPeriodic(l.opts.SessionTTL, sess, nil, l.sessionRenew)
+
+		// If we fail to acquire the lock, cleanup the session
+		defer func() {
+			if !l.isHeld {
+				close(l.sessionRenew)
+				l.sessionRenew = nil
+			}
+		}()
+	}
+
+	// Create the contender entry
+	kv := l.c.KV()
+	wOpts := WriteOptions{Namespace: l.opts.Namespace}
+
+	made, _, err := kv.Acquire(l.contenderEntry(l.lockSession), &wOpts)
+	if err != nil || !made {
+		return nil, fmt.Errorf("failed to make contender entry: %v", err)
+	}
+
+	// A writer which gives up waiting must withdraw, otherwise readers
+	// would wait for it until its session is invalidated
+	waiting := false
+	defer func() {
+		if waiting && !l.isHeld {
+			l.update(func(lock *rwLock) bool {
+				if !lock.Waiting[l.lockSession] {
+					return false
+				}
+				delete(lock.Waiting, l.lockSession)
+				return true
+			})
+		}
+	}()
+
+	// Setup the query options
+	qOpts := QueryOptions{
+		WaitTime:  l.opts.LockWaitTime,
+		Namespace: l.opts.Namespace,
+	}
+
+	start := time.Now()
+	attempts := 0
+WAIT:
+	// Check if we should quit
+	select {
+	case <-stopCh:
+		return nil, nil
+	default:
+	}
+
+	// Handle the one-shot mode.
+	if l.opts.LockTryOnce && attempts > 0 {
+		elapsed := time.Since(start)
+		if elapsed > l.opts.LockWaitTime {
+			return nil, nil
+		}
+
+		// Query wait time should not exceed the lock wait time
+		qOpts.WaitTime = l.opts.LockWaitTime - elapsed
+	}
+	attempts++
+
+	// Read the prefix
+	pairs, meta, err := kv.List(l.opts.Prefix, &qOpts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read prefix: %v", err)
+	}
+
+	// Decode the lock
+	lockPair := l.findLock(pairs)
+	if lockPair.Flags != RWLockFlagValue {
+		return nil, ErrRWLockConflict
+	}
+	lock, err := l.decodeLock(lockPair)
+	if err != nil {
+		return nil, err
+	}
+
+	// Prune the dead holders
+	l.pruneDeadHolders(lock, pairs)
+
+	// Check if the lock is available in the requested mode
+	available := lock.Writer == "" && len(lock.Readers) == 0
+	if shared {
+		available = lock.Writer == "" && len(lock.Waiting) == 0
+	}
+	if !available {
+		if shared || lock.Waiting[l.lockSession] {
+			qOpts.WaitIndex = meta.LastIndex
+			goto WAIT
+		}
+
+		// Register as a waiting writer so that no new readers acquire the
+		// lock in the meantime
+		lock.Waiting[l.lockSession] = true
+		newLock, err := l.encodeLock(lock, lockPair.ModifyIndex)
+		if err != nil {
+			return nil, err
+		}
+		didSet, _, err := kv.CAS(newLock, &wOpts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to update lock: %v", err)
+		}
+		if didSet {
+			waiting = true
+		}
+		goto WAIT
+	}
+
+	// Create a new lock with us as a holder
+	if shared {
+		lock.Readers[l.lockSession] = true
+	} else {
+		lock.Writer = l.lockSession
+		delete(lock.Waiting, l.lockSession)
+	}
+	newLock, err := l.encodeLock(lock, lockPair.ModifyIndex)
+	if err != nil {
+		return nil, err
+	}
+
+	// Attempt the acquisition, keeping the index of the write as the
+	// fencing token
+	token, didSet, err := kv.casIndex(newLock, &QueryOptions{Namespace: l.opts.Namespace})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update lock: %v", err)
+	}
+	if !didSet {
+		// Update failed, could have been a race with another contender,
+		// retry the operation
+		goto WAIT
+	}
+	l.fencingToken = token
+
+	// Watch to ensure we maintain ownership of the lock
+	lockCh := make(chan struct{})
+	go l.monitorLock(l.lockSession, shared, lockCh)
+
+	// Set that we own the lock
+	l.isHeld = true
+	l.isShared = shared
+
+	// Locked! All done
+	return lockCh, nil
+}
+
+// Unlock releases the lock held by Lock(). It is an error to call this if
+// the lock is not currently held as a writer.
+func (l *RWLock) Unlock() error {
+	return l.release(false)
+}
+
+// RUnlock releases the lock held by RLock(). It is an error to call this if
+// the lock is not currently held as a reader.
+func (l *RWLock) RUnlock() error {
+	return l.release(true)
+}
+
+func (l *RWLock) release(shared bool) error {
+	// Hold the lock as we try to release
+	l.l.Lock()
+	defer l.l.Unlock()
+
+	// Ensure the lock is actually held in this mode
+	if !l.isHeld || l.isShared != shared {
+		return ErrRWLockNotHeld
+	}
+
+	// Set that we no longer own the lock
+	l.isHeld = false
+	l.fencingToken = 0
+
+	// Stop the session renew
+	if l.sessionRenew != nil {
+		defer func() {
+			close(l.sessionRenew)
+			l.sessionRenew = nil
+		}()
+	}
+
+	// Remove ourselves as a lock holder
+	lockSession := l.lockSession
+	err := l.update(func(lock *rwLock) bool {
+		if shared {
+			if !lock.Readers[lockSession] {
+				return false
+			}
+			delete(lock.Readers, lockSession)
+			return true
+		}
+		if lock.Writer != lockSession {
+			return false
+		}
+		lock.Writer = ""
+		return true
+	})
+	l.lockSession = ""
+	if err != nil {
+		return err
+	}
+
+	// Destroy the contender entry
+	kv := l.c.KV()
+	wOpts := WriteOptions{Namespace: l.opts.Namespace}
+	contenderKey := path.Join(l.opts.Prefix, lockSession)
+	if _, err := kv.Delete(contenderKey, &wOpts); err != nil {
+		return err
+	}
+	return nil
+}
+
+// FencingToken returns a token which increases every time the lock is
+// acquired, by a reader or a writer. It can be passed along to the systems
+// protected by the lock so that they reject the requests of a previous
+// holder. The token is the ModifyIndex of the lock entry when the lock was
+// acquired. It is an error to call this if the lock is not held.
+func (l *RWLock) FencingToken() (uint64, error) {
+	l.l.Lock()
+	defer l.l.Unlock()
+
+	if !l.isHeld {
+		return 0, ErrRWLockNotHeld
+	}
+	return l.fencingToken, nil
+}
+
+// Destroy is used to cleanup the lock entry. It is not necessary
+// to invoke. It will fail if the lock is in use.
+func (l *RWLock) Destroy() error {
+	// Hold the lock as we try to acquire
+	l.l.Lock()
+	defer l.l.Unlock()
+
+	// Check if we already hold the lock
+	if l.isHeld {
+		return ErrRWLockHeld
+	}
+
+	// List for the lock
+	kv := l.c.KV()
+
+	q := QueryOptions{Namespace: l.opts.Namespace}
+	pairs, _, err := kv.List(l.opts.Prefix, &q)
+	if err != nil {
+		return fmt.Errorf("failed to read prefix: %v", err)
+	}
+
+	// Find the lock pair, bail if it doesn't exist
+	lockPair := l.findLock(pairs)
+	if lockPair.ModifyIndex == 0 {
+		return nil
+	}
+	if lockPair.Flags != RWLockFlagValue {
+		return ErrRWLockConflict
+	}
+
+	// Decode the lock
+	lock, err := l.decodeLock(lockPair)
+	if err != nil {
+		return err
+	}
+
+	// Prune the dead holders
+	l.pruneDeadHolders(lock, pairs)
+
+	// Check if there are any holders or waiting writers
+	if lock.Writer != "" || len(lock.Readers) > 0 || len(lock.Waiting) > 0 {
+		return ErrRWLockInUse
+	}
+
+	// Attempt the delete
+	w := WriteOptions{Namespace: l.opts.Namespace}
+	didRemove, _, err := kv.DeleteCAS(lockPair, &w)
+	if err != nil {
+		return fmt.Errorf("failed to remove lock: %v", err)
+	}
+	if !didRemove {
+		return ErrRWLockInUse
+	}
+	return nil
+}
+
+// update is used to apply a change to the lock entry with a Check-And-Set,
+// retrying if the entry is modified concurrently. The change func returns
+// false if there is nothing to write.
+func (l *RWLock) update(change func(*rwLock) bool) error {
+	kv := l.c.KV()
+	key := path.Join(l.opts.Prefix, DefaultSemaphoreKey)
+
+	wOpts := WriteOptions{Namespace: l.opts.Namespace}
+	qOpts := QueryOptions{Namespace: l.opts.Namespace}
+
+READ:
+	pair, _, err := kv.Get(key, &qOpts)
+	if err != nil {
+		return err
+	}
+	if pair == nil {
+		return nil
+	}
+	lock, err := l.decodeLock(pair)
+	if err != nil {
+		return err
+	}
+	if !change(lock) {
+		return nil
+	}
+
+	newLock, err := l.encodeLock(lock, pair.ModifyIndex)
+	if err != nil {
+		return err
+	}
+	didSet, _, err := kv.CAS(newLock, &wOpts)
+	if err != nil {
+		return fmt.Errorf("failed to update lock: %v", err)
+	}
+	if !didSet {
+		goto READ
+	}
+	return nil
+}
+
+// createSession is used to create a new managed session
+func (l *RWLock) createSession() (string, error) {
+	session := l.c.Session()
+	se := &SessionEntry{
+		Name:     l.opts.SessionName,
+		TTL:      l.opts.SessionTTL,
+		Behavior: SessionBehaviorDelete,
+	}
+
+	w := WriteOptions{Namespace: l.opts.Namespace}
+	id, _, err := session.Create(se, &w)
+	if err != nil {
+		return "", err
+	}
+	return id, nil
+}
+
+// contenderEntry returns a formatted KVPair for the contender
+func (l *RWLock) contenderEntry(session string) *KVPair {
+	return &KVPair{
+		Key:     path.Join(l.opts.Prefix, session),
+		Value:   l.opts.Value,
+		Session: session,
+		Flags:   RWLockFlagValue,
+	}
+}
+
+// findLock is used to find the KV Pair which is used for coordination
+func (l *RWLock) findLock(pairs KVPairs) *KVPair {
+	key := path.Join(l.opts.Prefix, DefaultSemaphoreKey)
+	for _, pair := range pairs {
+		if pair.Key == key {
+			return pair
+		}
+	}
+	return &KVPair{Flags: RWLockFlagValue}
+}
+
+// decodeLock is used to decode a rwLock from an
+// entry in Consul
+func (l *RWLock) decodeLock(pair *KVPair) (*rwLock, error) {
+	lock := &rwLock{}
+
+	// Handle if there is no lock
+	if pair != nil && pair.Value != nil {
+		if err := json.Unmarshal(pair.Value, lock); err != nil {
+			return nil, fmt.Errorf("lock decoding failed: %v", err)
+		}
+	}
+	if lock.Readers == nil {
+		lock.Readers = make(map[string]bool)
+	}
+	if lock.Waiting == nil {
+		lock.Waiting = make(map[string]bool)
+	}
+	return lock, nil
+}
+
+// encodeLock is used to encode a rwLock into a KVPair
+// that can be PUT
+func (l *RWLock) encodeLock(lock *rwLock, oldIndex uint64) (*KVPair, error) {
+	enc, err := json.Marshal(lock)
+	if err != nil {
+		return nil, fmt.Errorf("lock encoding failed: %v", err)
+	}
+	pair := &KVPair{
+		Key:         path.Join(l.opts.Prefix, DefaultSemaphoreKey),
+		Value:       enc,
+		Flags:       RWLockFlagValue,
+		ModifyIndex: oldIndex,
+	}
+	return pair, nil
+}
+
+// pruneDeadHolders is used to remove all the dead lock holders
+// and waiting writers
+func (l *RWLock) pruneDeadHolders(lock *rwLock, pairs KVPairs) {
+	// Gather all the live holders
+	alive := make(map[string]struct{}, len(pairs))
+	for _, pair := range pairs {
+		if pair.Session != "" {
+			alive[pair.Session] = struct{}{}
+		}
+	}
+
+	// Remove any holders that are dead
+	if _, ok := alive[lock.Writer]; !ok {
+		lock.Writer = ""
+	}
+	for holder := range lock.Readers {
+		if _, ok := alive[holder]; !ok {
+			delete(lock.Readers, holder)
+		}
+	}
+	for waiter := range lock.Waiting {
+		if _, ok := alive[waiter]; !ok {
+			delete(lock.Waiting, waiter)
+		}
+	}
+}
+
+// monitorLock is a long running routine to monitor a lock ownership
+// It closes the stopCh if we lose our lock.
+func (l *RWLock) monitorLock(session string, shared bool, stopCh chan struct{}) {
+	defer close(stopCh)
+	kv := l.c.KV()
+	opts := QueryOptions{
+		RequireConsistent: true,
+		Namespace:         l.opts.Namespace,
+	}
+WAIT:
+	retries := l.opts.MonitorRetries
+RETRY:
+	pairs, meta, err := kv.List(l.opts.Prefix, &opts)
+	if err != nil {
+		// If configured we can try to ride out a brief Consul unavailability
+		// by doing retries. Note that we have to attempt the retry in a non-
+		// blocking fashion so that we have a clean place to reset the retry
+		// counter if service is restored.
+		if retries > 0 && IsRetryableError(err) {
+			time.Sleep(l.opts.MonitorRetryTime)
+			retries--
+			opts.WaitIndex = 0
+			goto RETRY
+		}
+		return
+	}
+	lockPair := l.findLock(pairs)
+	lock, err := l.decodeLock(lockPair)
+	if err != nil {
+		return
+	}
+	l.pruneDeadHolders(lock, pairs)
+	held := lock.Writer == session
+	if shared {
+		held = lock.Readers[session]
+	}
+	if held {
+		opts.WaitIndex = meta.LastIndex
+		goto WAIT
+	}
+}
@@ -0,0 +1,407 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package api
+
+import (
+	"testing"
+	"time"
+)
+
+func createTestRWLock(t *testing.T, c *Client, prefix string) (*RWLock, *Session) {
+	t.Helper()
+	session := c.Session()
+
+	se := &SessionEntry{
+		Name:     DefaultRWLockSessionName,
+		TTL:      DefaultRWLockSessionTTL,
+		Behavior: SessionBehaviorDelete,
+	}
+	id, _, err := session.CreateNoChecks(se, nil)
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+
+	opts := &RWLockOptions{
+		Prefix:      prefix,
+		Session:     id,
+		SessionName: se.Name,
+		SessionTTL:  se.TTL,
+	}
+	lock, err := c.RWLockOpts(opts)
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+
+	return lock, session
+}
+
+func TestAPI_RWLockLockUnlock(t *testing.T) {
+	t.Parallel()
+	c, s := makeClient(t)
+	defer s.Stop()
+
+	lock, session := createTestRWLock(t, c, "test/rwlock")
+	defer session.Destroy(lock.opts.Session, nil)
+
+	// Initial unlock should fail
+	if err := lock.Unlock(); err != ErrRWLockNotHeld {
+		t.Fatalf("err: %v", err)
+	}
+	if err := lock.RUnlock(); err != ErrRWLockNotHeld {
+		t.Fatalf("err: %v", err)
+	}
+
+	// Should work
+	lockCh, err := lock.Lock(nil)
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if lockCh == nil {
+		t.Fatalf("not held")
+	}
+
+	// Double lock should fail
+	if _, err := lock.RLock(nil); err != ErrRWLockHeld {
+		t.Fatalf("err: %v", err)
+	}
+
+	// Unlocking in the wrong mode should fail
+	if err := lock.RUnlock(); err != ErrRWLockNotHeld {
+		t.Fatalf("err: %v", err)
+	}
+
+	// Should be held
+	select {
+	case <-lockCh:
+		t.Fatalf("should be held")
+	default:
+	}
+
+	if err := lock.Unlock(); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+
+	// Should lose the lock
+	select {
+	case <-lockCh:
+	case <-time.After(time.Second):
+		t.Fatalf("should not be held")
+	}
+}
+
+func TestAPI_RWLockReaders(t *testing.T) {
+	t.Parallel()
+	c, s := makeClient(t)
+	defer s.Stop()
+
+	reader, session := createTestRWLock(t, c, "test/rwlock")
+	defer session.Destroy(reader.opts.Session, nil)
+
+	reader2, session := createTestRWLock(t, c, "test/rwlock")
+	defer session.Destroy(reader2.opts.Session, nil)
+
+	writer, session := createTestRWLock(t, c, "test/rwlock")
+	defer session.Destroy(writer.opts.Session, nil)
+
+	// Readers share the lock
+	if _, err := reader.RLock(nil); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if _, err := reader2.RLock(nil); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+
+	// The writer waits for the readers
+	writerCh := make(chan (<-chan struct{}), 1)
+	go func() {
+		lockCh, err := writer.Lock(nil)
+		if err != nil {
+			t.Errorf("err: %v", err)
+		}
+		writerCh <- lockCh
+	}()
+
+	select {
+	case <-writerCh:
+		t.Fatalf("writer should wait")
+	case <-time.After(500 * time.Millisecond):
+	}
+
+	if err := reader.RUnlock(); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if err := reader2.RUnlock(); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+
+	select {
+	case lockCh := <-writerCh:
+		if lockCh == nil {
+			t.Fatalf("not held")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("writer should hold the lock")
+	}
+
+	if err := writer.Unlock(); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+}
+
+func TestAPI_RWLockWriterPreference(t *testing.T) {
+	t.Parallel()
+	c, s := makeClient(t)
+	defer s.Stop()
+
+	reader, session := createTestRWLock(t, c, "test/rwlock")
+	defer session.Destroy(reader.opts.Session, nil)
+
+	reader2, session := createTestRWLock(t, c, "test/rwlock")
+	defer session.Destroy(reader2.opts.Session, nil)
+
+	writer, session := createTestRWLock(t, c, "test/rwlock")
+	defer session.Destroy(writer.opts.Session, nil)
+
+	if _, err := reader.RLock(nil); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+
+	// The writer waits for the reader
+	writerCh := make(chan (<-chan struct{}), 1)
+	go func() {
+		lockCh, err := writer.Lock(nil)
+		if err != nil {
+			t.Errorf("err: %v", err)
+		}
+		writerCh <- lockCh
+	}()
+
+	// Wait for the writer to be registered as waiting
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		pair, _, err := c.KV().Get("test/rwlock/.lock", nil)
+		if err != nil {
+			t.Fatalf("err: %v", err)
+		}
+		lock, err := writer.decodeLock(pair)
+		if err != nil {
+			t.Fatalf("err: %v", err)
+		}
+		if len(lock.Waiting) == 1 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("writer should be waiting")
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+
+	// A new reader waits for the waiting writer
+	readerCh := make(chan (<-chan struct{}), 1)
+	go func() {
+		lockCh, err := reader2.RLock(nil)
+		if err != nil {
+			t.Errorf("err: %v", err)
+		}
+		readerCh <- lockCh
+	}()
+
+	select {
+	case <-readerCh:
+		t.Fatalf("reader should wait for the writer")
+	case <-time.After(500 * time.Millisecond):
+	}
+
+	// The writer goes first once the lock is released
+	if err := reader.RUnlock(); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+
+	select {
+	case <-writerCh:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("writer should hold the lock")
+	}
+
+	select {
+	case <-readerCh:
+		t.Fatalf("reader should wait for the writer")
+	case <-time.After(500 * time.Millisecond):
+	}
+
+	if err := writer.Unlock(); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+
+	select {
+	case <-readerCh:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("reader should hold the lock")
+	}
+
+	if err := reader2.RUnlock(); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+}
+
+func TestAPI_RWLockOneShot(t *testing.T) {
+	t.Parallel()
+	c, s := makeClient(t)
+	defer s.Stop()
+
+	writer, session := createTestRWLock(t, c, "test/rwlock")
+	defer session.Destroy(writer.opts.Session, nil)
+
+	if _, err := writer.Lock(nil); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+
+	// A second writer gives up
+	writer2, session := createTestRWLock(t, c, "test/rwlock")
+	defer session.Destroy(writer2.opts.Session, nil)
+	writer2.opts.LockTryOnce = true
+	writer2.opts.LockWaitTime = 250 * time.Millisecond
+
+	lockCh, err := writer2.Lock(nil)
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if lockCh != nil {
+		t.Fatalf("should not be held")
+	}
+
+	// It withdrew as a waiting writer, so readers can acquire the lock once
+	// the first writer releases it
+	if err := writer.Unlock(); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+
+	reader, session := createTestRWLock(t, c, "test/rwlock")
+	defer session.Destroy(reader.opts.Session, nil)
+	reader.opts.LockTryOnce = true
+	reader.opts.LockWaitTime = 250 * time.Millisecond
+
+	lockCh, err = reader.RLock(nil)
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if lockCh == nil {
+		t.Fatalf("not held")
+	}
+	if err := reader.RUnlock(); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+}
+
+func TestAPI_RWLockFencingToken(t *testing.T) {
+	t.Parallel()
+	c, s := makeClient(t)
+	defer s.Stop()
+
+	lock, session := createTestRWLock(t, c, "test/rwlock")
+	defer session.Destroy(lock.opts.Session, nil)
+
+	if _, err := lock.FencingToken(); err != ErrRWLockNotHeld {
+		t.Fatalf("err: %v", err)
+	}
+
+	if _, err := lock.RLock(nil); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	token, err := lock.FencingToken()
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if token == 0 {
+		t.Fatalf("bad token: %d", token)
+	}
+	if err := lock.RUnlock(); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+
+	if _, err := lock.Lock(nil); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	token2, err := lock.FencingToken()
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if token2 <= token {
+		t.Fatalf("token did not increase: %d <= %d", token2, token)
+	}
+	if err := lock.Unlock(); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+}
+
+func TestAPI_RWLockDestroy(t *testing.T) {
+	t.Parallel()
+	c, s := makeClient(t)
+	defer s.Stop()
+
+	lock, session := createTestRWLock(t, c, "test/rwlock")
+	defer session.Destroy(lock.opts.Session, nil)
+
+	lock2, session := createTestRWLock(t, c, "test/rwlock")
+	defer session.Destroy(lock2.opts.Session, nil)
+
+	if _, err := lock.RLock(nil); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+
+	// Destroy should fail, still held
+	if err := lock.Destroy(); err != ErrRWLockHeld {
+		t.Fatalf("err: %v", err)
+	}
+
+	// Destroy should fail, still in use
+	if err := lock2.Destroy(); err != ErrRWLockInUse {
+		t.Fatalf("err: %v", err)
+	}
+
+	if err := lock.RUnlock(); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+
+	// Destroy should work
+	if err := lock.Destroy(); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+
+	pair, _, err := c.KV().Get("test/rwlock/.lock", nil)
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if pair != nil {
+		t.Fatalf("lock entry should be removed: %v", pair)
+	}
+}
+
+func TestAPI_RWLockConflict(t *testing.T) {
+	t.Parallel()
+	c, s := makeClient(t)
+	defer s.Stop()
+
+	s.WaitForSerfCheck(t)
+	sema, session := createTestSemaphore(t, c, "test/rwlock/", 2)
+	defer session.Destroy(sema.opts.Session, nil)
+
+	if _, err := sema.Acquire(nil); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	defer sema.Release()
+
+	lock, session := createTestRWLock(t, c, "test/rwlock/")
+	defer session.Destroy(lock.opts.Session, nil)
+
+	// Should conflict with the semaphore
+	if _, err := lock.Lock(nil); err != ErrRWLockConflict {
+		t.Fatalf("err: %v", err)
+	}
+
+	// Should conflict with the semaphore
+	if err := lock.Destroy(); err != ErrRWLockConflict {
+		t.Fatalf("err: %v", err)
+	}
+}
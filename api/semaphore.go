@@ -61,6 +61,7 @@ type Semaphore struct {
 	isHeld       bool
 	sessionRenew chan struct{}
 	lockSession  string
+	fencingToken uint64
 	l            sync.Mutex
 }
 
@@ -253,8 +254,9 @@ WAIT:
 		return nil, err
 	}
 
-	// Attempt the acquisition
-	didSet, _, err := kv.CAS(newLock, &wOpts)
+	// Attempt the acquisition, keeping the index of the write as the
+	// fencing token
+	token, didSet, err := kv.casIndex(newLock, &QueryOptions{Namespace: s.opts.Namespace})
 	if err != nil {
 		return nil, fmt.Errorf("failed to update lock: %v", err)
 	}
@@ -263,6 +265,7 @@ WAIT:
 		// retry the operation
 		goto WAIT
 	}
+	s.fencingToken = token
 
 	// Watch to ensure we maintain ownership of the slot
 	lockCh := make(chan struct{})
@@ -289,6 +292,7 @@ func (s *Semaphore) Release() error {
 
 	// Set that we no longer own the lock
 	s.isHeld = false
+	s.fencingToken = 0
 
 	// Stop the session renew
 	if s.sessionRenew != nil {
@@ -348,6 +352,21 @@ READ:
 	return nil
 }
 
+// FencingToken returns a token which increases every time a slot of the
+// semaphore is acquired. It can be passed along to the systems protected by
+// the semaphore so that they reject the requests of a previous holder. The
+// token is the ModifyIndex of the semaphore's lock entry when the slot was
+// acquired. It is an error to call this if the semaphore is not held.
+func (s *Semaphore) FencingToken() (uint64, error) {
+	s.l.Lock()
+	defer s.l.Unlock()
+
+	if !s.isHeld {
+		return 0, ErrSemaphoreNotHeld
+	}
+	return s.fencingToken, nil
+}
+
 // Destroy is used to cleanup the semaphore entry. It is not necessary
 // to invoke. It will fail if the semaphore is in use.
 func (s *Semaphore) Destroy() error {
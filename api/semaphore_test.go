@@ -545,3 +545,58 @@ func TestAPI_SemaphoreOneShot(t *testing.T) {
 		t.Fatalf("should have acquired the semaphore")
 	}
 }
+
+func TestAPI_SemaphoreFencingToken(t *testing.T) {
+	t.Parallel()
+	c, s := makeClient(t)
+	defer s.Stop()
+
+	sema, session := createTestSemaphore(t, c, "test/semaphore", 2)
+	defer session.Destroy(sema.opts.Session, nil)
+
+	sema2, session := createTestSemaphore(t, c, "test/semaphore", 2)
+	defer session.Destroy(sema2.opts.Session, nil)
+
+	// Not held yet
+	if _, err := sema.FencingToken(); err != ErrSemaphoreNotHeld {
+		t.Fatalf("err: %v", err)
+	}
+
+	if _, err := sema.Acquire(nil); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	token, err := sema.FencingToken()
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if token == 0 {
+		t.Fatalf("bad token: %d", token)
+	}
+
+	// Each slot acquisition gets a greater token
+	if _, err := sema2.Acquire(nil); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	token2, err := sema2.FencingToken()
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if token2 <= token {
+		t.Fatalf("token did not increase: %d <= %d", token2, token)
+	}
+
+	// The token of a holder is unchanged by other holders
+	if current, _ := sema.FencingToken(); current != token {
+		t.Fatalf("token changed: %d != %d", current, token)
+	}
+
+	if err := sema.Release(); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if _, err := sema.FencingToken(); err != ErrSemaphoreNotHeld {
+		t.Fatalf("err: %v", err)
+	}
+	if err := sema2.Release(); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+}
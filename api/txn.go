@@ -87,6 +87,10 @@ type KVTxnOp struct {
 	Session   string
 	Namespace string `json:",omitempty"`
 	Partition string `json:",omitempty"`
+
+	// TTL is an optional duration, such as "30s", after which the entry is
+	// deleted. It's only used by the set, cas, lock and unlock verbs.
+	TTL string `json:",omitempty"`
 }
 
 // KVTxnOps defines a set of operations to be performed inside a single
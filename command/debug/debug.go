@@ -345,9 +345,47 @@ func (c *cmd) captureStatic() error {
 			errs = multierror.Append(errs, err)
 		}
 	}
+
+	if c.captureTarget(targetChecks) {
+		checks, err := c.captureChecks()
+		if err != nil {
+			errs = multierror.Append(errs, err)
+		}
+		if err := writeJSONFile(filepath.Join(c.output, targetChecks+".json"), checks); err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	}
 	return errs
 }
 
+// debugCheck is a check of the agent along with its recent results.
+type debugCheck struct {
+	Check   *api.AgentCheck
+	History []*api.AgentCheckResult
+}
+
+// captureChecks returns the checks of the agent along with their history.
+func (c *cmd) captureChecks() (map[string]*debugCheck, error) {
+	checks, err := c.client.Agent().Checks()
+	if err != nil {
+		return nil, err
+	}
+
+	var errs error
+	result := make(map[string]*debugCheck, len(checks))
+	for id, check := range checks {
+		history, err := c.client.Agent().CheckHistory(id, &api.QueryOptions{
+			Namespace: check.Namespace,
+			Partition: check.Partition,
+		})
+		if err != nil {
+			errs = multierror.Append(errs, fmt.Errorf("failed to fetch history of check %q: %w", id, err))
+		}
+		result[id] = &debugCheck{Check: check, History: history}
+	}
+	return result, errs
+}
+
 func writeJSONFile(filename string, content interface{}) error {
 	marshaled, err := json.MarshalIndent(content, "", "\t")
 	if err != nil {
@@ -704,6 +742,7 @@ const (
 	targetHost     = "host"
 	targetAgent    = "agent"
 	targetMembers  = "members"
+	targetChecks   = "checks"
 	// targetCluster is the now deprecated name for targetMembers
 	targetCluster = "cluster"
 )
@@ -716,6 +755,7 @@ var defaultTargets = []string{
 	targetHost,
 	targetAgent,
 	targetMembers,
+	targetChecks,
 }
 
 var deprecatedTargets = []string{targetCluster}
@@ -26,6 +26,7 @@ import (
 	"gotest.tools/v3/fs"
 
 	"github.com/hashicorp/consul/agent"
+	"github.com/hashicorp/consul/api"
 	"github.com/hashicorp/consul/sdk/testutil"
 	"github.com/hashicorp/consul/testrpc"
 )
@@ -78,6 +79,7 @@ func TestDebugCommand(t *testing.T) {
 			fs.WithFile("agent.json", "", fs.MatchFileContent(validJSON)),
 			fs.WithFile("host.json", "", fs.MatchFileContent(validJSON)),
 			fs.WithFile("members.json", "", fs.MatchFileContent(validJSON)),
+			fs.WithFile("checks.json", "", fs.MatchFileContent(validJSON)),
 			fs.WithFile("metrics.json", "", fs.MatchAnyFileContent),
 			fs.WithFile("consul.log", "", fs.MatchFileContent(validLogFile)),
 			fs.WithFile("profile.prof", "", fs.MatchFileContent(validProfileData)),
@@ -203,6 +205,49 @@ func TestDebugCommand_Archive(t *testing.T) {
 	}
 }
 
+func TestDebugCommand_CaptureChecks(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	testDir := testutil.TempDir(t, "debug")
+
+	a := agent.NewTestAgent(t, "")
+	defer a.Shutdown()
+	testrpc.WaitForLeader(t, a.RPC, "dc1")
+
+	client := a.Client()
+	reg := &api.AgentCheckRegistration{Name: "web"}
+	reg.TTL = "15s"
+	require.NoError(t, client.Agent().CheckRegister(reg))
+	require.NoError(t, client.Agent().UpdateTTL("web", "all good", api.HealthPassing))
+
+	ui := cli.NewMockUi()
+	cmd := New(ui)
+	cmd.validateTiming = false
+
+	outputPath := fmt.Sprintf("%s/debug", testDir)
+	args := []string{
+		"-http-addr=" + a.HTTPAddr(),
+		"-output=" + outputPath,
+		"-archive=false",
+		"-capture=checks",
+	}
+
+	code := cmd.Run(args)
+	require.Equal(t, 0, code)
+	require.Equal(t, "", ui.ErrorWriter.String())
+
+	raw, err := os.ReadFile(filepath.Join(outputPath, "checks.json"))
+	require.NoError(t, err)
+	var checks map[string]*debugCheck
+	require.NoError(t, json.Unmarshal(raw, &checks))
+	require.Contains(t, checks, "web")
+	require.Equal(t, api.HealthPassing, checks["web"].Check.Status)
+	require.Len(t, checks["web"].History, 1)
+	require.Equal(t, "all good", checks["web"].History[0].Output)
+}
+
 func TestDebugCommand_ArgsBad(t *testing.T) {
 	ui := cli.NewMockUi()
 	cmd := New(ui)
@@ -337,6 +382,11 @@ func TestDebugCommand_CaptureTargets(t *testing.T) {
 			[]string{"agent.json", "host.json", "members.json"},
 			[]string{"metrics.json"},
 		},
+		"checks-only": {
+			[]string{"checks"},
+			[]string{"checks.json"},
+			[]string{"agent.json", "host.json", "members.json"},
+		},
 		"metrics-only": {
 			[]string{"metrics"},
 			[]string{"metrics.json"},
@@ -0,0 +1,135 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package flags
+
+import (
+	"context"
+	"flag"
+	"os"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/hashicorp/consul/api"
+)
+
+const defaultGRPCAddr = "127.0.0.1:8502"
+
+type GRPCFlags struct {
+	address       StringValue
+	caFile        StringValue
+	caPath        StringValue
+	certFile      StringValue
+	keyFile       StringValue
+	tlsServerName StringValue
+}
+
+// GRPCConfig contains the settings used to connect to Consul's gRPC API.
+type GRPCConfig struct {
+	// Address of the gRPC server. It may be prefixed with http:// or https:// to
+	// explicitly disable or enable TLS.
+	Address string
+
+	// TLSConfig is used to connect if Address has an https:// scheme or a CA is
+	// configured.
+	TLSConfig api.TLSConfig
+}
+
+func (f *GRPCFlags) ClientFlags() *flag.FlagSet {
+	fs := flag.NewFlagSet("", flag.ContinueOnError)
+	fs.Var(&f.address, "grpc-addr",
+		"The `address` and port of the Consul gRPC agent. The value can be an IP "+
+			"address or DNS address, but it must also include the port. This can "+
+			"also be specified via the CONSUL_GRPC_ADDR environment variable. The "+
+			"default value is 127.0.0.1:8502. Prefix the address with https:// to "+
+			"connect using TLS.")
+	fs.Var(&f.caFile, "grpc-ca-file",
+		"Path to a CA file to use for TLS when communicating with Consul's gRPC "+
+			"API. This can also be specified via the CONSUL_GRPC_CACERT environment variable.")
+	fs.Var(&f.caPath, "grpc-ca-path",
+		"Path to a directory of CA certificates to use for TLS when communicating "+
+			"with Consul's gRPC API. This can also be specified via the "+
+			"CONSUL_GRPC_CAPATH environment variable.")
+	fs.Var(&f.certFile, "grpc-client-cert",
+		"Path to a client cert file to use for TLS when 'verify_incoming' is enabled. This "+
+			"can also be specified via the CONSUL_CLIENT_CERT environment variable.")
+	fs.Var(&f.keyFile, "grpc-client-key",
+		"Path to a client key file to use for TLS when 'verify_incoming' is enabled. This "+
+			"can also be specified via the CONSUL_CLIENT_KEY environment variable.")
+	fs.Var(&f.tlsServerName, "grpc-tls-server-name",
+		"The server name to use as the SNI host when connecting via TLS. This "+
+			"can also be specified via the CONSUL_TLS_SERVER_NAME environment variable.")
+	return fs
+}
+
+func (f *GRPCFlags) Addr() string {
+	return f.address.String()
+}
+
+// GRPCConfig returns the configuration from the environment, overlaid with
+// any of the flags that have been set.
+func (f *GRPCFlags) GRPCConfig() *GRPCConfig {
+	c := &GRPCConfig{
+		Address: defaultGRPCAddr,
+		TLSConfig: api.TLSConfig{
+			CAFile:   os.Getenv(api.GRPCCAFileEnvName),
+			CAPath:   os.Getenv(api.GRPCCAPathEnvName),
+			CertFile: os.Getenv(api.HTTPClientCert),
+			KeyFile:  os.Getenv(api.HTTPClientKey),
+			Address:  os.Getenv(api.HTTPTLSServerName),
+		},
+	}
+	if addr := os.Getenv(api.GRPCAddrEnvName); addr != "" {
+		c.Address = addr
+	}
+
+	f.address.Merge(&c.Address)
+	f.caFile.Merge(&c.TLSConfig.CAFile)
+	f.caPath.Merge(&c.TLSConfig.CAPath)
+	f.certFile.Merge(&c.TLSConfig.CertFile)
+	f.keyFile.Merge(&c.TLSConfig.KeyFile)
+	f.tlsServerName.Merge(&c.TLSConfig.Address)
+	return c
+}
+
+// ClientConn dials Consul's gRPC API. If token is non-empty, it is sent as the
+// ACL token with every request.
+func (f *GRPCFlags) ClientConn(token string) (*grpc.ClientConn, error) {
+	c := f.GRPCConfig()
+
+	addr, useTLS := c.Address, c.TLSConfig.CAFile != "" || c.TLSConfig.CAPath != ""
+	switch {
+	case strings.HasPrefix(strings.ToLower(addr), "https://"):
+		addr, useTLS = addr[len("https://"):], true
+	case strings.HasPrefix(strings.ToLower(addr), "http://"):
+		addr, useTLS = addr[len("http://"):], false
+	}
+
+	opts := []grpc.DialOption{grpc.WithPerRPCCredentials(tokenCredentials(token))}
+	if useTLS {
+		tlsConfig, err := api.SetupTLSConfig(&c.TLSConfig)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)))
+	} else {
+		opts = append(opts, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	}
+	return grpc.Dial(addr, opts...)
+}
+
+// tokenCredentials implements credentials.PerRPCCredentials by sending the ACL
+// token in the same metadata key used by Consul's gRPC services.
+type tokenCredentials string
+
+func (t tokenCredentials) GetRequestMetadata(context.Context, ...string) (map[string]string, error) {
+	if t == "" {
+		return nil, nil
+	}
+	return map[string]string{"x-consul-token": string(t)}, nil
+}
+
+func (t tokenCredentials) RequireTransportSecurity() bool { return false }
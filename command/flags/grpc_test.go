@@ -0,0 +1,30 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package flags
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/consul/api"
+)
+
+func TestGRPCFlags_GRPCConfig(t *testing.T) {
+	t.Setenv(api.GRPCAddrEnvName, "https://127.0.0.1:8503")
+	t.Setenv(api.GRPCCAFileEnvName, "ca.pem")
+
+	var f GRPCFlags
+	c := f.GRPCConfig()
+	require.Equal(t, "https://127.0.0.1:8503", c.Address)
+	require.Equal(t, "ca.pem", c.TLSConfig.CAFile)
+
+	fs := f.ClientFlags()
+	require.NoError(t, fs.Parse([]string{"-grpc-addr=10.0.0.1:8502", "-grpc-client-cert=cert.pem"}))
+
+	c = f.GRPCConfig()
+	require.Equal(t, "10.0.0.1:8502", c.Address)
+	require.Equal(t, "ca.pem", c.TLSConfig.CAFile)
+	require.Equal(t, "cert.pem", c.TLSConfig.CertFile)
+}
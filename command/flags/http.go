@@ -101,6 +101,10 @@ func (f *HTTPFlags) Datacenter() string {
 	return f.datacenter.String()
 }
 
+func (f *HTTPFlags) Namespace() string {
+	return f.namespace.String()
+}
+
 func (f *HTTPFlags) Partition() string {
 	return f.partition.String()
 }
@@ -87,6 +87,12 @@ func hclDecode(out interface{}, in string) error {
 	return json.Unmarshal(data, out)
 }
 
+// DecodeHCLOrJSON decodes the given HCL or JSON input into out, in the same way
+// as config entries are decoded.
+func DecodeHCLOrJSON(out interface{}, in string) error {
+	return hclDecode(out, in)
+}
+
 // this is an inlined variant of hcl.lexMode()
 func isHCL(v []byte) bool {
 	var (
@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"io"
 	"text/tabwriter"
+	"time"
 
 	"github.com/hashicorp/consul/api"
 	"github.com/hashicorp/consul/command/flags"
@@ -228,6 +229,9 @@ func prettyKVPair(w io.Writer, pair *api.KVPair, base64EncodeValue bool, keysOnl
 	} else {
 		fmt.Fprintf(tw, "Session\t%s\n", pair.Session)
 	}
+	if pair.ExpirationTime != nil {
+		fmt.Fprintf(tw, "ExpirationTime\t%s\n", pair.ExpirationTime.Format(time.RFC3339))
+	}
 	if pair.Partition != "" {
 		fmt.Fprintf(tw, "Partition\t%s\n", pair.Partition)
 	}
@@ -0,0 +1,139 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package history
+
+import (
+	"encoding/base64"
+	"flag"
+	"fmt"
+
+	"github.com/hashicorp/consul/api"
+	"github.com/hashicorp/consul/command/flags"
+	"github.com/mitchellh/cli"
+	"github.com/ryanuber/columnize"
+)
+
+func New(ui cli.Ui) *cmd {
+	c := &cmd{UI: ui}
+	c.init()
+	return c
+}
+
+type cmd struct {
+	UI           cli.Ui
+	flags        *flag.FlagSet
+	http         *flags.HTTPFlags
+	help         string
+	base64encode bool
+}
+
+func (c *cmd) init() {
+	c.flags = flag.NewFlagSet("", flag.ContinueOnError)
+	c.flags.BoolVar(&c.base64encode, "base64", false,
+		"Base64 encode the values. The default value is false.")
+
+	c.http = &flags.HTTPFlags{}
+	flags.Merge(c.flags, c.http.ClientFlags())
+	flags.Merge(c.flags, c.http.ServerFlags())
+	flags.Merge(c.flags, c.http.MultiTenancyFlags())
+	c.help = flags.Usage(help, c.flags)
+}
+
+func (c *cmd) Run(args []string) int {
+	if err := c.flags.Parse(args); err != nil {
+		return 1
+	}
+
+	key := ""
+
+	// Check for arg validation
+	args = c.flags.Args()
+	switch len(args) {
+	case 0:
+		key = ""
+	case 1:
+		key = args[0]
+	default:
+		c.UI.Error(fmt.Sprintf("Too many arguments (expected 1, got %d)", len(args)))
+		return 1
+	}
+
+	// This is just a "nice" thing to do. Since pairs cannot start with a /, but
+	// users will likely put "/" or "/foo", lets go ahead and strip that for them
+	// here.
+	if len(key) > 0 && key[0] == '/' {
+		key = key[1:]
+	}
+
+	if key == "" {
+		c.UI.Error("Error! Missing KEY argument")
+		return 1
+	}
+
+	// Create and test the HTTP client
+	client, err := c.http.APIClient()
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("Error connecting to Consul agent: %s", err))
+		return 1
+	}
+
+	history, _, err := client.KV().History(key, &api.QueryOptions{
+		AllowStale: c.http.Stale(),
+	})
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("Error querying Consul agent: %s", err))
+		return 1
+	}
+
+	if len(history) == 0 {
+		c.UI.Error(fmt.Sprintf("Error! No history exists for key: %s", key))
+		return 1
+	}
+
+	result := []string{"ModifyIndex\x1fReplacedIndex\x1fDeleted\x1fValue"}
+	for _, rev := range history {
+		replaced := "-"
+		if rev.ReplacedIndex != 0 {
+			replaced = fmt.Sprintf("%d", rev.ReplacedIndex)
+		}
+		value := string(rev.Value)
+		if c.base64encode {
+			value = base64.StdEncoding.EncodeToString(rev.Value)
+		}
+		result = append(result, fmt.Sprintf("%d\x1f%s\x1f%t\x1f%s",
+			rev.ModifyIndex, replaced, rev.Deleted, value))
+	}
+	c.UI.Output(columnize.Format(result, &columnize.Config{Delim: string([]byte{0x1f})}))
+	return 0
+}
+
+func (c *cmd) Synopsis() string {
+	return synopsis
+}
+
+func (c *cmd) Help() string {
+	return c.help
+}
+
+const (
+	synopsis = "Lists the previous revisions of a key in the KV store"
+	help     = `
+Usage: consul kv history [options] KEY
+
+  Lists the current value of a key in Consul's key-value store, followed by
+  the previous revisions of the key that are retained by the servers, newest
+  first. Revisions are only retained for the keys under the prefixes set in
+  the "kv_revisions" configuration of the servers.
+
+  To list the history of the key named "foo":
+
+      $ consul kv history foo
+
+  Each revision is listed with the index at which it was written, and the
+  index at which it was replaced or deleted. To restore a previous revision,
+  use the "consul kv rollback" command.
+
+  For a full list of options and examples, please see the Consul documentation.
+`
+)
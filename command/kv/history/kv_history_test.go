@@ -0,0 +1,103 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package history
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/consul/agent"
+	"github.com/hashicorp/consul/api"
+	"github.com/mitchellh/cli"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKVHistoryCommand_noTabs(t *testing.T) {
+	t.Parallel()
+	if strings.ContainsRune(New(nil).Help(), '\t') {
+		t.Fatal("help has tabs")
+	}
+}
+
+func TestKVHistoryCommand_Validation(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		args   []string
+		output string
+	}{
+		"no key": {
+			[]string{},
+			"Missing KEY argument",
+		},
+		"extra args": {
+			[]string{"foo", "bar", "baz"},
+			"Too many arguments",
+		},
+	}
+
+	for name, tc := range cases {
+		ui := cli.NewMockUi()
+		c := New(ui)
+
+		code := c.Run(tc.args)
+		require.NotEqual(t, 0, code, name)
+		require.Contains(t, ui.ErrorWriter.String(), tc.output, name)
+	}
+}
+
+func TestKVHistoryCommand(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+	a := agent.NewTestAgent(t, `kv_revisions { "" = 5 }`)
+	defer a.Shutdown()
+	client := a.Client()
+
+	for _, value := range []string{"one", "two"} {
+		_, err := client.KV().Put(&api.KVPair{Key: "foo", Value: []byte(value)}, nil)
+		require.NoError(t, err)
+	}
+	_, err := client.KV().Delete("foo", nil)
+	require.NoError(t, err)
+	_, err = client.KV().Put(&api.KVPair{Key: "foo", Value: []byte("three")}, nil)
+	require.NoError(t, err)
+
+	ui := cli.NewMockUi()
+	c := New(ui)
+	code := c.Run([]string{"-http-addr=" + a.HTTPAddr(), "foo"})
+	require.Equal(t, 0, code, ui.ErrorWriter.String())
+
+	lines := strings.Split(strings.TrimSpace(ui.OutputWriter.String()), "\n")
+	require.Len(t, lines, 4)
+	require.Regexp(t, `^ModifyIndex\s+ReplacedIndex\s+Deleted\s+Value$`, lines[0])
+	require.Regexp(t, `^\d+\s+-\s+false\s+three$`, lines[1])
+	require.Regexp(t, `^\d+\s+\d+\s+true\s+two$`, lines[2])
+	require.Regexp(t, `^\d+\s+\d+\s+false\s+one$`, lines[3])
+
+	ui = cli.NewMockUi()
+	c = New(ui)
+	code = c.Run([]string{"-http-addr=" + a.HTTPAddr(), "-base64", "foo"})
+	require.Equal(t, 0, code, ui.ErrorWriter.String())
+	require.Contains(t, ui.OutputWriter.String(), base64.StdEncoding.EncodeToString([]byte("three")))
+}
+
+func TestKVHistoryCommand_Missing(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+	a := agent.NewTestAgent(t, ``)
+	defer a.Shutdown()
+
+	ui := cli.NewMockUi()
+	c := New(ui)
+	code := c.Run([]string{"-http-addr=" + a.HTTPAddr(), "not-a-real-key"})
+	require.Equal(t, 1, code)
+	require.Contains(t, ui.ErrorWriter.String(), "No history exists for key")
+}
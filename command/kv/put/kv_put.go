@@ -8,6 +8,7 @@ import (
 	"flag"
 	"fmt"
 	"io"
+	"time"
 
 	"github.com/hashicorp/consul/api"
 	"github.com/hashicorp/consul/command/flags"
@@ -35,6 +36,7 @@ type cmd struct {
 	session       string
 	acquire       bool
 	release       bool
+	ttl           time.Duration
 
 	// testStdin is the input for testing.
 	testStdin io.Reader
@@ -67,6 +69,10 @@ func (c *cmd) init() {
 		"Forfeit the lock on the key at the given path. This requires the "+
 			"-session flag to be set. The key must be held by the session in order to "+
 			"be unlocked. The default value is false.")
+	c.flags.DurationVar(&c.ttl, "ttl", 0,
+		"Duration after which the key is deleted, such as \"30s\". Writing the "+
+			"key again without -ttl makes it permanent. The default value is 0 "+
+			"(the key never expires).")
 
 	c.http = &flags.HTTPFlags{}
 	flags.Merge(c.flags, c.http.ClientFlags())
@@ -102,6 +108,11 @@ func (c *cmd) Run(args []string) int {
 		return 1
 	}
 
+	if c.ttl < 0 {
+		c.UI.Error("Error! -ttl must not be negative")
+		return 1
+	}
+
 	// Create and test the HTTP client
 	client, err := c.http.APIClient()
 	if err != nil {
@@ -110,11 +121,12 @@ func (c *cmd) Run(args []string) int {
 	}
 
 	pair := &api.KVPair{
-		Key:         key,
-		ModifyIndex: c.modifyIndex,
-		Flags:       c.kvflags,
-		Value:       dataBytes,
-		Session:     c.session,
+		Key:           key,
+		ModifyIndex:   c.modifyIndex,
+		Flags:         c.kvflags,
+		Value:         dataBytes,
+		Session:       c.session,
+		ExpirationTTL: c.ttl,
 	}
 
 	switch {
@@ -234,6 +246,11 @@ Usage: consul kv put [options] KEY [DATA]
 
       $ consul kv put -cas -modify-index=844 config/redis/maxconns 5
 
+  To delete the key automatically, specify a TTL with the -ttl flag. Writing
+  the key again before it expires renews it:
+
+      $ consul kv put -ttl=30s cache/session/abc123 active
+
   Additional flags and more advanced use cases are detailed below.
 `
 )
@@ -10,6 +10,7 @@ import (
 	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/consul/agent"
 	"github.com/hashicorp/consul/api"
@@ -49,6 +50,10 @@ func TestKVPutCommand_Validation(t *testing.T) {
 			[]string{"foo", "bar", "baz"},
 			"Too many arguments",
 		},
+		"negative -ttl": {
+			[]string{"-ttl=-1s", "foo"},
+			"-ttl must not be negative",
+		},
 	}
 
 	for name, tc := range cases {
@@ -346,6 +351,44 @@ func TestKVPutCommand_Flags(t *testing.T) {
 	}
 }
 
+func TestKVPutCommand_TTL(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+	a := agent.NewTestAgent(t, ``)
+	defer a.Shutdown()
+	client := a.Client()
+
+	ui := cli.NewMockUi()
+	c := New(ui)
+
+	start := time.Now()
+	args := []string{
+		"-http-addr=" + a.HTTPAddr(),
+		"-ttl", "1h",
+		"foo", "bar",
+	}
+
+	code := c.Run(args)
+	if code != 0 {
+		t.Fatalf("bad: %d. %#v", code, ui.ErrorWriter.String())
+	}
+
+	data, _, err := client.KV().Get("foo", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if data.ExpirationTime == nil {
+		t.Fatalf("missing expiration time")
+	}
+	if expires := start.Add(time.Hour); data.ExpirationTime.Before(expires.Add(-time.Minute)) || data.ExpirationTime.After(expires.Add(time.Minute)) {
+		t.Errorf("bad: %v", data.ExpirationTime)
+	}
+}
+
 func TestKVPutCommand_CAS(t *testing.T) {
 	if testing.Short() {
 		t.Skip("too slow for testing.Short")
@@ -0,0 +1,156 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package rollback
+
+import (
+	"flag"
+	"fmt"
+
+	"github.com/hashicorp/consul/api"
+	"github.com/hashicorp/consul/command/flags"
+	"github.com/mitchellh/cli"
+)
+
+func New(ui cli.Ui) *cmd {
+	c := &cmd{UI: ui}
+	c.init()
+	return c
+}
+
+type cmd struct {
+	UI    cli.Ui
+	flags *flag.FlagSet
+	http  *flags.HTTPFlags
+	help  string
+	index uint64
+}
+
+func (c *cmd) init() {
+	c.flags = flag.NewFlagSet("", flag.ContinueOnError)
+	c.flags.Uint64Var(&c.index, "index", 0,
+		"Unsigned integer representing the index at which the revision of the "+
+			"key to restore was current, as listed by \"consul kv history\". "+
+			"This flag is required.")
+
+	c.http = &flags.HTTPFlags{}
+	flags.Merge(c.flags, c.http.ClientFlags())
+	flags.Merge(c.flags, c.http.ServerFlags())
+	flags.Merge(c.flags, c.http.MultiTenancyFlags())
+	c.help = flags.Usage(help, c.flags)
+}
+
+func (c *cmd) Run(args []string) int {
+	if err := c.flags.Parse(args); err != nil {
+		return 1
+	}
+
+	key := ""
+
+	// Check for arg validation
+	args = c.flags.Args()
+	switch len(args) {
+	case 0:
+		key = ""
+	case 1:
+		key = args[0]
+	default:
+		c.UI.Error(fmt.Sprintf("Too many arguments (expected 1, got %d)", len(args)))
+		return 1
+	}
+
+	// This is just a "nice" thing to do. Since pairs cannot start with a /, but
+	// users will likely put "/" or "/foo", lets go ahead and strip that for them
+	// here.
+	if len(key) > 0 && key[0] == '/' {
+		key = key[1:]
+	}
+
+	if key == "" {
+		c.UI.Error("Error! Missing KEY argument")
+		return 1
+	}
+
+	if c.index == 0 {
+		c.UI.Error("Must specify the -index of the revision to restore")
+		return 1
+	}
+
+	// Create and test the HTTP client
+	client, err := c.http.APIClient()
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("Error connecting to Consul agent: %s", err))
+		return 1
+	}
+	kv := client.KV()
+
+	// Read the current entry first, so that the rollback fails if the key is
+	// modified in the meantime.
+	current, _, err := kv.Get(key, nil)
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("Error querying Consul agent: %s", err))
+		return 1
+	}
+
+	revision, _, err := kv.GetAtIndex(key, c.index, nil)
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("Error querying Consul agent: %s", err))
+		return 1
+	}
+	if revision == nil {
+		c.UI.Error(fmt.Sprintf("Error! No revision of key %s is retained at index %d", key, c.index))
+		return 1
+	}
+
+	pair := &api.KVPair{
+		Key:   key,
+		Flags: revision.Flags,
+		Value: revision.Value,
+	}
+	if current != nil {
+		pair.ModifyIndex = current.ModifyIndex
+	}
+
+	ok, _, err := kv.CAS(pair, nil)
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("Error! Did not write to %s: %s", key, err))
+		return 1
+	}
+	if !ok {
+		c.UI.Error(fmt.Sprintf("Error! Did not write to %s: key was modified during the rollback", key))
+		return 1
+	}
+
+	c.UI.Info(fmt.Sprintf("Success! Rolled back key %s to its revision at index %d", key, c.index))
+	return 0
+}
+
+func (c *cmd) Synopsis() string {
+	return synopsis
+}
+
+func (c *cmd) Help() string {
+	return c.help
+}
+
+const (
+	synopsis = "Restores a previous revision of a key in the KV store"
+	help     = `
+Usage: consul kv rollback [options] -index=<index> KEY
+
+  Writes the value and flags that a key of Consul's key-value store had at the
+  given index back to the key. The revision must be retained by the servers,
+  which is only the case for the keys under the prefixes set in the
+  "kv_revisions" configuration of the servers.
+
+  To restore the value that the key named "foo" had at index 42:
+
+      $ consul kv rollback -index=42 foo
+
+  Deleted keys are recreated. The rollback fails if the key is modified while
+  it is in progress. The indexes of the previous revisions of a key are listed
+  by the "consul kv history" command.
+
+  For a full list of options and examples, please see the Consul documentation.
+`
+)
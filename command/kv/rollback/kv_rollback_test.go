@@ -0,0 +1,132 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package rollback
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/consul/agent"
+	"github.com/hashicorp/consul/api"
+	"github.com/mitchellh/cli"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKVRollbackCommand_noTabs(t *testing.T) {
+	t.Parallel()
+	if strings.ContainsRune(New(nil).Help(), '\t') {
+		t.Fatal("help has tabs")
+	}
+}
+
+func TestKVRollbackCommand_Validation(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		args   []string
+		output string
+	}{
+		"no key": {
+			[]string{"-index=5"},
+			"Missing KEY argument",
+		},
+		"no index": {
+			[]string{"foo"},
+			"Must specify the -index",
+		},
+		"extra args": {
+			[]string{"foo", "bar", "baz"},
+			"Too many arguments",
+		},
+	}
+
+	for name, tc := range cases {
+		ui := cli.NewMockUi()
+		c := New(ui)
+
+		code := c.Run(tc.args)
+		require.NotEqual(t, 0, code, name)
+		require.Contains(t, ui.ErrorWriter.String(), tc.output, name)
+	}
+}
+
+func TestKVRollbackCommand(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+	a := agent.NewTestAgent(t, `kv_revisions { "" = 5 }`)
+	defer a.Shutdown()
+	client := a.Client()
+
+	_, err := client.KV().Put(&api.KVPair{Key: "foo", Flags: 12, Value: []byte("good")}, nil)
+	require.NoError(t, err)
+	good, _, err := client.KV().Get("foo", nil)
+	require.NoError(t, err)
+	_, err = client.KV().Put(&api.KVPair{Key: "foo", Value: []byte("bad")}, nil)
+	require.NoError(t, err)
+
+	ui := cli.NewMockUi()
+	c := New(ui)
+	code := c.Run([]string{
+		"-http-addr=" + a.HTTPAddr(),
+		"-index=" + strconv.FormatUint(good.ModifyIndex, 10),
+		"foo",
+	})
+	require.Equal(t, 0, code, ui.ErrorWriter.String())
+	require.Contains(t, ui.OutputWriter.String(), "Success! Rolled back key foo")
+
+	pair, _, err := client.KV().Get("foo", nil)
+	require.NoError(t, err)
+	require.Equal(t, []byte("good"), pair.Value)
+	require.Equal(t, uint64(12), pair.Flags)
+
+	// Deleted keys are recreated.
+	_, err = client.KV().Delete("foo", nil)
+	require.NoError(t, err)
+
+	ui = cli.NewMockUi()
+	c = New(ui)
+	code = c.Run([]string{
+		"-http-addr=" + a.HTTPAddr(),
+		"-index=" + strconv.FormatUint(pair.ModifyIndex, 10),
+		"foo",
+	})
+	require.Equal(t, 0, code, ui.ErrorWriter.String())
+
+	pair, _, err = client.KV().Get("foo", nil)
+	require.NoError(t, err)
+	require.NotNil(t, pair)
+	require.Equal(t, []byte("good"), pair.Value)
+}
+
+func TestKVRollbackCommand_NotRetained(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+	a := agent.NewTestAgent(t, ``)
+	defer a.Shutdown()
+	client := a.Client()
+
+	_, err := client.KV().Put(&api.KVPair{Key: "foo", Value: []byte("one")}, nil)
+	require.NoError(t, err)
+	pair, _, err := client.KV().Get("foo", nil)
+	require.NoError(t, err)
+	_, err = client.KV().Put(&api.KVPair{Key: "foo", Value: []byte("two")}, nil)
+	require.NoError(t, err)
+
+	ui := cli.NewMockUi()
+	c := New(ui)
+	code := c.Run([]string{
+		"-http-addr=" + a.HTTPAddr(),
+		"-index=" + strconv.FormatUint(pair.ModifyIndex, 10),
+		"foo",
+	})
+	require.Equal(t, 1, code)
+	require.Contains(t, ui.ErrorWriter.String(), "No revision of key foo is retained")
+}
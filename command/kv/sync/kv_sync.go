@@ -0,0 +1,499 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package sync
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/consul/api"
+	"github.com/hashicorp/consul/command/flags"
+	"github.com/hashicorp/consul/command/kv/impexp"
+	"github.com/mitchellh/cli"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	formatJSON = "json"
+	formatYAML = "yaml"
+	formatDir  = "dir"
+
+	// maxTxnOps is the maximum number of operations applied in a single
+	// transaction, which is the limit of the /v1/txn endpoint.
+	maxTxnOps = 128
+
+	// maxTxnValueBytes is the maximum size of the values written in a single
+	// transaction. It leaves room for the base64 encoding of the values within
+	// the default limit of the /v1/txn request size.
+	maxTxnValueBytes = 256 * 1024
+)
+
+func New(ui cli.Ui) *cmd {
+	c := &cmd{UI: ui}
+	c.init()
+	return c
+}
+
+type cmd struct {
+	UI     cli.Ui
+	flags  *flag.FlagSet
+	http   *flags.HTTPFlags
+	help   string
+	prefix string
+	format string
+	prune  bool
+	dryRun bool
+
+	// testStdin is the input for testing.
+	testStdin io.Reader
+}
+
+func (c *cmd) init() {
+	c.flags = flag.NewFlagSet("", flag.ContinueOnError)
+	c.flags.StringVar(&c.prefix, "prefix", "",
+		"Key prefix that the local tree is synced to.")
+	c.flags.StringVar(&c.format, "format", "",
+		"Format of the local tree. Must be one of \"json\", \"yaml\" or \"dir\". "+
+			"Defaults to \"dir\" for directories, \"yaml\" for files with a .yaml "+
+			"or .yml extension, and \"json\" otherwise.")
+	c.flags.BoolVar(&c.prune, "prune", false,
+		"Delete the keys under the prefix which are not present in the local tree. "+
+			"Requires a non-empty -prefix.")
+	c.flags.BoolVar(&c.dryRun, "dry-run", false,
+		"Only print the changes that would be made, without applying them.")
+
+	c.http = &flags.HTTPFlags{}
+	flags.Merge(c.flags, c.http.ClientFlags())
+	flags.Merge(c.flags, c.http.ServerFlags())
+	flags.Merge(c.flags, c.http.MultiTenancyFlags())
+	c.help = flags.Usage(help, c.flags)
+}
+
+func (c *cmd) Run(args []string) int {
+	if err := c.flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Check for arg validation
+	args = c.flags.Args()
+	switch len(args) {
+	case 0:
+		c.UI.Error("Error! Missing SOURCE argument")
+		return 1
+	case 1:
+	default:
+		c.UI.Error(fmt.Sprintf("Too many arguments (expected 1, got %d)", len(args)))
+		return 1
+	}
+
+	switch c.format {
+	case "", formatJSON, formatYAML, formatDir:
+	default:
+		c.UI.Error(fmt.Sprintf("Invalid format %q, must be one of \"json\", \"yaml\" or \"dir\"", c.format))
+		return 1
+	}
+
+	// Since pairs cannot start with a /, strip it from the prefix like the
+	// other commands do for keys.
+	c.prefix = strings.TrimPrefix(c.prefix, "/")
+
+	// Pruning without a prefix would delete every other key of the KV store.
+	if c.prune && c.prefix == "" {
+		c.UI.Error("Error! -prune requires a non-empty -prefix")
+		return 1
+	}
+
+	local, err := c.loadSource(args[0])
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("Error! %s", err))
+		return 1
+	}
+
+	// Create and test the HTTP client
+	client, err := c.http.APIClient()
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("Error connecting to Consul agent: %s", err))
+		return 1
+	}
+
+	listPrefix := c.prefix
+	if listPrefix != "" && !strings.HasSuffix(listPrefix, "/") {
+		listPrefix += "/"
+	}
+	remote, _, err := client.KV().List(listPrefix, &api.QueryOptions{
+		AllowStale: c.http.Stale(),
+	})
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("Error querying Consul agent: %s", err))
+		return 1
+	}
+
+	changes := c.plan(local, remote, listPrefix)
+	if len(changes) == 0 {
+		c.UI.Info("No changes. The KV store matches the local tree.")
+		return 0
+	}
+
+	var adds, updates, deletes int
+	for _, change := range changes {
+		switch change.op {
+		case opAdd:
+			adds++
+		case opUpdate:
+			updates++
+		case opDelete:
+			deletes++
+		}
+		c.UI.Output(fmt.Sprintf("%s %s", change.op, change.key))
+	}
+	c.UI.Output(fmt.Sprintf("\nPlan: %d to add, %d to update, %d to delete.", adds, updates, deletes))
+
+	if c.dryRun {
+		return 0
+	}
+
+	if err := applyChanges(client.Txn(), changes); err != nil {
+		c.UI.Error(fmt.Sprintf("Error! %s", err))
+		return 1
+	}
+
+	c.UI.Info(fmt.Sprintf("Success! Applied %d changes", len(changes)))
+	return 0
+}
+
+// entry is the flags and value of a key of the local tree.
+type entry struct {
+	Flags uint64
+	Value []byte
+}
+
+// loadSource reads the local tree from the given source, keyed by the keys
+// relative to the prefix.
+func (c *cmd) loadSource(source string) (map[string]*entry, error) {
+	format := c.format
+
+	var data []byte
+	if source == "-" {
+		var stdin io.Reader = os.Stdin
+		if c.testStdin != nil {
+			stdin = c.testStdin
+		}
+		var b bytes.Buffer
+		if _, err := io.Copy(&b, stdin); err != nil {
+			return nil, fmt.Errorf("Failed to read stdin: %s", err)
+		}
+		data = b.Bytes()
+	} else {
+		info, err := os.Stat(source)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to read source: %s", err)
+		}
+		if format == "" {
+			switch {
+			case info.IsDir():
+				format = formatDir
+			case strings.HasSuffix(source, ".yaml"), strings.HasSuffix(source, ".yml"):
+				format = formatYAML
+			}
+		}
+		if format == formatDir {
+			if !info.IsDir() {
+				return nil, fmt.Errorf("Source %s is not a directory", source)
+			}
+			return loadDir(source)
+		}
+		if data, err = os.ReadFile(source); err != nil {
+			return nil, fmt.Errorf("Failed to read file: %s", err)
+		}
+	}
+
+	switch format {
+	case formatYAML:
+		return loadYAML(data)
+	case formatDir:
+		return nil, fmt.Errorf("The %q format can't be read from stdin", formatDir)
+	default:
+		return loadJSON(data)
+	}
+}
+
+// loadJSON reads the JSON representation generated by "consul kv export".
+func loadJSON(data []byte) (map[string]*entry, error) {
+	var entries []*impexp.Entry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("Cannot unmarshal data: %s", err)
+	}
+
+	tree := make(map[string]*entry, len(entries))
+	for _, e := range entries {
+		value, err := base64.StdEncoding.DecodeString(e.Value)
+		if err != nil {
+			return nil, fmt.Errorf("Error base 64 decoding value for key %s: %s", e.Key, err)
+		}
+		tree[e.Key] = &entry{Flags: e.Flags, Value: value}
+	}
+	return tree, nil
+}
+
+// loadYAML reads a YAML document whose nested mappings are joined into keys.
+// Scalars are the values of the keys and empty mappings are folders.
+func loadYAML(data []byte) (map[string]*entry, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("Cannot unmarshal data: %s", err)
+	}
+
+	tree := make(map[string]*entry)
+	if len(doc.Content) == 0 {
+		return tree, nil
+	}
+	if err := flattenYAML(tree, "", doc.Content[0]); err != nil {
+		return nil, err
+	}
+	return tree, nil
+}
+
+func flattenYAML(tree map[string]*entry, key string, node *yaml.Node) error {
+	switch node.Kind {
+	case yaml.MappingNode:
+		if len(node.Content) == 0 && key != "" {
+			tree[key+"/"] = &entry{}
+			return nil
+		}
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			name := node.Content[i].Value
+			if key != "" {
+				name = key + "/" + name
+			}
+			if err := flattenYAML(tree, name, node.Content[i+1]); err != nil {
+				return err
+			}
+		}
+		return nil
+	case yaml.ScalarNode:
+		if key == "" {
+			return fmt.Errorf("Cannot unmarshal data: the document must be a mapping")
+		}
+		e := &entry{}
+		if node.Tag != "!!null" {
+			e.Value = []byte(node.Value)
+		}
+		tree[key] = e
+		return nil
+	case yaml.AliasNode:
+		return flattenYAML(tree, key, node.Alias)
+	default:
+		return fmt.Errorf("Cannot unmarshal data: unsupported value for key %q at line %d", key, node.Line)
+	}
+}
+
+// loadDir reads the files of a directory, using their paths relative to the
+// directory as keys. Files and directories whose names start with a "." are
+// skipped.
+func loadDir(dir string) (map[string]*entry, error) {
+	tree := make(map[string]*entry)
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if p != dir && strings.HasPrefix(d.Name(), ".") {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.IsDir() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		value, err := os.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		tree[filepath.ToSlash(rel)] = &entry{Value: value}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read directory: %s", err)
+	}
+	return tree, nil
+}
+
+const (
+	opAdd    = "+"
+	opUpdate = "~"
+	opDelete = "-"
+)
+
+// change is a write needed for the KV store to match the local tree. The index
+// is the ModifyIndex of the key observed when planning, which is used for the
+// Check-And-Set of the write.
+type change struct {
+	op    string
+	key   string
+	entry *entry
+	index uint64
+}
+
+// plan computes the changes needed for the keys under listPrefix to match the
+// local tree, sorted by key.
+func (c *cmd) plan(local map[string]*entry, remote api.KVPairs, listPrefix string) []*change {
+	existing := make(map[string]*api.KVPair, len(remote))
+	for _, pair := range remote {
+		existing[pair.Key] = pair
+	}
+
+	var changes []*change
+	wanted := make(map[string]struct{}, len(local))
+	for key, e := range local {
+		key = c.fullKey(key)
+		wanted[key] = struct{}{}
+
+		pair, ok := existing[key]
+		switch {
+		case !ok:
+			changes = append(changes, &change{op: opAdd, key: key, entry: e})
+		case pair.Flags != e.Flags || !bytes.Equal(pair.Value, e.Value):
+			changes = append(changes, &change{op: opUpdate, key: key, entry: e, index: pair.ModifyIndex})
+		}
+	}
+
+	if c.prune {
+		for _, pair := range remote {
+			// The folder of the prefix itself is left alone.
+			if pair.Key == listPrefix {
+				continue
+			}
+			if _, ok := wanted[pair.Key]; !ok {
+				changes = append(changes, &change{op: opDelete, key: pair.Key, index: pair.ModifyIndex})
+			}
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].key < changes[j].key
+	})
+	return changes
+}
+
+// fullKey joins the key of the local tree with the prefix the same way
+// "consul kv import" does.
+func (c *cmd) fullKey(key string) string {
+	full := path.Join(c.prefix, key)
+	// if the key is a directory, we need to append /
+	if strings.HasSuffix(key, "/") {
+		full += "/"
+	}
+	return full
+}
+
+// applyChanges writes the changes in transactions of up to maxTxnOps
+// operations. Each write is a Check-And-Set on the index observed when
+// planning, so a transaction is rolled back if any of its keys was modified
+// since. Transactions applied before a failed one are not rolled back.
+func applyChanges(txn *api.Txn, changes []*change) error {
+	var (
+		ops     api.TxnOps
+		size    int
+		applied int
+	)
+
+	flush := func() error {
+		if len(ops) == 0 {
+			return nil
+		}
+		ok, resp, _, err := txn.Txn(ops, nil)
+		if err != nil {
+			return fmt.Errorf("Failed applying changes: %s", err)
+		}
+		if !ok {
+			var errs []string
+			for _, txnErr := range resp.Errors {
+				key := changes[applied+txnErr.OpIndex].key
+				errs = append(errs, fmt.Sprintf("%s: %s", key, txnErr.What))
+			}
+			return fmt.Errorf("Failed applying changes after %d of %d were applied, the keys may have been modified since the plan:\n%s",
+				applied, len(changes), strings.Join(errs, "\n"))
+		}
+		applied += len(ops)
+		ops, size = nil, 0
+		return nil
+	}
+
+	for _, change := range changes {
+		op := &api.KVTxnOp{Key: change.key, Index: change.index}
+		switch change.op {
+		case opDelete:
+			op.Verb = api.KVDeleteCAS
+		default:
+			op.Verb = api.KVCAS
+			op.Flags = change.entry.Flags
+			op.Value = change.entry.Value
+		}
+
+		if len(ops) == maxTxnOps || (len(ops) > 0 && size+len(op.Value) > maxTxnValueBytes) {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+		ops = append(ops, &api.TxnOp{KV: op})
+		size += len(op.Value)
+	}
+	return flush()
+}
+
+func (c *cmd) Synopsis() string {
+	return synopsis
+}
+
+func (c *cmd) Help() string {
+	return c.help
+}
+
+const (
+	synopsis = "Syncs a prefix of the KV store with a local tree"
+	help     = `
+Usage: consul kv sync [options] SOURCE
+
+  Makes the keys of Consul's key-value store under a prefix match a local tree,
+  and prints the keys that are added (+), updated (~) and deleted (-).
+
+  The local tree can be the JSON representation generated by the
+  "consul kv export" command, a YAML document whose nested mappings are joined
+  into keys, or a directory whose file paths are the keys:
+
+      $ consul kv sync -prefix=config/ config.json
+      $ consul kv sync -prefix=config/ config.yaml
+      $ consul kv sync -prefix=config/ ./config
+
+  JSON and YAML can also be read from stdin using the "-" symbol.
+
+  To also delete the keys under the prefix which are not in the local tree:
+
+      $ consul kv sync -prefix=config/ -prune ./config
+
+  To only print the changes without applying them:
+
+      $ consul kv sync -prefix=config/ -dry-run ./config
+
+  The changes are applied in transactions using Check-And-Set operations, so
+  they fail if the keys are modified after the changes were computed.
+
+  For a full list of options and examples, please see the Consul documentation.
+`
+)
@@ -0,0 +1,274 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package sync
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/consul/agent"
+	"github.com/hashicorp/consul/api"
+	"github.com/mitchellh/cli"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKVSyncCommand_noTabs(t *testing.T) {
+	t.Parallel()
+	if strings.ContainsRune(New(nil).Help(), '\t') {
+		t.Fatal("help has tabs")
+	}
+}
+
+func TestKVSyncCommand_Validation(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		args   []string
+		output string
+	}{
+		"no source": {
+			[]string{},
+			"Missing SOURCE argument",
+		},
+		"extra args": {
+			[]string{"foo", "bar"},
+			"Too many arguments",
+		},
+		"bad format": {
+			[]string{"-format=toml", "foo"},
+			"Invalid format",
+		},
+		"dir from stdin": {
+			[]string{"-format=dir", "-"},
+			"can't be read from stdin",
+		},
+		"prune without prefix": {
+			[]string{"-prune", "foo.json"},
+			"-prune requires a non-empty -prefix",
+		},
+		"prune the root": {
+			[]string{"-prune", "-prefix=/", "foo.json"},
+			"-prune requires a non-empty -prefix",
+		},
+		"missing source": {
+			[]string{filepath.Join(t.TempDir(), "missing.json")},
+			"Failed to read source",
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			ui := cli.NewMockUi()
+			c := New(ui)
+			c.testStdin = strings.NewReader("")
+
+			code := c.Run(tc.args)
+			require.Equal(t, 1, code)
+			require.Contains(t, ui.ErrorWriter.String(), tc.output)
+		})
+	}
+}
+
+func TestLoadYAML(t *testing.T) {
+	t.Parallel()
+
+	tree, err := loadYAML([]byte(`
+redis:
+  port: 6379
+  host: redis.local
+  empty:
+  replicas: {}
+flag: true
+`))
+	require.NoError(t, err)
+
+	values := make(map[string]string)
+	for key, e := range tree {
+		values[key] = string(e.Value)
+	}
+	require.Equal(t, map[string]string{
+		"redis/port":      "6379",
+		"redis/host":      "redis.local",
+		"redis/empty":     "",
+		"redis/replicas/": "",
+		"flag":            "true",
+	}, values)
+
+	_, err = loadYAML([]byte("- foo\n- bar\n"))
+	require.Error(t, err)
+
+	_, err = loadYAML([]byte("foo: [a, b]\n"))
+	require.Error(t, err)
+}
+
+func TestLoadDir(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "redis"), 0755))
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, ".git"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "redis", "port"), []byte("6379"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "flag"), []byte("true"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".hidden"), []byte("x"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".git", "HEAD"), []byte("x"), 0644))
+
+	tree, err := loadDir(dir)
+	require.NoError(t, err)
+
+	values := make(map[string]string)
+	for key, e := range tree {
+		values[key] = string(e.Value)
+	}
+	require.Equal(t, map[string]string{
+		"redis/port": "6379",
+		"flag":       "true",
+	}, values)
+}
+
+func TestKVSyncCommand(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+	a := agent.NewTestAgent(t, ``)
+	defer a.Shutdown()
+	kv := a.Client().KV()
+
+	put := func(key, value string) {
+		_, err := kv.Put(&api.KVPair{Key: key, Value: []byte(value)}, nil)
+		require.NoError(t, err)
+	}
+	get := func(key string) *api.KVPair {
+		pair, _, err := kv.Get(key, nil)
+		require.NoError(t, err)
+		return pair
+	}
+
+	put("config/same", "same")
+	put("config/changed", "old")
+	put("config/extra", "extra")
+	put("configuration", "outside")
+
+	// JSON representation of same=same, changed=new, added=added.
+	const json = `[
+		{"key": "same", "flags": 0, "value": "c2FtZQ=="},
+		{"key": "changed", "flags": 0, "value": "bmV3"},
+		{"key": "added", "flags": 0, "value": "YWRkZWQ="}
+	]`
+
+	run := func(t *testing.T, args ...string) string {
+		t.Helper()
+		ui := cli.NewMockUi()
+		c := New(ui)
+		c.testStdin = strings.NewReader(json)
+
+		code := c.Run(append([]string{"-http-addr=" + a.HTTPAddr()}, args...))
+		require.Equal(t, 0, code, ui.ErrorWriter.String())
+		return ui.OutputWriter.String()
+	}
+
+	t.Run("dry run", func(t *testing.T) {
+		output := run(t, "-prefix=config", "-prune", "-dry-run", "-")
+		require.Equal(t, "+ config/added\n~ config/changed\n- config/extra\n\nPlan: 1 to add, 1 to update, 1 to delete.\n", output)
+
+		require.Nil(t, get("config/added"))
+		require.Equal(t, "old", string(get("config/changed").Value))
+	})
+
+	t.Run("without prune", func(t *testing.T) {
+		output := run(t, "-prefix=config", "-")
+		require.Contains(t, output, "Plan: 1 to add, 1 to update, 0 to delete.")
+
+		require.Equal(t, "added", string(get("config/added").Value))
+		require.Equal(t, "new", string(get("config/changed").Value))
+		require.Equal(t, "extra", string(get("config/extra").Value))
+	})
+
+	t.Run("prune", func(t *testing.T) {
+		output := run(t, "-prefix=config", "-prune", "-")
+		require.Contains(t, output, "Plan: 0 to add, 0 to update, 1 to delete.")
+
+		require.Nil(t, get("config/extra"))
+		require.Equal(t, "outside", string(get("configuration").Value))
+	})
+
+	t.Run("no changes", func(t *testing.T) {
+		output := run(t, "-prefix=config", "-prune", "-")
+		require.Contains(t, output, "No changes")
+	})
+
+	t.Run("directory", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, "sub"), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "sub", "key"), []byte("value"), 0644))
+
+		run(t, "-prefix=dir/", dir)
+		require.Equal(t, "value", string(get("dir/sub/key").Value))
+	})
+}
+
+func TestApplyChanges_CAS(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+	a := agent.NewTestAgent(t, ``)
+	defer a.Shutdown()
+	client := a.Client()
+	kv := client.KV()
+
+	_, err := kv.Put(&api.KVPair{Key: "foo", Value: []byte("a")}, nil)
+	require.NoError(t, err)
+	pair, _, err := kv.Get("foo", nil)
+	require.NoError(t, err)
+
+	c := New(cli.NewMockUi())
+	changes := c.plan(map[string]*entry{
+		"foo": {Value: []byte("b")},
+		"bar": {Value: []byte("b")},
+	}, api.KVPairs{pair}, "")
+	require.Len(t, changes, 2)
+
+	// Modify the key after the plan was computed.
+	_, err = kv.Put(&api.KVPair{Key: "foo", Value: []byte("c")}, nil)
+	require.NoError(t, err)
+
+	err = applyChanges(client.Txn(), changes)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "foo")
+
+	// The whole transaction was rolled back.
+	bar, _, err := kv.Get("bar", nil)
+	require.NoError(t, err)
+	require.Nil(t, bar)
+}
+
+func TestApplyChanges_Chunks(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+	a := agent.NewTestAgent(t, ``)
+	defer a.Shutdown()
+	client := a.Client()
+
+	local := make(map[string]*entry)
+	for i := 0; i < maxTxnOps+10; i++ {
+		local[strings.Repeat("k", i+1)] = &entry{Value: []byte("v")}
+	}
+
+	c := New(cli.NewMockUi())
+	changes := c.plan(local, nil, "")
+	require.NoError(t, applyChanges(client.Txn(), changes))
+
+	keys, _, err := client.KV().Keys("", "", nil)
+	require.NoError(t, err)
+	require.Len(t, keys, maxTxnOps+10)
+}
@@ -60,6 +60,7 @@ type cmd struct {
 	name               string
 	passStdin          bool
 	propagateChildCode bool
+	shared             bool
 	shell              bool
 	timeout            time.Duration
 }
@@ -91,6 +92,12 @@ func (c *cmd) init() {
 			"is generated based on the provided child command.")
 	c.flags.BoolVar(&c.passStdin, "pass-stdin", false,
 		"Pass stdin to the child process.")
+	c.flags.BoolVar(&c.shared, "shared", false,
+		"Use a reader/writer lock, held in shared mode if true or in exclusive "+
+			"mode if false. Shared holders run concurrently, and exclusive holders "+
+			"have preference over new shared holders. If this flag is not set, a "+
+			"regular lock or semaphore is used, which can't be mixed with a "+
+			"reader/writer lock at the same prefix.")
 	c.flags.BoolVar(&c.shell, "shell", true,
 		"Use a shell to run the command (can set a custom shell via the SHELL "+
 			"environment variable).")
@@ -126,6 +133,18 @@ func (c *cmd) run(args []string, lu **LockUnlock) int {
 		return 1
 	}
 
+	// Use a reader/writer lock if -shared is set, either way
+	rwLock := false
+	c.flags.Visit(func(f *flag.Flag) {
+		if f.Name == "shared" {
+			rwLock = true
+		}
+	})
+	if rwLock && c.limit != 1 {
+		c.UI.Error("The -shared and -n flags can't be used together")
+		return 1
+	}
+
 	// Verify the prefix and child are provided
 	extra := c.flags.Args()
 	if len(extra) < 2 {
@@ -167,7 +186,9 @@ func (c *cmd) run(args []string, lu **LockUnlock) int {
 	}
 
 	// Setup the lock or semaphore
-	if c.limit == 1 {
+	if rwLock {
+		*lu, err = c.setupRWLock(client, prefix, c.name, c.shared, oneshot, c.timeout, c.monitorRetry)
+	} else if c.limit == 1 {
 		*lu, err = c.setupLock(client, prefix, c.name, oneshot, c.timeout, c.monitorRetry)
 	} else {
 		*lu, err = c.setupSemaphore(client, c.limit, prefix, c.name, oneshot, c.timeout, c.monitorRetry)
@@ -191,9 +212,20 @@ func (c *cmd) run(args []string, lu **LockUnlock) int {
 		return 1
 	}
 
-	// Check if we were shutdown but managed to still acquire the lock
+	var token uint64
+	var tokenErr error
 	var childCode int
 	var childErr chan error
+
+	// Get the fencing token to pass to the child, the lock is held so it
+	// must be released if that fails
+	token, tokenErr = (*lu).tokenFn()
+	if tokenErr != nil {
+		c.UI.Error(fmt.Sprintf("Lock acquisition failed: %s", tokenErr))
+		goto RELEASE
+	}
+
+	// Check if we were shutdown but managed to still acquire the lock
 	select {
 	case <-c.ShutdownCh:
 		c.UI.Error("Shutdown triggered during lock acquisition")
@@ -204,7 +236,7 @@ func (c *cmd) run(args []string, lu **LockUnlock) int {
 	// Start the child process
 	childErr = make(chan error, 1)
 	go func() {
-		childErr <- c.startChild(c.flags.Args()[1:], c.passStdin, c.shell)
+		childErr <- c.startChild(c.flags.Args()[1:], token, c.passStdin, c.shell)
 	}()
 
 	// Monitor for shutdown, child termination, or lock loss
@@ -255,6 +287,11 @@ RELEASE:
 		c.UI.Info("Cleanup succeeded")
 	}
 
+	// The child was never started without a fencing token.
+	if tokenErr != nil {
+		return 1
+	}
+
 	// If we detected an error from the child process then we propagate
 	// that.
 	if c.propagateChildCode {
@@ -296,6 +333,7 @@ func (c *cmd) setupLock(client *api.Client, prefix, name string,
 		lockFn:    l.Lock,
 		unlockFn:  l.Unlock,
 		cleanupFn: l.Destroy,
+		tokenFn:   l.FencingToken,
 		inUseErr:  api.ErrLockInUse,
 		rawOpts:   &opts,
 	}
@@ -331,15 +369,59 @@ func (c *cmd) setupSemaphore(client *api.Client, limit int, prefix, name string,
 		lockFn:    s.Acquire,
 		unlockFn:  s.Release,
 		cleanupFn: s.Destroy,
+		tokenFn:   s.FencingToken,
 		inUseErr:  api.ErrSemaphoreInUse,
 		rawOpts:   &opts,
 	}
 	return lu, nil
 }
 
+// setupRWLock is used to setup a new RWLock given the API client, key prefix,
+// session name, and whether the lock is held in shared or exclusive mode. If
+// oneshot is true then we will set up for a single attempt at acquisition,
+// using the given wait time. The retry parameter sets how many 500 errors the
+// lock monitor will tolerate before giving up the lock.
+func (c *cmd) setupRWLock(client *api.Client, prefix, name string, shared bool,
+	oneshot bool, wait time.Duration, retry int) (*LockUnlock, error) {
+	if c.verbose {
+		mode := "exclusive"
+		if shared {
+			mode = "shared"
+		}
+		c.UI.Info(fmt.Sprintf("Setting up reader/writer lock (%s) at prefix: %s", mode, prefix))
+	}
+	opts := api.RWLockOptions{
+		Prefix:           prefix,
+		SessionName:      name,
+		MonitorRetries:   retry,
+		MonitorRetryTime: defaultMonitorRetryTime,
+	}
+	if oneshot {
+		opts.LockTryOnce = true
+		opts.LockWaitTime = wait
+	}
+	l, err := client.RWLockOpts(&opts)
+	if err != nil {
+		return nil, err
+	}
+	lu := &LockUnlock{
+		lockFn:    l.Lock,
+		unlockFn:  l.Unlock,
+		cleanupFn: l.Destroy,
+		tokenFn:   l.FencingToken,
+		inUseErr:  api.ErrRWLockInUse,
+		rawOpts:   &opts,
+	}
+	if shared {
+		lu.lockFn = l.RLock
+		lu.unlockFn = l.RUnlock
+	}
+	return lu, nil
+}
+
 // startChild is a long running routine used to start and
 // wait for the child process to exit.
-func (c *cmd) startChild(args []string, passStdin, shell bool) error {
+func (c *cmd) startChild(args []string, token uint64, passStdin, shell bool) error {
 	if c.verbose {
 		c.UI.Info("Starting handler")
 	}
@@ -360,6 +442,7 @@ func (c *cmd) startChild(args []string, passStdin, shell bool) error {
 	// Setup the command streams
 	cmd.Env = append(os.Environ(),
 		"CONSUL_LOCK_HELD=true",
+		fmt.Sprintf("CONSUL_LOCK_FENCING_TOKEN=%d", token),
 	)
 	if passStdin {
 		if c.verbose {
@@ -463,6 +546,7 @@ type LockUnlock struct {
 	lockFn    func(<-chan struct{}) (<-chan struct{}, error)
 	unlockFn  func() error
 	cleanupFn func() error
+	tokenFn   func() (uint64, error)
 	inUseErr  error
 	rawOpts   interface{}
 }
@@ -484,5 +568,14 @@ Usage: consul lock [options] prefix child...
   exclusion. Setting a higher value switches to a semaphore allowing multiple
   holders to coordinate.
 
+  When -shared is set, a reader/writer lock is used instead. Any number of
+  child processes run with -shared=true at the same time, while a child run
+  with -shared=false runs alone.
+
+  The child process can pass the CONSUL_LOCK_FENCING_TOKEN environment
+  variable along to the systems it writes to. It increases every time the
+  lock is acquired, so those systems can reject the writes of a previous
+  holder which lost the lock.
+
   The prefix provided must have write privileges.
 `
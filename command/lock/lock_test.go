@@ -6,6 +6,7 @@ package lock
 import (
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
@@ -41,6 +42,7 @@ func TestLockCommand_BadArgs(t *testing.T) {
 	argFail(t, []string{"-try=blah", "test/prefix", "date"}, "parse error")
 	argFail(t, []string{"-try=-10s", "test/prefix", "date"}, "Timeout must be positive")
 	argFail(t, []string{"-monitor-retry=-5", "test/prefix", "date"}, "must be >= 0")
+	argFail(t, []string{"-shared", "-n=3", "test/prefix", "date"}, "can't be used together")
 }
 
 func TestLockCommand(t *testing.T) {
@@ -177,6 +179,78 @@ func TestLockCommand_TrySemaphore(t *testing.T) {
 	}
 }
 
+func TestLockCommand_Shared(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+	a := agent.NewTestAgent(t, ``)
+	defer a.Shutdown()
+
+	testrpc.WaitForTestAgent(t, a.RPC, "dc1")
+
+	for _, shared := range []string{"true", "false"} {
+		ui := cli.NewMockUi()
+		c := New(ui, nil)
+
+		filePath := filepath.Join(a.Config.DataDir, "test_touch_"+shared)
+		args := []string{"-http-addr=" + a.HTTPAddr(), "-shared=" + shared, "-try=10s", "test/prefix", "touch", filePath}
+
+		// Run the command.
+		var lu *LockUnlock
+		code := c.run(args, &lu)
+		if code != 0 {
+			t.Fatalf("bad: %d. %#v", code, ui.ErrorWriter.String())
+		}
+		_, err := os.ReadFile(filePath)
+		if err != nil {
+			t.Fatalf("err: %v", err)
+		}
+
+		// Make sure a reader/writer lock was used.
+		opts, ok := lu.rawOpts.(*api.RWLockOptions)
+		if !ok {
+			t.Fatalf("bad type")
+		}
+		if !opts.LockTryOnce || opts.LockWaitTime != 10*time.Second {
+			t.Fatalf("bad: %#v", opts)
+		}
+	}
+}
+
+func TestLockCommand_FencingToken(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+	a := agent.NewTestAgent(t, ``)
+	defer a.Shutdown()
+
+	testrpc.WaitForTestAgent(t, a.RPC, "dc1")
+
+	ui := cli.NewMockUi()
+	c := New(ui, nil)
+
+	filePath := filepath.Join(a.Config.DataDir, "test_token")
+	args := []string{"-http-addr=" + a.HTTPAddr(), "test/prefix", "echo $CONSUL_LOCK_FENCING_TOKEN > " + filePath}
+
+	code := c.Run(args)
+	if code != 0 {
+		t.Fatalf("bad: %d. %#v", code, ui.ErrorWriter.String())
+	}
+
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	token, err := strconv.ParseUint(strings.TrimSpace(string(data)), 10, 64)
+	if err != nil || token == 0 {
+		t.Fatalf("bad token %q: %v", data, err)
+	}
+}
+
 func TestLockCommand_MonitorRetry_Lock_Default(t *testing.T) {
 	if testing.Short() {
 		t.Skip("too slow for testing.Short")
@@ -81,8 +81,11 @@ import (
 	kvdel "github.com/hashicorp/consul/command/kv/del"
 	kvexp "github.com/hashicorp/consul/command/kv/exp"
 	kvget "github.com/hashicorp/consul/command/kv/get"
+	kvhistory "github.com/hashicorp/consul/command/kv/history"
 	kvimp "github.com/hashicorp/consul/command/kv/imp"
 	kvput "github.com/hashicorp/consul/command/kv/put"
+	kvrollback "github.com/hashicorp/consul/command/kv/rollback"
+	kvsync "github.com/hashicorp/consul/command/kv/sync"
 	"github.com/hashicorp/consul/command/leave"
 	"github.com/hashicorp/consul/command/lock"
 	"github.com/hashicorp/consul/command/login"
@@ -108,6 +111,12 @@ import (
 	peerlist "github.com/hashicorp/consul/command/peering/list"
 	peerread "github.com/hashicorp/consul/command/peering/read"
 	"github.com/hashicorp/consul/command/reload"
+	"github.com/hashicorp/consul/command/resource"
+	resourceapply "github.com/hashicorp/consul/command/resource/apply"
+	resourcedelete "github.com/hashicorp/consul/command/resource/delete"
+	resourcelist "github.com/hashicorp/consul/command/resource/list"
+	resourceread "github.com/hashicorp/consul/command/resource/read"
+	resourcewatch "github.com/hashicorp/consul/command/resource/watch"
 	"github.com/hashicorp/consul/command/rtt"
 	"github.com/hashicorp/consul/command/services"
 	svcsderegister "github.com/hashicorp/consul/command/services/deregister"
@@ -210,8 +219,11 @@ func RegisteredCommands(ui cli.Ui) map[string]mcli.CommandFactory {
 		entry{"kv delete", func(ui cli.Ui) (cli.Command, error) { return kvdel.New(ui), nil }},
 		entry{"kv export", func(ui cli.Ui) (cli.Command, error) { return kvexp.New(ui), nil }},
 		entry{"kv get", func(ui cli.Ui) (cli.Command, error) { return kvget.New(ui), nil }},
+		entry{"kv history", func(ui cli.Ui) (cli.Command, error) { return kvhistory.New(ui), nil }},
 		entry{"kv import", func(ui cli.Ui) (cli.Command, error) { return kvimp.New(ui), nil }},
 		entry{"kv put", func(ui cli.Ui) (cli.Command, error) { return kvput.New(ui), nil }},
+		entry{"kv rollback", func(ui cli.Ui) (cli.Command, error) { return kvrollback.New(ui), nil }},
+		entry{"kv sync", func(ui cli.Ui) (cli.Command, error) { return kvsync.New(ui), nil }},
 		entry{"leave", func(ui cli.Ui) (cli.Command, error) { return leave.New(ui), nil }},
 		entry{"lock", func(ui cli.Ui) (cli.Command, error) { return lock.New(ui, MakeShutdownCh()), nil }},
 		entry{"login", func(ui cli.Ui) (cli.Command, error) { return login.New(ui), nil }},
@@ -237,6 +249,12 @@ func RegisteredCommands(ui cli.Ui) map[string]mcli.CommandFactory {
 		entry{"peering list", func(ui cli.Ui) (cli.Command, error) { return peerlist.New(ui), nil }},
 		entry{"peering read", func(ui cli.Ui) (cli.Command, error) { return peerread.New(ui), nil }},
 		entry{"reload", func(ui cli.Ui) (cli.Command, error) { return reload.New(ui), nil }},
+		entry{"resource", func(cli.Ui) (cli.Command, error) { return resource.New(), nil }},
+		entry{"resource apply", func(ui cli.Ui) (cli.Command, error) { return resourceapply.New(ui), nil }},
+		entry{"resource delete", func(ui cli.Ui) (cli.Command, error) { return resourcedelete.New(ui), nil }},
+		entry{"resource list", func(ui cli.Ui) (cli.Command, error) { return resourcelist.New(ui), nil }},
+		entry{"resource read", func(ui cli.Ui) (cli.Command, error) { return resourceread.New(ui), nil }},
+		entry{"resource watch", func(ui cli.Ui) (cli.Command, error) { return resourcewatch.New(ui, MakeShutdownCh()), nil }},
 		entry{"rtt", func(ui cli.Ui) (cli.Command, error) { return rtt.New(ui), nil }},
 		entry{"services", func(cli.Ui) (cli.Command, error) { return services.New(), nil }},
 		entry{"services register", func(ui cli.Ui) (cli.Command, error) { return svcsregister.New(ui), nil }},
@@ -0,0 +1,143 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package apply
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/mitchellh/cli"
+
+	"github.com/hashicorp/consul/command/flags"
+	"github.com/hashicorp/consul/command/helpers"
+	"github.com/hashicorp/consul/command/resource"
+	"github.com/hashicorp/consul/proto-public/pbresource"
+)
+
+func New(ui cli.Ui) *cmd {
+	c := &cmd{UI: ui}
+	c.init()
+	return c
+}
+
+type cmd struct {
+	UI    cli.Ui
+	flags *flag.FlagSet
+	http  *flags.HTTPFlags
+	grpc  *flags.GRPCFlags
+	help  string
+
+	version   string
+	testStdin io.Reader
+}
+
+func (c *cmd) init() {
+	c.flags = flag.NewFlagSet("", flag.ContinueOnError)
+	c.flags.StringVar(&c.version, "version", "",
+		"Perform a Check-And-Set operation, only writing the resource if its "+
+			"current version matches the given version. This overrides any "+
+			"version given in the resource's definition.")
+
+	c.http = &flags.HTTPFlags{}
+	c.grpc = &flags.GRPCFlags{}
+	flags.Merge(c.flags, c.http.ClientFlags())
+	flags.Merge(c.flags, c.grpc.ClientFlags())
+	flags.Merge(c.flags, c.http.MultiTenancyFlags())
+	c.help = flags.Usage(help, c.flags)
+}
+
+func (c *cmd) Run(args []string) int {
+	if err := c.flags.Parse(args); err != nil {
+		return 1
+	}
+
+	args = c.flags.Args()
+	if len(args) != 1 {
+		c.UI.Error("Must provide exactly one positional argument to specify the resource to write")
+		return 1
+	}
+
+	data, err := helpers.LoadDataSourceNoRaw(args[0], c.testStdin)
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("Failed to load data: %v", err))
+		return 1
+	}
+
+	res, err := resource.ParseResource(resource.Registry(), data)
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	if res.Id.Tenancy == nil {
+		res.Id.Tenancy = resource.Tenancy(c.http, "")
+	}
+	if c.version != "" {
+		res.Version = c.version
+	}
+
+	conn, err := resource.ClientConn(c.http, c.grpc)
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("Error connecting to Consul agent: %s", err))
+		return 1
+	}
+	defer conn.Close()
+
+	gvk := resource.FormatType(res.Id.Type)
+	rsp, err := pbresource.NewResourceServiceClient(conn).Write(context.Background(), &pbresource.WriteRequest{Resource: res})
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("Error writing resource %s/%s: %v", gvk, res.Id.Name, err))
+		return 1
+	}
+
+	c.UI.Info(fmt.Sprintf("Resource written: %s/%s (version %s)", gvk, rsp.Resource.Id.Name, rsp.Resource.Version))
+	return 0
+}
+
+func (c *cmd) Synopsis() string {
+	return synopsis
+}
+
+func (c *cmd) Help() string {
+	return flags.Usage(c.help, nil)
+}
+
+const (
+	synopsis = "Create or update a resource"
+	help     = `
+Usage: consul resource apply [options] <resource>
+
+  Creates or updates a resource. The resource argument is either a file path
+  or '-' to indicate that the resource should be read from stdin. The data
+  should be either in HCL or JSON form, and use the protobuf field names of
+  the resource and of its type's data, e.g.:
+
+    id {
+      type {
+        group         = "demo"
+        group_version = "v2"
+        kind          = "artist"
+      }
+      name = "korn"
+    }
+
+    data {
+      name  = "Korn"
+      genre = "GENRE_METAL"
+    }
+
+  If the resource's tenancy is omitted, the -partition and -namespace flags
+  are used.
+
+  Example (from file):
+
+    $ consul resource apply artist.hcl
+
+  Example (from stdin, with check-and-set):
+
+    $ consul resource apply -version 42 -
+`
+)
@@ -0,0 +1,111 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package apply
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mitchellh/cli"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/consul/agent"
+	"github.com/hashicorp/consul/testrpc"
+)
+
+func TestResourceApply_noTabs(t *testing.T) {
+	t.Parallel()
+
+	require.NotContains(t, New(cli.NewMockUi()).Help(), "\t")
+}
+
+func TestResourceApply(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+
+	// The demo types are only registered in dev mode.
+	a := agent.StartTestAgent(t, agent.TestAgent{DevMode: true})
+	defer a.Shutdown()
+	testrpc.WaitForLeader(t, a.RPC, "dc1")
+
+	grpcAddr := "-grpc-addr=" + a.Config.GRPCAddrs[0].String()
+
+	ui := cli.NewMockUi()
+	c := New(ui)
+	c.testStdin = strings.NewReader(`
+id {
+  type {
+    group         = "demo"
+    group_version = "v2"
+    kind          = "artist"
+  }
+  name = "korn"
+}
+
+metadata {
+  label = "value"
+}
+
+data {
+  name  = "Korn"
+  genre = "GENRE_METAL"
+}
+`)
+
+	code := c.Run([]string{grpcAddr, "-"})
+	require.Equal(t, 0, code, ui.ErrorWriter.String())
+	require.Contains(t, ui.OutputWriter.String(), "Resource written: demo.v2.artist/korn")
+
+	// Check-and-set with the wrong version fails.
+	ui = cli.NewMockUi()
+	c = New(ui)
+	c.testStdin = strings.NewReader(`{
+  "id": {
+    "type": {"group": "demo", "groupVersion": "v2", "kind": "artist"},
+    "name": "korn"
+  },
+  "data": {"name": "Korn", "genre": "GENRE_METAL"}
+}`)
+
+	code = c.Run([]string{grpcAddr, "-version=wrong-version", "-"})
+	require.Equal(t, 1, code)
+	require.Contains(t, ui.ErrorWriter.String(), "CAS operation failed")
+}
+
+func TestResourceApply_InvalidArgs(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		args  []string
+		stdin string
+	}{
+		"no arguments": {},
+		"unknown type": {
+			args:  []string{"-"},
+			stdin: `id { type { group = "demo", group_version = "v9", kind = "artist" }, name = "korn" } data {}`,
+		},
+		"no data": {
+			args:  []string{"-"},
+			stdin: `id { type { group = "demo", group_version = "v2", kind = "artist" }, name = "korn" }`,
+		},
+		"unknown data field": {
+			args:  []string{"-"},
+			stdin: `id { type { group = "demo", group_version = "v2", kind = "artist" }, name = "korn" } data { nope = "" }`,
+		},
+	}
+
+	for name, tcase := range cases {
+		t.Run(name, func(t *testing.T) {
+			ui := cli.NewMockUi()
+			c := New(ui)
+			c.testStdin = strings.NewReader(tcase.stdin)
+
+			require.NotEqual(t, 0, c.Run(tcase.args))
+			require.NotEmpty(t, ui.ErrorWriter.String())
+		})
+	}
+}
@@ -0,0 +1,125 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package delete
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	"github.com/mitchellh/cli"
+
+	"github.com/hashicorp/consul/command/flags"
+	"github.com/hashicorp/consul/command/resource"
+	"github.com/hashicorp/consul/proto-public/pbresource"
+)
+
+func New(ui cli.Ui) *cmd {
+	c := &cmd{UI: ui}
+	c.init()
+	return c
+}
+
+type cmd struct {
+	UI    cli.Ui
+	flags *flag.FlagSet
+	http  *flags.HTTPFlags
+	grpc  *flags.GRPCFlags
+	help  string
+
+	resourceType string
+	name         string
+	peerName     string
+	version      string
+}
+
+func (c *cmd) init() {
+	c.flags = flag.NewFlagSet("", flag.ContinueOnError)
+	c.flags.StringVar(&c.resourceType, "type", "",
+		"The type of the resource to delete, in the format group.version.kind.")
+	c.flags.StringVar(&c.name, "name", "", "The name of the resource to delete.")
+	c.flags.StringVar(&c.peerName, "peer", "",
+		"The name of the peer the resource was imported from. Defaults to the local cluster.")
+	c.flags.StringVar(&c.version, "version", "",
+		"Perform a Check-And-Set operation, only deleting the resource if its "+
+			"current version matches the given version.")
+
+	c.http = &flags.HTTPFlags{}
+	c.grpc = &flags.GRPCFlags{}
+	flags.Merge(c.flags, c.http.ClientFlags())
+	flags.Merge(c.flags, c.grpc.ClientFlags())
+	flags.Merge(c.flags, c.http.MultiTenancyFlags())
+	c.help = flags.Usage(help, c.flags)
+}
+
+func (c *cmd) Run(args []string) int {
+	if err := c.flags.Parse(args); err != nil {
+		return 1
+	}
+
+	if c.resourceType == "" {
+		c.UI.Error("Must specify the -type parameter")
+		return 1
+	}
+
+	if c.name == "" {
+		c.UI.Error("Must specify the -name parameter")
+		return 1
+	}
+
+	typ, err := resource.ParseType(c.resourceType)
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	conn, err := resource.ClientConn(c.http, c.grpc)
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("Error connecting to Consul agent: %s", err))
+		return 1
+	}
+	defer conn.Close()
+
+	req := &pbresource.DeleteRequest{
+		Id: &pbresource.ID{
+			Type:    typ,
+			Tenancy: resource.Tenancy(c.http, c.peerName),
+			Name:    c.name,
+		},
+		Version: c.version,
+	}
+	if _, err := pbresource.NewResourceServiceClient(conn).Delete(context.Background(), req); err != nil {
+		c.UI.Error(fmt.Sprintf("Error deleting resource %s/%s: %v", c.resourceType, c.name, err))
+		return 1
+	}
+
+	c.UI.Info(fmt.Sprintf("Resource deleted: %s/%s", c.resourceType, c.name))
+	return 0
+}
+
+func (c *cmd) Synopsis() string {
+	return synopsis
+}
+
+func (c *cmd) Help() string {
+	return flags.Usage(c.help, nil)
+}
+
+const (
+	synopsis = "Delete a resource"
+	help     = `
+Usage: consul resource delete [options] -type <group.version.kind> -name <name>
+
+  Deletes the resource specified by the given type and name. Deleting a
+  resource that does not exist is not an error.
+
+  Example:
+
+    $ consul resource delete -type demo.v2.artist -name korn
+
+  Example (check-and-set):
+
+    $ consul resource delete -type demo.v2.artist -name korn -version 42
+`
+)
@@ -0,0 +1,303 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"github.com/hashicorp/consul/agent/consul"
+	"github.com/hashicorp/consul/api"
+	"github.com/hashicorp/consul/command/flags"
+	"github.com/hashicorp/consul/command/helpers"
+	"github.com/hashicorp/consul/internal/resource"
+	"github.com/hashicorp/consul/proto-public/pbresource"
+)
+
+// Registry returns a registry containing the resource types supported by the
+// servers, which is used to decode resource data into the type's registered
+// Proto.
+func Registry() resource.Registry {
+	return consul.NewTypeRegistry()
+}
+
+// ParseType parses a resource type given on the command line in the format
+// group.version.kind.
+func ParseType(gvk string) (*pbresource.Type, error) {
+	return resource.ParseGVK(gvk)
+}
+
+// FormatType formats a resource type in the format accepted by ParseType.
+func FormatType(typ *pbresource.Type) string {
+	return resource.ToGVK(typ)
+}
+
+// ClientConn dials the resource service using the gRPC flags. The ACL token
+// is configured using the same flags and environment variables as the HTTP API.
+func ClientConn(httpFlags *flags.HTTPFlags, grpcFlags *flags.GRPCFlags) (*grpc.ClientConn, error) {
+	config := api.DefaultConfig()
+	httpFlags.MergeOntoConfig(config)
+
+	token := config.Token
+	if token == "" && config.TokenFile != "" {
+		data, err := os.ReadFile(config.TokenFile)
+		if err != nil {
+			return nil, fmt.Errorf("Error loading token file: %s", err)
+		}
+		token = strings.TrimSpace(string(data))
+	}
+
+	return grpcFlags.ClientConn(token)
+}
+
+// Tenancy returns the tenancy given by the -partition and -namespace flags and
+// the given peer name, using the defaults for any that are unset.
+func Tenancy(httpFlags *flags.HTTPFlags, peerName string) *pbresource.Tenancy {
+	tenancy := &pbresource.Tenancy{
+		Partition: httpFlags.Partition(),
+		Namespace: httpFlags.Namespace(),
+		PeerName:  peerName,
+	}
+	if tenancy.Partition == "" {
+		tenancy.Partition = "default"
+	}
+	if tenancy.Namespace == "" {
+		tenancy.Namespace = "default"
+	}
+	if tenancy.PeerName == "" {
+		tenancy.PeerName = "local"
+	}
+	return tenancy
+}
+
+// ParseResource decodes a resource from its HCL or JSON representation. The
+// resource's fields use their protobuf JSON names (e.g. id, owner, metadata),
+// and its data is decoded using the Proto registered for the resource's type:
+//
+//	id {
+//	  type {
+//	    group         = "demo"
+//	    group_version = "v2"
+//	    kind          = "artist"
+//	  }
+//	  name = "korn"
+//	}
+//
+//	data {
+//	  name  = "Korn"
+//	  genre = "GENRE_METAL"
+//	}
+func ParseResource(registry resource.Registry, data string) (*pbresource.Resource, error) {
+	var raw map[string]interface{}
+	if err := helpers.DecodeHCLOrJSON(&raw, data); err != nil {
+		return nil, fmt.Errorf("Failed to decode resource input: %v", err)
+	}
+
+	rawData, ok := raw["data"]
+	if !ok {
+		return nil, fmt.Errorf("Payload does not contain a data key at the top level")
+	}
+	delete(raw, "data")
+
+	res := &pbresource.Resource{}
+	if err := decodeMessage(raw, res); err != nil {
+		return nil, fmt.Errorf("Failed to decode resource: %v", err)
+	}
+
+	if res.Id == nil || res.Id.Type == nil {
+		return nil, fmt.Errorf("Payload does not contain an id.type key")
+	}
+
+	reg, ok := registry.Resolve(res.Id.Type)
+	if !ok {
+		return nil, fmt.Errorf("Unknown resource type %s", resource.ToGVK(res.Id.Type))
+	}
+
+	dataMap, ok := singleMap(rawData)
+	if !ok {
+		return nil, fmt.Errorf("Resource data must be an object")
+	}
+
+	// Allow the output of read and list to be used as input, which includes the
+	// data's type URL.
+	if typeURL, ok := dataMap["@type"]; ok {
+		expected := string(reg.Proto.ProtoReflect().Descriptor().FullName())
+		if typeURL != "type.googleapis.com/"+expected {
+			return nil, fmt.Errorf("Resource data is of wrong type (expected=%q, got=%q)", expected, typeURL)
+		}
+		delete(dataMap, "@type")
+	}
+
+	msg := reg.Proto.ProtoReflect().New().Interface()
+	if err := decodeMessage(dataMap, msg); err != nil {
+		return nil, fmt.Errorf("Failed to decode resource data: %v", err)
+	}
+
+	anyData, err := anypb.New(msg)
+	if err != nil {
+		return nil, err
+	}
+	res.Data = anyData
+
+	return res, nil
+}
+
+// MarshalResource returns the JSON representation of the given resource, with
+// its data expanded.
+func MarshalResource(res *pbresource.Resource) (string, error) {
+	b, err := protojson.Marshal(res)
+	if err != nil {
+		return "", err
+	}
+	return indentJSON(b)
+}
+
+// MarshalResources returns the JSON representation of the given resources as
+// an array.
+func MarshalResources(resources []*pbresource.Resource) (string, error) {
+	encoded := make([]json.RawMessage, len(resources))
+	for i, res := range resources {
+		b, err := protojson.Marshal(res)
+		if err != nil {
+			return "", err
+		}
+		encoded[i] = b
+	}
+
+	b, err := json.Marshal(encoded)
+	if err != nil {
+		return "", err
+	}
+	return indentJSON(b)
+}
+
+// indentJSON re-indents the output of protojson, which deliberately randomizes
+// its whitespace, to keep the output stable.
+func indentJSON(b []byte) (string, error) {
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, b, "", "    "); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// decodeMessage decodes the given HCL or JSON object into msg using the
+// protobuf JSON mapping.
+func decodeMessage(raw map[string]interface{}, msg proto.Message) error {
+	normalizeMessage(msg.ProtoReflect().Descriptor(), raw)
+
+	b, err := json.Marshal(raw)
+	if err != nil {
+		return err
+	}
+	return protojson.Unmarshal(b, msg)
+}
+
+// normalizeMessage corrects the HCL decoder's representation of blocks (which
+// are always decoded as lists of objects) based on the message's fields, so
+// that singular message and map fields are objects rather than lists.
+func normalizeMessage(md protoreflect.MessageDescriptor, raw map[string]interface{}) {
+	for key, value := range raw {
+		fd := md.Fields().ByJSONName(key)
+		if fd == nil {
+			fd = md.Fields().ByName(protoreflect.Name(key))
+		}
+		if fd == nil {
+			// Let protojson report the unknown field.
+			continue
+		}
+		raw[key] = normalizeField(fd, value)
+	}
+}
+
+func normalizeField(fd protoreflect.FieldDescriptor, value interface{}) interface{} {
+	switch {
+	case fd.IsList():
+		if fd.Message() == nil {
+			return value
+		}
+
+		var items []interface{}
+		switch v := value.(type) {
+		case []map[string]interface{}:
+			for _, item := range v {
+				items = append(items, item)
+			}
+		case []interface{}:
+			items = v
+		default:
+			return value
+		}
+
+		for _, item := range items {
+			if m, ok := item.(map[string]interface{}); ok && !isWellKnown(fd.Message()) {
+				normalizeMessage(fd.Message(), m)
+			}
+		}
+		return items
+
+	case fd.IsMap():
+		m, ok := singleMap(value)
+		if !ok {
+			return value
+		}
+
+		if valueMsg := fd.MapValue().Message(); valueMsg != nil {
+			for k, v := range m {
+				if vm, ok := singleMap(v); ok {
+					if !isWellKnown(valueMsg) {
+						normalizeMessage(valueMsg, vm)
+					}
+					m[k] = vm
+				}
+			}
+		}
+		return m
+
+	case fd.Message() != nil:
+		m, ok := singleMap(value)
+		if !ok {
+			return value
+		}
+		if !isWellKnown(fd.Message()) {
+			normalizeMessage(fd.Message(), m)
+		}
+		return m
+	}
+	return value
+}
+
+// isWellKnown returns whether the message has a special JSON representation
+// (e.g. google.protobuf.Duration is represented as a string).
+func isWellKnown(md protoreflect.MessageDescriptor) bool {
+	return md.ParentFile().Package() == "google.protobuf"
+}
+
+// singleMap returns the object represented by value, unwrapping it from the
+// single-element list produced by the HCL decoder for blocks.
+func singleMap(value interface{}) (map[string]interface{}, bool) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return v, true
+	case []map[string]interface{}:
+		if len(v) == 1 {
+			return v[0], true
+		}
+	case []interface{}:
+		if len(v) == 1 {
+			m, ok := v[0].(map[string]interface{})
+			return m, ok
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,119 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"github.com/hashicorp/consul/internal/resource/demo"
+	"github.com/hashicorp/consul/proto-public/pbresource"
+	pbdemov2 "github.com/hashicorp/consul/proto/private/pbdemo/v2"
+	"github.com/hashicorp/consul/proto/private/prototest"
+)
+
+func TestParseResource(t *testing.T) {
+	data, err := anypb.New(&pbdemov2.Album{
+		Title:         "Issues",
+		YearOfRelease: 1999,
+		Tracks:        []string{"Dead", "Falling Away from Me"},
+	})
+	require.NoError(t, err)
+
+	expected := &pbresource.Resource{
+		Id: &pbresource.ID{
+			Type: demo.TypeV2Album,
+			Tenancy: &pbresource.Tenancy{
+				Partition: "default",
+				Namespace: "default",
+				PeerName:  "local",
+			},
+			Name: "issues",
+		},
+		Owner: &pbresource.ID{
+			Type: demo.TypeV2Artist,
+			Name: "korn",
+			Uid:  "01H5RSNE3EJ0YWGD6WNE4HJPHF",
+		},
+		Metadata: map[string]string{"label": "value"},
+		Version:  "42",
+		Data:     data,
+	}
+
+	testCases := map[string]string{
+		"hcl": `
+id {
+  type {
+    group         = "demo"
+    group_version = "v2"
+    kind          = "album"
+  }
+  tenancy {
+    partition = "default"
+    namespace = "default"
+    peer_name = "local"
+  }
+  name = "issues"
+}
+
+owner {
+  type {
+    group         = "demo"
+    group_version = "v2"
+    kind          = "artist"
+  }
+  name = "korn"
+  uid  = "01H5RSNE3EJ0YWGD6WNE4HJPHF"
+}
+
+metadata {
+  label = "value"
+}
+
+version = "42"
+
+data {
+  title           = "Issues"
+  year_of_release = 1999
+  tracks          = ["Dead", "Falling Away from Me"]
+}
+`,
+		"json": `{
+  "id": {
+    "type": {"group": "demo", "groupVersion": "v2", "kind": "album"},
+    "tenancy": {"partition": "default", "namespace": "default", "peerName": "local"},
+    "name": "issues"
+  },
+  "owner": {
+    "type": {"group": "demo", "groupVersion": "v2", "kind": "artist"},
+    "name": "korn",
+    "uid": "01H5RSNE3EJ0YWGD6WNE4HJPHF"
+  },
+  "metadata": {"label": "value"},
+  "version": "42",
+  "data": {
+    "title": "Issues",
+    "yearOfRelease": 1999,
+    "tracks": ["Dead", "Falling Away from Me"]
+  }
+}`,
+	}
+	for desc, input := range testCases {
+		t.Run(desc, func(t *testing.T) {
+			res, err := ParseResource(Registry(), input)
+			require.NoError(t, err)
+			prototest.AssertDeepEqual(t, expected, res)
+
+			// The output can be parsed again.
+			out, err := MarshalResource(res)
+			require.NoError(t, err)
+
+			res, err = ParseResource(Registry(), out)
+			require.NoError(t, err)
+			prototest.AssertDeepEqual(t, expected, res)
+		})
+	}
+}
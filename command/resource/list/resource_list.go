@@ -0,0 +1,132 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package list
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	"github.com/mitchellh/cli"
+
+	"github.com/hashicorp/consul/command/flags"
+	"github.com/hashicorp/consul/command/resource"
+	"github.com/hashicorp/consul/proto-public/pbresource"
+)
+
+func New(ui cli.Ui) *cmd {
+	c := &cmd{UI: ui}
+	c.init()
+	return c
+}
+
+type cmd struct {
+	UI    cli.Ui
+	flags *flag.FlagSet
+	http  *flags.HTTPFlags
+	grpc  *flags.GRPCFlags
+	help  string
+
+	resourceType string
+	namePrefix   string
+	peerName     string
+}
+
+func (c *cmd) init() {
+	c.flags = flag.NewFlagSet("", flag.ContinueOnError)
+	c.flags.StringVar(&c.resourceType, "type", "",
+		"The type of the resources to list, in the format group.version.kind.")
+	c.flags.StringVar(&c.namePrefix, "prefix", "",
+		"Only list resources whose names begin with the given prefix.")
+	c.flags.StringVar(&c.peerName, "peer", "",
+		"The name of the peer the resources were imported from. Defaults to the local cluster.")
+
+	c.http = &flags.HTTPFlags{}
+	c.grpc = &flags.GRPCFlags{}
+	flags.Merge(c.flags, c.http.ClientFlags())
+	flags.Merge(c.flags, c.grpc.ClientFlags())
+	flags.Merge(c.flags, c.http.MultiTenancyFlags())
+	c.help = flags.Usage(help, c.flags)
+}
+
+func (c *cmd) Run(args []string) int {
+	if err := c.flags.Parse(args); err != nil {
+		return 1
+	}
+
+	if c.resourceType == "" {
+		c.UI.Error("Must specify the -type parameter")
+		return 1
+	}
+
+	typ, err := resource.ParseType(c.resourceType)
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	conn, err := resource.ClientConn(c.http, c.grpc)
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("Error connecting to Consul agent: %s", err))
+		return 1
+	}
+	defer conn.Close()
+
+	client := pbresource.NewResourceServiceClient(conn)
+	req := &pbresource.ListRequest{
+		Type:       typ,
+		Tenancy:    resource.Tenancy(c.http, c.peerName),
+		NamePrefix: c.namePrefix,
+	}
+
+	var resources []*pbresource.Resource
+	for {
+		rsp, err := client.List(context.Background(), req)
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("Error listing resources of type %s: %v", c.resourceType, err))
+			return 1
+		}
+		resources = append(resources, rsp.Resources...)
+
+		if rsp.NextPageToken == "" {
+			break
+		}
+		req.PageToken = rsp.NextPageToken
+	}
+
+	out, err := resource.MarshalResources(resources)
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("Failed to encode output data: %v", err))
+		return 1
+	}
+
+	c.UI.Info(out)
+	return 0
+}
+
+func (c *cmd) Synopsis() string {
+	return synopsis
+}
+
+func (c *cmd) Help() string {
+	return flags.Usage(c.help, nil)
+}
+
+const (
+	synopsis = "List resources of a type"
+	help     = `
+Usage: consul resource list [options] -type <group.version.kind>
+
+  Lists the resources of the given type in the given partition and namespace,
+  and outputs their JSON representation.
+
+  Example:
+
+    $ consul resource list -type demo.v2.artist
+
+  Example (with name prefix):
+
+    $ consul resource list -type demo.v2.album -prefix korn/
+`
+)
@@ -0,0 +1,100 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package list
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/mitchellh/cli"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/hashicorp/consul/agent"
+	"github.com/hashicorp/consul/internal/resource/demo"
+	"github.com/hashicorp/consul/proto-public/pbresource"
+	"github.com/hashicorp/consul/testrpc"
+)
+
+func TestResourceList_noTabs(t *testing.T) {
+	t.Parallel()
+
+	require.NotContains(t, New(cli.NewMockUi()).Help(), "\t")
+}
+
+func TestResourceList(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+
+	// The demo types are only registered in dev mode.
+	a := agent.StartTestAgent(t, agent.TestAgent{DevMode: true})
+	defer a.Shutdown()
+	testrpc.WaitForLeader(t, a.RPC, "dc1")
+
+	grpcAddr := a.Config.GRPCAddrs[0].String()
+
+	conn, err := grpc.Dial(grpcAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	client := pbresource.NewResourceServiceClient(conn)
+
+	names := make(map[string]struct{})
+	for _, name := range []string{"korn", "korn-ii", "slipknot"} {
+		artist, err := demo.GenerateV2Artist()
+		require.NoError(t, err)
+		artist.Id.Name = name
+
+		_, err = client.Write(context.Background(), &pbresource.WriteRequest{Resource: artist})
+		require.NoError(t, err)
+		names[name] = struct{}{}
+	}
+
+	listNames := func(t *testing.T, args ...string) []string {
+		ui := cli.NewMockUi()
+		c := New(ui)
+
+		code := c.Run(append([]string{"-grpc-addr=" + grpcAddr, "-type=demo.v2.artist"}, args...))
+		require.Equal(t, 0, code, ui.ErrorWriter.String())
+
+		var raw []json.RawMessage
+		require.NoError(t, json.Unmarshal(ui.OutputWriter.Bytes(), &raw))
+
+		var names []string
+		for _, r := range raw {
+			var res pbresource.Resource
+			require.NoError(t, protojson.Unmarshal(r, &res))
+			names = append(names, res.Id.Name)
+		}
+		return names
+	}
+
+	require.ElementsMatch(t, []string{"korn", "korn-ii", "slipknot"}, listNames(t))
+	require.ElementsMatch(t, []string{"korn", "korn-ii"}, listNames(t, "-prefix=korn"))
+	require.Empty(t, listNames(t, "-prefix=metallica"))
+}
+
+func TestResourceList_InvalidArgs(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string][]string{
+		"no type":      {},
+		"invalid type": {"-type", "demo.artist"},
+	}
+
+	for name, tcase := range cases {
+		t.Run(name, func(t *testing.T) {
+			ui := cli.NewMockUi()
+			c := New(ui)
+
+			require.NotEqual(t, 0, c.Run(tcase))
+			require.NotEmpty(t, ui.ErrorWriter.String())
+		})
+	}
+}
@@ -0,0 +1,127 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package read
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	"github.com/mitchellh/cli"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/hashicorp/consul/command/flags"
+	"github.com/hashicorp/consul/command/resource"
+	"github.com/hashicorp/consul/proto-public/pbresource"
+)
+
+func New(ui cli.Ui) *cmd {
+	c := &cmd{UI: ui}
+	c.init()
+	return c
+}
+
+type cmd struct {
+	UI    cli.Ui
+	flags *flag.FlagSet
+	http  *flags.HTTPFlags
+	grpc  *flags.GRPCFlags
+	help  string
+
+	resourceType string
+	name         string
+	peerName     string
+}
+
+func (c *cmd) init() {
+	c.flags = flag.NewFlagSet("", flag.ContinueOnError)
+	c.flags.StringVar(&c.resourceType, "type", "",
+		"The type of the resource to read, in the format group.version.kind.")
+	c.flags.StringVar(&c.name, "name", "", "The name of the resource to read.")
+	c.flags.StringVar(&c.peerName, "peer", "",
+		"The name of the peer the resource was imported from. Defaults to the local cluster.")
+
+	c.http = &flags.HTTPFlags{}
+	c.grpc = &flags.GRPCFlags{}
+	flags.Merge(c.flags, c.http.ClientFlags())
+	flags.Merge(c.flags, c.grpc.ClientFlags())
+	flags.Merge(c.flags, c.http.MultiTenancyFlags())
+	c.help = flags.Usage(help, c.flags)
+}
+
+func (c *cmd) Run(args []string) int {
+	if err := c.flags.Parse(args); err != nil {
+		return 1
+	}
+
+	if c.resourceType == "" {
+		c.UI.Error("Must specify the -type parameter")
+		return 1
+	}
+
+	if c.name == "" {
+		c.UI.Error("Must specify the -name parameter")
+		return 1
+	}
+
+	typ, err := resource.ParseType(c.resourceType)
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	conn, err := resource.ClientConn(c.http, c.grpc)
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("Error connecting to Consul agent: %s", err))
+		return 1
+	}
+	defer conn.Close()
+
+	id := &pbresource.ID{
+		Type:    typ,
+		Tenancy: resource.Tenancy(c.http, c.peerName),
+		Name:    c.name,
+	}
+	rsp, err := pbresource.NewResourceServiceClient(conn).Read(context.Background(), &pbresource.ReadRequest{Id: id})
+	switch {
+	case status.Code(err) == codes.NotFound:
+		c.UI.Error(fmt.Sprintf("Resource not found: %s/%s", c.resourceType, c.name))
+		return 1
+	case err != nil:
+		c.UI.Error(fmt.Sprintf("Error reading resource %s/%s: %v", c.resourceType, c.name, err))
+		return 1
+	}
+
+	out, err := resource.MarshalResource(rsp.Resource)
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("Failed to encode output data: %v", err))
+		return 1
+	}
+
+	c.UI.Info(out)
+	return 0
+}
+
+func (c *cmd) Synopsis() string {
+	return synopsis
+}
+
+func (c *cmd) Help() string {
+	return flags.Usage(c.help, nil)
+}
+
+const (
+	synopsis = "Read a resource"
+	help     = `
+Usage: consul resource read [options] -type <group.version.kind> -name <name>
+
+  Reads the resource specified by the given type and name and outputs its
+  JSON representation.
+
+  Example:
+
+    $ consul resource read -type demo.v2.artist -name korn
+`
+)
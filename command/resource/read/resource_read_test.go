@@ -0,0 +1,97 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package read
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mitchellh/cli"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/hashicorp/consul/agent"
+	"github.com/hashicorp/consul/internal/resource/demo"
+	"github.com/hashicorp/consul/proto-public/pbresource"
+	"github.com/hashicorp/consul/proto/private/prototest"
+	"github.com/hashicorp/consul/testrpc"
+)
+
+func TestResourceRead_noTabs(t *testing.T) {
+	t.Parallel()
+
+	require.NotContains(t, New(cli.NewMockUi()).Help(), "\t")
+}
+
+func TestResourceRead(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+
+	// The demo types are only registered in dev mode.
+	a := agent.StartTestAgent(t, agent.TestAgent{DevMode: true})
+	defer a.Shutdown()
+	testrpc.WaitForLeader(t, a.RPC, "dc1")
+
+	grpcAddr := a.Config.GRPCAddrs[0].String()
+
+	conn, err := grpc.Dial(grpcAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	artist, err := demo.GenerateV2Artist()
+	require.NoError(t, err)
+
+	rsp, err := pbresource.NewResourceServiceClient(conn).Write(context.Background(), &pbresource.WriteRequest{Resource: artist})
+	require.NoError(t, err)
+
+	ui := cli.NewMockUi()
+	c := New(ui)
+
+	args := []string{
+		"-grpc-addr=" + grpcAddr,
+		"-type=demo.v2.artist",
+		"-name=" + artist.Id.Name,
+	}
+
+	code := c.Run(args)
+	require.Equal(t, 0, code, ui.ErrorWriter.String())
+
+	var res pbresource.Resource
+	require.NoError(t, protojson.Unmarshal(ui.OutputWriter.Bytes(), &res))
+	prototest.AssertDeepEqual(t, rsp.Resource.Id, res.Id)
+	prototest.AssertDeepEqual(t, rsp.Resource.Data, res.Data)
+
+	// Reading a resource that doesn't exist is an error.
+	ui = cli.NewMockUi()
+	c = New(ui)
+
+	code = c.Run([]string{"-grpc-addr=" + grpcAddr, "-type=demo.v2.artist", "-name=does-not-exist"})
+	require.Equal(t, 1, code)
+	require.Contains(t, ui.ErrorWriter.String(), "Resource not found")
+}
+
+func TestResourceRead_InvalidArgs(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string][]string{
+		"no type":      {},
+		"no name":      {"-type", "demo.v2.artist"},
+		"invalid type": {"-type", "demo.artist", "-name", "korn"},
+	}
+
+	for name, tcase := range cases {
+		t.Run(name, func(t *testing.T) {
+			ui := cli.NewMockUi()
+			c := New(ui)
+
+			require.NotEqual(t, 0, c.Run(tcase))
+			require.NotEmpty(t, ui.ErrorWriter.String())
+		})
+	}
+}
@@ -0,0 +1,60 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"github.com/mitchellh/cli"
+
+	"github.com/hashicorp/consul/command/flags"
+)
+
+func New() *cmd {
+	return &cmd{}
+}
+
+type cmd struct{}
+
+func (c *cmd) Run(args []string) int {
+	return cli.RunResultHelp
+}
+
+func (c *cmd) Synopsis() string {
+	return synopsis
+}
+
+func (c *cmd) Help() string {
+	return flags.Usage(help, nil)
+}
+
+const synopsis = "Interact with Consul's v2 resources"
+const help = `
+Usage: consul resource <subcommand> [options] [args]
+
+  This command has subcommands for interacting with the resources stored by
+  Consul's resource service. Resources are identified by their type, in the
+  format group.version.kind, and their name. Here are some simple examples, and
+  more detailed examples are available in the subcommands or the documentation.
+
+  Create or update a resource:
+
+    $ consul resource apply artist.hcl
+
+  Read a resource:
+
+    $ consul resource read -type demo.v2.artist -name korn
+
+  List all resources of a type:
+
+    $ consul resource list -type demo.v2.artist
+
+  Watch resources of a type for changes:
+
+    $ consul resource watch -type demo.v2.artist
+
+  Delete a resource:
+
+    $ consul resource delete -type demo.v2.artist -name korn
+
+  For more examples, ask for subcommand help or view the documentation.
+`
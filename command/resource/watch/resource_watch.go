@@ -0,0 +1,155 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package watch
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	"github.com/mitchellh/cli"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/hashicorp/consul/command/flags"
+	"github.com/hashicorp/consul/command/resource"
+	"github.com/hashicorp/consul/proto-public/pbresource"
+)
+
+func New(ui cli.Ui, shutdownCh <-chan struct{}) *cmd {
+	c := &cmd{UI: ui, shutdownCh: shutdownCh}
+	c.init()
+	return c
+}
+
+type cmd struct {
+	UI    cli.Ui
+	flags *flag.FlagSet
+	http  *flags.HTTPFlags
+	grpc  *flags.GRPCFlags
+	help  string
+
+	shutdownCh <-chan struct{}
+
+	resourceType string
+	namePrefix   string
+	peerName     string
+}
+
+func (c *cmd) init() {
+	c.flags = flag.NewFlagSet("", flag.ContinueOnError)
+	c.flags.StringVar(&c.resourceType, "type", "",
+		"The type of the resources to watch, in the format group.version.kind.")
+	c.flags.StringVar(&c.namePrefix, "prefix", "",
+		"Only watch resources whose names begin with the given prefix.")
+	c.flags.StringVar(&c.peerName, "peer", "",
+		"The name of the peer the resources were imported from. Defaults to the local cluster.")
+
+	c.http = &flags.HTTPFlags{}
+	c.grpc = &flags.GRPCFlags{}
+	flags.Merge(c.flags, c.http.ClientFlags())
+	flags.Merge(c.flags, c.grpc.ClientFlags())
+	flags.Merge(c.flags, c.http.MultiTenancyFlags())
+	c.help = flags.Usage(help, c.flags)
+}
+
+func (c *cmd) Run(args []string) int {
+	if err := c.flags.Parse(args); err != nil {
+		return 1
+	}
+
+	if c.resourceType == "" {
+		c.UI.Error("Must specify the -type parameter")
+		return 1
+	}
+
+	typ, err := resource.ParseType(c.resourceType)
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	conn, err := resource.ClientConn(c.http, c.grpc)
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("Error connecting to Consul agent: %s", err))
+		return 1
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go func() {
+		select {
+		case <-c.shutdownCh:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	stream, err := pbresource.NewResourceServiceClient(conn).WatchList(ctx, &pbresource.WatchListRequest{
+		Type:       typ,
+		Tenancy:    resource.Tenancy(c.http, c.peerName),
+		NamePrefix: c.namePrefix,
+	})
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("Error watching resources of type %s: %v", c.resourceType, err))
+		return 1
+	}
+
+	for {
+		event, err := stream.Recv()
+		switch {
+		case status.Code(err) == codes.Canceled:
+			return 0
+		case err != nil:
+			c.UI.Error(fmt.Sprintf("Error watching resources of type %s: %v", c.resourceType, err))
+			return 1
+		}
+
+		out, err := resource.MarshalResource(event.Resource)
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("Failed to encode output data: %v", err))
+			return 1
+		}
+
+		c.UI.Info(fmt.Sprintf("%s %s/%s", operation(event.Operation), c.resourceType, event.Resource.Id.Name))
+		c.UI.Output(out)
+	}
+}
+
+func operation(op pbresource.WatchEvent_Operation) string {
+	switch op {
+	case pbresource.WatchEvent_OPERATION_UPSERT:
+		return "Upserted"
+	case pbresource.WatchEvent_OPERATION_DELETE:
+		return "Deleted"
+	default:
+		return op.String()
+	}
+}
+
+func (c *cmd) Synopsis() string {
+	return synopsis
+}
+
+func (c *cmd) Help() string {
+	return flags.Usage(c.help, nil)
+}
+
+const (
+	synopsis = "Watch resources of a type for changes"
+	help     = `
+Usage: consul resource watch [options] -type <group.version.kind>
+
+  Watches the resources of the given type in the given partition and namespace,
+  and outputs the JSON representation of each resource as it is upserted or
+  deleted. The current resources are output first. The command runs until
+  interrupted.
+
+  Example:
+
+    $ consul resource watch -type demo.v2.artist
+`
+)
@@ -0,0 +1,99 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package watch
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/mitchellh/cli"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/hashicorp/consul/agent"
+	"github.com/hashicorp/consul/internal/resource/demo"
+	"github.com/hashicorp/consul/proto-public/pbresource"
+	"github.com/hashicorp/consul/sdk/testutil/retry"
+	"github.com/hashicorp/consul/testrpc"
+)
+
+func TestResourceWatch_noTabs(t *testing.T) {
+	t.Parallel()
+
+	require.NotContains(t, New(cli.NewMockUi(), nil).Help(), "\t")
+}
+
+func TestResourceWatch(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+
+	// The demo types are only registered in dev mode.
+	a := agent.StartTestAgent(t, agent.TestAgent{DevMode: true})
+	defer a.Shutdown()
+	testrpc.WaitForLeader(t, a.RPC, "dc1")
+
+	grpcAddr := a.Config.GRPCAddrs[0].String()
+
+	conn, err := grpc.Dial(grpcAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	client := pbresource.NewResourceServiceClient(conn)
+
+	artist, err := demo.GenerateV2Artist()
+	require.NoError(t, err)
+
+	_, err = client.Write(context.Background(), &pbresource.WriteRequest{Resource: artist})
+	require.NoError(t, err)
+
+	shutdownCh := make(chan struct{})
+	ui := cli.NewMockUi()
+	c := New(ui, shutdownCh)
+
+	codeCh := make(chan int, 1)
+	go func() {
+		codeCh <- c.Run([]string{"-grpc-addr=" + grpcAddr, "-type=demo.v2.artist"})
+	}()
+
+	retry.Run(t, func(r *retry.R) {
+		if !strings.Contains(ui.OutputWriter.String(), "Upserted demo.v2.artist/"+artist.Id.Name) {
+			r.Fatalf("bad: %q", ui.OutputWriter.String())
+		}
+	})
+
+	_, err = client.Delete(context.Background(), &pbresource.DeleteRequest{Id: artist.Id})
+	require.NoError(t, err)
+
+	retry.Run(t, func(r *retry.R) {
+		if !strings.Contains(ui.OutputWriter.String(), "Deleted demo.v2.artist/"+artist.Id.Name) {
+			r.Fatalf("bad: %q", ui.OutputWriter.String())
+		}
+	})
+
+	close(shutdownCh)
+	require.Equal(t, 0, <-codeCh, ui.ErrorWriter.String())
+}
+
+func TestResourceWatch_InvalidArgs(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string][]string{
+		"no type":      {},
+		"invalid type": {"-type", "demo.artist"},
+	}
+
+	for name, tcase := range cases {
+		t.Run(name, func(t *testing.T) {
+			ui := cli.NewMockUi()
+			c := New(ui, nil)
+
+			require.NotEqual(t, 0, c.Run(tcase))
+			require.NotEmpty(t, ui.ErrorWriter.String())
+		})
+	}
+}
@@ -0,0 +1,233 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/hashicorp/consul/internal/controller/queue"
+	"github.com/hashicorp/consul/internal/resource"
+	"github.com/hashicorp/consul/internal/storage"
+	"github.com/hashicorp/consul/proto-public/pbresource"
+)
+
+// controllerRunner contains the actual implementation of running a controller
+// including creating watches, calling the reconciler, handling retries, etc.
+type controllerRunner struct {
+	ctrl   Controller
+	client pbresource.ResourceServiceClient
+	logger hclog.Logger
+}
+
+func (c *controllerRunner) run(ctx context.Context) error {
+	c.logger.Debug("controller running")
+	defer c.logger.Debug("controller stopping")
+
+	group, groupCtx := errgroup.WithContext(ctx)
+	recQueue := runQueue[Request](groupCtx, c.ctrl)
+
+	// Managed Type Events → Reconciliation Queue
+	group.Go(func() error {
+		return c.watch(groupCtx, c.ctrl.managedType, func(res *pbresource.Resource) {
+			recQueue.Add(Request{ID: res.Id})
+		})
+	})
+
+	for _, w := range c.ctrl.watches {
+		mapQueue := runQueue[mapperRequest](groupCtx, c.ctrl)
+		watcher := w
+
+		// Watched Type Events → Mapper Queue
+		group.Go(func() error {
+			return c.watch(groupCtx, watcher.watchedType, func(res *pbresource.Resource) {
+				mapQueue.Add(mapperRequest{res: res})
+			})
+		})
+
+		// Mapper Queue → Mapper → Reconciliation Queue
+		group.Go(func() error {
+			return c.runMapper(groupCtx, watcher, mapQueue, recQueue)
+		})
+	}
+
+	// Reconciliation Queue → Reconciler
+	group.Go(func() error {
+		return c.runReconciler(groupCtx, recQueue)
+	})
+
+	return group.Wait()
+}
+
+func runQueue[T queue.ItemType](ctx context.Context, ctrl Controller) queue.WorkQueue[T] {
+	base, max := ctrl.backoff()
+	return queue.RunWorkQueue[T](ctx, base, max)
+}
+
+func (c *controllerRunner) watch(ctx context.Context, typ *pbresource.Type, add func(*pbresource.Resource)) error {
+	wl, err := c.client.WatchList(ctx, &pbresource.WatchListRequest{
+		Type: typ,
+		Tenancy: &pbresource.Tenancy{
+			Partition: storage.Wildcard,
+			PeerName:  storage.Wildcard,
+			Namespace: storage.Wildcard,
+		},
+	})
+	if err != nil {
+		c.logger.Error("failed to create watch", "error", err)
+		return err
+	}
+
+	for {
+		event, err := wl.Recv()
+		if err != nil {
+			c.logger.Warn("error received from watch", "error", err)
+			return err
+		}
+		add(event.Resource)
+	}
+}
+
+func (c *controllerRunner) runMapper(
+	ctx context.Context,
+	w watch,
+	from queue.WorkQueue[mapperRequest],
+	to queue.WorkQueue[Request],
+) error {
+	logger := c.logger.With("watched_resource_type", resource.ToGVK(w.watchedType))
+
+	for {
+		item, shutdown := from.Get()
+		if shutdown {
+			return nil
+		}
+
+		if err := c.doMap(ctx, w.mapper, to, item, logger); err != nil {
+			from.AddRateLimited(item)
+			from.Done(item)
+			continue
+		}
+
+		from.Forget(item)
+		from.Done(item)
+	}
+}
+
+func (c *controllerRunner) doMap(ctx context.Context, mapper DependencyMapper, to queue.WorkQueue[Request], item mapperRequest, logger hclog.Logger) (err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("panic [recovered]: %v", p)
+			logger.Error("mapper panic",
+				"error", err,
+				"stack", hclog.Stacktrace(),
+			)
+		}
+	}()
+
+	var reqs []Request
+	reqs, err = mapper(ctx, c.runtime(), item.res)
+	if err != nil {
+		logger.Error("failed to map resource to reconciliation requests", "error", err)
+		return err
+	}
+
+	for _, r := range reqs {
+		if !proto.Equal(r.ID.Type, c.ctrl.managedType) {
+			err = fmt.Errorf("dependency mapper returned request for a resource of the wrong type (expected: %s, got: %s)",
+				resource.ToGVK(c.ctrl.managedType),
+				resource.ToGVK(r.ID.Type),
+			)
+			logger.Error("dependency mapper returned request for a resource of the wrong type", "error", err)
+			return err
+		}
+	}
+
+	for _, r := range reqs {
+		to.Add(r)
+	}
+	return nil
+}
+
+func (c *controllerRunner) runReconciler(ctx context.Context, queue queue.WorkQueue[Request]) error {
+	for {
+		req, shutdown := queue.Get()
+		if shutdown {
+			return nil
+		}
+
+		c.logger.Trace("handling request", "request", req)
+		err := c.handle(ctx, req)
+		if err == nil {
+			// if no error then Forget this request so it is not retried
+			queue.Forget(req)
+		} else {
+			// handle the case where we're specifically told to requeue later
+			var requeueAfter RequeueAfterError
+			if errors.As(err, &requeueAfter) {
+				queue.Forget(req)
+				queue.AddAfter(req, time.Duration(requeueAfter))
+			} else {
+				// fallback to rate limit ourselves
+				queue.AddRateLimited(req)
+			}
+		}
+		// Done is called here because it is required to be called when we've
+		// finished processing each request
+		queue.Done(req)
+	}
+}
+
+// handle wraps the reconciler in a panic handler.
+func (c *controllerRunner) handle(ctx context.Context, req Request) (err error) {
+	logger := c.logger.With("resource-id", req.ID.String())
+	logger.Trace("running reconciler")
+
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("panic [recovered]: %v", p)
+			logger.Error("reconciler panic",
+				"error", err,
+				"stack", hclog.Stacktrace(),
+			)
+		}
+	}()
+
+	if err = c.ctrl.reconciler.Reconcile(ctx, c.runtime(), req); err != nil {
+		var requeueAfter RequeueAfterError
+		if !errors.As(err, &requeueAfter) {
+			logger.Error("reconciler returned an error", "error", err)
+		}
+	}
+	return err
+}
+
+func (c *controllerRunner) runtime() Runtime {
+	return Runtime{
+		Client: c.client,
+		Logger: c.logger,
+	}
+}
+
+type mapperRequest struct{ res *pbresource.Resource }
+
+// Key satisfies the queue.ItemType interface. It returns a string which will be
+// used to de-duplicate requests in the queue.
+func (i mapperRequest) Key() string {
+	return fmt.Sprintf(
+		"type=%q,part=%q,peer=%q,ns=%q,name=%q,uid=%q,version=%q",
+		resource.ToGVK(i.res.Id.Type),
+		i.res.Id.Tenancy.Partition,
+		i.res.Id.Tenancy.PeerName,
+		i.res.Id.Tenancy.Namespace,
+		i.res.Id.Name,
+		i.res.Id.Uid,
+		i.res.Version,
+	)
+}
@@ -0,0 +1,13 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Package controller provides an API for implementing control loops on top of
+// Consul resources. It is heavily inspired by the Kubernetes
+// [controller-runtime](https://github.com/kubernetes-sigs/controller-runtime)
+// but drives reconcilers using the Resource Service's WatchList RPC rather
+// than Kubernetes' client list/watch APIs.
+//
+// Controllers are constructed using the builder methods in this package
+// (starting with ForType) and executed by a Manager, which runs them on the
+// Raft leader.
+package controller
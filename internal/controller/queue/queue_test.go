@@ -0,0 +1,70 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package queue
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkQueue_Dedupe(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	q := RunWorkQueue[testItem](ctx, time.Millisecond, time.Second)
+	q.Add("a")
+	q.Add("a")
+	q.Add("b")
+
+	item, shutdown := q.Get()
+	require.False(t, shutdown)
+	require.Equal(t, testItem("a"), item)
+
+	// Adding an item that is being processed defers it until Done is called.
+	q.Add("a")
+
+	item, shutdown = q.Get()
+	require.False(t, shutdown)
+	require.Equal(t, testItem("b"), item)
+	q.Done("b")
+
+	q.Done("a")
+	item, shutdown = q.Get()
+	require.False(t, shutdown)
+	require.Equal(t, testItem("a"), item)
+}
+
+func TestWorkQueue_AddAfter(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	q := RunWorkQueue[testItem](ctx, time.Millisecond, time.Second)
+
+	start := time.Now()
+	q.AddAfter("a", 50*time.Millisecond)
+
+	item, shutdown := q.Get()
+	require.False(t, shutdown)
+	require.Equal(t, testItem("a"), item)
+	require.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+}
+
+func TestWorkQueue_Shutdown(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	q := RunWorkQueue[testItem](ctx, time.Millisecond, time.Second)
+
+	cancel()
+
+	_, shutdown := q.Get()
+	require.True(t, shutdown)
+}
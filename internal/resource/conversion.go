@@ -0,0 +1,86 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"errors"
+	"fmt"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"github.com/hashicorp/consul/proto-public/pbresource"
+)
+
+// ErrNoConversion is returned by Convert when there is no conversion registered
+// between the given types.
+var ErrNoConversion = errors.New("no conversion registered")
+
+// CanConvert returns whether resources of the from type can be converted to the
+// to type, using the conversions registered for it.
+func CanConvert(registry Registry, from, to *pbresource.Type) bool {
+	if from.Group != to.Group || from.Kind != to.Kind {
+		return false
+	}
+	if from.GroupVersion == to.GroupVersion {
+		return true
+	}
+
+	reg, ok := registry.Resolve(to)
+	if !ok {
+		return false
+	}
+	_, ok = reg.Conversions[from.GroupVersion]
+	return ok
+}
+
+// Convert returns a copy of the given resource converted to the given type,
+// which must differ from the resource's type only by GroupVersion. If the types
+// are the same, the resource is returned as-is.
+//
+// The resource's data is decoded using the Go type of its Proto message, so
+// the other GroupVersion need not be registered, but its protobuf package must
+// still be linked into the binary.
+func Convert(registry Registry, res *pbresource.Resource, to *pbresource.Type) (*pbresource.Resource, error) {
+	from := res.Id.Type
+	if proto.Equal(from, to) {
+		return res, nil
+	}
+
+	if !CanConvert(registry, from, to) {
+		return nil, fmt.Errorf("%w from %s to %s", ErrNoConversion, ToGVK(from), ToGVK(to))
+	}
+
+	// CanConvert has already checked the type is registered.
+	reg, _ := registry.Resolve(to)
+	convert := reg.Conversions[from.GroupVersion]
+
+	data, err := res.Data.UnmarshalNew()
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode %s data: %w", ToGVK(from), err)
+	}
+
+	converted, err := convert(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert %s to %s: %w", ToGVK(from), ToGVK(to), err)
+	}
+
+	convertedData, err := anypb.New(converted)
+	if err != nil {
+		return nil, err
+	}
+	if !convertedData.MessageIs(reg.Proto) {
+		return nil, fmt.Errorf("conversion from %s to %s returned data of wrong type (expected=%q, got=%q)",
+			ToGVK(from),
+			ToGVK(to),
+			reg.Proto.ProtoReflect().Descriptor().FullName(),
+			converted.ProtoReflect().Descriptor().FullName(),
+		)
+	}
+
+	out := proto.Clone(res).(*pbresource.Resource)
+	out.Id.Type = proto.Clone(to).(*pbresource.Type)
+	out.Data = convertedData
+	return out, nil
+}
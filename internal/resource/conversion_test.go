@@ -0,0 +1,96 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/hashicorp/consul/internal/resource"
+	"github.com/hashicorp/consul/internal/resource/demo"
+	pbdemov1 "github.com/hashicorp/consul/proto/private/pbdemo/v1"
+	pbdemov2 "github.com/hashicorp/consul/proto/private/pbdemo/v2"
+	"github.com/hashicorp/consul/proto/private/prototest"
+)
+
+func TestCanConvert(t *testing.T) {
+	r := resource.NewRegistry()
+	demo.Register(r)
+
+	require.True(t, resource.CanConvert(r, demo.TypeV1Album, demo.TypeV2Album))
+	require.True(t, resource.CanConvert(r, demo.TypeV2Album, demo.TypeV1Album))
+	require.True(t, resource.CanConvert(r, demo.TypeV2Artist, demo.TypeV2Artist))
+
+	// no conversion registered
+	require.False(t, resource.CanConvert(r, demo.TypeV1Artist, demo.TypeV2Artist))
+
+	// different kinds
+	require.False(t, resource.CanConvert(r, demo.TypeV1Album, demo.TypeV2Artist))
+}
+
+func TestConvert(t *testing.T) {
+	r := resource.NewRegistry()
+	demo.Register(r)
+
+	artist, err := demo.GenerateV2Artist()
+	require.NoError(t, err)
+
+	v2, err := demo.GenerateV2Album(artist.Id)
+	require.NoError(t, err)
+	v2.Version = "1"
+
+	t.Run("same type", func(t *testing.T) {
+		out, err := resource.Convert(r, v2, demo.TypeV2Album)
+		require.NoError(t, err)
+		require.Same(t, v2, out)
+	})
+
+	t.Run("round trip", func(t *testing.T) {
+		v1, err := resource.Convert(r, v2, demo.TypeV1Album)
+		require.NoError(t, err)
+		prototest.AssertDeepEqual(t, demo.TypeV1Album, v1.Id.Type)
+		require.Equal(t, v2.Id.Name, v1.Id.Name)
+		require.Equal(t, v2.Version, v1.Version)
+		prototest.AssertDeepEqual(t, v2.Owner, v1.Owner)
+
+		v1Data := &pbdemov1.Album{}
+		require.NoError(t, v1.Data.UnmarshalTo(v1Data))
+		v2Data := &pbdemov2.Album{}
+		require.NoError(t, v2.Data.UnmarshalTo(v2Data))
+		require.Equal(t, v2Data.Title, v1Data.Name)
+		require.Equal(t, v2Data.Tracks, v1Data.Tracks)
+
+		// the original resource is not modified
+		prototest.AssertDeepEqual(t, demo.TypeV2Album, v2.Id.Type)
+
+		back, err := resource.Convert(r, v1, demo.TypeV2Album)
+		require.NoError(t, err)
+		prototest.AssertDeepEqual(t, v2, back)
+	})
+
+	t.Run("no conversion", func(t *testing.T) {
+		_, err := resource.Convert(r, artist, demo.TypeV1Artist)
+		require.True(t, errors.Is(err, resource.ErrNoConversion))
+	})
+
+	t.Run("wrong type returned", func(t *testing.T) {
+		r := resource.NewRegistry()
+		r.Register(resource.Registration{
+			Type:  demo.TypeV1Album,
+			Proto: &pbdemov1.Album{},
+			Conversions: map[string]resource.ConvertFunc{
+				demo.TypeV2Album.GroupVersion: func(data proto.Message) (proto.Message, error) {
+					return data, nil
+				},
+			},
+		})
+
+		_, err := resource.Convert(r, v2, demo.TypeV1Album)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "returned data of wrong type")
+	})
+}
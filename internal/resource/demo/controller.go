@@ -0,0 +1,94 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package demo
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/hashicorp/consul/internal/controller"
+	"github.com/hashicorp/consul/internal/resource"
+	"github.com/hashicorp/consul/proto-public/pbresource"
+)
+
+const (
+	// StatusKey is the key under which the artist controller writes its status.
+	StatusKey = "consul.io/artist-controller"
+
+	// ConditionAlbumsReleased is the type of the condition written by the artist
+	// controller to indicate whether the artist owns any albums.
+	ConditionAlbumsReleased = "AlbumsReleased"
+)
+
+// RegisterControllers registers controllers for the demo types. Should only be
+// called in dev mode.
+func RegisterControllers(mgr *controller.Manager) {
+	mgr.Register(artistController())
+}
+
+func artistController() controller.Controller {
+	return controller.ForType(TypeV2Artist).
+		WithWatch(TypeV2Album, controller.MapOwner).
+		WithReconciler(&artistReconciler{})
+}
+
+type artistReconciler struct{}
+
+func (r *artistReconciler) Reconcile(ctx context.Context, rt controller.Runtime, req controller.Request) error {
+	rsp, err := rt.Client.Read(ctx, &pbresource.ReadRequest{Id: req.ID})
+	switch {
+	case status.Code(err) == codes.NotFound:
+		return nil
+	case err != nil:
+		return err
+	}
+	res := rsp.Resource
+
+	albums, err := rt.Client.List(ctx, &pbresource.ListRequest{
+		Type:       TypeV2Album,
+		Tenancy:    res.Id.Tenancy,
+		NamePrefix: fmt.Sprintf("%s/", res.Id.Name),
+	})
+	if err != nil {
+		return err
+	}
+
+	var owned int
+	for _, album := range albums.Resources {
+		if proto.Equal(album.Owner, res.Id) {
+			owned++
+		}
+	}
+
+	condition := &pbresource.Condition{
+		Type:    ConditionAlbumsReleased,
+		State:   pbresource.Condition_STATE_FALSE,
+		Reason:  "NoAlbums",
+		Message: "Artist has not released any albums",
+	}
+	if owned != 0 {
+		condition.State = pbresource.Condition_STATE_TRUE
+		condition.Reason = "HasAlbums"
+		condition.Message = fmt.Sprintf("Artist has released %d album(s)", owned)
+	}
+
+	newStatus := &pbresource.Status{
+		ObservedGeneration: res.Generation,
+		Conditions:         []*pbresource.Condition{condition},
+	}
+	if resource.EqualStatusConditions(res.Status[StatusKey], newStatus) {
+		return nil
+	}
+
+	_, err = rt.Client.WriteStatus(ctx, &pbresource.WriteStatusRequest{
+		Id:     res.Id,
+		Key:    StatusKey,
+		Status: newStatus,
+	})
+	return err
+}
@@ -0,0 +1,68 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package demo_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	svctest "github.com/hashicorp/consul/agent/grpc-external/services/resource/testing"
+	"github.com/hashicorp/consul/internal/controller"
+	"github.com/hashicorp/consul/internal/resource/demo"
+	"github.com/hashicorp/consul/proto-public/pbresource"
+	"github.com/hashicorp/consul/sdk/testutil"
+	"github.com/hashicorp/consul/sdk/testutil/retry"
+)
+
+func TestArtistController(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	client := svctest.RunResourceService(t, demo.Register)
+
+	mgr := controller.NewManager(client, testutil.Logger(t))
+	demo.RegisterControllers(mgr)
+	mgr.SetRaftLeader(true)
+	go mgr.Run(ctx)
+
+	artist, err := demo.GenerateV2Artist()
+	require.NoError(t, err)
+
+	rsp, err := client.Write(ctx, &pbresource.WriteRequest{Resource: artist})
+	require.NoError(t, err)
+	artist = rsp.Resource
+
+	requireCondition(t, client, artist.Id, pbresource.Condition_STATE_FALSE, "NoAlbums")
+
+	album, err := demo.GenerateV2Album(artist.Id)
+	require.NoError(t, err)
+
+	_, err = client.Write(ctx, &pbresource.WriteRequest{Resource: album})
+	require.NoError(t, err)
+
+	requireCondition(t, client, artist.Id, pbresource.Condition_STATE_TRUE, "HasAlbums")
+}
+
+func requireCondition(t *testing.T, client pbresource.ResourceServiceClient, id *pbresource.ID, state pbresource.Condition_State, reason string) {
+	t.Helper()
+
+	retry.Run(t, func(r *retry.R) {
+		rsp, err := client.Read(context.Background(), &pbresource.ReadRequest{Id: id})
+		require.NoError(r, err)
+
+		status, ok := rsp.Resource.Status[demo.StatusKey]
+		require.True(r, ok, "status not found")
+		require.Equal(r, rsp.Resource.Generation, status.ObservedGeneration)
+		require.Len(r, status.Conditions, 1)
+
+		condition := status.Conditions[0]
+		require.Equal(r, demo.ConditionAlbumsReleased, condition.Type)
+		require.Equal(r, state, condition.State)
+		require.Equal(r, reason, condition.Reason)
+	})
+}
@@ -6,11 +6,14 @@
 package demo
 
 import (
+	"context"
 	"fmt"
 	"math/rand"
 	"strings"
 	"time"
 
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/anypb"
 
@@ -108,9 +111,30 @@ func Register(r resource.Registry) {
 		if artist.Name == "" {
 			return fmt.Errorf("artist.name required")
 		}
+		if ref := artist.InfluencedBy; ref != nil {
+			if !proto.Equal(ref.Type, TypeV2Artist) || ref.Tenancy == nil || ref.Name == "" {
+				return fmt.Errorf("artist.influenced_by must reference an artist")
+			}
+		}
 		return nil
 	}
 
+	admitV2ArtistFn := func(ctx context.Context, reader resource.Reader, res *pbresource.Resource) error {
+		artist := &pbdemov2.Artist{}
+		if err := anypb.UnmarshalTo(res.Data, artist, proto.UnmarshalOptions{}); err != nil {
+			return err
+		}
+		if artist.InfluencedBy == nil {
+			return nil
+		}
+
+		_, err := reader.Read(ctx, resource.IDFromReference(artist.InfluencedBy))
+		if status.Code(err) == codes.NotFound {
+			return status.Errorf(codes.FailedPrecondition, "artist %q referenced by artist.influenced_by does not exist", artist.InfluencedBy.Name)
+		}
+		return err
+	}
+
 	mutateV2ArtistFn := func(res *pbresource.Resource) error {
 		// Not a realistic use for this hook, but set genre if not specified
 		artist := &pbdemov2.Artist{}
@@ -143,6 +167,9 @@ func Register(r resource.Registry) {
 			Write: writeACL,
 			List:  makeListACL(TypeV1Album),
 		},
+		Conversions: map[string]resource.ConvertFunc{
+			TypeV2Album.GroupVersion: convertV2AlbumToV1,
+		},
 	})
 
 	r.Register(resource.Registration{
@@ -155,6 +182,7 @@ func Register(r resource.Registry) {
 		},
 		Validate: validateV2ArtistFn,
 		Mutate:   mutateV2ArtistFn,
+		Admit:    admitV2ArtistFn,
 	})
 
 	r.Register(resource.Registration{
@@ -165,9 +193,35 @@ func Register(r resource.Registry) {
 			Write: writeACL,
 			List:  makeListACL(TypeV2Album),
 		},
+		Conversions: map[string]resource.ConvertFunc{
+			TypeV1Album.GroupVersion: convertV1AlbumToV2,
+		},
+		StorageVersion: true,
 	})
 }
 
+// Albums were renamed between v1 and v2, so we can convert between them. The
+// same isn't true of artists, whose group members changed shape.
+func convertV1AlbumToV2(data proto.Message) (proto.Message, error) {
+	album := data.(*pbdemov1.Album)
+	return &pbdemov2.Album{
+		Title:              album.Name,
+		YearOfRelease:      album.YearOfRelease,
+		CriticallyAclaimed: album.CriticallyAclaimed,
+		Tracks:             album.Tracks,
+	}, nil
+}
+
+func convertV2AlbumToV1(data proto.Message) (proto.Message, error) {
+	album := data.(*pbdemov2.Album)
+	return &pbdemov1.Album{
+		Name:               album.Title,
+		YearOfRelease:      album.YearOfRelease,
+		CriticallyAclaimed: album.CriticallyAclaimed,
+		Tracks:             album.Tracks,
+	}, nil
+}
+
 // GenerateV2Artist generates a random Artist resource.
 func GenerateV2Artist() (*pbresource.Resource, error) {
 	adjective := adjectives[rand.Intn(len(adjectives))]
@@ -0,0 +1,93 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"strings"
+
+	"github.com/hashicorp/consul/proto-public/pbresource"
+)
+
+const (
+	// FinalizerKey is the key of the Resource.Metadata entry that holds the
+	// resource's finalizers, as a space-separated list.
+	//
+	// A resource with finalizers isn't deleted straight away. Instead, the
+	// Resource Service marks it for deletion (see DeletionTimestampKey) and it's
+	// only deleted once its finalizers have been removed. This gives controllers
+	// an opportunity to clean up external state first.
+	FinalizerKey = "finalizers"
+
+	// DeletionTimestampKey is the key of the Resource.Metadata entry that marks
+	// a resource with finalizers for deletion. It's set by the Resource Service,
+	// to the time the deletion was requested, and cannot be changed.
+	//
+	// Once a resource is marked for deletion, the only change that can be made to
+	// it is to remove its finalizers.
+	DeletionTimestampKey = "deletionTimestamp"
+
+	// ForegroundDeletionFinalizer is the finalizer added to a resource deleted
+	// with foreground propagation while it still owns other resources. The
+	// garbage collector removes it, and so deletes the resource, once the owned
+	// resources are gone.
+	ForegroundDeletionFinalizer = "consul.io/foreground-deletion"
+)
+
+// Finalizers returns the resource's finalizers.
+func Finalizers(res *pbresource.Resource) []string {
+	return strings.Fields(res.Metadata[FinalizerKey])
+}
+
+// HasFinalizers returns whether the resource has any finalizers.
+func HasFinalizers(res *pbresource.Resource) bool {
+	return len(Finalizers(res)) != 0
+}
+
+// HasFinalizer returns whether the resource has the given finalizer.
+func HasFinalizer(res *pbresource.Resource, finalizer string) bool {
+	for _, f := range Finalizers(res) {
+		if f == finalizer {
+			return true
+		}
+	}
+	return false
+}
+
+// AddFinalizer adds the given finalizer to the resource, if it doesn't already
+// have it.
+func AddFinalizer(res *pbresource.Resource, finalizer string) {
+	if HasFinalizer(res, finalizer) {
+		return
+	}
+	setFinalizers(res, append(Finalizers(res), finalizer))
+}
+
+// RemoveFinalizer removes the given finalizer from the resource.
+func RemoveFinalizer(res *pbresource.Resource, finalizer string) {
+	var remaining []string
+	for _, f := range Finalizers(res) {
+		if f != finalizer {
+			remaining = append(remaining, f)
+		}
+	}
+	setFinalizers(res, remaining)
+}
+
+func setFinalizers(res *pbresource.Resource, finalizers []string) {
+	if len(finalizers) == 0 {
+		delete(res.Metadata, FinalizerKey)
+		return
+	}
+	if res.Metadata == nil {
+		res.Metadata = make(map[string]string)
+	}
+	res.Metadata[FinalizerKey] = strings.Join(finalizers, " ")
+}
+
+// IsMarkedForDeletion returns whether the resource has been marked for deletion
+// and is waiting for its finalizers to be removed.
+func IsMarkedForDeletion(res *pbresource.Resource) bool {
+	_, ok := res.Metadata[DeletionTimestampKey]
+	return ok
+}
@@ -0,0 +1,40 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/consul/internal/resource"
+	"github.com/hashicorp/consul/proto-public/pbresource"
+)
+
+func TestFinalizers(t *testing.T) {
+	res := &pbresource.Resource{}
+	require.False(t, resource.HasFinalizers(res))
+	require.Empty(t, resource.Finalizers(res))
+
+	resource.AddFinalizer(res, "a")
+	resource.AddFinalizer(res, "b")
+	resource.AddFinalizer(res, "a")
+	require.True(t, resource.HasFinalizers(res))
+	require.True(t, resource.HasFinalizer(res, "a"))
+	require.True(t, resource.HasFinalizer(res, "b"))
+	require.False(t, resource.HasFinalizer(res, "c"))
+	require.Equal(t, []string{"a", "b"}, resource.Finalizers(res))
+	require.Equal(t, "a b", res.Metadata[resource.FinalizerKey])
+
+	resource.RemoveFinalizer(res, "a")
+	require.Equal(t, []string{"b"}, resource.Finalizers(res))
+
+	resource.RemoveFinalizer(res, "b")
+	require.False(t, resource.HasFinalizers(res))
+	require.NotContains(t, res.Metadata, resource.FinalizerKey)
+
+	require.False(t, resource.IsMarkedForDeletion(res))
+	res.Metadata[resource.DeletionTimestampKey] = "2023-05-01T00:00:00Z"
+	require.True(t, resource.IsMarkedForDeletion(res))
+}
@@ -0,0 +1,134 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Package migration rewrites stored resources to the storage version of their
+// type, so that support for older GroupVersions can eventually be removed.
+package migration
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+
+	"github.com/hashicorp/consul/internal/resource"
+	"github.com/hashicorp/consul/internal/storage"
+	"github.com/hashicorp/consul/lib/retry"
+	"github.com/hashicorp/consul/proto-public/pbresource"
+)
+
+// Migrator watches the resources of every type that has a registered storage
+// version, and rewrites any that are stored at a different GroupVersion to the
+// storage version, using the type's registered conversions.
+//
+// It must only be run on the Raft leader.
+type Migrator struct {
+	Backend  storage.Backend
+	Registry resource.Registry
+	Logger   hclog.Logger
+}
+
+// Run the migrator until the given context is canceled.
+func (m *Migrator) Run(ctx context.Context) error {
+	var wg sync.WaitGroup
+	for _, reg := range m.Registry.Types() {
+		if !reg.StorageVersion {
+			continue
+		}
+
+		wg.Add(1)
+		go func(typ *pbresource.Type) {
+			defer wg.Done()
+			m.runType(ctx, typ)
+		}(reg.Type)
+	}
+	wg.Wait()
+	return nil
+}
+
+// runType migrates resources of the given type, re-establishing the watch with
+// backoff if it fails.
+func (m *Migrator) runType(ctx context.Context, typ *pbresource.Type) {
+	logger := m.Logger.With("resource-type", resource.ToGVK(typ))
+	waiter := &retry.Waiter{
+		MinFailures: 1,
+		MinWait:     time.Second,
+		MaxWait:     time.Minute,
+		Jitter:      retry.NewJitter(10),
+	}
+
+	for {
+		err := m.watchType(ctx, logger, typ)
+		if ctx.Err() != nil {
+			return
+		}
+		logger.Error("failed to watch resources for migration", "error", err)
+
+		if err := waiter.Wait(ctx); err != nil {
+			return
+		}
+	}
+}
+
+func (m *Migrator) watchType(ctx context.Context, logger hclog.Logger, typ *pbresource.Type) error {
+	watch, err := m.Backend.WatchList(
+		ctx,
+		storage.UnversionedTypeFrom(typ),
+		&pbresource.Tenancy{
+			Partition: storage.Wildcard,
+			PeerName:  storage.Wildcard,
+			Namespace: storage.Wildcard,
+		},
+		"",
+	)
+	if err != nil {
+		return err
+	}
+
+	for {
+		event, err := watch.Next(ctx)
+		if err != nil {
+			return err
+		}
+
+		if event.Operation != pbresource.WatchEvent_OPERATION_UPSERT {
+			continue
+		}
+
+		if err := m.migrate(ctx, event.Resource, typ); err != nil {
+			logger.Error("failed to migrate resource",
+				"resource-name", event.Resource.Id.Name,
+				"group-version", event.Resource.Id.Type.GroupVersion,
+				"error", err,
+			)
+		}
+	}
+}
+
+// migrate rewrites the given resource at the storage version, if it's stored
+// at a different GroupVersion that can be converted.
+func (m *Migrator) migrate(ctx context.Context, res *pbresource.Resource, typ *pbresource.Type) error {
+	if res.Id.Type.GroupVersion == typ.GroupVersion {
+		return nil
+	}
+
+	if !resource.CanConvert(m.Registry, res.Id.Type, typ) {
+		return nil
+	}
+
+	converted, err := resource.Convert(m.Registry, res, typ)
+	if err != nil {
+		return err
+	}
+
+	// The resource's Version gives us CAS semantics, so we won't overwrite a
+	// concurrent change. If the resource has changed, the watch will deliver the
+	// new version to be migrated instead.
+	_, err = m.Backend.WriteCAS(ctx, converted)
+	if errors.Is(err, storage.ErrCASFailure) || errors.Is(err, storage.ErrWrongUid) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,96 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package migration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/oklog/ulid/v2"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"github.com/hashicorp/consul/internal/resource"
+	"github.com/hashicorp/consul/internal/resource/demo"
+	"github.com/hashicorp/consul/internal/storage"
+	"github.com/hashicorp/consul/internal/storage/inmem"
+	"github.com/hashicorp/consul/proto-public/pbresource"
+	pbdemov1 "github.com/hashicorp/consul/proto/private/pbdemo/v1"
+	"github.com/hashicorp/consul/proto/private/prototest"
+	"github.com/hashicorp/consul/sdk/testutil"
+	"github.com/hashicorp/consul/sdk/testutil/retry"
+)
+
+func TestMigrator(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	backend, err := inmem.NewBackend()
+	require.NoError(t, err)
+	go backend.Run(ctx)
+
+	registry := resource.NewRegistry()
+	demo.Register(registry)
+
+	// Artists have no storage version, so are left alone.
+	artistData, err := anypb.New(&pbdemov1.Artist{Name: "Korn"})
+	require.NoError(t, err)
+
+	artist, err := backend.WriteCAS(ctx, &pbresource.Resource{
+		Id: &pbresource.ID{
+			Type:    demo.TypeV1Artist,
+			Tenancy: demo.TenancyDefault,
+			Name:    "korn",
+			Uid:     ulid.Make().String(),
+		},
+		Data: artistData,
+	})
+	require.NoError(t, err)
+
+	// Write an album before starting the migrator, so it's migrated from the
+	// initial snapshot, and another after.
+	before, err := backend.WriteCAS(ctx, generateV1Album(t, registry, artist.Id))
+	require.NoError(t, err)
+
+	migrator := &Migrator{
+		Backend:  backend,
+		Registry: registry,
+		Logger:   testutil.Logger(t),
+	}
+	go migrator.Run(ctx)
+
+	after, err := backend.WriteCAS(ctx, generateV1Album(t, registry, artist.Id))
+	require.NoError(t, err)
+
+	for _, album := range []*pbresource.Resource{before, after} {
+		expected, err := resource.Convert(registry, album, demo.TypeV2Album)
+		require.NoError(t, err)
+
+		retry.Run(t, func(r *retry.R) {
+			migrated, err := backend.Read(ctx, storage.EventualConsistency, expected.Id)
+			require.NoError(r, err)
+			prototest.AssertDeepEqual(r, expected.Id, migrated.Id)
+			prototest.AssertDeepEqual(r, expected.Data, migrated.Data)
+		})
+	}
+
+	stored, err := backend.Read(ctx, storage.EventualConsistency, artist.Id)
+	require.NoError(t, err)
+	require.Equal(t, artist.Version, stored.Version)
+}
+
+func generateV1Album(t *testing.T, registry resource.Registry, artistID *pbresource.ID) *pbresource.Resource {
+	t.Helper()
+
+	album, err := demo.GenerateV2Album(artistID)
+	require.NoError(t, err)
+
+	album, err = resource.Convert(registry, album, demo.TypeV1Album)
+	require.NoError(t, err)
+
+	album.Id.Uid = ulid.Make().String()
+	return album
+}
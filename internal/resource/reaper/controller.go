@@ -0,0 +1,185 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Package reaper contains the controller responsible for garbage collecting
+// resources whose owner has been deleted.
+package reaper
+
+import (
+	"context"
+	"time"
+
+	"github.com/oklog/ulid/v2"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/hashicorp/consul/internal/controller"
+	"github.com/hashicorp/consul/internal/resource"
+	"github.com/hashicorp/consul/proto-public/pbresource"
+)
+
+// secondPassDelay is how long we wait after a resource is deleted before
+// looking for owned resources again and discarding its tombstone.
+//
+// OwnerReferences only guarantees to return the references that existed when
+// the owner was deleted (see the storage.Backend docs), so a resource written
+// concurrently with the owner's deletion may be missed by the first pass.
+const secondPassDelay = 1 * time.Hour
+
+// foregroundDeletionInterval is how often we check whether the resources owned
+// by a resource marked for foreground deletion are gone.
+const foregroundDeletionInterval = 1 * time.Second
+
+// RegisterControllers registers the controllers responsible for garbage
+// collection. Should be called on all servers.
+func RegisterControllers(mgr *controller.Manager) {
+	mgr.Register(reaperController(secondPassDelay))
+}
+
+func reaperController(secondPassDelay time.Duration) controller.Controller {
+	return controller.ForType(resource.TypeV1Tombstone).
+		WithReconciler(&reaperReconciler{
+			secondPassDelay: secondPassDelay,
+			now:             time.Now,
+		})
+}
+
+type reaperReconciler struct {
+	secondPassDelay time.Duration
+	now             func() time.Time
+}
+
+// Reconcile deletes the resources owned by the tombstone's owner, in two passes
+// separated by secondPassDelay, and then deletes the tombstone itself.
+//
+// Deleting an owned resource writes a tombstone of its own, so the deletion
+// cascades through the whole ownership tree.
+func (r *reaperReconciler) Reconcile(ctx context.Context, rt controller.Runtime, req controller.Request) error {
+	rsp, err := rt.Client.Read(ctx, &pbresource.ReadRequest{Id: req.ID})
+	switch {
+	case status.Code(err) == codes.NotFound:
+		return nil
+	case err != nil:
+		return err
+	}
+	res := rsp.Resource
+
+	var tombstone pbresource.Tombstone
+	if err := res.Data.UnmarshalTo(&tombstone); err != nil {
+		rt.Logger.Error("invalid tombstone, deleting it", "name", res.Id.Name, "error", err)
+		return r.deleteTombstone(ctx, rt, res)
+	}
+	owner := tombstone.Owner
+
+	// The tombstone is written before the owner is deleted, so if the owner still
+	// exists the delete is either in-flight, failed, or waiting for its owned
+	// resources to be deleted first.
+	ownerRsp, err := rt.Client.Read(ctx, &pbresource.ReadRequest{Id: owner})
+	switch {
+	case err == nil:
+		if res := ownerRsp.Resource; resource.IsMarkedForDeletion(res) && resource.HasFinalizer(res, resource.ForegroundDeletionFinalizer) {
+			return r.deleteForeground(ctx, rt, res)
+		}
+		if remaining := r.remaining(res); remaining > 0 {
+			return controller.RequeueAfter(remaining)
+		}
+		rt.Logger.Trace("owner was not deleted, discarding tombstone", "owner", owner.Name)
+		return r.deleteTombstone(ctx, rt, res)
+	case status.Code(err) == codes.InvalidArgument:
+		// The owner's type has been unregistered (or its GroupVersion changed), so
+		// we're unable to garbage collect its resources.
+		rt.Logger.Warn("unable to garbage collect owned resources, discarding tombstone",
+			"owner_type", resource.ToGVK(owner.Type),
+			"owner", owner.Name,
+			"error", err,
+		)
+		return r.deleteTombstone(ctx, rt, res)
+	case status.Code(err) != codes.NotFound:
+		return err
+	}
+
+	owned, err := rt.Client.ListByOwner(ctx, &pbresource.ListByOwnerRequest{Owner: owner})
+	if err != nil {
+		return err
+	}
+
+	for _, child := range owned.Resources {
+		rt.Logger.Trace("deleting owned resource",
+			"owner", owner.Name,
+			"resource_type", resource.ToGVK(child.Id.Type),
+			"resource", child.Id.Name,
+		)
+
+		// Child ID includes the Uid, so we won't delete a newer resource that
+		// happens to have the same name.
+		if _, err := rt.Client.Delete(ctx, &pbresource.DeleteRequest{Id: child.Id}); err != nil {
+			return err
+		}
+	}
+
+	if remaining := r.remaining(res); remaining > 0 {
+		return controller.RequeueAfter(remaining)
+	}
+	return r.deleteTombstone(ctx, rt, res)
+}
+
+// deleteForeground deletes the resources owned by a resource marked for
+// foreground deletion, and then removes its finalizer so that it's deleted too
+// (unless it has other finalizers).
+func (r *reaperReconciler) deleteForeground(ctx context.Context, rt controller.Runtime, owner *pbresource.Resource) error {
+	owned, err := rt.Client.ListByOwner(ctx, &pbresource.ListByOwnerRequest{Owner: owner.Id})
+	if err != nil {
+		return err
+	}
+
+	if len(owned.Resources) != 0 {
+		for _, child := range owned.Resources {
+			// The child is waiting for its finalizers to be removed, or its own owned
+			// resources to be deleted.
+			if resource.IsMarkedForDeletion(child) {
+				continue
+			}
+
+			rt.Logger.Trace("deleting owned resource",
+				"owner", owner.Id.Name,
+				"resource_type", resource.ToGVK(child.Id.Type),
+				"resource", child.Id.Name,
+			)
+
+			_, err := rt.Client.Delete(ctx, &pbresource.DeleteRequest{
+				Id:          child.Id,
+				Propagation: pbresource.DeleteRequest_PROPAGATION_FOREGROUND,
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return controller.RequeueAfter(foregroundDeletionInterval)
+	}
+
+	updated := proto.Clone(owner).(*pbresource.Resource)
+	resource.RemoveFinalizer(updated, resource.ForegroundDeletionFinalizer)
+	if _, err := rt.Client.Write(ctx, &pbresource.WriteRequest{Resource: updated}); err != nil {
+		return err
+	}
+
+	// Garbage collect the owned resources written concurrently with the owner's
+	// deletion, as for any other tombstone.
+	return controller.RequeueNow()
+}
+
+// remaining returns how long until the second pass is due. Tombstones are
+// written with a ULID Uid, so we use its timestamp as the time of deletion.
+func (r *reaperReconciler) remaining(res *pbresource.Resource) time.Duration {
+	id, err := ulid.Parse(res.Id.Uid)
+	if err != nil {
+		return 0
+	}
+	return ulid.Time(id.Time()).Add(r.secondPassDelay).Sub(r.now())
+}
+
+func (r *reaperReconciler) deleteTombstone(ctx context.Context, rt controller.Runtime, res *pbresource.Resource) error {
+	_, err := rt.Client.Delete(ctx, &pbresource.DeleteRequest{Id: res.Id, Version: res.Version})
+	return err
+}
@@ -0,0 +1,200 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package reaper
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/oklog/ulid/v2"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	svctest "github.com/hashicorp/consul/agent/grpc-external/services/resource/testing"
+	"github.com/hashicorp/consul/internal/controller"
+	"github.com/hashicorp/consul/internal/resource"
+	"github.com/hashicorp/consul/internal/resource/demo"
+	"github.com/hashicorp/consul/proto-public/pbresource"
+	"github.com/hashicorp/consul/sdk/testutil"
+	"github.com/hashicorp/consul/sdk/testutil/retry"
+)
+
+func TestReaperController(t *testing.T) {
+	t.Parallel()
+
+	client := runReaper(t)
+	ctx := context.Background()
+
+	artist, err := demo.GenerateV2Artist()
+	require.NoError(t, err)
+
+	rsp, err := client.Write(ctx, &pbresource.WriteRequest{Resource: artist})
+	require.NoError(t, err)
+	artist = rsp.Resource
+
+	album, err := demo.GenerateV2Album(artist.Id)
+	require.NoError(t, err)
+
+	rsp, err = client.Write(ctx, &pbresource.WriteRequest{Resource: album})
+	require.NoError(t, err)
+	album = rsp.Resource
+
+	_, err = client.Delete(ctx, &pbresource.DeleteRequest{Id: artist.Id})
+	require.NoError(t, err)
+
+	// The album is deleted by the first pass.
+	retry.Run(t, func(r *retry.R) {
+		_, err := client.Read(ctx, &pbresource.ReadRequest{Id: album.Id})
+		require.Equal(r, codes.NotFound.String(), status.Code(err).String())
+	})
+
+	// The artist's and album's tombstones are deleted after the second pass.
+	retry.Run(t, func(r *retry.R) {
+		rsp, err := client.List(ctx, &pbresource.ListRequest{
+			Type:    resource.TypeV1Tombstone,
+			Tenancy: demo.TenancyDefault,
+		})
+		require.NoError(r, err)
+		require.Empty(r, rsp.Resources)
+	})
+}
+
+func TestReaperController_ForegroundDeletion(t *testing.T) {
+	t.Parallel()
+
+	client := runReaper(t)
+	ctx := context.Background()
+
+	artist, err := demo.GenerateV2Artist()
+	require.NoError(t, err)
+
+	rsp, err := client.Write(ctx, &pbresource.WriteRequest{Resource: artist})
+	require.NoError(t, err)
+	artist = rsp.Resource
+
+	album, err := demo.GenerateV2Album(artist.Id)
+	require.NoError(t, err)
+	resource.AddFinalizer(album, "test")
+
+	rsp, err = client.Write(ctx, &pbresource.WriteRequest{Resource: album})
+	require.NoError(t, err)
+	album = rsp.Resource
+
+	_, err = client.Delete(ctx, &pbresource.DeleteRequest{
+		Id:          artist.Id,
+		Propagation: pbresource.DeleteRequest_PROPAGATION_FOREGROUND,
+	})
+	require.NoError(t, err)
+
+	// The album is marked for deletion, and the artist waits for it to be gone.
+	retry.Run(t, func(r *retry.R) {
+		rsp, err := client.Read(ctx, &pbresource.ReadRequest{Id: album.Id})
+		require.NoError(r, err)
+		require.True(r, resource.IsMarkedForDeletion(rsp.Resource))
+		album = rsp.Resource
+	})
+
+	_, err = client.Read(ctx, &pbresource.ReadRequest{Id: artist.Id})
+	require.NoError(t, err)
+
+	// Removing the finalizer deletes the album, and then the artist.
+	resource.RemoveFinalizer(album, "test")
+	_, err = client.Write(ctx, &pbresource.WriteRequest{Resource: album})
+	require.NoError(t, err)
+
+	retry.Run(t, func(r *retry.R) {
+		_, err := client.Read(ctx, &pbresource.ReadRequest{Id: artist.Id})
+		require.Equal(r, codes.NotFound.String(), status.Code(err).String())
+	})
+}
+
+func TestReaperController_OwnerNotDeleted(t *testing.T) {
+	t.Parallel()
+
+	client := runReaper(t)
+	ctx := context.Background()
+
+	artist, err := demo.GenerateV2Artist()
+	require.NoError(t, err)
+
+	rsp, err := client.Write(ctx, &pbresource.WriteRequest{Resource: artist})
+	require.NoError(t, err)
+	artist = rsp.Resource
+
+	album, err := demo.GenerateV2Album(artist.Id)
+	require.NoError(t, err)
+
+	rsp, err = client.Write(ctx, &pbresource.WriteRequest{Resource: album})
+	require.NoError(t, err)
+	album = rsp.Resource
+
+	// Simulate a failed delete by writing a tombstone without deleting the artist.
+	data, err := anypb.New(&pbresource.Tombstone{Owner: artist.Id})
+	require.NoError(t, err)
+
+	rsp, err = client.Write(ctx, &pbresource.WriteRequest{
+		Resource: &pbresource.Resource{
+			Id: &pbresource.ID{
+				Type:    resource.TypeV1Tombstone,
+				Tenancy: artist.Id.Tenancy,
+				Name:    resource.TombstoneNameFor(artist.Id),
+			},
+			Data: data,
+		},
+	})
+	require.NoError(t, err)
+	tombstone := rsp.Resource
+
+	retry.Run(t, func(r *retry.R) {
+		_, err := client.Read(ctx, &pbresource.ReadRequest{Id: tombstone.Id})
+		require.Equal(r, codes.NotFound.String(), status.Code(err).String())
+	})
+
+	// Neither the artist nor the album should have been deleted.
+	_, err = client.Read(ctx, &pbresource.ReadRequest{Id: artist.Id})
+	require.NoError(t, err)
+
+	_, err = client.Read(ctx, &pbresource.ReadRequest{Id: album.Id})
+	require.NoError(t, err)
+}
+
+func TestReaperReconciler_Remaining(t *testing.T) {
+	now := time.Now()
+	r := &reaperReconciler{
+		secondPassDelay: time.Hour,
+		now:             func() time.Time { return now },
+	}
+
+	newTombstone := func(createdAt time.Time) *pbresource.Resource {
+		return &pbresource.Resource{
+			Id: &pbresource.ID{Uid: ulid.MustNew(ulid.Timestamp(createdAt), nil).String()},
+		}
+	}
+
+	require.Equal(t, time.Hour, r.remaining(newTombstone(now)).Round(time.Second))
+	require.Equal(t, 15*time.Minute, r.remaining(newTombstone(now.Add(-45*time.Minute))).Round(time.Second))
+	require.LessOrEqual(t, r.remaining(newTombstone(now.Add(-2*time.Hour))), time.Duration(0))
+
+	// Tombstones without a ULID are collected after a single pass.
+	require.Equal(t, time.Duration(0), r.remaining(&pbresource.Resource{Id: &pbresource.ID{Uid: "not-a-ulid"}}))
+}
+
+func runReaper(t *testing.T) pbresource.ResourceServiceClient {
+	t.Helper()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	client := svctest.RunResourceService(t, demo.Register)
+
+	mgr := controller.NewManager(client, testutil.Logger(t))
+	mgr.Register(reaperController(100 * time.Millisecond))
+	mgr.SetRaftLeader(true)
+	go mgr.Run(ctx)
+
+	return client
+}
@@ -14,3 +14,13 @@ func Reference(id *pbresource.ID, section string) *pbresource.Reference {
 		Section: section,
 	}
 }
+
+// IDFromReference returns the ID of the resource the given reference refers
+// to. It has no Uid, so it will match any incarnation of the resource.
+func IDFromReference(ref *pbresource.Reference) *pbresource.ID {
+	return &pbresource.ID{
+		Type:    ref.Type,
+		Tenancy: ref.Tenancy,
+		Name:    ref.Name,
+	}
+}
@@ -4,7 +4,9 @@
 package resource
 
 import (
+	"context"
 	"fmt"
+	"strings"
 	"sync"
 
 	"google.golang.org/protobuf/proto"
@@ -19,6 +21,9 @@ type Registry interface {
 
 	// Resolve the given resource type and its hooks.
 	Resolve(typ *pbresource.Type) (reg Registration, ok bool)
+
+	// Types returns all of the registered resource types and their hooks.
+	Types() []Registration
 }
 
 type Registration struct {
@@ -38,10 +43,44 @@ type Registration struct {
 	// Mutate is called to fill out any autogenerated fields (e.g. UUIDs).
 	Mutate func(*pbresource.Resource) error
 
-	// In the future, we'll add hooks, the controller etc. here.
-	// TODO: https://github.com/hashicorp/consul/pull/16622#discussion_r1134515909
+	// Admit is called after Validate and Mutate to check the resource against
+	// the resources it relates to (e.g. to reject a write that references a
+	// resource that doesn't exist). Unlike Validate, it may read other resources
+	// using the given Reader, but must not modify the resource.
+	//
+	// Returning a gRPC status error rejects the write with that status, other
+	// errors reject it with InvalidArgument.
+	Admit func(ctx context.Context, reader Reader, res *pbresource.Resource) error
+
+	// Conversions are called to convert the data of resources stored at other
+	// GroupVersions of the same group and kind to this type, keyed by the other
+	// GroupVersion. They allow the Resource Service to transparently return those
+	// resources as this type, see Convert.
+	Conversions map[string]ConvertFunc
+
+	// StorageVersion indicates this is the GroupVersion at which resources of the
+	// group and kind should be stored. Resources stored at other GroupVersions
+	// will be rewritten at this GroupVersion by the leader, using Conversions.
+	//
+	// At most one GroupVersion of each group and kind may be the StorageVersion.
+	StorageVersion bool
+}
+
+// Reader is used by admission hooks to read other resources. Its errors have the
+// same gRPC status codes as the Resource Service (e.g. NotFound).
+type Reader interface {
+	// Read the resource with the given ID.
+	Read(ctx context.Context, id *pbresource.ID) (*pbresource.Resource, error)
+
+	// List resources of the given type and tenancy, optionally matching the
+	// given name prefix.
+	List(ctx context.Context, typ *pbresource.Type, tenancy *pbresource.Tenancy, namePrefix string) ([]*pbresource.Resource, error)
 }
 
+// ConvertFunc converts resource data of another GroupVersion (i.e. an instance
+// of that type's Proto) to an instance of the registration's Proto.
+type ConvertFunc func(proto.Message) (proto.Message, error)
+
 type ACLHooks struct {
 	// Read is used to authorize Read RPCs and to filter results in List
 	// RPCs.
@@ -68,9 +107,11 @@ type TypeRegistry struct {
 }
 
 func NewRegistry() Registry {
-	return &TypeRegistry{
+	registry := &TypeRegistry{
 		registrations: make(map[string]Registration),
 	}
+	registerTombstone(registry)
+	return registry
 }
 
 func (r *TypeRegistry) Register(registration Registration) {
@@ -87,6 +128,18 @@ func (r *TypeRegistry) Register(registration Registration) {
 		panic(fmt.Sprintf("resource type %s already registered", key))
 	}
 
+	if _, ok := registration.Conversions[typ.GroupVersion]; ok {
+		panic(fmt.Sprintf("resource type %s cannot have a conversion from its own GroupVersion", key))
+	}
+
+	if registration.StorageVersion {
+		for _, other := range r.registrations {
+			if other.StorageVersion && other.Type.Group == typ.Group && other.Type.Kind == typ.Kind {
+				panic(fmt.Sprintf("resource type %s cannot be the storage version, %s already is", key, ToGVK(other.Type)))
+			}
+		}
+	}
+
 	// set default acl hooks for those not provided
 	if registration.ACLs == nil {
 		registration.ACLs = &ACLHooks{}
@@ -117,6 +170,11 @@ func (r *TypeRegistry) Register(registration Registration) {
 		registration.Mutate = func(resource *pbresource.Resource) error { return nil }
 	}
 
+	// default admit to a no-op
+	if registration.Admit == nil {
+		registration.Admit = func(context.Context, Reader, *pbresource.Resource) error { return nil }
+	}
+
 	r.registrations[key] = registration
 }
 
@@ -130,6 +188,30 @@ func (r *TypeRegistry) Resolve(typ *pbresource.Type) (reg Registration, ok bool)
 	return Registration{}, false
 }
 
+func (r *TypeRegistry) Types() []Registration {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	types := make([]Registration, 0, len(r.registrations))
+	for _, registration := range r.registrations {
+		types = append(types, registration)
+	}
+	return types
+}
+
 func ToGVK(resourceType *pbresource.Type) string {
 	return fmt.Sprintf("%s.%s.%s", resourceType.Group, resourceType.GroupVersion, resourceType.Kind)
 }
+
+// ParseGVK parses a type in the format returned by ToGVK (e.g. demo.v2.artist).
+func ParseGVK(gvk string) (*pbresource.Type, error) {
+	parts := strings.Split(gvk, ".")
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		return nil, fmt.Errorf("invalid resource type %q, must be in the format group.version.kind", gvk)
+	}
+	return &pbresource.Type{
+		Group:        parts[0],
+		GroupVersion: parts[1],
+		Kind:         parts[2],
+	}, nil
+}
@@ -4,6 +4,7 @@
 package resource_test
 
 import (
+	"context"
 	"testing"
 
 	"github.com/hashicorp/consul/acl"
@@ -11,6 +12,7 @@ import (
 	"github.com/hashicorp/consul/internal/resource"
 	"github.com/hashicorp/consul/internal/resource/demo"
 	"github.com/hashicorp/consul/proto-public/pbresource"
+	"github.com/hashicorp/consul/proto/private/prototest"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -57,6 +59,34 @@ func TestRegister(t *testing.T) {
 			Kind:         "",
 		},
 	}, "type field(s) cannot be empty")
+
+	// register conversion from own GroupVersion should panic
+	assertRegisterPanics(t, r.Register, resource.Registration{
+		Type: &pbresource.Type{
+			Group:        "mesh",
+			GroupVersion: "v2",
+			Kind:         "service",
+		},
+		Conversions: map[string]resource.ConvertFunc{"v2": nil},
+	}, "resource type mesh.v2.service cannot have a conversion from its own GroupVersion")
+
+	// register second storage version should panic
+	r.Register(resource.Registration{
+		Type: &pbresource.Type{
+			Group:        "mesh",
+			GroupVersion: "v2",
+			Kind:         "service",
+		},
+		StorageVersion: true,
+	})
+	assertRegisterPanics(t, r.Register, resource.Registration{
+		Type: &pbresource.Type{
+			Group:        "mesh",
+			GroupVersion: "v3",
+			Kind:         "service",
+		},
+		StorageVersion: true,
+	}, "resource type mesh.v3.service cannot be the storage version, mesh.v2.service already is")
 }
 
 func TestRegister_Defaults(t *testing.T) {
@@ -85,6 +115,9 @@ func TestRegister_Defaults(t *testing.T) {
 
 	// verify default mutate is a no-op
 	require.NoError(t, reg.Mutate(nil))
+
+	// verify default admit is a no-op
+	require.NoError(t, reg.Admit(context.Background(), nil, nil))
 }
 
 func assertRegisterPanics(t *testing.T, registerFn func(reg resource.Registration), registration resource.Registration, panicString string) {
@@ -123,3 +156,28 @@ func TestResolve(t *testing.T) {
 	assert.True(t, ok)
 	assert.Equal(t, registration.Type, serviceType)
 }
+
+func TestRegistry_Types(t *testing.T) {
+	r := resource.NewRegistry()
+	demo.Register(r)
+
+	var storageVersions []*pbresource.Type
+	for _, reg := range r.Types() {
+		if reg.StorageVersion {
+			storageVersions = append(storageVersions, reg.Type)
+		}
+	}
+	prototest.AssertElementsMatch(t, []*pbresource.Type{demo.TypeV2Album}, storageVersions)
+}
+
+func TestParseGVK(t *testing.T) {
+	typ, err := resource.ParseGVK("demo.v2.artist")
+	require.NoError(t, err)
+	prototest.AssertDeepEqual(t, demo.TypeV2Artist, typ)
+	require.Equal(t, "demo.v2.artist", resource.ToGVK(typ))
+
+	for _, gvk := range []string{"", "demo", "demo.v2", "demo..artist", "demo.v2.artist.extra"} {
+		_, err := resource.ParseGVK(gvk)
+		require.Error(t, err, gvk)
+	}
+}
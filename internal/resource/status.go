@@ -4,7 +4,10 @@
 package resource
 
 import (
+	"time"
+
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/hashicorp/consul/proto-public/pbresource"
 )
@@ -44,3 +47,72 @@ func EqualStatus(a, b map[string]*pbresource.Status) bool {
 
 	return true
 }
+
+// MaxStatusHistory is the number of condition transitions kept in the history
+// of each status.
+const MaxStatusHistory = 20
+
+// EqualStatusConditions compares two statuses for equality, ignoring the
+// fields maintained by the Resource Service (i.e. the conditions' transition
+// times and the status history). Controllers can use it to avoid rewriting a
+// status that hasn't changed.
+func EqualStatusConditions(a, b *pbresource.Status) bool {
+	return proto.Equal(withoutTransitions(a), withoutTransitions(b))
+}
+
+func withoutTransitions(status *pbresource.Status) *pbresource.Status {
+	if status == nil {
+		return nil
+	}
+
+	status = proto.Clone(status).(*pbresource.Status)
+	status.History = nil
+	for _, condition := range status.Conditions {
+		condition.LastTransitionTime = nil
+	}
+	return status
+}
+
+// RecordTransitions returns a copy of the next status with the transition time
+// of each of its conditions set to now if its state differs from prev, or
+// carried over from prev if it doesn't, and with the transitions appended to
+// prev's history (which is trimmed to MaxStatusHistory entries).
+//
+// A condition that isn't in prev is treated as transitioning from the
+// STATE_UNKNOWN state.
+func RecordTransitions(prev, next *pbresource.Status, now time.Time) *pbresource.Status {
+	next = proto.Clone(next).(*pbresource.Status)
+	next.History = nil
+
+	prevConditions := make(map[string]*pbresource.Condition)
+	if prev != nil {
+		for _, condition := range prev.Conditions {
+			prevConditions[condition.Type] = condition
+		}
+		next.History = append(next.History, prev.History...)
+	}
+
+	for _, condition := range next.Conditions {
+		prevCondition, ok := prevConditions[condition.Type]
+		if ok && prevCondition.State == condition.State {
+			condition.LastTransitionTime = prevCondition.LastTransitionTime
+			continue
+		}
+
+		condition.LastTransitionTime = timestamppb.New(now)
+		next.History = append(next.History, &pbresource.ConditionTransition{
+			Type:               condition.Type,
+			PreviousState:      prevCondition.GetState(),
+			State:              condition.State,
+			Reason:             condition.Reason,
+			Message:            condition.Message,
+			ObservedGeneration: next.ObservedGeneration,
+			Time:               condition.LastTransitionTime,
+		})
+	}
+
+	if len(next.History) > MaxStatusHistory {
+		next.History = next.History[len(next.History)-MaxStatusHistory:]
+	}
+	return next
+}
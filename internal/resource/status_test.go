@@ -6,9 +6,11 @@ package resource
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/oklog/ulid/v2"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/hashicorp/consul/proto-public/pbresource"
 )
@@ -127,3 +129,85 @@ func TestEqualStatus(t *testing.T) {
 		})
 	}
 }
+
+func TestRecordTransitions(t *testing.T) {
+	t0 := time.Now().Add(-time.Hour)
+	t1 := time.Now()
+
+	condition := func(typ string, state pbresource.Condition_State) *pbresource.Condition {
+		return &pbresource.Condition{Type: typ, State: state, Reason: "Reason", Message: "Message"}
+	}
+
+	// New conditions transition from STATE_UNKNOWN.
+	status := RecordTransitions(nil, &pbresource.Status{
+		ObservedGeneration: "gen-1",
+		Conditions: []*pbresource.Condition{
+			condition("Foo", pbresource.Condition_STATE_TRUE),
+			condition("Bar", pbresource.Condition_STATE_FALSE),
+		},
+	}, t0)
+	require.Equal(t, t0.UnixNano(), status.Conditions[0].LastTransitionTime.AsTime().UnixNano())
+	require.Equal(t, t0.UnixNano(), status.Conditions[1].LastTransitionTime.AsTime().UnixNano())
+	require.Len(t, status.History, 2)
+	require.Equal(t, "Foo", status.History[0].Type)
+	require.Equal(t, pbresource.Condition_STATE_UNKNOWN, status.History[0].PreviousState)
+	require.Equal(t, pbresource.Condition_STATE_TRUE, status.History[0].State)
+	require.Equal(t, "gen-1", status.History[0].ObservedGeneration)
+
+	// Only conditions whose state changed get a new transition time.
+	next := &pbresource.Status{
+		ObservedGeneration: "gen-2",
+		Conditions: []*pbresource.Condition{
+			condition("Foo", pbresource.Condition_STATE_TRUE),
+			condition("Bar", pbresource.Condition_STATE_TRUE),
+		},
+		// History and transition times given by the caller are ignored.
+		History: []*pbresource.ConditionTransition{{Type: "Bogus"}},
+	}
+	next.Conditions[0].LastTransitionTime = timestamppb.New(t1.Add(time.Hour))
+
+	status = RecordTransitions(status, next, t1)
+	require.Equal(t, t0.UnixNano(), status.Conditions[0].LastTransitionTime.AsTime().UnixNano())
+	require.Equal(t, t1.UnixNano(), status.Conditions[1].LastTransitionTime.AsTime().UnixNano())
+	require.Len(t, status.History, 3)
+	require.Equal(t, "Bar", status.History[2].Type)
+	require.Equal(t, pbresource.Condition_STATE_FALSE, status.History[2].PreviousState)
+	require.Equal(t, pbresource.Condition_STATE_TRUE, status.History[2].State)
+
+	// The caller's status isn't modified.
+	require.Len(t, next.History, 1)
+	require.Nil(t, next.Conditions[1].LastTransitionTime)
+
+	// The history is bounded.
+	for i := 0; i < MaxStatusHistory; i++ {
+		state := pbresource.Condition_STATE_TRUE
+		if i%2 == 0 {
+			state = pbresource.Condition_STATE_FALSE
+		}
+		status = RecordTransitions(status, &pbresource.Status{
+			ObservedGeneration: "gen-3",
+			Conditions:         []*pbresource.Condition{condition("Foo", state)},
+		}, t1)
+	}
+	require.Len(t, status.History, MaxStatusHistory)
+	for _, transition := range status.History {
+		require.Equal(t, "Foo", transition.Type)
+	}
+}
+
+func TestEqualStatusConditions(t *testing.T) {
+	status := &pbresource.Status{
+		ObservedGeneration: ulid.Make().String(),
+		Conditions: []*pbresource.Condition{
+			{Type: "Foo", State: pbresource.Condition_STATE_TRUE},
+		},
+	}
+
+	recorded := RecordTransitions(nil, status, time.Now())
+	require.True(t, EqualStatusConditions(status, recorded))
+	require.True(t, EqualStatusConditions(nil, nil))
+	require.False(t, EqualStatusConditions(status, nil))
+
+	recorded.Conditions[0].State = pbresource.Condition_STATE_FALSE
+	require.False(t, EqualStatusConditions(status, recorded))
+}
@@ -0,0 +1,44 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/consul/proto-public/pbresource"
+)
+
+// TypeV1Tombstone is the type of the resources written by the Resource Service
+// when a resource is deleted, so that the resources it owned can be garbage
+// collected. It is registered in every Registry.
+var TypeV1Tombstone = &pbresource.Type{
+	Group:        "internal",
+	GroupVersion: "v1",
+	Kind:         "Tombstone",
+}
+
+// TombstoneNameFor returns the name of the tombstone written when the resource
+// with the given ID is deleted. The owner's Uid is included so that deleting a
+// resource, re-creating it, and deleting it again doesn't collide.
+func TombstoneNameFor(owner *pbresource.ID) string {
+	return fmt.Sprintf("tombstone-%s-%s", owner.Name, strings.ToLower(owner.Uid))
+}
+
+func registerTombstone(r *TypeRegistry) {
+	r.Register(Registration{
+		Type:  TypeV1Tombstone,
+		Proto: &pbresource.Tombstone{},
+		Validate: func(res *pbresource.Resource) error {
+			var tombstone pbresource.Tombstone
+			if err := res.Data.UnmarshalTo(&tombstone); err != nil {
+				return fmt.Errorf("invalid tombstone data: %w", err)
+			}
+			if tombstone.Owner == nil {
+				return fmt.Errorf("tombstone owner is required")
+			}
+			return nil
+		},
+	})
+}
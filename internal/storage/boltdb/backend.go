@@ -0,0 +1,263 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Package boltdb implements a storage backend that persists resources to a
+// BoltDB file on the local disk.
+package boltdb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.etcd.io/bbolt"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/hashicorp/consul/internal/storage"
+	"github.com/hashicorp/consul/internal/storage/inmem"
+	"github.com/hashicorp/consul/proto-public/pbresource"
+)
+
+var (
+	bucketResources = []byte("resources")
+	bucketMeta      = []byte("meta")
+
+	metaKeyVersion = []byte("version")
+)
+
+// NewBackend returns a storage backend that persists resources to the BoltDB
+// file at the given path, creating it if it doesn't exist, and serves reads and
+// watches from an in-memory database. It's suitable for single-node (e.g. dev
+// mode) agents and tests that need resources to survive a restart, but should
+// NOT be used by servers in a cluster as it has no support for replication.
+//
+// The file is locked while the backend is open, so it cannot be shared between
+// processes.
+//
+// You must call Run before using the backend, and Close when you're done with
+// it.
+func NewBackend(path string) (*Backend, error) {
+	db, err := bbolt.Open(path, 0600, &bbolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open resource database: %w", err)
+	}
+
+	b, err := newBackend(db)
+	if err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+	return b, nil
+}
+
+func newBackend(db *bbolt.DB) (*Backend, error) {
+	store, err := inmem.NewStore()
+	if err != nil {
+		return nil, err
+	}
+	b := &Backend{db: db, store: store}
+
+	restore, err := store.Restore()
+	if err != nil {
+		return nil, err
+	}
+	defer restore.Abort()
+
+	// Load the stored resources into the in-memory database.
+	err = db.Update(func(tx *bbolt.Tx) error {
+		resources, err := tx.CreateBucketIfNotExists(bucketResources)
+		if err != nil {
+			return err
+		}
+
+		meta, err := tx.CreateBucketIfNotExists(bucketMeta)
+		if err != nil {
+			return err
+		}
+
+		if v := meta.Get(metaKeyVersion); v != nil {
+			if b.vsn, err = strconv.ParseUint(string(v), 10, 64); err != nil {
+				return fmt.Errorf("failed to decode version: %w", err)
+			}
+		}
+
+		return resources.ForEach(func(_, v []byte) error {
+			res, err := decodeResource(v)
+			if err != nil {
+				return err
+			}
+			return restore.Apply(res)
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load resources: %w", err)
+	}
+	restore.Commit()
+
+	return b, nil
+}
+
+// Backend is a storage backend implementation that persists resources using
+// BoltDB.
+type Backend struct {
+	// mu serializes writes so that the in-memory database is updated in the
+	// same order as BoltDB, and the CAS checks made against BoltDB remain valid
+	// when the operation is applied to the in-memory database.
+	mu sync.Mutex
+
+	// vsn is the last version given to a resource, which also numbers the
+	// events of the in-memory database. It is guarded by mu.
+	vsn uint64
+
+	db    *bbolt.DB
+	store *inmem.Store
+}
+
+// Run until the given context is canceled. This method blocks, so should be
+// called in a goroutine.
+func (b *Backend) Run(ctx context.Context) { b.store.Run(ctx) }
+
+// Close the underlying BoltDB file.
+func (b *Backend) Close() error { return b.db.Close() }
+
+// Read implements the storage.Backend interface.
+//
+// Writes are applied to the in-memory database before they return, so all
+// reads are strongly consistent.
+func (b *Backend) Read(_ context.Context, _ storage.ReadConsistency, id *pbresource.ID) (*pbresource.Resource, error) {
+	return b.store.Read(id)
+}
+
+// WriteCAS implements the storage.Backend interface.
+func (b *Backend) WriteCAS(_ context.Context, res *pbresource.Resource) (*pbresource.Resource, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	stored := proto.Clone(res).(*pbresource.Resource)
+
+	err := b.update(func(tx *bbolt.Tx, vsn string) error {
+		stored.Version = vsn
+		return writeTxn(tx, stored, res.Version)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := b.store.WriteCASAt(b.vsn, stored, res.Version); err != nil {
+		return nil, err
+	}
+	return stored, nil
+}
+
+// DeleteCAS implements the storage.Backend interface.
+func (b *Backend) DeleteCAS(_ context.Context, id *pbresource.ID, version string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var deleted bool
+	err := b.update(func(tx *bbolt.Tx, _ string) error {
+		var err error
+		deleted, err = deleteTxn(tx, id, version)
+		return err
+	})
+	if err != nil || !deleted {
+		return err
+	}
+
+	return b.store.DeleteCASAt(b.vsn, id, version)
+}
+
+// Txn implements the storage.Backend interface.
+func (b *Backend) Txn(_ context.Context, ops []storage.TxnOp) ([]*pbresource.Resource, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	storeOps := make([]inmem.TxnOp, len(ops))
+	results := make([]*pbresource.Resource, len(ops))
+
+	err := b.update(func(tx *bbolt.Tx, vsn string) error {
+		for i, op := range ops {
+			var err error
+			switch {
+			case op.Write != nil && op.Delete == nil:
+				stored := proto.Clone(op.Write).(*pbresource.Resource)
+				stored.Version = vsn
+
+				err = writeTxn(tx, stored, op.Write.Version)
+				storeOps[i] = inmem.TxnOp{Write: stored, Version: op.Write.Version}
+				results[i] = stored
+			case op.Delete != nil && op.Write == nil:
+				_, err = deleteTxn(tx, op.Delete, op.DeleteVersion)
+				storeOps[i] = inmem.TxnOp{Delete: op.Delete, Version: op.DeleteVersion}
+			default:
+				err = errors.New("exactly one of Write or Delete must be set")
+			}
+			if err != nil {
+				return storage.TxnError{Index: i, Err: err}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := b.store.TxnAt(b.vsn, storeOps); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// List implements the storage.Backend interface.
+func (b *Backend) List(_ context.Context, _ storage.ReadConsistency, resType storage.UnversionedType, tenancy *pbresource.Tenancy, namePrefix string) ([]*pbresource.Resource, error) {
+	return b.store.List(resType, tenancy, namePrefix)
+}
+
+// ListPage implements the storage.Backend interface.
+func (b *Backend) ListPage(_ context.Context, _ storage.ReadConsistency, resType storage.UnversionedType, tenancy *pbresource.Tenancy, namePrefix string, opts storage.ListOptions) ([]*pbresource.Resource, string, error) {
+	return b.store.ListPage(resType, tenancy, namePrefix, opts)
+}
+
+// WatchList implements the storage.Backend interface.
+func (b *Backend) WatchList(_ context.Context, resType storage.UnversionedType, tenancy *pbresource.Tenancy, namePrefix string) (storage.Watch, error) {
+	return b.store.WatchList(resType, tenancy, namePrefix)
+}
+
+// WatchListFrom implements the storage.Backend interface.
+//
+// Event indexes are not persisted, so watches cannot be resumed across a
+// restart.
+func (b *Backend) WatchListFrom(_ context.Context, resType storage.UnversionedType, tenancy *pbresource.Tenancy, namePrefix string, index uint64) (storage.Watch, error) {
+	return b.store.WatchListFrom(resType, tenancy, namePrefix, index)
+}
+
+// OwnerReferences implements the storage.Backend interface.
+func (b *Backend) OwnerReferences(_ context.Context, id *pbresource.ID) ([]*pbresource.ID, error) {
+	return b.store.OwnerReferences(id)
+}
+
+// update runs fn in a BoltDB read-write transaction, passing it the version to
+// give any resources it writes. The version is only consumed if the
+// transaction is committed.
+//
+// Callers must hold mu.
+func (b *Backend) update(fn func(tx *bbolt.Tx, vsn string) error) error {
+	next := b.vsn + 1
+
+	err := b.db.Update(func(tx *bbolt.Tx) error {
+		vsn := strconv.FormatUint(next, 10)
+		if err := tx.Bucket(bucketMeta).Put(metaKeyVersion, []byte(vsn)); err != nil {
+			return err
+		}
+		return fn(tx, vsn)
+	})
+	if err != nil {
+		return err
+	}
+
+	b.vsn = next
+	return nil
+}
@@ -0,0 +1,128 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package boltdb_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/consul/internal/storage"
+	"github.com/hashicorp/consul/internal/storage/boltdb"
+	"github.com/hashicorp/consul/internal/storage/conformance"
+	"github.com/hashicorp/consul/proto-public/pbresource"
+	"github.com/hashicorp/consul/proto/private/prototest"
+)
+
+func TestBackend_Conformance(t *testing.T) {
+	conformance.Test(t, conformance.TestOptions{
+		NewBackend: func(t *testing.T) storage.Backend {
+			return runBackend(t, filepath.Join(t.TempDir(), "resources.db"))
+		},
+		SupportsStronglyConsistentList: true,
+	})
+}
+
+func TestBackend_Persistence(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "resources.db")
+	ctx := context.Background()
+
+	owner := &pbresource.Resource{
+		Id: &pbresource.ID{
+			Type:    typeAv1,
+			Tenancy: tenancyDefault,
+			Name:    "owner",
+			Uid:     "a",
+		},
+	}
+	owned := &pbresource.Resource{
+		Id: &pbresource.ID{
+			Type:    typeAv1,
+			Tenancy: tenancyDefault,
+			Name:    "owned",
+			Uid:     "b",
+		},
+		Owner: owner.Id,
+	}
+	deleted := &pbresource.Resource{
+		Id: &pbresource.ID{
+			Type:    typeAv1,
+			Tenancy: tenancyDefault,
+			Name:    "deleted",
+			Uid:     "c",
+		},
+	}
+
+	backend := runBackend(t, path)
+
+	var err error
+	owner, err = backend.WriteCAS(ctx, owner)
+	require.NoError(t, err)
+
+	txnResults, err := backend.Txn(ctx, []storage.TxnOp{{Write: owned}, {Write: deleted}})
+	require.NoError(t, err)
+	owned, deleted = txnResults[0], txnResults[1]
+
+	require.NoError(t, backend.DeleteCAS(ctx, deleted.Id, deleted.Version))
+	require.NoError(t, backend.Close())
+
+	// Reopen the database.
+	backend = runBackend(t, path)
+
+	for _, res := range []*pbresource.Resource{owner, owned} {
+		stored, err := backend.Read(ctx, storage.StrongConsistency, res.Id)
+		require.NoError(t, err)
+		prototest.AssertDeepEqual(t, res, stored)
+	}
+
+	_, err = backend.Read(ctx, storage.StrongConsistency, deleted.Id)
+	require.ErrorIs(t, err, storage.ErrNotFound)
+
+	refs, err := backend.OwnerReferences(ctx, owner.Id)
+	require.NoError(t, err)
+	prototest.AssertElementsMatch(t, []*pbresource.ID{owned.Id}, refs)
+
+	// Versions continue to be unique after the restart.
+	updated, err := backend.WriteCAS(ctx, owner)
+	require.NoError(t, err)
+	require.NotEqual(t, owner.Version, updated.Version)
+	require.NotEqual(t, owned.Version, updated.Version)
+}
+
+func TestBackend_Locked(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "resources.db")
+	runBackend(t, path)
+
+	_, err := boltdb.NewBackend(path)
+	require.Error(t, err)
+}
+
+func runBackend(t *testing.T, path string) *boltdb.Backend {
+	t.Helper()
+
+	backend, err := boltdb.NewBackend(path)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = backend.Close() })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	go backend.Run(ctx)
+
+	return backend
+}
+
+var (
+	typeAv1 = &pbresource.Type{
+		Group:        "test",
+		GroupVersion: "v1",
+		Kind:         "A",
+	}
+	tenancyDefault = &pbresource.Tenancy{
+		Partition: "default",
+		PeerName:  "local",
+		Namespace: "default",
+	}
+)
@@ -0,0 +1,115 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package boltdb
+
+import (
+	"bytes"
+	"fmt"
+
+	"go.etcd.io/bbolt"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/hashicorp/consul/internal/storage"
+	"github.com/hashicorp/consul/proto-public/pbresource"
+)
+
+// keySeparator separates the components of a resource's key. It cannot appear
+// in a resource's type, tenancy, or name.
+const keySeparator = "\x00"
+
+// resourceKey returns the key a resource is stored under. Like the in-memory
+// database's ID index, it doesn't include the GroupVersion or Uid, so there
+// can only be one version of a resource with a given name.
+func resourceKey(id *pbresource.ID) []byte {
+	var b bytes.Buffer
+	for _, part := range []string{
+		id.Type.Group,
+		id.Type.Kind,
+		id.Tenancy.Partition,
+		id.Tenancy.PeerName,
+		id.Tenancy.Namespace,
+		id.Name,
+	} {
+		b.WriteString(part)
+		b.WriteString(keySeparator)
+	}
+	return b.Bytes()
+}
+
+func readTxn(tx *bbolt.Tx, id *pbresource.ID) (*pbresource.Resource, error) {
+	v := tx.Bucket(bucketResources).Get(resourceKey(id))
+	if v == nil {
+		return nil, nil
+	}
+	return decodeResource(v)
+}
+
+// writeTxn writes the given resource, checking its Uid and the given version
+// match the existing resource, with the same semantics as the in-memory store.
+func writeTxn(tx *bbolt.Tx, res *pbresource.Resource, vsn string) error {
+	existing, err := readTxn(tx, res.Id)
+	if err != nil {
+		return err
+	}
+
+	// Callers provide an empty version string on initial resource creation.
+	if existing == nil && vsn != "" {
+		return storage.ErrCASFailure
+	}
+
+	if existing != nil {
+		// Uid is immutable.
+		if existing.Id.Uid != res.Id.Uid {
+			return storage.ErrWrongUid
+		}
+
+		// Ensure CAS semantics.
+		if existing.Version != vsn {
+			return storage.ErrCASFailure
+		}
+	}
+
+	v, err := proto.Marshal(res)
+	if err != nil {
+		return fmt.Errorf("failed to encode resource: %w", err)
+	}
+	return tx.Bucket(bucketResources).Put(resourceKey(res.Id), v)
+}
+
+// deleteTxn deletes the resource with the given ID, with the same semantics as
+// the in-memory store. It returns false if the deletion was a no-op.
+func deleteTxn(tx *bbolt.Tx, id *pbresource.ID, vsn string) (bool, error) {
+	existing, err := readTxn(tx, id)
+	if err != nil {
+		return false, err
+	}
+
+	// Deleting an already deleted resource is a no-op.
+	if existing == nil {
+		return false, nil
+	}
+
+	// Deleting a resource using a previous Uid is a no-op.
+	if id.Uid != existing.Id.Uid {
+		return false, nil
+	}
+
+	// Ensure CAS semantics.
+	if vsn != existing.Version {
+		return false, storage.ErrCASFailure
+	}
+
+	if err := tx.Bucket(bucketResources).Delete(resourceKey(id)); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func decodeResource(v []byte) (*pbresource.Resource, error) {
+	var res pbresource.Resource
+	if err := proto.Unmarshal(v, &res); err != nil {
+		return nil, fmt.Errorf("failed to decode resource: %w", err)
+	}
+	return &res, nil
+}
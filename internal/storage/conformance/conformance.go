@@ -7,6 +7,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
 	"math/rand"
 	"testing"
 	"time"
@@ -14,6 +15,9 @@ import (
 	"github.com/stretchr/testify/require"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
 
 	"github.com/hashicorp/consul/internal/storage"
 	"github.com/hashicorp/consul/proto-public/pbresource"
@@ -39,7 +43,10 @@ func Test(t *testing.T, opts TestOptions) {
 	t.Run("Read", func(t *testing.T) { testRead(t, opts) })
 	t.Run("CAS Write", func(t *testing.T) { testCASWrite(t, opts) })
 	t.Run("CAS Delete", func(t *testing.T) { testCASDelete(t, opts) })
+	t.Run("Txn", func(t *testing.T) { testTxn(t, opts) })
 	t.Run("OwnerReferences", func(t *testing.T) { testOwnerReferences(t, opts) })
+	t.Run("ListPage", func(t *testing.T) { testListPage(t, opts) })
+	t.Run("WatchListFrom", func(t *testing.T) { testWatchListFrom(t, opts) })
 
 	testListWatch(t, opts)
 }
@@ -276,6 +283,153 @@ func testCASDelete(t *testing.T, opts TestOptions) {
 	})
 }
 
+func testTxn(t *testing.T, opts TestOptions) {
+	t.Run("all operations are applied", func(t *testing.T) {
+		backend := opts.NewBackend(t)
+		ctx := testContext(t)
+
+		existing, err := backend.WriteCAS(ctx, resource(typeB, tenancyDefault, "existing"))
+		require.NoError(t, err)
+
+		deleted, err := backend.WriteCAS(ctx, resource(typeB, tenancyDefault, "deleted"))
+		require.NoError(t, err)
+
+		results, err := backend.Txn(ctx, []storage.TxnOp{
+			{Write: resource(typeB, tenancyDefault, "created")},
+			{Write: existing},
+			{Delete: deleted.Id, DeleteVersion: deleted.Version},
+		})
+		require.NoError(t, err)
+		require.Len(t, results, 3)
+
+		require.NotEmpty(t, results[0].Version)
+		require.NotEqual(t, existing.Version, results[1].Version)
+		require.Nil(t, results[2])
+
+		eventually(t, func(t testingT) {
+			res, err := backend.Read(ctx, storage.EventualConsistency, results[0].Id)
+			require.NoError(t, err)
+			prototest.AssertDeepEqual(t, results[0], res)
+
+			res, err = backend.Read(ctx, storage.EventualConsistency, existing.Id)
+			require.NoError(t, err)
+			prototest.AssertDeepEqual(t, results[1], res)
+
+			_, err = backend.Read(ctx, storage.EventualConsistency, deleted.Id)
+			require.ErrorIs(t, err, storage.ErrNotFound)
+		})
+	})
+
+	t.Run("no operations are applied if one fails", func(t *testing.T) {
+		backend := opts.NewBackend(t)
+		ctx := testContext(t)
+
+		existing, err := backend.WriteCAS(ctx, resource(typeB, tenancyDefault, "existing"))
+		require.NoError(t, err)
+
+		other, err := backend.WriteCAS(ctx, resource(typeB, tenancyDefault, "other"))
+		require.NoError(t, err)
+
+		stale := clone(existing)
+		stale.Version = "some-version"
+
+		_, err = backend.Txn(ctx, []storage.TxnOp{
+			{Write: resource(typeB, tenancyDefault, "created")},
+			{Delete: other.Id, DeleteVersion: other.Version},
+			{Write: stale},
+		})
+		require.ErrorIs(t, err, storage.ErrCASFailure)
+
+		var txnErr storage.TxnError
+		require.ErrorAs(t, err, &txnErr)
+		require.Equal(t, 2, txnErr.Index)
+
+		// Uid is immutable within a transaction too.
+		wrongUid := clone(existing)
+		wrongUid.Id.Uid = "b"
+
+		_, err = backend.Txn(ctx, []storage.TxnOp{
+			{Write: resource(typeB, tenancyDefault, "created")},
+			{Write: wrongUid},
+		})
+		require.ErrorIs(t, err, storage.ErrWrongUid)
+		require.ErrorAs(t, err, &txnErr)
+		require.Equal(t, 1, txnErr.Index)
+
+		// Give any (incorrectly applied) operations time to replicate.
+		time.Sleep(100 * time.Millisecond)
+
+		_, err = backend.Read(ctx, storage.EventualConsistency, resource(typeB, tenancyDefault, "created").Id)
+		require.ErrorIs(t, err, storage.ErrNotFound)
+
+		res, err := backend.Read(ctx, storage.EventualConsistency, other.Id)
+		require.NoError(t, err)
+		prototest.AssertDeepEqual(t, other, res)
+
+		res, err = backend.Read(ctx, storage.EventualConsistency, existing.Id)
+		require.NoError(t, err)
+		prototest.AssertDeepEqual(t, existing, res)
+	})
+
+	t.Run("operations observe earlier operations", func(t *testing.T) {
+		backend := opts.NewBackend(t)
+		ctx := testContext(t)
+
+		existing, err := backend.WriteCAS(ctx, resource(typeB, tenancyDefault, "existing"))
+		require.NoError(t, err)
+
+		// Re-create a resource with a different Uid in the same transaction.
+		recreated := clone(existing)
+		recreated.Id.Uid = "b"
+		recreated.Version = ""
+
+		results, err := backend.Txn(ctx, []storage.TxnOp{
+			{Delete: existing.Id, DeleteVersion: existing.Version},
+			{Write: recreated},
+		})
+		require.NoError(t, err)
+
+		eventually(t, func(t testingT) {
+			res, err := backend.Read(ctx, storage.EventualConsistency, recreated.Id)
+			require.NoError(t, err)
+			prototest.AssertDeepEqual(t, results[1], res)
+		})
+	})
+
+	t.Run("watch events", func(t *testing.T) {
+		backend := opts.NewBackend(t)
+		ctx := testContext(t)
+
+		watch, err := backend.WatchList(ctx, storage.UnversionedTypeFrom(typeB), tenancyDefault, "")
+		require.NoError(t, err)
+		t.Cleanup(watch.Close)
+
+		existing, err := backend.WriteCAS(ctx, resource(typeB, tenancyDefault, "existing"))
+		require.NoError(t, err)
+
+		event, err := watch.Next(ctx)
+		require.NoError(t, err)
+		require.Equal(t, pbresource.WatchEvent_OPERATION_UPSERT, event.Operation)
+		prototest.AssertDeepEqual(t, existing, event.Resource)
+
+		results, err := backend.Txn(ctx, []storage.TxnOp{
+			{Write: resource(typeB, tenancyDefault, "created")},
+			{Delete: existing.Id, DeleteVersion: existing.Version},
+		})
+		require.NoError(t, err)
+
+		event, err = watch.Next(ctx)
+		require.NoError(t, err)
+		require.Equal(t, pbresource.WatchEvent_OPERATION_UPSERT, event.Operation)
+		prototest.AssertDeepEqual(t, results[0], event.Resource)
+
+		event, err = watch.Next(ctx)
+		require.NoError(t, err)
+		require.Equal(t, pbresource.WatchEvent_OPERATION_DELETE, event.Operation)
+		prototest.AssertDeepEqual(t, existing, event.Resource)
+	})
+}
+
 func testListWatch(t *testing.T, opts TestOptions) {
 	testCases := map[string]struct {
 		resourceType storage.UnversionedType
@@ -518,6 +672,317 @@ func testListWatch(t *testing.T, opts TestOptions) {
 	})
 }
 
+func testListPage(t *testing.T, opts TestOptions) {
+	consistencyModes := map[storage.ReadConsistency]consistencyChecker{
+		storage.EventualConsistency: eventually,
+	}
+	if opts.SupportsStronglyConsistentList {
+		consistencyModes[storage.StrongConsistency] = immediately
+	}
+
+	for consistency, check := range consistencyModes {
+		t.Run(consistency.String(), func(t *testing.T) {
+			t.Run("pagination", func(t *testing.T) {
+				backend := opts.NewBackend(t)
+				ctx := testContext(t)
+
+				var expected []*pbresource.Resource
+				for i := 0; i < 10; i++ {
+					res, err := backend.WriteCAS(ctx, resource(typeAv1, tenancyDefault, fmt.Sprintf("page-%02d", i)))
+					require.NoError(t, err)
+					expected = append(expected, res)
+				}
+
+				// Resources in other tenancy units must not be included.
+				_, err := backend.WriteCAS(ctx, resource(typeAv1, tenancyOther, "page-00"))
+				require.NoError(t, err)
+
+				check(t, func(t testingT) {
+					var (
+						actual []*pbresource.Resource
+						pages  int
+						token  string
+					)
+					for {
+						res, next, err := backend.ListPage(ctx, consistency, storage.UnversionedTypeFrom(typeAv1), tenancyDefault, "", storage.ListOptions{
+							PageSize:  3,
+							PageToken: token,
+						})
+						require.NoError(t, err)
+						require.LessOrEqual(t, len(res), 3)
+
+						actual = append(actual, res...)
+						pages++
+
+						if next == "" {
+							break
+						}
+						token = next
+					}
+
+					// Resources are returned in order, without gaps or duplicates.
+					prototest.AssertDeepEqual(t, expected, actual, ignoreVersion)
+					require.Equal(t, 4, pages)
+				})
+			})
+
+			t.Run("exact number of pages", func(t *testing.T) {
+				backend := opts.NewBackend(t)
+				ctx := testContext(t)
+
+				for i := 0; i < 4; i++ {
+					_, err := backend.WriteCAS(ctx, resource(typeAv1, tenancyDefault, fmt.Sprintf("page-%02d", i)))
+					require.NoError(t, err)
+				}
+
+				check(t, func(t testingT) {
+					res, token, err := backend.ListPage(ctx, consistency, storage.UnversionedTypeFrom(typeAv1), tenancyDefault, "", storage.ListOptions{PageSize: 2})
+					require.NoError(t, err)
+					require.Len(t, res, 2)
+					require.NotEmpty(t, token)
+
+					// The second page is also the last one, so mustn't return a token.
+					res, token, err = backend.ListPage(ctx, consistency, storage.UnversionedTypeFrom(typeAv1), tenancyDefault, "", storage.ListOptions{PageSize: 2, PageToken: token})
+					require.NoError(t, err)
+					require.Len(t, res, 2)
+					require.Empty(t, token)
+				})
+			})
+
+			t.Run("label selector", func(t *testing.T) {
+				backend := opts.NewBackend(t)
+				ctx := testContext(t)
+
+				gold := resource(typeAv1, tenancyDefault, "gold")
+				gold.Metadata = map[string]string{"tier": "gold", "env": "production"}
+
+				silver := resource(typeAv1, tenancyDefault, "silver")
+				silver.Metadata = map[string]string{"tier": "silver", "env": "production"}
+
+				none := resource(typeAv1, tenancyDefault, "none")
+
+				for _, r := range []*pbresource.Resource{gold, silver, none} {
+					_, err := backend.WriteCAS(ctx, r)
+					require.NoError(t, err)
+				}
+
+				testCases := map[string][]*pbresource.Resource{
+					"":                          {gold, silver, none},
+					"tier":                      {gold, silver},
+					"!tier":                     {none},
+					"tier=gold":                 {gold},
+					"tier!=gold":                {silver, none},
+					"tier in (gold,silver)":     {gold, silver},
+					"tier notin (gold)":         {silver, none},
+					"env=production,tier=gold":  {gold},
+					"env=production,tier=other": nil,
+				}
+				for selector, expected := range testCases {
+					check(t, func(t testingT) {
+						res, token, err := backend.ListPage(ctx, consistency, storage.UnversionedTypeFrom(typeAv1), tenancyDefault, "", storage.ListOptions{
+							LabelSelector: selector,
+						})
+						require.NoError(t, err)
+						require.Empty(t, token)
+						prototest.AssertElementsMatch(t, expected, res, ignoreVersion)
+					})
+				}
+			})
+
+			t.Run("filter", func(t *testing.T) {
+				backend := opts.NewBackend(t)
+				ctx := testContext(t)
+
+				var expected []*pbresource.Resource
+				for i := 0; i < 6; i++ {
+					value := "odd"
+					if i%2 == 0 {
+						value = "even"
+					}
+					res := resource(typeAv1, tenancyDefault, fmt.Sprintf("filter-%d", i))
+					res.Data = mustAny(t, &wrapperspb.StringValue{Value: value})
+
+					res, err := backend.WriteCAS(ctx, res)
+					require.NoError(t, err)
+					if value == "even" {
+						expected = append(expected, res)
+					}
+				}
+
+				// The filter cannot be evaluated against resources stored with another
+				// GroupVersion's schema, so they mustn't match (or cause an error).
+				other := resource(typeAv2, tenancyDefault, "filter-other")
+				other.Data = mustAny(t, &emptypb.Empty{})
+				_, err := backend.WriteCAS(ctx, other)
+				require.NoError(t, err)
+
+				// Resources without data never match a filter.
+				_, err = backend.WriteCAS(ctx, resource(typeAv1, tenancyDefault, "filter-nodata"))
+				require.NoError(t, err)
+
+				check(t, func(t testingT) {
+					var (
+						actual []*pbresource.Resource
+						token  string
+					)
+					for {
+						res, next, err := backend.ListPage(ctx, consistency, storage.UnversionedTypeFrom(typeAv1), tenancyDefault, "", storage.ListOptions{
+							PageSize:  2,
+							PageToken: token,
+							Filter:    `Value == "even"`,
+						})
+						require.NoError(t, err)
+
+						actual = append(actual, res...)
+						if next == "" {
+							break
+						}
+						token = next
+					}
+					prototest.AssertDeepEqual(t, expected, actual, ignoreVersion)
+				})
+			})
+
+			t.Run("invalid options", func(t *testing.T) {
+				backend := opts.NewBackend(t)
+				ctx := testContext(t)
+
+				_, err := backend.WriteCAS(ctx, resource(typeAv1, tenancyDefault, "web"))
+				require.NoError(t, err)
+
+				testCases := map[string]storage.ListOptions{
+					"label selector": {LabelSelector: "tier in gold"},
+					"filter":         {Filter: "Value =="},
+					"page token":     {PageToken: "not a page token!"},
+				}
+				for desc, listOpts := range testCases {
+					t.Run(desc, func(t *testing.T) {
+						_, _, err := backend.ListPage(ctx, consistency, storage.UnversionedTypeFrom(typeAv1), tenancyDefault, "", listOpts)
+						require.ErrorIs(t, err, storage.ErrInvalidListOptions)
+					})
+				}
+			})
+		})
+	}
+}
+
+func testWatchListFrom(t *testing.T, opts TestOptions) {
+	typ := storage.UnversionedTypeFrom(typeB)
+
+	next := func(t *testing.T, watch storage.Watch) *pbresource.WatchEvent {
+		t.Helper()
+
+		ctx, cancel := context.WithTimeout(testContext(t), 5*time.Second)
+		defer cancel()
+
+		event, err := watch.Next(ctx)
+		require.NoError(t, err)
+		return event
+	}
+
+	t.Run("replays missed events", func(t *testing.T) {
+		backend := opts.NewBackend(t)
+		ctx := testContext(t)
+
+		watch, err := backend.WatchList(ctx, typ, tenancyDefault, "")
+		require.NoError(t, err)
+
+		deleted, err := backend.WriteCAS(ctx, resource(typeB, tenancyDefault, "deleted"))
+		require.NoError(t, err)
+
+		event := next(t, watch)
+		prototest.AssertDeepEqual(t, deleted, event.Resource)
+		require.NotZero(t, event.Index)
+		lastIndex := event.Index
+		watch.Close()
+
+		// Make some writes while the watch is disconnected.
+		created, err := backend.WriteCAS(ctx, resource(typeB, tenancyDefault, "created"))
+		require.NoError(t, err)
+
+		_, err = backend.WriteCAS(ctx, resource(typeAv1, tenancyDefault, "other-type"))
+		require.NoError(t, err)
+
+		require.NoError(t, backend.DeleteCAS(ctx, deleted.Id, deleted.Version))
+
+		watch, err = backend.WatchListFrom(ctx, typ, tenancyDefault, "", lastIndex)
+		require.NoError(t, err)
+		t.Cleanup(watch.Close)
+
+		// Only the missed events are emitted, rather than the current state of the
+		// world (which wouldn't include the delete).
+		event = next(t, watch)
+		require.Equal(t, pbresource.WatchEvent_OPERATION_UPSERT, event.Operation)
+		prototest.AssertDeepEqual(t, created, event.Resource)
+		require.Greater(t, event.Index, lastIndex)
+		lastIndex = event.Index
+
+		event = next(t, watch)
+		require.Equal(t, pbresource.WatchEvent_OPERATION_DELETE, event.Operation)
+		prototest.AssertDeepEqual(t, deleted, event.Resource)
+		require.Greater(t, event.Index, lastIndex)
+		lastIndex = event.Index
+
+		// Followed by new events.
+		updated, err := backend.WriteCAS(ctx, resource(typeB, tenancyDefault, "updated"))
+		require.NoError(t, err)
+
+		event = next(t, watch)
+		require.Equal(t, pbresource.WatchEvent_OPERATION_UPSERT, event.Operation)
+		prototest.AssertDeepEqual(t, updated, event.Resource)
+		require.Greater(t, event.Index, lastIndex)
+	})
+
+	t.Run("nothing missed", func(t *testing.T) {
+		backend := opts.NewBackend(t)
+		ctx := testContext(t)
+
+		watch, err := backend.WatchList(ctx, typ, tenancyDefault, "")
+		require.NoError(t, err)
+
+		_, err = backend.WriteCAS(ctx, resource(typeB, tenancyDefault, "existing"))
+		require.NoError(t, err)
+
+		lastIndex := next(t, watch).Index
+		watch.Close()
+
+		watch, err = backend.WatchListFrom(ctx, typ, tenancyDefault, "", lastIndex)
+		require.NoError(t, err)
+		t.Cleanup(watch.Close)
+
+		created, err := backend.WriteCAS(ctx, resource(typeB, tenancyDefault, "created"))
+		require.NoError(t, err)
+
+		event := next(t, watch)
+		require.Equal(t, pbresource.WatchEvent_OPERATION_UPSERT, event.Operation)
+		prototest.AssertDeepEqual(t, created, event.Resource)
+	})
+
+	t.Run("unknown index", func(t *testing.T) {
+		backend := opts.NewBackend(t)
+		ctx := testContext(t)
+
+		existing, err := backend.WriteCAS(ctx, resource(typeB, tenancyDefault, "existing"))
+		require.NoError(t, err)
+
+		watch, err := backend.WatchListFrom(ctx, typ, tenancyDefault, "", math.MaxUint64)
+		require.NoError(t, err)
+		t.Cleanup(watch.Close)
+
+		// The watch falls back to the current state of the world, preceded by a
+		// reset marker.
+		event := next(t, watch)
+		require.Equal(t, pbresource.WatchEvent_OPERATION_RESET, event.Operation)
+		require.Nil(t, event.Resource)
+
+		event = next(t, watch)
+		require.Equal(t, pbresource.WatchEvent_OPERATION_UPSERT, event.Operation)
+		prototest.AssertDeepEqual(t, existing, event.Resource)
+		require.NotZero(t, event.Index)
+	})
+
+}
+
 func testOwnerReferences(t *testing.T, opts TestOptions) {
 	backend := opts.NewBackend(t)
 	ctx := testContext(t)
@@ -654,6 +1119,14 @@ func resource(typ *pbresource.Type, ten *pbresource.Tenancy, name string) *pbres
 	}
 }
 
+func mustAny(t *testing.T, m proto.Message) *anypb.Any {
+	t.Helper()
+
+	v, err := anypb.New(m)
+	require.NoError(t, err)
+	return v
+}
+
 func testContext(t *testing.T) context.Context {
 	ctx, cancel := context.WithCancel(context.Background())
 	t.Cleanup(cancel)
@@ -60,16 +60,51 @@ func (b *Backend) DeleteCAS(_ context.Context, id *pbresource.ID, version string
 	return b.store.DeleteCAS(id, version)
 }
 
+// Txn implements the storage.Backend interface.
+func (b *Backend) Txn(_ context.Context, ops []storage.TxnOp) ([]*pbresource.Resource, error) {
+	vsn := strconv.Itoa(int(atomic.AddUint64(&b.vsn, 1)))
+
+	storeOps := make([]TxnOp, len(ops))
+	results := make([]*pbresource.Resource, len(ops))
+	for i, op := range ops {
+		if op.Write == nil {
+			storeOps[i] = TxnOp{Delete: op.Delete, Version: op.DeleteVersion}
+			continue
+		}
+
+		stored := proto.Clone(op.Write).(*pbresource.Resource)
+		stored.Version = vsn
+
+		storeOps[i] = TxnOp{Write: stored, Delete: op.Delete, Version: op.Write.Version}
+		results[i] = stored
+	}
+
+	if err := b.store.Txn(storeOps); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
 // List implements the storage.Backend interface.
 func (b *Backend) List(_ context.Context, _ storage.ReadConsistency, resType storage.UnversionedType, tenancy *pbresource.Tenancy, namePrefix string) ([]*pbresource.Resource, error) {
 	return b.store.List(resType, tenancy, namePrefix)
 }
 
+// ListPage implements the storage.Backend interface.
+func (b *Backend) ListPage(_ context.Context, _ storage.ReadConsistency, resType storage.UnversionedType, tenancy *pbresource.Tenancy, namePrefix string, opts storage.ListOptions) ([]*pbresource.Resource, string, error) {
+	return b.store.ListPage(resType, tenancy, namePrefix, opts)
+}
+
 // WatchList implements the storage.Backend interface.
 func (b *Backend) WatchList(_ context.Context, resType storage.UnversionedType, tenancy *pbresource.Tenancy, namePrefix string) (storage.Watch, error) {
 	return b.store.WatchList(resType, tenancy, namePrefix)
 }
 
+// WatchListFrom implements the storage.Backend interface.
+func (b *Backend) WatchListFrom(_ context.Context, resType storage.UnversionedType, tenancy *pbresource.Tenancy, namePrefix string, index uint64) (storage.Watch, error) {
+	return b.store.WatchListFrom(resType, tenancy, namePrefix, index)
+}
+
 // OwnerReferences implements the storage.Backend interface.
 func (b *Backend) OwnerReferences(_ context.Context, id *pbresource.ID) ([]*pbresource.ID, error) {
 	return b.store.OwnerReferences(id)
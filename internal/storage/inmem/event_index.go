@@ -3,24 +3,52 @@
 
 package inmem
 
-import "github.com/hashicorp/go-memdb"
+import (
+	"github.com/hashicorp/go-memdb"
+)
 
 type meta struct {
 	Key   string
 	Value any
 }
 
-func incrementEventIndex(tx *memdb.Txn) (uint64, error) {
+// Event indexes are derived from the index of the log entry (e.g. the Raft
+// index) that caused the event, such that every Store fed the same log issues
+// the same indexes, and a watch can be resumed against any of them (e.g. after
+// a client reconnects to another server). The upper bits of each index hold
+// the log index, and the lower eventOpBits hold the position of the event
+// within the log entry, as a transaction may produce several events.
+const eventOpBits = 16
+
+// maxTxnOps is the maximum number of operations in a transaction, such that
+// each of them can be given its own event index.
+const maxTxnOps = 1<<eventOpBits - 1
+
+// eventIndex returns the index of the op-th event caused by the log entry at
+// the given index. The lower bits are never zero, so that the 0 and 1 index
+// reserved for special use in the stream package are never issued.
+func eventIndex(logIndex uint64, op int) uint64 {
+	return logIndex<<eventOpBits | uint64(op+1)
+}
+
+// nextLogIndex returns the log index to number the events of a write from. If
+// logIndex is zero (i.e. the Store isn't fed by a log) it is the one after the
+// current event index. Indexes must never go backwards, so the same is true of
+// a logIndex that isn't greater than the current one.
+func nextLogIndex(tx *memdb.Txn, logIndex uint64) (uint64, error) {
 	idx, err := currentEventIndex(tx)
 	if err != nil {
 		return 0, err
 	}
 
-	idx++
-	if err := tx.Insert(tableNameMetadata, meta{Key: metaKeyEventIndex, Value: idx}); err != nil {
-		return 0, nil
+	if current := idx >> eventOpBits; logIndex <= current {
+		return current + 1, nil
 	}
-	return idx, nil
+	return logIndex, nil
+}
+
+func setEventIndex(tx *memdb.Txn, idx uint64) error {
+	return tx.Insert(tableNameMetadata, meta{Key: metaKeyEventIndex, Value: idx})
 }
 
 func currentEventIndex(tx *memdb.Txn) (uint64, error) {
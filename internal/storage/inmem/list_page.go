@@ -0,0 +1,113 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package inmem
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"reflect"
+
+	"github.com/hashicorp/go-bexpr"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"github.com/hashicorp/consul/internal/storage"
+	"github.com/hashicorp/consul/proto-public/pbresource"
+)
+
+// listFilter applies the label selector and filter expression given in the
+// ListOptions to resources.
+type listFilter struct {
+	selector storage.LabelSelector
+
+	expression string
+
+	// evaluators caches a bexpr.Evaluator per data type, because an evaluator
+	// is bound to the type of the first value it evaluates, and a list may span
+	// multiple GroupVersions with different schemas.
+	evaluators map[reflect.Type]*bexpr.Evaluator
+}
+
+func newListFilter(opts storage.ListOptions) (*listFilter, error) {
+	selector, err := storage.ParseLabelSelector(opts.LabelSelector)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", storage.ErrInvalidListOptions, err)
+	}
+
+	if opts.Filter != "" {
+		// Check the expression is syntactically valid up-front, so that we don't
+		// silently return no results.
+		if _, err := bexpr.CreateEvaluator(opts.Filter, nil); err != nil {
+			return nil, fmt.Errorf("%w: invalid filter: %v", storage.ErrInvalidListOptions, err)
+		}
+	}
+
+	return &listFilter{
+		selector:   selector,
+		expression: opts.Filter,
+		evaluators: make(map[reflect.Type]*bexpr.Evaluator),
+	}, nil
+}
+
+func (f *listFilter) matches(res *pbresource.Resource) bool {
+	if !f.selector.Matches(res.Metadata) {
+		return false
+	}
+
+	if f.expression == "" {
+		return true
+	}
+
+	if res.Data == nil {
+		return false
+	}
+
+	data, err := anypb.UnmarshalNew(res.Data, proto.UnmarshalOptions{})
+	if err != nil {
+		return false
+	}
+
+	typ := reflect.TypeOf(data)
+	eval, ok := f.evaluators[typ]
+	if !ok {
+		eval, err = bexpr.CreateEvaluatorForType(f.expression, nil, data)
+		if err != nil {
+			// The expression refers to fields that don't exist in this type.
+			eval = nil
+		}
+		f.evaluators[typ] = eval
+	}
+	if eval == nil {
+		return false
+	}
+
+	match, err := eval.Evaluate(data)
+	return err == nil && match
+}
+
+// encodePageToken returns an opaque token identifying the given resource as the
+// last one on a page. The token is the resource's radix tree key, which allows
+// us to efficiently seek to the next page with a LowerBound scan.
+func encodePageToken(id *pbresource.ID) string {
+	return base64.RawURLEncoding.EncodeToString(indexFromID(id, false))
+}
+
+// decodePageToken decodes the given token, and checks that it belongs to a
+// resource matched by the given query's index prefix.
+func decodePageToken(token string, q query) ([]byte, error) {
+	key, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid page token", storage.ErrInvalidListOptions)
+	}
+	if !bytes.HasPrefix(key, q.indexPrefix()) {
+		return nil, fmt.Errorf("%w: page token does not match query", storage.ErrInvalidListOptions)
+	}
+	return key, nil
+}
+
+// pageTokenID is passed to memdb's LowerBound to seek to the key in a page
+// token. It satisfies idIndexer.FromArgs without decoding the key back into
+// an ID.
+type pageTokenID []byte
@@ -72,11 +72,13 @@ func (i idIndexer) FromArgs(args ...any) ([]byte, error) {
 	if l := len(args); l != 1 {
 		return nil, fmt.Errorf("expected 1 arg, got: %d", l)
 	}
-	id, ok := args[0].(*pbresource.ID)
-	if !ok {
-		return nil, fmt.Errorf("expected *pbresource.ID, got: %T", args[0])
+	switch t := args[0].(type) {
+	case *pbresource.ID:
+		return indexFromID(t, false), nil
+	case pageTokenID:
+		return t, nil
 	}
-	return indexFromID(id, false), nil
+	return nil, fmt.Errorf("expected *pbresource.ID, got: %T", args[0])
 }
 
 // FromObject constructs a radix tree key from a Resource at write-time, or an
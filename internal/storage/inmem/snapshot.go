@@ -45,10 +45,26 @@ func (s *Store) Restore() (*Restoration, error) {
 	if err != nil {
 		return nil, err
 	}
+	// Event indexes must keep increasing across the restoration, as events
+	// published before it may still be delivered to watches after it, and are
+	// skipped by their lower index.
+	oldTx := s.txn(false)
+	prev, err := currentEventIndex(oldTx)
+	oldTx.Abort()
+	if err != nil {
+		return nil, err
+	}
+
+	tx := db.Txn(true)
+	if err := setEventIndex(tx, prev); err != nil {
+		tx.Abort()
+		return nil, err
+	}
+
 	return &Restoration{
 		s:  s,
 		db: db,
-		tx: db.Txn(true),
+		tx: tx,
 	}, nil
 }
 
@@ -67,6 +83,12 @@ func (r *Restoration) Apply(res *pbresource.Resource) error {
 // Commit the restoration. Replaces the in-memory database wholesale and closes
 // any watches.
 func (r *Restoration) Commit() {
+	r.s.eventLock.Lock()
+	defer r.s.eventLock.Unlock()
+
+	// Discard the event history because watches cannot be resumed across the
+	// restoration (we don't know which events the snapshot includes).
+	r.s.history.reset(0)
 	r.tx.Commit()
 
 	r.s.mu.Lock()
@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
 
 	"github.com/hashicorp/consul/internal/storage"
 	"github.com/hashicorp/consul/internal/storage/inmem"
@@ -97,3 +98,85 @@ func TestSnapshotRestore(t *testing.T) {
 	_, err = watch.Next(ctx)
 	require.ErrorIs(t, err, storage.ErrWatchClosed)
 }
+
+func TestSnapshotRestore_ResumeWatch(t *testing.T) {
+	store, err := inmem.NewStore()
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	go store.Run(ctx)
+
+	res := &pbresource.Resource{
+		Id: &pbresource.ID{
+			Type: &pbresource.Type{
+				Group:        "mesh",
+				GroupVersion: "v1",
+				Kind:         "service",
+			},
+			Tenancy: &pbresource.Tenancy{
+				Partition: "default",
+				PeerName:  "local",
+				Namespace: "default",
+			},
+			Name: "billing",
+			Uid:  "a",
+		},
+		Version: "1",
+	}
+	require.NoError(t, store.WriteCAS(res, ""))
+
+	watch, err := store.WatchList(storage.UnversionedTypeFrom(res.Id.Type), res.Id.Tenancy, "")
+	require.NoError(t, err)
+
+	event, err := watch.Next(ctx)
+	require.NoError(t, err)
+	lastIndex := event.Index
+	watch.Close()
+
+	snap, err := store.Snapshot()
+	require.NoError(t, err)
+
+	restore, err := store.Restore()
+	require.NoError(t, err)
+	defer restore.Abort()
+
+	for r := snap.Next(); r != nil; r = snap.Next() {
+		restore.Apply(r)
+	}
+	restore.Commit()
+
+	// Events that happened before the restoration cannot be replayed, so the
+	// watch must be reset.
+	watch, err = store.WatchListFrom(storage.UnversionedTypeFrom(res.Id.Type), res.Id.Tenancy, "", lastIndex-1)
+	require.NoError(t, err)
+	t.Cleanup(watch.Close)
+
+	event, err = watch.Next(ctx)
+	require.NoError(t, err)
+	require.Equal(t, pbresource.WatchEvent_OPERATION_RESET, event.Operation)
+
+	event, err = watch.Next(ctx)
+	require.NoError(t, err)
+	require.Equal(t, pbresource.WatchEvent_OPERATION_UPSERT, event.Operation)
+	prototest.AssertDeepEqual(t, res, event.Resource)
+
+	// Event indexes continue to increase after the restoration, but the watch
+	// can't be resumed from indexes issued before it.
+	updated := proto.Clone(res).(*pbresource.Resource)
+	updated.Version = "2"
+	require.NoError(t, store.WriteCAS(updated, res.Version))
+
+	event, err = watch.Next(ctx)
+	require.NoError(t, err)
+	prototest.AssertDeepEqual(t, updated, event.Resource)
+	require.Greater(t, event.Index, lastIndex)
+
+	watch, err = store.WatchListFrom(storage.UnversionedTypeFrom(res.Id.Type), res.Id.Tenancy, "", lastIndex)
+	require.NoError(t, err)
+	t.Cleanup(watch.Close)
+
+	event, err = watch.Next(ctx)
+	require.NoError(t, err)
+	require.Equal(t, pbresource.WatchEvent_OPERATION_RESET, event.Operation)
+}
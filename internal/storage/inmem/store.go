@@ -4,7 +4,10 @@
 package inmem
 
 import (
+	"bytes"
 	"context"
+	"errors"
+	"fmt"
 	"sync"
 	"time"
 
@@ -39,6 +42,10 @@ type Store struct {
 	//
 	// Without this lock, it would be possible to publish events out-of-order.
 	eventLock sync.Mutex
+
+	// history holds recently published events, to resume watches from. It is
+	// guarded by eventLock.
+	history eventHistory
 }
 
 // NewStore creates a Store.
@@ -56,6 +63,14 @@ func NewStore() (*Store, error) {
 	}
 	s.pub.RegisterHandler(eventTopic, s.watchSnapshot, false)
 
+	// The Store starts out empty, so watches can be resumed from the beginning
+	// (e.g. the Store is fed the log from its first entry).
+	idx, err := currentEventIndex(db.Txn(false))
+	if err != nil {
+		return nil, err
+	}
+	s.history.reset(idx)
+
 	return s, nil
 }
 
@@ -101,12 +116,39 @@ func (s *Store) Read(id *pbresource.ID) (*pbresource.Resource, error) {
 //
 // For more information, see the storage.Backend documentation.
 func (s *Store) WriteCAS(res *pbresource.Resource, vsn string) error {
+	return s.WriteCASAt(0, res, vsn)
+}
+
+// WriteCASAt is like WriteCAS, but numbers the resulting event from the index
+// of the log entry that caused the write (e.g. the Raft index), such that all
+// Stores fed the same log issue the same event indexes.
+func (s *Store) WriteCASAt(logIndex uint64, res *pbresource.Resource, vsn string) error {
 	s.eventLock.Lock()
 	defer s.eventLock.Unlock()
 
 	tx := s.txn(true)
 	defer tx.Abort()
 
+	if err := writeTxn(tx, res, vsn); err != nil {
+		return err
+	}
+
+	logIndex, err := nextLogIndex(tx, logIndex)
+	if err != nil {
+		return err
+	}
+	idx := eventIndex(logIndex, 0)
+	if err := setEventIndex(tx, idx); err != nil {
+		return err
+	}
+	tx.Commit()
+
+	s.publishEvent(idx, pbresource.WatchEvent_OPERATION_UPSERT, res)
+
+	return nil
+}
+
+func writeTxn(tx *memdb.Txn, res *pbresource.Resource, vsn string) error {
 	existing, err := tx.First(tableNameResources, indexNameID, res.Id)
 	if err != nil {
 		return err
@@ -131,64 +173,159 @@ func (s *Store) WriteCAS(res *pbresource.Resource, vsn string) error {
 		}
 	}
 
-	if err := tx.Insert(tableNameResources, res); err != nil {
-		return err
-	}
-
-	idx, err := incrementEventIndex(tx)
-	if err != nil {
-		return nil
-	}
-	tx.Commit()
-
-	s.publishEvent(idx, pbresource.WatchEvent_OPERATION_UPSERT, res)
-
-	return nil
+	return tx.Insert(tableNameResources, res)
 }
 
 // DeleteCAS performs an atomic Compare-And-Swap (CAS) deletion of a resource.
 //
 // For more information, see the storage.Backend documentation.
 func (s *Store) DeleteCAS(id *pbresource.ID, vsn string) error {
+	return s.DeleteCASAt(0, id, vsn)
+}
+
+// DeleteCASAt is like DeleteCAS, but numbers the resulting event from the index
+// of the log entry that caused the deletion (see WriteCASAt).
+func (s *Store) DeleteCASAt(logIndex uint64, id *pbresource.ID, vsn string) error {
 	s.eventLock.Lock()
 	defer s.eventLock.Unlock()
 
 	tx := s.txn(true)
 	defer tx.Abort()
 
-	existing, err := tx.First(tableNameResources, indexNameID, id)
+	res, err := deleteTxn(tx, id, vsn)
+	if err != nil || res == nil {
+		return err
+	}
+
+	logIndex, err = nextLogIndex(tx, logIndex)
 	if err != nil {
 		return err
 	}
+	idx := eventIndex(logIndex, 0)
+	if err := setEventIndex(tx, idx); err != nil {
+		return err
+	}
+	tx.Commit()
+
+	s.publishEvent(idx, pbresource.WatchEvent_OPERATION_DELETE, res)
+
+	return nil
+}
+
+// deleteTxn deletes the resource with the given ID, and returns it. It returns
+// a nil resource if the deletion was a no-op.
+func deleteTxn(tx *memdb.Txn, id *pbresource.ID, vsn string) (*pbresource.Resource, error) {
+	existing, err := tx.First(tableNameResources, indexNameID, id)
+	if err != nil {
+		return nil, err
+	}
 
 	// Deleting an already deleted resource is a no-op.
 	if existing == nil {
-		return nil
+		return nil, nil
 	}
 
 	res := existing.(*pbresource.Resource)
 
 	// Deleting a resource using a previous Uid is a no-op.
 	if id.Uid != res.Id.Uid {
-		return nil
+		return nil, nil
 	}
 
 	// Ensure CAS semantics.
 	if vsn != res.Version {
-		return storage.ErrCASFailure
+		return nil, storage.ErrCASFailure
 	}
 
 	if err := tx.Delete(tableNameResources, id); err != nil {
-		return err
+		return nil, err
+	}
+	return res, nil
+}
+
+// TxnOp is an operation within a transaction applied by Txn.
+type TxnOp struct {
+	// Write is the resource to write, with its new version already set.
+	Write *pbresource.Resource
+
+	// Delete is the ID of the resource to delete.
+	Delete *pbresource.ID
+
+	// Version is the expected current version of the resource being written or
+	// deleted.
+	Version string
+}
+
+// Txn atomically applies the given operations.
+//
+// For more information, see the storage.Backend documentation.
+func (s *Store) Txn(ops []TxnOp) error {
+	return s.TxnAt(0, ops)
+}
+
+// TxnAt is like Txn, but numbers the resulting events from the index of the
+// log entry that caused the transaction (see WriteCASAt).
+func (s *Store) TxnAt(logIndex uint64, ops []TxnOp) error {
+	if len(ops) > maxTxnOps {
+		return fmt.Errorf("transaction has %d operations, but at most %d are allowed", len(ops), maxTxnOps)
 	}
 
-	idx, err := incrementEventIndex(tx)
+	s.eventLock.Lock()
+	defer s.eventLock.Unlock()
+
+	tx := s.txn(true)
+	defer tx.Abort()
+
+	logIndex, err := nextLogIndex(tx, logIndex)
 	if err != nil {
-		return nil
+		return err
+	}
+
+	type event struct {
+		idx uint64
+		op  pbresource.WatchEvent_Operation
+		res *pbresource.Resource
+	}
+	events := make([]event, 0, len(ops))
+
+	for i, op := range ops {
+		var (
+			ev  = event{op: pbresource.WatchEvent_OPERATION_UPSERT, res: op.Write}
+			err error
+		)
+		switch {
+		case op.Write != nil && op.Delete == nil:
+			err = writeTxn(tx, op.Write, op.Version)
+		case op.Delete != nil && op.Write == nil:
+			ev.op = pbresource.WatchEvent_OPERATION_DELETE
+			ev.res, err = deleteTxn(tx, op.Delete, op.Version)
+		default:
+			err = errors.New("exactly one of Write or Delete must be set")
+		}
+		if err != nil {
+			return storage.TxnError{Index: i, Err: err}
+		}
+
+		// Deletion was a no-op.
+		if ev.res == nil {
+			continue
+		}
+
+		// Each operation gets its own event index so that watchers observe them
+		// as distinct events, in order.
+		ev.idx = eventIndex(logIndex, len(events))
+		events = append(events, ev)
+	}
+	if len(events) != 0 {
+		if err := setEventIndex(tx, events[len(events)-1].idx); err != nil {
+			return err
+		}
 	}
 	tx.Commit()
 
-	s.publishEvent(idx, pbresource.WatchEvent_OPERATION_DELETE, res)
+	for _, ev := range events {
+		s.publishEvent(ev.idx, ev.op, ev.res)
+	}
 
 	return nil
 }
@@ -221,11 +358,83 @@ func listTxn(tx *memdb.Txn, q query) ([]*pbresource.Resource, error) {
 	return list, nil
 }
 
+// ListPage lists a page of resources of the given type, tenancy, and optionally
+// matching the given name prefix, label selector, and filter expression.
+//
+// For more information, see the storage.Backend documentation.
+func (s *Store) ListPage(typ storage.UnversionedType, ten *pbresource.Tenancy, namePrefix string, opts storage.ListOptions) ([]*pbresource.Resource, string, error) {
+	filter, err := newListFilter(opts)
+	if err != nil {
+		return nil, "", err
+	}
+
+	q := query{typ, ten, namePrefix}
+
+	var after []byte
+	if opts.PageToken != "" {
+		after, err = decodePageToken(opts.PageToken, q)
+		if err != nil {
+			return nil, "", err
+		}
+	}
+
+	tx := s.txn(false)
+	defer tx.Abort()
+
+	var iter memdb.ResultIterator
+	if after == nil {
+		iter, err = tx.Get(tableNameResources, indexNameID+"_prefix", q)
+	} else {
+		iter, err = tx.LowerBound(tableNameResources, indexNameID, pageTokenID(after))
+	}
+	if err != nil {
+		return nil, "", err
+	}
+
+	prefix := q.indexPrefix()
+	list := make([]*pbresource.Resource, 0)
+	for v := iter.Next(); v != nil; v = iter.Next() {
+		res := v.(*pbresource.Resource)
+
+		// LowerBound iterates until the end of the table, so stop when we've
+		// passed the resources matching the query.
+		key := indexFromID(res.Id, false)
+		if !bytes.HasPrefix(key, prefix) {
+			break
+		}
+
+		// Skip the last resource of the previous page.
+		if after != nil && bytes.Compare(key, after) <= 0 {
+			continue
+		}
+
+		if !q.matches(res) || !filter.matches(res) {
+			continue
+		}
+
+		// We've found a resource beyond the end of the page, so there's at least
+		// one more page.
+		if opts.PageSize > 0 && len(list) == opts.PageSize {
+			return list, encodePageToken(list[len(list)-1].Id), nil
+		}
+		list = append(list, res)
+	}
+	return list, "", nil
+}
+
 // WatchList watches resources of the given type, tenancy, and optionally
 // matching the given name prefix.
 //
 // For more information, see the storage.Backend documentation.
 func (s *Store) WatchList(typ storage.UnversionedType, ten *pbresource.Tenancy, namePrefix string) (*Watch, error) {
+	return s.WatchListFrom(typ, ten, namePrefix, 0)
+}
+
+// WatchListFrom is like WatchList, but resumes the watch from the given event
+// index.
+//
+// For more information, see the storage.Backend documentation.
+func (s *Store) WatchListFrom(typ storage.UnversionedType, ten *pbresource.Tenancy, namePrefix string, index uint64) (*Watch, error) {
 	// If the user specifies a wildcard, we subscribe to events for resources in
 	// all partitions, peers, and namespaces, and manually filter out irrelevant
 	// stuff (in Watch.Next).
@@ -241,22 +450,51 @@ func (s *Store) WatchList(typ storage.UnversionedType, ten *pbresource.Tenancy,
 		sub = tenancySubject{typ, ten}
 	}
 
+	// The EventPublisher can only resume the subscription if the given index is
+	// that of the last event on the subject, otherwise it falls back to emitting
+	// a snapshot of the current state of the world.
 	ss, err := s.pub.Subscribe(&stream.SubscribeRequest{
 		Topic:   eventTopic,
 		Subject: sub,
+		Index:   index,
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	return &Watch{
+	w := &Watch{
 		sub: ss,
 		query: query{
 			resourceType: typ,
 			tenancy:      ten,
 			namePrefix:   namePrefix,
 		},
-	}, nil
+	}
+	if index == 0 {
+		return w, nil
+	}
+
+	// If we still have the events that happened after the given index, we can
+	// replay them rather than falling back to a snapshot. The subscription was
+	// created before taking the lock, so will emit any event published after
+	// the history's head.
+	s.eventLock.Lock()
+	defer s.eventLock.Unlock()
+
+	events, ok := s.history.since(index)
+	if !ok {
+		return w, nil
+	}
+	for _, e := range events {
+		// Unlike the subscription, the history contains events for all types.
+		if storage.UnversionedTypeFrom(e.Resource.Id.Type) == typ && w.query.matches(e.Resource) {
+			w.replay = append(w.replay, e)
+		}
+	}
+	w.resumed = true
+	w.skipIndex = s.history.headIndex()
+
+	return w, nil
 }
 
 // OwnerReferences returns the IDs of resources owned by the resource with the
@@ -22,10 +22,29 @@ type Watch struct {
 	// events holds excess events when they are bundled in a stream.PayloadEvents,
 	// until Next is called again.
 	events []stream.Event
+
+	// replay holds the events from the Store's history that happened after the
+	// index the watch was resumed from, until Next is called again.
+	replay []*pbresource.WatchEvent
+
+	// resumed indicates the missed events were replayed from the Store's history,
+	// so the snapshot the subscription may fall back to is not needed.
+	resumed bool
+
+	// skipIndex is the index up to which events have already been emitted (i.e.
+	// from replay or a snapshot), so events from the subscription up to and
+	// including it are skipped.
+	skipIndex uint64
 }
 
 // Next returns the next WatchEvent, blocking until one is available.
 func (w *Watch) Next(ctx context.Context) (*pbresource.WatchEvent, error) {
+	if len(w.replay) != 0 {
+		event := w.replay[0]
+		w.replay = w.replay[1:]
+		return event, nil
+	}
+
 	for {
 		e, err := w.nextEvent(ctx)
 		if err == stream.ErrSubForceClosed {
@@ -35,6 +54,29 @@ func (w *Watch) Next(ctx context.Context) (*pbresource.WatchEvent, error) {
 			return nil, err
 		}
 
+		switch {
+		case e.IsNewSnapshotToFollow():
+			// The subscription could not be resumed from the given index, so will be
+			// followed by a snapshot of the current state of the world. Unless we've
+			// already replayed the missed events, tell the caller to discard their
+			// view of the world.
+			if w.resumed {
+				continue
+			}
+			return &pbresource.WatchEvent{Operation: pbresource.WatchEvent_OPERATION_RESET}, nil
+
+		case e.IsEndOfSnapshot():
+			// Events published while the snapshot was being taken may follow it, but
+			// they're already reflected in the snapshot.
+			if e.Index > w.skipIndex {
+				w.skipIndex = e.Index
+			}
+			continue
+
+		case e.Index <= w.skipIndex:
+			continue
+		}
+
 		event := e.Payload.(eventPayload).event
 		if w.query.matches(event.Resource) {
 			return event, nil
@@ -55,6 +97,11 @@ func (w *Watch) nextEvent(ctx context.Context) (*stream.Event, error) {
 			return nil, err
 		}
 
+		// Snapshot framing events are handled by Next.
+		if e.IsNewSnapshotToFollow() || e.IsEndOfSnapshot() {
+			return &e, nil
+		}
+
 		if e.IsFramingEvent() {
 			continue
 		}
@@ -117,7 +164,8 @@ func (s tenancySubject) String() string {
 func (s *Store) publishEvent(idx uint64, op pbresource.WatchEvent_Operation, res *pbresource.Resource) {
 	id := res.Id
 	resourceType := storage.UnversionedTypeFrom(id.Type)
-	event := &pbresource.WatchEvent{Operation: op, Resource: res}
+	event := &pbresource.WatchEvent{Operation: op, Resource: res, Index: idx}
+	s.history.append(event)
 
 	// We publish two copies of the event: one to the tenancy-specific subject and
 	// another to a wildcard subject. Ideally, we'd be able to put the type in the
@@ -188,6 +236,7 @@ func (s *Store) watchSnapshot(req stream.SubscribeRequest, snap stream.SnapshotA
 				event: &pbresource.WatchEvent{
 					Operation: pbresource.WatchEvent_OPERATION_UPSERT,
 					Resource:  r,
+					Index:     idx,
 				},
 			},
 		}
@@ -196,3 +245,68 @@ func (s *Store) watchSnapshot(req stream.SubscribeRequest, snap stream.SnapshotA
 
 	return idx, nil
 }
+
+// eventHistorySize is the number of events retained by the Store to resume
+// watches from.
+const eventHistorySize = 1024
+
+// eventHistory retains the most recently published events, so that watches can
+// be resumed from a given index without emitting the current state of the
+// world. It must only be accessed while holding the Store's eventLock.
+type eventHistory struct {
+	events []*pbresource.WatchEvent
+
+	// compactedIndex is the index of the most recent event that is no longer
+	// retained (or the index at which the history began). Watches can only be
+	// resumed from this index or later. Zero means the index at which the
+	// history began is unknown, in which case it begins at the next event.
+	compactedIndex uint64
+}
+
+func (h *eventHistory) append(event *pbresource.WatchEvent) {
+	if h.compactedIndex == 0 {
+		h.compactedIndex = event.Index
+		return
+	}
+	h.events = append(h.events, event)
+
+	if len(h.events) > eventHistorySize {
+		h.compactedIndex = h.events[0].Index
+		h.events[0] = nil
+		h.events = h.events[1:]
+	}
+}
+
+// reset discards the retained events, such that watches can only be resumed
+// from the given index or later (or, if it is zero, from the next event).
+func (h *eventHistory) reset(idx uint64) {
+	h.events = nil
+	h.compactedIndex = idx
+}
+
+// headIndex returns the index of the most recent event.
+func (h *eventHistory) headIndex() uint64 {
+	if len(h.events) == 0 {
+		return h.compactedIndex
+	}
+	return h.events[len(h.events)-1].Index
+}
+
+// since returns the events that happened after the given index, or false if
+// they have been compacted away or haven't happened yet (e.g. the index was
+// issued by a Store further along the log).
+func (h *eventHistory) since(idx uint64) ([]*pbresource.WatchEvent, bool) {
+	if h.compactedIndex == 0 || idx < h.compactedIndex || idx > h.headIndex() {
+		return nil, false
+	}
+
+	// Events are in index order, so skip to the first one after idx.
+	first := len(h.events)
+	for i, e := range h.events {
+		if e.Index > idx {
+			first = i
+			break
+		}
+	}
+	return h.events[first:], true
+}
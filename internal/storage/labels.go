@@ -0,0 +1,212 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package storage
+
+import (
+	"fmt"
+	"strings"
+)
+
+// LabelSelector matches resources based on their Metadata. It's a conjunction
+// of requirements (i.e. a resource must satisfy all of them to match). The zero
+// value matches every resource.
+type LabelSelector []labelRequirement
+
+type labelOperator int
+
+const (
+	labelOpExists labelOperator = iota
+	labelOpNotExists
+	labelOpEquals
+	labelOpNotEquals
+	labelOpIn
+	labelOpNotIn
+)
+
+type labelRequirement struct {
+	key    string
+	op     labelOperator
+	values []string
+}
+
+// ParseLabelSelector parses a comma-separated list of requirements, using the
+// same syntax as Kubernetes label selectors:
+//
+//	key             the key is present
+//	!key            the key is not present
+//	key=value       the key is present and has the given value (== also works)
+//	key!=value      the key is not present, or has a different value
+//	key in (a,b)    the key is present and has one of the given values
+//	key notin (a,b) the key is not present, or has none of the given values
+//
+// For example: "env=production,tier in (web,api),!deprecated".
+func ParseLabelSelector(selector string) (LabelSelector, error) {
+	var result LabelSelector
+
+	requirements, err := splitLabelRequirements(selector)
+	if err != nil {
+		return nil, err
+	}
+	for _, raw := range requirements {
+		req, err := parseLabelRequirement(raw)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, req)
+	}
+	return result, nil
+}
+
+// Matches returns whether the given labels satisfy all of the selector's
+// requirements.
+func (s LabelSelector) Matches(labels map[string]string) bool {
+	for _, req := range s {
+		if !req.matches(labels) {
+			return false
+		}
+	}
+	return true
+}
+
+func (r labelRequirement) matches(labels map[string]string) bool {
+	value, ok := labels[r.key]
+
+	switch r.op {
+	case labelOpExists:
+		return ok
+	case labelOpNotExists:
+		return !ok
+	case labelOpEquals:
+		return ok && value == r.values[0]
+	case labelOpNotEquals:
+		return !ok || value != r.values[0]
+	case labelOpIn:
+		return ok && containsString(r.values, value)
+	case labelOpNotIn:
+		return !ok || !containsString(r.values, value)
+	}
+	return false
+}
+
+// splitLabelRequirements splits the selector on commas that aren't inside a
+// set of values (e.g. "a in (x,y),b" becomes ["a in (x,y)", "b"]).
+func splitLabelRequirements(selector string) ([]string, error) {
+	if strings.TrimSpace(selector) == "" {
+		return nil, nil
+	}
+
+	var (
+		parts []string
+		depth int
+		start int
+	)
+	for i, c := range selector {
+		switch c {
+		case '(':
+			depth++
+			if depth > 1 {
+				return nil, fmt.Errorf("invalid label selector %q: unexpected '('", selector)
+			}
+		case ')':
+			depth--
+			if depth < 0 {
+				return nil, fmt.Errorf("invalid label selector %q: unexpected ')'", selector)
+			}
+		case ',':
+			if depth == 0 {
+				parts = append(parts, selector[start:i])
+				start = i + 1
+			}
+		}
+	}
+	if depth != 0 {
+		return nil, fmt.Errorf("invalid label selector %q: missing ')'", selector)
+	}
+	return append(parts, selector[start:]), nil
+}
+
+func parseLabelRequirement(raw string) (labelRequirement, error) {
+	raw = strings.TrimSpace(raw)
+
+	switch {
+	case raw == "":
+		return labelRequirement{}, fmt.Errorf("invalid label selector: empty requirement")
+
+	case strings.HasPrefix(raw, "!") && !strings.Contains(raw, "="):
+		key := strings.TrimSpace(raw[1:])
+		return newLabelRequirement(key, labelOpNotExists, nil)
+
+	case strings.Contains(raw, "!="):
+		key, value, _ := strings.Cut(raw, "!=")
+		return newLabelRequirement(key, labelOpNotEquals, []string{value})
+
+	case strings.Contains(raw, "=="):
+		key, value, _ := strings.Cut(raw, "==")
+		return newLabelRequirement(key, labelOpEquals, []string{value})
+
+	case strings.Contains(raw, "="):
+		key, value, _ := strings.Cut(raw, "=")
+		return newLabelRequirement(key, labelOpEquals, []string{value})
+
+	case strings.Contains(raw, "("):
+		fields := strings.SplitN(raw, " ", 2)
+		if len(fields) != 2 {
+			return labelRequirement{}, fmt.Errorf("invalid label selector requirement %q", raw)
+		}
+		key, rest := fields[0], strings.TrimSpace(fields[1])
+
+		var op labelOperator
+		switch {
+		case strings.HasPrefix(rest, "notin"):
+			op, rest = labelOpNotIn, strings.TrimPrefix(rest, "notin")
+		case strings.HasPrefix(rest, "in"):
+			op, rest = labelOpIn, strings.TrimPrefix(rest, "in")
+		default:
+			return labelRequirement{}, fmt.Errorf("invalid label selector requirement %q: expected 'in' or 'notin'", raw)
+		}
+
+		rest = strings.TrimSpace(rest)
+		if !strings.HasPrefix(rest, "(") || !strings.HasSuffix(rest, ")") {
+			return labelRequirement{}, fmt.Errorf("invalid label selector requirement %q: values must be enclosed in parentheses", raw)
+		}
+
+		var values []string
+		for _, v := range strings.Split(rest[1:len(rest)-1], ",") {
+			if v = strings.TrimSpace(v); v != "" {
+				values = append(values, v)
+			}
+		}
+		if len(values) == 0 {
+			return labelRequirement{}, fmt.Errorf("invalid label selector requirement %q: at least one value is required", raw)
+		}
+		return newLabelRequirement(key, op, values)
+
+	default:
+		return newLabelRequirement(raw, labelOpExists, nil)
+	}
+}
+
+func newLabelRequirement(key string, op labelOperator, values []string) (labelRequirement, error) {
+	key = strings.TrimSpace(key)
+	if key == "" {
+		return labelRequirement{}, fmt.Errorf("invalid label selector: key is required")
+	}
+	if strings.ContainsAny(key, " \t!=(),") {
+		return labelRequirement{}, fmt.Errorf("invalid label selector: invalid key %q", key)
+	}
+
+	for i, v := range values {
+		values[i] = strings.TrimSpace(v)
+	}
+	return labelRequirement{key: key, op: op, values: values}, nil
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,67 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package storage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLabelSelector(t *testing.T) {
+	labels := map[string]string{
+		"env":  "production",
+		"tier": "web",
+	}
+
+	testCases := map[string]bool{
+		"":                              true,
+		"env":                           true,
+		"!env":                          false,
+		"!region":                       true,
+		"env=production":                true,
+		"env==production":               true,
+		"env = production":              true,
+		"env=staging":                   false,
+		"env!=staging":                  true,
+		"region!=us-east":               true,
+		"tier in (web, api)":            true,
+		"tier in (api)":                 false,
+		"region in (us-east)":           false,
+		"tier notin (api,worker)":       true,
+		"tier notin (web)":              false,
+		"region notin (us-east)":        true,
+		"env=production,tier in (web)":  true,
+		"env=production, tier=api":      false,
+		"tier in (web,api),!deprecated": true,
+	}
+	for selector, expected := range testCases {
+		t.Run(selector, func(t *testing.T) {
+			s, err := ParseLabelSelector(selector)
+			require.NoError(t, err)
+			require.Equal(t, expected, s.Matches(labels))
+		})
+	}
+}
+
+func TestParseLabelSelector_Invalid(t *testing.T) {
+	for _, selector := range []string{
+		",",
+		"env=production,",
+		"=production",
+		"!",
+		"tier in web",
+		"tier in ()",
+		"tier within (web)",
+		"tier in (web",
+		"tier in (web))",
+		"tier in ((web))",
+		"my env=production",
+	} {
+		t.Run(selector, func(t *testing.T) {
+			_, err := ParseLabelSelector(selector)
+			require.Error(t, err)
+		})
+	}
+}
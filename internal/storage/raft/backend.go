@@ -5,6 +5,7 @@ package raft
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net"
 	"strconv"
@@ -174,6 +175,58 @@ func (b *Backend) DeleteCAS(ctx context.Context, id *pbresource.ID, version stri
 	return b.forwardingClient.delete(ctx, req)
 }
 
+// Txn implements the storage.Backend interface.
+func (b *Backend) Txn(ctx context.Context, ops []storage.TxnOp) ([]*pbresource.Resource, error) {
+	req := &pbstorage.TxnRequest{Ops: make([]*pbstorage.TxnOp, len(ops))}
+	for i, op := range ops {
+		switch {
+		case op.Write != nil && op.Delete == nil:
+			req.Ops[i] = &pbstorage.TxnOp{
+				Op: &pbstorage.TxnOp_Write{
+					Write: &pbstorage.WriteRequest{Resource: op.Write},
+				},
+			}
+		case op.Delete != nil && op.Write == nil:
+			req.Ops[i] = &pbstorage.TxnOp{
+				Op: &pbstorage.TxnOp_Delete{
+					Delete: &pbstorage.DeleteRequest{Id: op.Delete, Version: op.DeleteVersion},
+				},
+			}
+		default:
+			return nil, storage.TxnError{
+				Index: i,
+				Err:   errors.New("exactly one of Write or Delete must be set"),
+			}
+		}
+	}
+
+	var rsp *pbstorage.TxnResponse
+	if b.handle.IsLeader() {
+		logRsp, err := b.raftApply(&pbstorage.Log{
+			Type: pbstorage.LogType_LOG_TYPE_TXN,
+			Request: &pbstorage.Log_Txn{
+				Txn: req,
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+		rsp = logRsp.GetTxn()
+	} else {
+		var err error
+		rsp, err = b.forwardingClient.txn(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	results := make([]*pbresource.Resource, len(ops))
+	for i, result := range rsp.GetResults() {
+		results[i] = result.GetResource()
+	}
+	return results, nil
+}
+
 // List implements the storage.Backend interface.
 func (b *Backend) List(ctx context.Context, consistency storage.ReadConsistency, resType storage.UnversionedType, tenancy *pbresource.Tenancy, namePrefix string) ([]*pbresource.Resource, error) {
 	// Easy case. Both leaders and followers can read from the local store.
@@ -212,11 +265,58 @@ func (b *Backend) leaderList(ctx context.Context, resType storage.UnversionedTyp
 	return b.store.List(resType, tenancy, namePrefix)
 }
 
+// ListPage implements the storage.Backend interface.
+func (b *Backend) ListPage(ctx context.Context, consistency storage.ReadConsistency, resType storage.UnversionedType, tenancy *pbresource.Tenancy, namePrefix string, opts storage.ListOptions) ([]*pbresource.Resource, string, error) {
+	// Easy case. Both leaders and followers can read from the local store.
+	if consistency == storage.EventualConsistency {
+		return b.store.ListPage(resType, tenancy, namePrefix, opts)
+	}
+
+	if consistency != storage.StrongConsistency {
+		return nil, "", fmt.Errorf("%w: unknown consistency: %s", storage.ErrInconsistent, consistency)
+	}
+
+	// We are the leader. Handle the request ourself.
+	if b.handle.IsLeader() {
+		return b.leaderListPage(ctx, resType, tenancy, namePrefix, opts)
+	}
+
+	// Forward the request to the leader.
+	rsp, err := b.forwardingClient.list(ctx, &pbstorage.ListRequest{
+		Type: &pbresource.Type{
+			Group: resType.Group,
+			Kind:  resType.Kind,
+		},
+		Tenancy:       tenancy,
+		NamePrefix:    namePrefix,
+		PageSize:      uint32(opts.PageSize),
+		PageToken:     opts.PageToken,
+		LabelSelector: opts.LabelSelector,
+		Filter:        opts.Filter,
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	return rsp.GetResources(), rsp.GetNextPageToken(), nil
+}
+
+func (b *Backend) leaderListPage(ctx context.Context, resType storage.UnversionedType, tenancy *pbresource.Tenancy, namePrefix string, opts storage.ListOptions) ([]*pbresource.Resource, string, error) {
+	if err := b.ensureStrongConsistency(ctx); err != nil {
+		return nil, "", err
+	}
+	return b.store.ListPage(resType, tenancy, namePrefix, opts)
+}
+
 // WatchList implements the storage.Backend interface.
 func (b *Backend) WatchList(_ context.Context, resType storage.UnversionedType, tenancy *pbresource.Tenancy, namePrefix string) (storage.Watch, error) {
 	return b.store.WatchList(resType, tenancy, namePrefix)
 }
 
+// WatchListFrom implements the storage.Backend interface.
+func (b *Backend) WatchListFrom(_ context.Context, resType storage.UnversionedType, tenancy *pbresource.Tenancy, namePrefix string, index uint64) (storage.Watch, error) {
+	return b.store.WatchListFrom(resType, tenancy, namePrefix, index)
+}
+
 // OwnerReferences implements the storage.Backend interface.
 func (b *Backend) OwnerReferences(_ context.Context, id *pbresource.ID) ([]*pbresource.ID, error) {
 	return b.store.OwnerReferences(id)
@@ -236,7 +336,7 @@ func (b *Backend) Apply(buf []byte, idx uint64) any {
 		oldVsn := res.Version
 		res.Version = strconv.Itoa(int(idx))
 
-		if err := b.store.WriteCAS(res, oldVsn); err != nil {
+		if err := b.store.WriteCASAt(idx, res, oldVsn); err != nil {
 			return err
 		}
 
@@ -247,12 +347,42 @@ func (b *Backend) Apply(buf []byte, idx uint64) any {
 		}
 	case pbstorage.LogType_LOG_TYPE_DELETE:
 		req := req.GetDelete()
-		if err := b.store.DeleteCAS(req.Id, req.Version); err != nil {
+		if err := b.store.DeleteCASAt(idx, req.Id, req.Version); err != nil {
 			return err
 		}
 		return &pbstorage.LogResponse{
 			Response: &pbstorage.LogResponse_Delete{},
 		}
+	case pbstorage.LogType_LOG_TYPE_TXN:
+		reqOps := req.GetTxn().GetOps()
+		ops := make([]inmem.TxnOp, len(reqOps))
+		results := make([]*pbstorage.TxnResult, len(reqOps))
+		for i, op := range reqOps {
+			results[i] = &pbstorage.TxnResult{}
+
+			switch t := op.Op.(type) {
+			case *pbstorage.TxnOp_Write:
+				res := t.Write.GetResource()
+				oldVsn := res.Version
+				res.Version = strconv.Itoa(int(idx))
+
+				ops[i] = inmem.TxnOp{Write: res, Version: oldVsn}
+				results[i].Resource = res
+			case *pbstorage.TxnOp_Delete:
+				ops[i] = inmem.TxnOp{Delete: t.Delete.GetId(), Version: t.Delete.GetVersion()}
+			default:
+				return storage.TxnError{Index: i, Err: fmt.Errorf("unexpected operation type: %T", op.Op)}
+			}
+		}
+
+		if err := b.store.TxnAt(idx, ops); err != nil {
+			return err
+		}
+		return &pbstorage.LogResponse{
+			Response: &pbstorage.LogResponse_Txn{
+				Txn: &pbstorage.TxnResponse{Results: results},
+			},
+		}
 	}
 
 	return fmt.Errorf("unexpected request type: %s", req.Type)
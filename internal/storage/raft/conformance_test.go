@@ -19,7 +19,10 @@ import (
 	"github.com/hashicorp/consul/internal/storage"
 	"github.com/hashicorp/consul/internal/storage/conformance"
 	"github.com/hashicorp/consul/internal/storage/raft"
+	"github.com/hashicorp/consul/proto-public/pbresource"
+	"github.com/hashicorp/consul/proto/private/prototest"
 	"github.com/hashicorp/consul/sdk/testutil"
+	"github.com/hashicorp/consul/sdk/testutil/retry"
 )
 
 func TestBackend_Conformance(t *testing.T) {
@@ -44,6 +47,51 @@ func TestBackend_Conformance(t *testing.T) {
 	})
 }
 
+func TestBackend_ResumeWatchOnFollower(t *testing.T) {
+	leader, follower := newRaftCluster(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	typ := &pbresource.Type{Group: "test", GroupVersion: "v1", Kind: "thing"}
+	tenancy := &pbresource.Tenancy{Partition: "default", PeerName: "local", Namespace: "default"}
+	newResource := func(name string) *pbresource.Resource {
+		return &pbresource.Resource{
+			Id: &pbresource.ID{Type: typ, Tenancy: tenancy, Name: name, Uid: name},
+		}
+	}
+
+	_, err := leader.WriteCAS(ctx, newResource("a"))
+	require.NoError(t, err)
+
+	watch, err := leader.WatchList(ctx, storage.UnversionedTypeFrom(typ), tenancy, "")
+	require.NoError(t, err)
+	event, err := watch.Next(ctx)
+	require.NoError(t, err)
+	lastIndex := event.Index
+	watch.Close()
+
+	b, err := leader.WriteCAS(ctx, newResource("b"))
+	require.NoError(t, err)
+
+	// Wait for the write to be replicated to the follower.
+	retry.Run(t, func(r *retry.R) {
+		_, err := follower.Read(ctx, storage.EventualConsistency, b.Id)
+		require.NoError(r, err)
+	})
+
+	// The follower issued the same indexes as the leader, so the watch is
+	// resumed from where it left off rather than reset.
+	watch, err = follower.WatchListFrom(ctx, storage.UnversionedTypeFrom(typ), tenancy, "", lastIndex)
+	require.NoError(t, err)
+	t.Cleanup(watch.Close)
+
+	event, err = watch.Next(ctx)
+	require.NoError(t, err)
+	require.Equal(t, pbresource.WatchEvent_OPERATION_UPSERT, event.Operation)
+	prototest.AssertDeepEqual(t, b, event.Resource)
+}
+
 func newRaftCluster(t *testing.T) (*raft.Backend, *raft.Backend) {
 	t.Helper()
 
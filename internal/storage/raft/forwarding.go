@@ -6,7 +6,9 @@ package raft
 import (
 	"context"
 	"errors"
+	"fmt"
 	"net"
+	"strings"
 	"sync"
 
 	"google.golang.org/grpc"
@@ -73,11 +75,33 @@ func (s *forwardingServer) Read(ctx context.Context, req *pbstorage.ReadRequest)
 }
 
 func (s *forwardingServer) List(ctx context.Context, req *pbstorage.ListRequest) (*pbstorage.ListResponse, error) {
-	res, err := s.backend.leaderList(ctx, storage.UnversionedTypeFrom(req.Type), req.Tenancy, req.NamePrefix)
+	res, nextToken, err := s.backend.leaderListPage(
+		ctx,
+		storage.UnversionedTypeFrom(req.Type),
+		req.Tenancy,
+		req.NamePrefix,
+		storage.ListOptions{
+			PageSize:      int(req.PageSize),
+			PageToken:     req.PageToken,
+			LabelSelector: req.LabelSelector,
+			Filter:        req.Filter,
+		},
+	)
 	if err != nil {
 		return nil, wrapError(err)
 	}
-	return &pbstorage.ListResponse{Resources: res}, nil
+	return &pbstorage.ListResponse{Resources: res, NextPageToken: nextToken}, nil
+}
+
+func (s *forwardingServer) Txn(ctx context.Context, req *pbstorage.TxnRequest) (*pbstorage.TxnResponse, error) {
+	rsp, err := s.raftApply(ctx, &pbstorage.Log{
+		Type:    pbstorage.LogType_LOG_TYPE_TXN,
+		Request: &pbstorage.Log_Txn{Txn: req},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return rsp.GetTxn(), nil
 }
 
 func (s *forwardingServer) raftApply(_ context.Context, req *pbstorage.Log) (*pbstorage.LogResponse, error) {
@@ -203,14 +227,24 @@ func (c *forwardingClient) list(ctx context.Context, req *pbstorage.ListRequest)
 	return rsp, unwrapError(err)
 }
 
+func (c *forwardingClient) txn(ctx context.Context, req *pbstorage.TxnRequest) (*pbstorage.TxnResponse, error) {
+	client, err := c.getClient()
+	if err != nil {
+		return nil, err
+	}
+	rsp, err := client.Txn(ctx, req)
+	return rsp, unwrapError(err)
+}
+
 var (
 	errorToCode = map[error]codes.Code{
 		// Note: OutOfRange is used to represent GroupVersionMismatchError, but is
 		// handled specially in wrapError and unwrapError because it has extra details.
-		storage.ErrNotFound:     codes.NotFound,
-		storage.ErrCASFailure:   codes.Aborted,
-		storage.ErrWrongUid:     codes.AlreadyExists,
-		storage.ErrInconsistent: codes.FailedPrecondition,
+		storage.ErrNotFound:           codes.NotFound,
+		storage.ErrCASFailure:         codes.Aborted,
+		storage.ErrWrongUid:           codes.AlreadyExists,
+		storage.ErrInconsistent:       codes.FailedPrecondition,
+		storage.ErrInvalidListOptions: codes.InvalidArgument,
 	}
 
 	codeToError = func() map[codes.Code]error {
@@ -236,9 +270,21 @@ func wrapError(err error) error {
 		}
 	}
 
-	code, ok := errorToCode[err]
-	if !ok {
-		code = codes.Internal
+	var txnErr storage.TxnError
+	if errors.As(err, &txnErr) {
+		// Encode the underlying error as usual, and attach the operation index.
+		s, _ := status.FromError(wrapError(txnErr.Err))
+		if s, err := s.WithDetails(&pbstorage.TxnErrorDetails{Index: uint32(txnErr.Index)}); err == nil {
+			return s.Err()
+		}
+	}
+
+	code := codes.Internal
+	for sentinel, c := range errorToCode {
+		if errors.Is(err, sentinel) {
+			code = c
+			break
+		}
 	}
 	return status.Error(code, err.Error())
 }
@@ -252,10 +298,17 @@ func unwrapError(err error) error {
 	}
 
 	for _, d := range s.Details() {
-		if gvm, ok := d.(*pbstorage.GroupVersionMismatchErrorDetails); ok {
+		switch t := d.(type) {
+		case *pbstorage.GroupVersionMismatchErrorDetails:
 			return storage.GroupVersionMismatchError{
-				RequestedType: gvm.RequestedType,
-				Stored:        gvm.Stored,
+				RequestedType: t.RequestedType,
+				Stored:        t.Stored,
+			}
+		case *pbstorage.TxnErrorDetails:
+			inner := status.New(s.Code(), s.Message())
+			return storage.TxnError{
+				Index: int(t.Index),
+				Err:   unwrapError(inner.Err()),
 			}
 		}
 	}
@@ -264,5 +317,10 @@ func unwrapError(err error) error {
 	if !ok {
 		return err
 	}
+
+	// Preserve any context that was wrapped around the sentinel error.
+	if msg := s.Message(); msg != unwrapped.Error() {
+		return fmt.Errorf("%w: %s", unwrapped, strings.TrimPrefix(msg, unwrapped.Error()+": "))
+	}
 	return unwrapped
 }
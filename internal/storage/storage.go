@@ -37,6 +37,11 @@ var (
 	// a snapshot is restored and the watch's events are no longer valid. Consumers
 	// should discard any materialized state and start a new watch.
 	ErrWatchClosed = errors.New("watch closed")
+
+	// ErrInvalidListOptions is returned by ListPage when the given ListOptions
+	// are invalid (e.g. the page token is malformed or the filter expression
+	// cannot be parsed).
+	ErrInvalidListOptions = errors.New("invalid list options")
 )
 
 // ReadConsistency is used to specify the required consistency guarantees for
@@ -199,6 +204,24 @@ type Backend interface {
 	// See Backend docs for more details.
 	DeleteCAS(ctx context.Context, id *pbresource.ID, version string) error
 
+	// Txn atomically applies the given write and delete operations: either all of
+	// them are applied, or none of them are.
+	//
+	// Each operation has the same semantics as WriteCAS or DeleteCAS (including
+	// CAS checks and Uid handling). Operations are applied in order, so the CAS
+	// check of a later operation observes the effects of earlier ones.
+	//
+	// On success, the returned slice contains one entry per operation: the written
+	// resource (with its new version) for writes, or nil for deletes.
+	//
+	// If an operation fails, a TxnError identifying it and wrapping the underlying
+	// error (e.g. ErrCASFailure) will be returned.
+	//
+	// If the transaction cannot be applied because of a consistency or
+	// availability issue (e.g. when interacting with a Raft follower, or when
+	// quorum is lost) ErrInconsistent will be returned.
+	Txn(ctx context.Context, ops []TxnOp) ([]*pbresource.Resource, error)
+
 	// List resources of the given type, tenancy, and optionally matching the given
 	// name prefix.
 	//
@@ -224,6 +247,33 @@ type Backend interface {
 	// it should not be depended on outside of the backward compatability layer.
 	List(ctx context.Context, consistency ReadConsistency, resType UnversionedType, tenancy *pbresource.Tenancy, namePrefix string) ([]*pbresource.Resource, error)
 
+	// ListPage is like List, but returns at most opts.PageSize resources matching
+	// the label selector and filter expression in opts, along with a token that
+	// can be passed back in opts.PageToken to retrieve the next page. An empty
+	// token means there are no more pages.
+	//
+	// Resources are returned in a stable order (by tenancy and then name), so
+	// paging through a changing set of resources will not return a resource
+	// twice, but won't necessarily include resources written after the first
+	// page was read.
+	//
+	// # Filtering
+	//
+	// opts.LabelSelector is matched against the resource's Metadata, see
+	// ParseLabelSelector for the syntax.
+	//
+	// opts.Filter is a go-bexpr expression evaluated against the resource's Data,
+	// decoded to its protobuf message type, with selectors referring to the Go
+	// struct field names (e.g. `Name == "foo"`). Resources whose data cannot be
+	// decoded, or that the expression cannot be evaluated against (e.g. because
+	// they are stored with a different GroupVersion) do not match.
+	//
+	// If the options are invalid, ErrInvalidListOptions will be returned.
+	//
+	// See List docs for details about Tenancy Wildcard, GroupVersion, and
+	// Consistency.
+	ListPage(ctx context.Context, consistency ReadConsistency, resType UnversionedType, tenancy *pbresource.Tenancy, namePrefix string, opts ListOptions) ([]*pbresource.Resource, string, error)
+
 	// WatchList watches resources of the given type, tenancy, and optionally
 	// matching the given name prefix. Upsert events for the current state of the
 	// world (i.e. existing resources that match the given filters) will be emitted
@@ -252,6 +302,24 @@ type Backend interface {
 	// [monotonic reads]: https://jepsen.io/consistency/models/monotonic-reads
 	WatchList(ctx context.Context, resType UnversionedType, tenancy *pbresource.Tenancy, namePrefix string) (Watch, error)
 
+	// WatchListFrom is like WatchList, but resumes the watch from the given
+	// event index (i.e. the Index of the last WatchEvent the caller received)
+	// rather than emitting events for the current state of the world. Only the
+	// events that happened after the given index will be emitted.
+	//
+	// Indexes are derived from the log that the backend applies writes from
+	// (e.g. the Raft log), so a watch can be resumed against another backend
+	// that applies the same log, such as another server in the cluster.
+	//
+	// If the watch cannot be resumed from the given index (e.g. because the
+	// backend no longer retains the events that happened after it, hasn't yet
+	// applied the write it was issued for, or has restored a snapshot since) an
+	// OPERATION_RESET event will be emitted, followed by upsert events for the
+	// current state of the world as in WatchList.
+	//
+	// An index of zero is equivalent to calling WatchList.
+	WatchListFrom(ctx context.Context, resType UnversionedType, tenancy *pbresource.Tenancy, namePrefix string, index uint64) (Watch, error)
+
 	// OwnerReferences returns the IDs of resources owned by the resource with the
 	// given ID. It is typically used to implement cascading deletion.
 	//
@@ -273,6 +341,56 @@ type Backend interface {
 	OwnerReferences(ctx context.Context, id *pbresource.ID) ([]*pbresource.ID, error)
 }
 
+// TxnOp is a single operation within a transaction. Exactly one of Write or
+// Delete must be set.
+type TxnOp struct {
+	// Write is a resource to write, with the same semantics as WriteCAS (i.e. its
+	// Version must match the stored version, or be empty to create it).
+	Write *pbresource.Resource
+
+	// Delete is the ID of a resource to delete, with the same semantics as
+	// DeleteCAS, using DeleteVersion as the expected version.
+	Delete        *pbresource.ID
+	DeleteVersion string
+}
+
+// TxnError is returned by Txn when one of its operations fails. None of the
+// transaction's operations will have been applied.
+type TxnError struct {
+	// Index of the operation that failed.
+	Index int
+
+	// Err is the reason the operation failed (e.g. ErrCASFailure).
+	Err error
+}
+
+// Error implements the error interface.
+func (e TxnError) Error() string {
+	return fmt.Sprintf("transaction operation %d failed: %v", e.Index, e.Err)
+}
+
+// Unwrap allows callers to check the underlying error with errors.Is.
+func (e TxnError) Unwrap() error { return e.Err }
+
+// ListOptions control pagination and filtering in ListPage.
+type ListOptions struct {
+	// PageSize is the maximum number of resources to return. Zero means there is
+	// no limit.
+	PageSize int
+
+	// PageToken is the token returned by a previous call to ListPage, or empty
+	// to retrieve the first page.
+	PageToken string
+
+	// LabelSelector restricts the results to resources whose Metadata matches
+	// the given label selector.
+	LabelSelector string
+
+	// Filter restricts the results to resources whose Data matches the given
+	// go-bexpr expression.
+	Filter string
+}
+
 // Watch represents a watch on a given set of resources. Call Next to get the
 // next event (i.e. upsert or deletion) and Close when you're done watching.
 type Watch interface {
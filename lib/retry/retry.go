@@ -115,6 +115,15 @@ func (w *Waiter) Wait(ctx context.Context) error {
 	}
 }
 
+// WaitDuration increases the number of failures by one, and returns the
+// duration the caller must wait for. This is an alternative to the Wait method
+// for cases where you want to handle the timer yourself (e.g. as part of a
+// larger select statement).
+func (w *Waiter) WaitDuration() time.Duration {
+	w.failures++
+	return w.delay()
+}
+
 // NextWait returns the period the next call to Wait with block for assuming
 // it's context is not cancelled. It's useful for informing a user how long
 // it will be before the next attempt is made.
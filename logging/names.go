@@ -20,6 +20,7 @@ const (
 	Consul                string = "consul"
 	ConsulClient          string = "client"
 	ConsulServer          string = "server"
+	ControllerRuntime     string = "controller_runtime"
 	Coordinate            string = "coordinate"
 	DNS                   string = "dns"
 	Envoy                 string = "envoy"
@@ -48,6 +49,7 @@ const (
 	ProxyConfig           string = "proxycfg"
 	Raft                  string = "raft"
 	Replication           string = "replication"
+	ResourceMigration     string = "resource_migration"
 	Router                string = "router"
 	RPC                   string = "rpc"
 	Serf                  string = "serf"
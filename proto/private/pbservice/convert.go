@@ -220,6 +220,31 @@ func NewCheckTypesFromStructs(t structs.CheckTypes) []*CheckType {
 	return s
 }
 
+// TODO: handle this with mog
+func CompositeCheckInputsToStructs(s []*CompositeCheckInput) []structs.CompositeCheckInput {
+	if len(s) == 0 {
+		return nil
+	}
+	t := make([]structs.CompositeCheckInput, len(s))
+	for i, v := range s {
+		CompositeCheckInputToStructs(v, &t[i])
+	}
+	return t
+}
+
+// TODO: handle this with mog
+func NewCompositeCheckInputsFromStructs(t []structs.CompositeCheckInput) []*CompositeCheckInput {
+	if len(t) == 0 {
+		return nil
+	}
+	s := make([]*CompositeCheckInput, len(t))
+	for i := range t {
+		s[i] = new(CompositeCheckInput)
+		CompositeCheckInputFromStructs(&t[i], s[i])
+	}
+	return s
+}
+
 // TODO: handle this with mog
 func ConnectProxyConfigPtrToStructs(s *ConnectProxyConfig) *structs.ConnectProxyConfig {
 	if s == nil {
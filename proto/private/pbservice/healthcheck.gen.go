@@ -20,16 +20,33 @@ func CheckTypeToStructs(s *CheckType, t *structs.CheckType) {
 	t.Method = s.Method
 	t.Body = s.Body
 	t.DisableRedirects = s.DisableRedirects
+	t.ResponseBodyRegex = s.ResponseBodyRegex
+	t.ResponseJSONPath = s.ResponseJSONPath
+	t.ResponseJSONValue = s.ResponseJSONValue
+	t.ResponseHeaders = s.ResponseHeaders
+	t.PassingStatusCodes = s.PassingStatusCodes
+	t.WarningStatusCodes = s.WarningStatusCodes
+	t.CriticalStatusCodes = s.CriticalStatusCodes
 	t.TCP = s.TCP
 	t.UDP = s.UDP
 	t.Interval = structs.DurationFromProto(s.Interval)
 	t.AliasNode = s.AliasNode
 	t.AliasService = s.AliasService
+	t.CompositeInputs = CompositeCheckInputsToStructs(s.CompositeInputs)
+	t.CompositeExpression = s.CompositeExpression
+	t.CompositeMinPassing = int(s.CompositeMinPassing)
 	t.DockerContainerID = s.DockerContainerID
 	t.Shell = s.Shell
 	t.GRPC = s.GRPC
 	t.GRPCUseTLS = s.GRPCUseTLS
 	t.OSService = s.OSService
+	t.Redis = s.Redis
+	t.PostgreSQL = s.PostgreSQL
+	t.MySQL = s.MySQL
+	t.Memcached = s.Memcached
+	t.TLS = s.TLS
+	t.TLSCAFile = s.TLSCAFile
+	t.TLSExpiryWarningDays = int(s.TLSExpiryWarningDays)
 	t.TLSServerName = s.TLSServerName
 	t.TLSSkipVerify = s.TLSSkipVerify
 	t.Timeout = structs.DurationFromProto(s.Timeout)
@@ -58,16 +75,33 @@ func CheckTypeFromStructs(t *structs.CheckType, s *CheckType) {
 	s.Method = t.Method
 	s.Body = t.Body
 	s.DisableRedirects = t.DisableRedirects
+	s.ResponseBodyRegex = t.ResponseBodyRegex
+	s.ResponseJSONPath = t.ResponseJSONPath
+	s.ResponseJSONValue = t.ResponseJSONValue
+	s.ResponseHeaders = t.ResponseHeaders
+	s.PassingStatusCodes = t.PassingStatusCodes
+	s.WarningStatusCodes = t.WarningStatusCodes
+	s.CriticalStatusCodes = t.CriticalStatusCodes
 	s.TCP = t.TCP
 	s.UDP = t.UDP
 	s.Interval = structs.DurationToProto(t.Interval)
 	s.AliasNode = t.AliasNode
 	s.AliasService = t.AliasService
+	s.CompositeInputs = NewCompositeCheckInputsFromStructs(t.CompositeInputs)
+	s.CompositeExpression = t.CompositeExpression
+	s.CompositeMinPassing = int32(t.CompositeMinPassing)
 	s.DockerContainerID = t.DockerContainerID
 	s.Shell = t.Shell
 	s.GRPC = t.GRPC
 	s.GRPCUseTLS = t.GRPCUseTLS
 	s.OSService = t.OSService
+	s.Redis = t.Redis
+	s.PostgreSQL = t.PostgreSQL
+	s.MySQL = t.MySQL
+	s.Memcached = t.Memcached
+	s.TLS = t.TLS
+	s.TLSCAFile = t.TLSCAFile
+	s.TLSExpiryWarningDays = int32(t.TLSExpiryWarningDays)
 	s.TLSServerName = t.TLSServerName
 	s.TLSSkipVerify = t.TLSSkipVerify
 	s.Timeout = structs.DurationToProto(t.Timeout)
@@ -80,6 +114,28 @@ func CheckTypeFromStructs(t *structs.CheckType, s *CheckType) {
 	s.DeregisterCriticalServiceAfter = structs.DurationToProto(t.DeregisterCriticalServiceAfter)
 	s.OutputMaxSize = int32(t.OutputMaxSize)
 }
+func CompositeCheckInputToStructs(s *CompositeCheckInput, t *structs.CompositeCheckInput) {
+	if s == nil {
+		return
+	}
+	t.Name = s.Name
+	t.Service = s.Service
+	t.Node = s.Node
+	t.CheckID = CheckIDType(s.CheckID)
+	t.Datacenter = s.Datacenter
+	t.MinPassing = int(s.MinPassing)
+}
+func CompositeCheckInputFromStructs(t *structs.CompositeCheckInput, s *CompositeCheckInput) {
+	if s == nil {
+		return
+	}
+	s.Name = t.Name
+	s.Service = t.Service
+	s.Node = t.Node
+	s.CheckID = string(t.CheckID)
+	s.Datacenter = t.Datacenter
+	s.MinPassing = int32(t.MinPassing)
+}
 func HealthCheckToStructs(s *HealthCheck, t *structs.HealthCheck) {
 	if s == nil {
 		return
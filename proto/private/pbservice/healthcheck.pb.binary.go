@@ -46,3 +46,13 @@ func (msg *CheckType) MarshalBinary() ([]byte, error) {
 func (msg *CheckType) UnmarshalBinary(b []byte) error {
 	return proto.Unmarshal(b, msg)
 }
+
+// MarshalBinary implements encoding.BinaryMarshaler
+func (msg *CompositeCheckInput) MarshalBinary() ([]byte, error) {
+	return proto.Marshal(msg)
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler
+func (msg *CompositeCheckInput) UnmarshalBinary(b []byte) error {
+	return proto.Unmarshal(b, msg)
+}
@@ -31,6 +31,8 @@ type ForwardingServiceClient interface {
 	Read(ctx context.Context, in *ReadRequest, opts ...grpc.CallOption) (*ReadResponse, error)
 	// List handles a forwarded list operation.
 	List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListResponse, error)
+	// Txn handles a forwarded transaction.
+	Txn(ctx context.Context, in *TxnRequest, opts ...grpc.CallOption) (*TxnResponse, error)
 }
 
 type forwardingServiceClient struct {
@@ -77,6 +79,15 @@ func (c *forwardingServiceClient) List(ctx context.Context, in *ListRequest, opt
 	return out, nil
 }
 
+func (c *forwardingServiceClient) Txn(ctx context.Context, in *TxnRequest, opts ...grpc.CallOption) (*TxnResponse, error) {
+	out := new(TxnResponse)
+	err := c.cc.Invoke(ctx, "/hashicorp.consul.internal.storage.raft.ForwardingService/Txn", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ForwardingServiceServer is the server API for ForwardingService service.
 // All implementations should embed UnimplementedForwardingServiceServer
 // for forward compatibility
@@ -89,6 +100,8 @@ type ForwardingServiceServer interface {
 	Read(context.Context, *ReadRequest) (*ReadResponse, error)
 	// List handles a forwarded list operation.
 	List(context.Context, *ListRequest) (*ListResponse, error)
+	// Txn handles a forwarded transaction.
+	Txn(context.Context, *TxnRequest) (*TxnResponse, error)
 }
 
 // UnimplementedForwardingServiceServer should be embedded to have forward compatible implementations.
@@ -107,6 +120,9 @@ func (UnimplementedForwardingServiceServer) Read(context.Context, *ReadRequest)
 func (UnimplementedForwardingServiceServer) List(context.Context, *ListRequest) (*ListResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method List not implemented")
 }
+func (UnimplementedForwardingServiceServer) Txn(context.Context, *TxnRequest) (*TxnResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Txn not implemented")
+}
 
 // UnsafeForwardingServiceServer may be embedded to opt out of forward compatibility for this service.
 // Use of this interface is not recommended, as added methods to ForwardingServiceServer will
@@ -191,6 +207,24 @@ func _ForwardingService_List_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ForwardingService_Txn_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TxnRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ForwardingServiceServer).Txn(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.consul.internal.storage.raft.ForwardingService/Txn",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ForwardingServiceServer).Txn(ctx, req.(*TxnRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ForwardingService_ServiceDesc is the grpc.ServiceDesc for ForwardingService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -214,6 +248,10 @@ var ForwardingService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "List",
 			Handler:    _ForwardingService_List_Handler,
 		},
+		{
+			MethodName: "Txn",
+			Handler:    _ForwardingService_Txn_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "private/pbstorage/raft.proto",
@@ -0,0 +1,54 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package pbsubscribe
+
+import (
+	"github.com/hashicorp/consul/agent/structs"
+	"github.com/hashicorp/consul/proto/private/pbcommon"
+)
+
+// NewKVEntryFromStructs converts a structs.DirEntry to a KVEntry.
+func NewKVEntryFromStructs(t *structs.DirEntry) *KVEntry {
+	if t == nil {
+		return nil
+	}
+	s := &KVEntry{
+		Key:            t.Key,
+		Flags:          t.Flags,
+		Value:          t.Value,
+		Session:        t.Session,
+		LockIndex:      t.LockIndex,
+		CreateIndex:    t.CreateIndex,
+		ModifyIndex:    t.ModifyIndex,
+		EnterpriseMeta: pbcommon.NewEnterpriseMetaFromStructs(t.EnterpriseMeta),
+	}
+	if t.ExpirationTime != nil {
+		s.ExpirationTime = structs.TimeToProto(*t.ExpirationTime)
+	}
+	return s
+}
+
+// KVEntryToStructs converts a KVEntry to a structs.DirEntry.
+func KVEntryToStructs(s *KVEntry) *structs.DirEntry {
+	if s == nil {
+		return nil
+	}
+	t := &structs.DirEntry{
+		Key:       s.Key,
+		Flags:     s.Flags,
+		Value:     s.Value,
+		Session:   s.Session,
+		LockIndex: s.LockIndex,
+		RaftIndex: structs.RaftIndex{
+			CreateIndex: s.CreateIndex,
+			ModifyIndex: s.ModifyIndex,
+		},
+	}
+	if s.ExpirationTime != nil {
+		expires := structs.TimeFromProto(s.ExpirationTime)
+		t.ExpirationTime = &expires
+	}
+	pbcommon.EnterpriseMetaToStructs(s.EnterpriseMeta, &t.EnterpriseMeta)
+	return t
+}
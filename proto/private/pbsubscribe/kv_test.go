@@ -0,0 +1,39 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package pbsubscribe
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/consul/agent/structs"
+)
+
+func TestKVEntry_RoundTrip(t *testing.T) {
+	expires := time.Now().Add(time.Hour).UTC()
+	entry := &structs.DirEntry{
+		Key:            "foo",
+		Flags:          42,
+		Value:          []byte("bar"),
+		Session:        "session",
+		LockIndex:      3,
+		ExpirationTime: &expires,
+		RaftIndex: structs.RaftIndex{
+			CreateIndex: 4,
+			ModifyIndex: 5,
+		},
+	}
+	entry.EnterpriseMeta = *structs.DefaultEnterpriseMetaInDefaultPartition()
+
+	out := KVEntryToStructs(NewKVEntryFromStructs(entry))
+	require.Equal(t, entry.Key, out.Key)
+	require.Equal(t, entry.Flags, out.Flags)
+	require.Equal(t, entry.Value, out.Value)
+	require.Equal(t, entry.Session, out.Session)
+	require.Equal(t, entry.LockIndex, out.LockIndex)
+	require.Equal(t, entry.RaftIndex, out.RaftIndex)
+	require.True(t, entry.ExpirationTime.Equal(*out.ExpirationTime))
+}